@@ -0,0 +1,167 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+)
+
+var bundleCmd = &cobra.Command{
+	Use:   "bundle",
+	Short: "Export and import test run bundles",
+	Long:  `Moves finished test runs between assertoor instances, e.g. from an ephemeral devnet to a long-lived archive instance`,
+}
+
+var bundleExportCmd = &cobra.Command{
+	Use:   "export <runId>",
+	Short: "Download the bundle of a finished test run",
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		outputFile := bundleOutput
+		if outputFile == "" {
+			outputFile = fmt.Sprintf("assertoor-run-%v.tar.gz", args[0])
+		}
+
+		if err := exportBundle(cmd.Context(), args[0], outputFile); err != nil {
+			logrus.Fatal(err)
+		}
+
+		logrus.Infof("exported test run %v to %v", args[0], outputFile)
+	},
+}
+
+// exportBundle downloads the bundle of a test run to outputFile.
+// Partially written files are removed if the download fails.
+func exportBundle(ctx context.Context, runID, outputFile string) error {
+	url := fmt.Sprintf("%v/api/v1/test_run/%v/bundle", strings.TrimRight(bundleURL, "/"), runID)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, http.NoBody)
+	if err != nil {
+		return err
+	}
+
+	resp, err := sendBundleRequest(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	file, err := os.Create(outputFile)
+	if err != nil {
+		return err
+	}
+
+	_, err = io.Copy(file, resp.Body)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+
+	if err != nil {
+		//nolint:errcheck // the write error is reported
+		os.Remove(outputFile)
+
+		return fmt.Errorf("failed writing bundle: %w", err)
+	}
+
+	return nil
+}
+
+var bundleImportCmd = &cobra.Command{
+	Use:   "import <file>",
+	Short: "Import a test run bundle into an assertoor instance",
+	Long:  `Uploads a test run bundle to an assertoor instance, where it shows up as finished, read-only test run`,
+	Args:  cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		file, err := os.Open(args[0])
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		defer file.Close()
+
+		url := fmt.Sprintf("%v/api/v1/test_runs/import", strings.TrimRight(bundleURL, "/"))
+
+		req, err := http.NewRequestWithContext(cmd.Context(), http.MethodPost, url, file)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		req.Header.Set("Content-Type", "application/gzip")
+
+		resp, err := sendBundleRequest(req)
+		if err != nil {
+			logrus.Fatal(err)
+		}
+
+		defer resp.Body.Close()
+
+		result := struct {
+			Data struct {
+				RunID         uint64 `json:"run_id"`
+				OriginalRunID uint64 `json:"original_run_id"`
+				TestID        string `json:"test_id"`
+			} `json:"data"`
+		}{}
+
+		if err := json.NewDecoder(resp.Body).Decode(&result); err != nil {
+			logrus.Fatalf("failed parsing response: %v", err)
+		}
+
+		logrus.Infof("imported test run %v of test %v as run %v", result.Data.OriginalRunID, result.Data.TestID, result.Data.RunID)
+	},
+}
+
+// sendBundleRequest sends a request to the assertoor API and returns the response if it succeeded.
+func sendBundleRequest(req *http.Request) (*http.Response, error) {
+	if bundleToken != "" {
+		req.Header.Set("Authorization", "Bearer "+bundleToken)
+	}
+
+	client := &http.Client{Timeout: 10 * time.Minute}
+
+	//nolint:gosec // G704: URL is given by the user on the command line
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("request failed: %w", err)
+	}
+
+	if resp.StatusCode != http.StatusOK {
+		defer resp.Body.Close()
+
+		errResult := struct {
+			Status string `json:"status"`
+		}{}
+
+		//nolint:errcheck // the status code is reported anyway
+		json.NewDecoder(resp.Body).Decode(&errResult)
+
+		return nil, fmt.Errorf("request failed with status %v: %v", resp.StatusCode, errResult.Status)
+	}
+
+	return resp, nil
+}
+
+var (
+	bundleURL    string
+	bundleToken  string
+	bundleOutput string
+)
+
+func init() {
+	bundleCmd.PersistentFlags().StringVar(&bundleURL, "url", "http://localhost:8080", "URL of the assertoor instance")
+	bundleCmd.PersistentFlags().StringVar(&bundleToken, "token", os.Getenv("ASSERTOOR_API_TOKEN"), "API token for authenticated instances (default $ASSERTOOR_API_TOKEN)")
+	bundleExportCmd.Flags().StringVarP(&bundleOutput, "output", "o", "", "Output file (default assertoor-run-<runId>.tar.gz)")
+
+	bundleCmd.AddCommand(bundleExportCmd)
+	bundleCmd.AddCommand(bundleImportCmd)
+	rootCmd.AddCommand(bundleCmd)
+}
@@ -0,0 +1,355 @@
+package cmd
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/assertoor"
+	"github.com/ethpandaops/assertoor/pkg/clients"
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/report"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
+)
+
+// exit codes of the run command
+const (
+	runExitSuccess = 0
+	runExitFailure = 1
+	runExitAborted = 2
+	runExitError   = 3
+)
+
+var runCmd = &cobra.Command{
+	Use:   "run [flags] <playbook>",
+	Short: "Runs a single test playbook and exits with its result",
+	Long: `Runs a single test playbook (local file or URL) against the given endpoints and exits when the test is finished.
+No web server or test scheduler is started, the task progress is printed to stdout.
+With --dry-run the task tree is expanded & validated without executing any task.
+
+Exit codes: 0 = success, 1 = failure, 2 = aborted, 3 = test could not be started or its outputs / report could not be written`,
+	Args: cobra.ExactArgs(1),
+	Run: func(cmd *cobra.Command, args []string) {
+		os.Exit(runSingleTest(cmd, args[0]))
+	},
+}
+
+var (
+	runEndpoints []string
+	runVars      []string
+	runOutputDir string
+	runTimeout   time.Duration
+	runDryRun    bool
+	runReport    string
+	runReportOut string
+)
+
+func init() {
+	runCmd.Flags().StringArrayVar(&runEndpoints, "endpoints", nil, "Endpoint to test against, format: [name=]<consensusUrl>,<executionUrl> (can be repeated, replaces the endpoints from the config file)")
+	runCmd.Flags().StringArrayVar(&runVars, "var", nil, "Test config variable, format: <name>=<value> (value is parsed as yaml, can be repeated)")
+	runCmd.Flags().StringVar(&runOutputDir, "output-dir", "", "Directory to write the task tree, outputs and result artifacts to")
+	runCmd.Flags().DurationVar(&runTimeout, "timeout", 0, "Test timeout (overrides the timeout from the playbook)")
+	runCmd.Flags().BoolVar(&runDryRun, "dry-run", false, "Expand & validate the task tree without executing any task")
+	runCmd.Flags().StringVar(&runReport, "report", "", "Write a test report in the given format (junit, tap or json-summary)")
+	runCmd.Flags().StringVar(&runReportOut, "report-file", "", "File to write the test report to (defaults to report.<ext> in the output dir, or stdout)")
+	runCmd.Flags().BoolVarP(&verbose, "verbose", "v", false, "Verbose output (show task logs)")
+
+	rootCmd.AddCommand(runCmd)
+}
+
+func runSingleTest(cmd *cobra.Command, playbook string) int {
+	logr := logrus.New()
+
+	switch logFormat {
+	case "json":
+		logr.SetFormatter(&logrus.JSONFormatter{})
+	case "text":
+		logr.SetFormatter(&logrus.TextFormatter{})
+	default:
+		logr.Errorf("Invalid log format: %s", logFormat)
+		return runExitError
+	}
+
+	// task logs are only shown in verbose mode, the progress is printed from the event stream
+	logr.SetLevel(logrus.WarnLevel)
+
+	if verbose {
+		logr.SetLevel(logrus.DebugLevel)
+	}
+
+	config, err := assertoor.NewConfig(cfgFile)
+	if err != nil {
+		logr.Errorf("failed loading config: %v", err)
+		return runExitError
+	}
+
+	// only the given playbook is run
+	config.Web = nil
+	config.Tests = nil
+	config.ExternalTests = nil
+
+	if len(runEndpoints) > 0 {
+		config.Endpoints = make([]clients.ClientConfig, 0, len(runEndpoints))
+
+		for idx, endpointStr := range runEndpoints {
+			endpoint, err := parseRunEndpoint(idx, endpointStr)
+			if err != nil {
+				logr.Errorf("invalid endpoint '%v': %v", endpointStr, err)
+				return runExitError
+			}
+
+			config.Endpoints = append(config.Endpoints, *endpoint)
+		}
+	}
+
+	if err := config.Validate(); err != nil {
+		logr.Errorf("invalid config: %v", err)
+		return runExitError
+	}
+
+	if runReport != "" && !slices.Contains(report.Formats, runReport) {
+		logr.Errorf("invalid report format '%v', supported: %v", runReport, strings.Join(report.Formats, ", "))
+		return runExitError
+	}
+
+	extTestCfg := &types.ExternalTestConfig{
+		ID:     "run",
+		File:   playbook,
+		Config: map[string]any{},
+	}
+
+	if runTimeout > 0 {
+		extTestCfg.Timeout = &helper.ChainRelativeDuration{Duration: runTimeout}
+	}
+
+	for _, varStr := range runVars {
+		varName, varValue, err := parseRunVar(varStr)
+		if err != nil {
+			logr.Errorf("invalid variable '%v': %v", varStr, err)
+			return runExitError
+		}
+
+		extTestCfg.Config[varName] = varValue
+	}
+
+	coord := assertoor.NewCoordinator(config, logr, 0)
+
+	if runDryRun {
+		testPlan, err := coord.PlanSingleTest(cmd.Context(), extTestCfg)
+		if err != nil {
+			logr.Errorf("test planning failed: %v", err)
+			return runExitError
+		}
+
+		printTestPlan(testPlan)
+
+		if !testPlan.Valid {
+			return runExitFailure
+		}
+
+		return runExitSuccess
+	}
+
+	testRef, err := coord.RunSingleTest(cmd.Context(), extTestCfg, &assertoor.SingleTestOptions{
+		EventFn:      printRunEvent,
+		OutputDir:    runOutputDir,
+		ReportFormat: runReport,
+		ReportFile:   runReportOut,
+	})
+	if err != nil {
+		logr.Errorf("test run failed: %v", err)
+
+		if testRef == nil {
+			return runExitError
+		}
+	}
+
+	if logFormat != "json" {
+		fmt.Printf("test %v finished with status: %v\n", testRef.Name(), testRef.Status())
+	}
+
+	if err != nil {
+		// the test outputs or report could not be written
+		return runExitError
+	}
+
+	switch testRef.Status() {
+	case types.TestStatusSuccess, types.TestStatusSkipped:
+		return runExitSuccess
+	case types.TestStatusAborted:
+		return runExitAborted
+	case types.TestStatusPending:
+		// test validation failed
+		return runExitError
+	default:
+		return runExitFailure
+	}
+}
+
+// parseRunEndpoint parses an endpoint in the format [name=]<consensusUrl>,<executionUrl>
+func parseRunEndpoint(idx int, endpointStr string) (*clients.ClientConfig, error) {
+	endpoint := &clients.ClientConfig{
+		Name: fmt.Sprintf("endpoint-%v", idx+1),
+	}
+
+	if sepIdx := strings.Index(endpointStr, "="); sepIdx > 0 && !strings.Contains(endpointStr[:sepIdx], "/") {
+		endpoint.Name = endpointStr[:sepIdx]
+		endpointStr = endpointStr[sepIdx+1:]
+	}
+
+	urls := strings.Split(endpointStr, ",")
+	if len(urls) != 2 {
+		return nil, fmt.Errorf("expected <consensusUrl>,<executionUrl>")
+	}
+
+	endpoint.ConsensusURL = strings.TrimSpace(urls[0])
+	endpoint.ExecutionURL = strings.TrimSpace(urls[1])
+
+	return endpoint, nil
+}
+
+// parseRunVar parses a variable in the format <name>=<value>
+func parseRunVar(varStr string) (name string, value any, err error) {
+	sepIdx := strings.Index(varStr, "=")
+	if sepIdx <= 0 {
+		return "", nil, fmt.Errorf("expected <name>=<value>")
+	}
+
+	name = varStr[:sepIdx]
+	valueStr := varStr[sepIdx+1:]
+
+	if err := yaml.Unmarshal([]byte(valueStr), &value); err != nil {
+		// not a valid yaml value, use the raw string
+		value = valueStr
+	}
+
+	return name, value, nil
+}
+
+func printRunEvent(event *events.Event) {
+	if logFormat == "json" {
+		eventJSON, err := json.Marshal(event)
+		if err == nil {
+			fmt.Println(string(eventJSON))
+		}
+
+		return
+	}
+
+	timeStr := event.Timestamp.Format("15:04:05")
+
+	switch event.Type {
+	case events.EventTestStarted:
+		data := &events.TestStartedData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  test started: %v (run %v)\n", timeStr, data.TestName, event.TestRunID)
+		}
+	case events.EventTestCompleted:
+		data := &events.TestCompletedData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  test completed: %v (%v)\n", timeStr, data.TestName, data.Status)
+		}
+	case events.EventTestFailed:
+		data := &events.TestFailedData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  test failed: %v %v\n", timeStr, data.TestName, data.Error)
+		}
+	case events.EventTestPaused:
+		fmt.Printf("%v  test paused\n", timeStr)
+	case events.EventTestResumed:
+		fmt.Printf("%v  test resumed\n", timeStr)
+	case events.EventTaskStarted:
+		data := &events.TaskStartedData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  [task %v] started: %v (%v)\n", timeStr, event.TaskIndex, data.TaskTitle, data.TaskName)
+		}
+	case events.EventTaskProgress:
+		data := &events.TaskProgressData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  [task %v] progress: %.0f%% %v\n", timeStr, event.TaskIndex, data.Progress, data.Message)
+		}
+	case events.EventTaskCompleted:
+		data := &events.TaskCompletedData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  [task %v] completed: %v (%v)\n", timeStr, event.TaskIndex, data.TaskTitle, data.Result)
+		}
+	case events.EventTaskFailed:
+		data := &events.TaskFailedData{}
+		if json.Unmarshal(event.Data, data) == nil {
+			fmt.Printf("%v  [task %v] failed: %v: %v\n", timeStr, event.TaskIndex, data.TaskTitle, data.Error)
+		}
+	}
+}
+
+func printTestPlan(testPlan *types.TestPlan) {
+	if logFormat == "json" {
+		planJSON, err := json.MarshalIndent(testPlan, "", "  ")
+		if err == nil {
+			fmt.Println(string(planJSON))
+		}
+
+		return
+	}
+
+	fmt.Printf("test plan: %v\n", testPlan.Name)
+
+	for _, planErr := range testPlan.Errors {
+		fmt.Printf("  error: %v\n", planErr)
+	}
+
+	printTaskPlans := func(taskPlans []*types.TaskPlan) {
+		for _, taskPlan := range taskPlans {
+			indent := strings.Repeat("  ", int(taskPlan.Depth)+1) //nolint:gosec // no overflow
+
+			fmt.Printf("%v[task %v] %v (%v)\n", indent, taskPlan.Index, taskPlan.Title, taskPlan.Name)
+
+			if taskPlan.If != nil {
+				fmt.Printf("%v    if: %v => %v\n", indent, taskPlan.If.Query, formatPlanQuery(taskPlan.If))
+			}
+
+			for _, configVar := range taskPlan.ConfigVars {
+				fmt.Printf("%v    configVars.%v: %v => %v\n", indent, configVar.Name, configVar.Query, formatPlanQuery(configVar))
+			}
+
+			if taskPlan.ConfigError != "" {
+				fmt.Printf("%v    config error: %v\n", indent, taskPlan.ConfigError)
+			}
+		}
+	}
+
+	printTaskPlans(testPlan.Tasks)
+
+	if len(testPlan.CleanupTasks) > 0 {
+		fmt.Printf("cleanup tasks:\n")
+		printTaskPlans(testPlan.CleanupTasks)
+	}
+
+	if testPlan.Valid {
+		fmt.Printf("test plan is valid\n")
+	} else {
+		fmt.Printf("test plan is invalid\n")
+	}
+}
+
+func formatPlanQuery(queryPlan *types.TaskPlanQuery) string {
+	switch queryPlan.Status {
+	case types.PlanQueryResolved:
+		valueJSON, err := json.Marshal(queryPlan.Value)
+		if err != nil {
+			return queryPlan.Status
+		}
+
+		return fmt.Sprintf("%v (%v)", queryPlan.Status, string(valueJSON))
+	case types.PlanQueryInvalid:
+		return fmt.Sprintf("%v: %v", queryPlan.Status, queryPlan.Error)
+	default:
+		return fmt.Sprintf("%v (resolved at runtime)", queryPlan.Status)
+	}
+}
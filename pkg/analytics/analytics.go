@@ -0,0 +1,133 @@
+// Package analytics computes pass rates, flakiness and duration statistics over the test history.
+//
+// Finished test runs are condensed into compact per-run and per-task records as soon as they
+// complete, so the statistics can be aggregated over large histories without touching the
+// task states of every run.
+package analytics
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	indexBatchSize = 100
+	indexInterval  = 5 * time.Minute
+)
+
+// Service keeps the analytics records up to date with the test history.
+type Service struct {
+	database *db.Database
+	eventBus *events.EventBus
+	logger   logrus.FieldLogger
+
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+}
+
+// NewService creates a new analytics service.
+func NewService(database *db.Database, eventBus *events.EventBus, logger logrus.FieldLogger) *Service {
+	return &Service{
+		database: database,
+		eventBus: eventBus,
+		logger:   logger.WithField("component", "analytics"),
+	}
+}
+
+// Start indexes all finished test runs without analytics records and keeps indexing
+// test runs as they finish, until the context is cancelled or Stop is called.
+func (s *Service) Start(ctx context.Context) {
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	var subscriber *events.Subscriber
+	if s.eventBus != nil {
+		subscriber = s.eventBus.Subscribe(events.CreateEventTypeFilter(events.EventTestCompleted, events.EventTestFailed))
+	}
+
+	s.waitGroup.Add(1)
+
+	go func() {
+		defer s.waitGroup.Done()
+
+		var eventChan <-chan *events.Event
+
+		if subscriber != nil {
+			defer s.eventBus.Unsubscribe(subscriber)
+
+			eventChan = subscriber.Channel()
+		}
+
+		for {
+			// runs aborted on startup or finished while the event queue was full are picked up here
+			s.indexPendingRuns(ctx)
+
+			timer := time.NewTimer(indexInterval)
+
+		waitLoop:
+			for {
+				select {
+				case <-ctx.Done():
+					timer.Stop()
+					return
+				case <-timer.C:
+					break waitLoop
+				case event, ok := <-eventChan:
+					if !ok {
+						eventChan = nil
+						continue
+					}
+
+					if err := s.IndexTestRun(event.TestRunID); err != nil {
+						s.logger.Warnf("failed indexing test run %v: %v", event.TestRunID, err)
+					}
+				}
+			}
+		}
+	}()
+}
+
+// Stop stops the indexing loop.
+func (s *Service) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.waitGroup.Wait()
+}
+
+func (s *Service) indexPendingRuns(ctx context.Context) {
+	indexed := 0
+
+	for ctx.Err() == nil {
+		runIDs, err := s.database.GetUnindexedTestRunIDs(indexBatchSize)
+		if err != nil {
+			s.logger.Warnf("failed loading unindexed test runs: %v", err)
+			return
+		}
+
+		failed := 0
+
+		for _, runID := range runIDs {
+			if err := s.IndexTestRun(runID); err != nil {
+				s.logger.Warnf("failed indexing test run %v: %v", runID, err)
+
+				failed++
+			}
+		}
+
+		indexed += len(runIDs) - failed
+
+		if len(runIDs) < indexBatchSize || failed > 0 {
+			break
+		}
+	}
+
+	if indexed > 0 {
+		s.logger.Infof("indexed %v test runs", indexed)
+	}
+}
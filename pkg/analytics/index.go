@@ -0,0 +1,128 @@
+package analytics
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/jmoiron/sqlx"
+)
+
+const maxTaskRefLength = 256
+
+// IndexTestRun condenses a finished test run into its analytics records.
+// Test runs that have not finished yet are ignored.
+func (s *Service) IndexTestRun(runID uint64) error {
+	testRun, err := s.database.GetTestRunByRunID(runID)
+	if err != nil {
+		return fmt.Errorf("failed loading test run: %w", err)
+	}
+
+	switch testRun.Status {
+	case string(types.TestStatusSuccess), string(types.TestStatusFailure), string(types.TestStatusAborted), string(types.TestStatusSkipped):
+	default:
+		return nil
+	}
+
+	taskStates, err := s.database.GetTaskStatesByRunID(runID)
+	if err != nil {
+		return fmt.Errorf("failed loading task states: %w", err)
+	}
+
+	analyticsRun := &db.AnalyticsTestRun{
+		RunID:     testRun.RunID,
+		TestID:    testRun.TestID,
+		StartTime: testRun.StartTime,
+		Duration:  getDuration(testRun.StartTime, testRun.StopTime),
+		Status:    testRun.Status,
+	}
+
+	analyticsTasks := []*db.AnalyticsTaskRun{}
+	taskRefs := map[string]bool{}
+
+	var firstFailure *db.TaskState
+
+	for _, taskState := range taskStates {
+		isCleanup := taskState.RunFlags&db.TaskRunFlagCleanup != 0
+		isSkipped := taskState.RunFlags&db.TaskRunFlagSkipped != 0
+
+		if taskState.TaskResult == int(types.TaskResultFailure) && !isCleanup && taskState.StopTime > 0 {
+			// the innermost failing task stops first, parent tasks fail afterwards
+			if firstFailure == nil || taskState.StopTime < firstFailure.StopTime ||
+				(taskState.StopTime == firstFailure.StopTime && taskState.TaskID > firstFailure.TaskID) {
+				firstFailure = taskState
+			}
+		}
+
+		if taskState.RefID == "" || taskRefs[taskState.RefID] {
+			continue
+		}
+
+		if taskState.RunFlags&db.TaskRunFlagStarted == 0 && !isSkipped {
+			continue
+		}
+
+		taskRefs[taskState.RefID] = true
+
+		analyticsTasks = append(analyticsTasks, &db.AnalyticsTaskRun{
+			RunID:     testRun.RunID,
+			TaskRef:   taskState.RefID,
+			TestID:    testRun.TestID,
+			StartTime: testRun.StartTime,
+			Duration:  getDuration(taskState.StartTime, taskState.StopTime),
+			Status:    getTaskStatus(taskState, isSkipped),
+		})
+	}
+
+	if firstFailure != nil {
+		analyticsRun.FirstFailure = getTaskRef(firstFailure)
+	}
+
+	return s.database.RunTransaction(func(tx *sqlx.Tx) error {
+		return s.database.InsertAnalyticsTestRun(tx, analyticsRun, analyticsTasks)
+	})
+}
+
+func getDuration(startTime, stopTime int64) int64 {
+	if startTime == 0 || stopTime < startTime {
+		return 0
+	}
+
+	return stopTime - startTime
+}
+
+func getTaskStatus(taskState *db.TaskState, isSkipped bool) string {
+	if isSkipped {
+		return "skipped"
+	}
+
+	switch types.TaskResult(taskState.TaskResult) {
+	case types.TaskResultSuccess:
+		return "success"
+	case types.TaskResultFailure:
+		return "failure"
+	case types.TaskResultNone:
+		return "none"
+	}
+
+	return "none"
+}
+
+// getTaskRef returns the task ID, or the title for tasks without ID.
+func getTaskRef(taskState *db.TaskState) string {
+	taskRef := taskState.RefID
+
+	switch {
+	case taskRef != "":
+	case taskState.Title != "":
+		taskRef = taskState.Title
+	default:
+		taskRef = taskState.Name
+	}
+
+	if len(taskRef) > maxTaskRefLength {
+		taskRef = taskRef[:maxTaskRefLength]
+	}
+
+	return taskRef
+}
@@ -0,0 +1,200 @@
+package analytics
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+)
+
+// DefaultFlakinessRuns is the default number of runs the flakiness score is normalized to.
+const DefaultFlakinessRuns = 10
+
+// Window selects the test runs the statistics are computed over.
+type Window struct {
+	// Since and Until restrict the runs by start time (unix milliseconds, 0 for open).
+	Since int64
+	Until int64
+
+	// FlakinessRuns is the number of runs the flakiness score is normalized to.
+	FlakinessRuns uint64
+}
+
+// Stats are the statistics of a test or task within a window.
+type Stats struct {
+	TestID       string  `json:"test_id,omitempty"`
+	TaskID       string  `json:"task_id,omitempty"`
+	Runs         uint64  `json:"runs"`
+	Success      uint64  `json:"success"`
+	Failure      uint64  `json:"failure"`
+	Aborted      uint64  `json:"aborted"`
+	Skipped      uint64  `json:"skipped"`
+	PassRate     float64 `json:"pass_rate"`
+	Flips        uint64  `json:"flips"`
+	Flakiness    float64 `json:"flakiness"`
+	MeanDuration int64   `json:"mean_duration"`
+	P95Duration  int64   `json:"p95_duration"`
+	LastRun      int64   `json:"last_run"`
+}
+
+// FailureCount is the number of failed runs that first failed at a task.
+type FailureCount struct {
+	TaskID string `json:"task_id"`
+	Count  uint64 `json:"count"`
+}
+
+// TestAnalytics are the statistics of a single test, its tasks and the tasks failed runs first failed at.
+type TestAnalytics struct {
+	Test          *Stats          `json:"test"`
+	Tasks         []*Stats        `json:"tasks"`
+	FirstFailures []*FailureCount `json:"first_failures"`
+}
+
+func (w *Window) dbWindow() *db.AnalyticsWindow {
+	return &db.AnalyticsWindow{
+		Since: w.Since,
+		Until: w.Until,
+	}
+}
+
+// GetTestStats returns the statistics of all tests with runs in the window.
+func GetTestStats(database *db.Database, window *Window) ([]*Stats, error) {
+	dbStats, err := database.GetAnalyticsTestStats("", window.dbWindow())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test stats: %w", err)
+	}
+
+	durations, err := database.GetAnalyticsTestDurations("", window.dbWindow())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test durations: %w", err)
+	}
+
+	p95Durations := getPercentiles(durations, 0.95)
+	stats := make([]*Stats, 0, len(dbStats))
+
+	for _, dbStat := range dbStats {
+		stat := newStats(dbStat, p95Durations[dbStat.Key], window)
+		stat.TestID = dbStat.Key
+		stats = append(stats, stat)
+	}
+
+	return stats, nil
+}
+
+// GetTestAnalytics returns the statistics of a test, its tasks (by task ID) and the
+// histogram of the tasks failed runs first failed at within the window.
+func GetTestAnalytics(database *db.Database, testID string, window *Window) (*TestAnalytics, error) {
+	result := &TestAnalytics{
+		Test:          &Stats{TestID: testID},
+		Tasks:         []*Stats{},
+		FirstFailures: []*FailureCount{},
+	}
+
+	dbStats, err := database.GetAnalyticsTestStats(testID, window.dbWindow())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test stats: %w", err)
+	}
+
+	if len(dbStats) > 0 {
+		durations, err2 := database.GetAnalyticsTestDurations(testID, window.dbWindow())
+		if err2 != nil {
+			return nil, fmt.Errorf("failed loading test durations: %w", err2)
+		}
+
+		result.Test = newStats(dbStats[0], getPercentiles(durations, 0.95)[testID], window)
+		result.Test.TestID = testID
+	}
+
+	dbTaskStats, err := database.GetAnalyticsTaskStats(testID, window.dbWindow())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading task stats: %w", err)
+	}
+
+	taskDurations, err := database.GetAnalyticsTaskDurations(testID, window.dbWindow())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading task durations: %w", err)
+	}
+
+	p95Durations := getPercentiles(taskDurations, 0.95)
+
+	for _, dbStat := range dbTaskStats {
+		stat := newStats(dbStat, p95Durations[dbStat.Key], window)
+		stat.TaskID = dbStat.Key
+		result.Tasks = append(result.Tasks, stat)
+	}
+
+	failureCounts, err := database.GetAnalyticsFirstFailures(testID, window.dbWindow())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading first failures: %w", err)
+	}
+
+	for _, failureCount := range failureCounts {
+		result.FirstFailures = append(result.FirstFailures, &FailureCount{
+			TaskID: failureCount.TaskRef,
+			Count:  failureCount.Count,
+		})
+	}
+
+	return result, nil
+}
+
+func newStats(dbStat *db.AnalyticsStats, p95Duration int64, window *Window) *Stats {
+	stat := &Stats{
+		Runs:         dbStat.Runs,
+		Success:      dbStat.Success,
+		Failure:      dbStat.Failure,
+		Aborted:      dbStat.Aborted,
+		Skipped:      dbStat.Skipped,
+		Flips:        dbStat.Flips,
+		MeanDuration: int64(math.Round(dbStat.MeanDuration)),
+		P95Duration:  p95Duration,
+	}
+
+	if dbStat.LastRun > 0 {
+		stat.LastRun = dbStat.LastRun / 1000
+	}
+
+	// skipped runs did not execute anything, so they neither pass nor fail
+	if executed := stat.Runs - stat.Skipped; executed > 0 {
+		stat.PassRate = roundRatio(float64(stat.Success) / float64(executed))
+	}
+
+	// flips are status changes between consecutive successful or failed runs
+	if decided := stat.Success + stat.Failure; decided > 0 {
+		flakinessRuns := window.FlakinessRuns
+		if flakinessRuns == 0 {
+			flakinessRuns = DefaultFlakinessRuns
+		}
+
+		stat.Flakiness = roundRatio(float64(stat.Flips) * float64(flakinessRuns) / float64(decided))
+	}
+
+	return stat
+}
+
+// getPercentiles returns the nearest-rank percentile of the durations per key.
+// The durations must be ordered by key and duration.
+func getPercentiles(durations []*db.AnalyticsDuration, percentile float64) map[string]int64 {
+	percentiles := map[string]int64{}
+
+	for start := 0; start < len(durations); {
+		end := start
+		for end < len(durations) && durations[end].Key == durations[start].Key {
+			end++
+		}
+
+		rank := int(math.Ceil(percentile*float64(end-start))) - 1
+		if rank < 0 {
+			rank = 0
+		}
+
+		percentiles[durations[start].Key] = durations[start+rank].Duration
+		start = end
+	}
+
+	return percentiles
+}
+
+func roundRatio(value float64) float64 {
+	return math.Round(value*10000) / 10000
+}
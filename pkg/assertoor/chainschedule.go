@@ -0,0 +1,59 @@
+package assertoor
+
+import (
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+// chainScheduleState keeps track of the chain schedule triggers that already fired.
+// Epochs that are reported again (e.g. after a wallclock adjustment) don't trigger tests a second time,
+// and finality loss triggers are only re-armed once finality advanced past the epoch they fired at.
+type chainScheduleState struct {
+	lastEpoch    uint64
+	hasLastEpoch bool
+
+	// finalized epoch at the time the finality loss trigger fired, by test id
+	finalityLossTriggered map[string]uint64
+}
+
+func newChainScheduleState() *chainScheduleState {
+	return &chainScheduleState{
+		finalityLossTriggered: map[string]uint64{},
+	}
+}
+
+// processEpoch returns false if the epoch (or a later one) has already been processed.
+func (s *chainScheduleState) processEpoch(epoch uint64) bool {
+	if s.hasLastEpoch && epoch <= s.lastEpoch {
+		return false
+	}
+
+	s.lastEpoch = epoch
+	s.hasLastEpoch = true
+
+	return true
+}
+
+// getFinalityLossTrigger returns the finality loss trigger of a test, if it fires at the given epoch.
+// The trigger fires once per incident.
+func (s *chainScheduleState) getFinalityLossTrigger(testID string, schedule *types.TestChainSchedule, epoch, finalizedEpoch uint64) string {
+	if _, triggered := s.finalityLossTriggered[testID]; triggered {
+		return ""
+	}
+
+	trigger := schedule.GetFinalityLossTrigger(epoch, finalizedEpoch)
+	if trigger != "" {
+		s.finalityLossTriggered[testID] = finalizedEpoch
+	}
+
+	return trigger
+}
+
+// updateFinalized re-arms the finality loss triggers that fired before finality advanced to the given epoch.
+// Checkpoints that are reported again or don't advance finality (e.g. after a reorg) keep the triggers disarmed.
+func (s *chainScheduleState) updateFinalized(finalizedEpoch uint64) {
+	for testID, triggeredEpoch := range s.finalityLossTriggered {
+		if finalizedEpoch > triggeredEpoch {
+			delete(s.finalityLossTriggered, testID)
+		}
+	}
+}
@@ -0,0 +1,107 @@
+package assertoor
+
+import (
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+func TestChainScheduleStateProcessEpoch(t *testing.T) {
+	tests := []struct {
+		name   string
+		epochs []uint64
+		want   []bool
+	}{
+		{name: "ascending epochs", epochs: []uint64{1, 2, 3}, want: []bool{true, true, true}},
+		{name: "genesis epoch", epochs: []uint64{0, 0, 1}, want: []bool{true, false, true}},
+		{name: "repeated epoch", epochs: []uint64{5, 5, 6}, want: []bool{true, false, true}},
+		{name: "epoch going backwards", epochs: []uint64{5, 6, 4, 6, 7}, want: []bool{true, true, false, false, true}},
+		{name: "skipped epochs", epochs: []uint64{5, 9}, want: []bool{true, true}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newChainScheduleState()
+
+			for idx, epoch := range tt.epochs {
+				if got := state.processEpoch(epoch); got != tt.want[idx] {
+					t.Errorf("processEpoch(%v) at step %v = %v, want %v", epoch, idx, got, tt.want[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestChainScheduleStateFinalityLoss(t *testing.T) {
+	schedule := &types.TestChainSchedule{FinalityLoss: 2}
+
+	type step struct {
+		finalized   uint64 // finalized checkpoint event, applied before the epoch is checked
+		epoch       uint64
+		wantTrigger bool
+	}
+
+	tests := []struct {
+		name  string
+		steps []step
+	}{
+		{
+			name: "fires once per incident",
+			steps: []step{
+				{finalized: 10, epoch: 13},
+				{finalized: 10, epoch: 14, wantTrigger: true},
+				{finalized: 10, epoch: 15},
+				{finalized: 10, epoch: 16},
+			},
+		},
+		{
+			name: "re-armed after finality advanced",
+			steps: []step{
+				{finalized: 10, epoch: 14, wantTrigger: true},
+				{finalized: 13, epoch: 15},
+				{finalized: 13, epoch: 16},
+				{finalized: 13, epoch: 17, wantTrigger: true},
+			},
+		},
+		{
+			name: "re-reported checkpoint keeps trigger disarmed",
+			steps: []step{
+				{finalized: 10, epoch: 14, wantTrigger: true},
+				{finalized: 10, epoch: 15},
+				{finalized: 9, epoch: 16},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := newChainScheduleState()
+
+			for idx, step := range tt.steps {
+				state.updateFinalized(step.finalized)
+
+				trigger := state.getFinalityLossTrigger("test1", schedule, step.epoch, step.finalized)
+				if (trigger != "") != step.wantTrigger {
+					t.Errorf("step %v: trigger = %q, wantTrigger %v", idx, trigger, step.wantTrigger)
+				}
+			}
+		})
+	}
+}
+
+func TestChainScheduleStateFinalityLossPerTest(t *testing.T) {
+	state := newChainScheduleState()
+	schedule := &types.TestChainSchedule{FinalityLoss: 1}
+
+	if trigger := state.getFinalityLossTrigger("test1", schedule, 13, 10); trigger == "" {
+		t.Errorf("expected trigger for test1")
+	}
+
+	if trigger := state.getFinalityLossTrigger("test2", schedule, 13, 10); trigger == "" {
+		t.Errorf("expected trigger for test2")
+	}
+
+	if trigger := state.getFinalityLossTrigger("test1", schedule, 14, 10); trigger != "" {
+		t.Errorf("unexpected second trigger for test1: %v", trigger)
+	}
+}
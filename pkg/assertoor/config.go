@@ -8,11 +8,18 @@ import (
 	"strings"
 
 	"github.com/ethpandaops/assertoor/pkg/clients"
+	"github.com/ethpandaops/assertoor/pkg/clients/consensus"
+	"github.com/ethpandaops/assertoor/pkg/clients/discovery"
+	"github.com/ethpandaops/assertoor/pkg/clients/execution"
 	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/events"
 	"github.com/ethpandaops/assertoor/pkg/helper"
 	"github.com/ethpandaops/assertoor/pkg/names"
+	"github.com/ethpandaops/assertoor/pkg/notifier"
 	"github.com/ethpandaops/assertoor/pkg/playbooklibrary"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/test"
+	"github.com/ethpandaops/assertoor/pkg/tracing"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	web_types "github.com/ethpandaops/assertoor/pkg/web/types"
 	"gopkg.in/yaml.v3"
@@ -25,6 +32,12 @@ type Config struct {
 	// List of execution & consensus clients to use.
 	Endpoints []clients.ClientConfig `yaml:"endpoints" json:"endpoints"`
 
+	// Scheduling settings of the consensus & execution client pools
+	ClientPool *clients.PoolConfig `yaml:"clientPool" json:"clientPool"`
+
+	// Discovery of additional endpoints from inventory files, HTTP inventories or DNS SRV records
+	EndpointDiscovery *discovery.Config `yaml:"endpointDiscovery" json:"endpointDiscovery"`
+
 	// WebServer config
 	Web *web_types.WebConfig `yaml:"web" json:"web"`
 
@@ -34,9 +47,22 @@ type Config struct {
 	// Global variables
 	GlobalVars map[string]any `yaml:"globalVars" json:"globalVars"`
 
+	// Global variables holding secrets, loaded from environment variables or files.
+	// Secret values are masked in variable maps, task configs, logs and command output.
+	SecretVars map[string]*secrets.Source `yaml:"secretVars" json:"secretVars"`
+
 	// Coordinator config
 	Coordinator *CoordinatorConfig `yaml:"coordinator" json:"coordinator"`
 
+	// OpenTelemetry tracing config
+	Tracing *tracing.Config `yaml:"tracing" json:"tracing"`
+
+	// Persisted event log for replaying missed events
+	EventLog *events.LogConfig `yaml:"eventLog" json:"eventLog"`
+
+	// Webhook & chat notifications for test lifecycle events
+	Notifications *notifier.Config `yaml:"notifications" json:"notifications"`
+
 	// AI assistant config
 	AI *web_types.AIConfig `yaml:"ai" json:"ai"`
 
@@ -57,6 +83,12 @@ type CoordinatorConfig struct {
 
 	// Test history cleanup delay
 	TestRetentionTime helper.Duration `yaml:"testRetentionTime" json:"testRetentionTime"`
+
+	// Resume test runs that were interrupted by a restart
+	ResumeTests bool `yaml:"resumeTests" json:"resumeTests"`
+
+	// Maximum number of test runs a single test sweep may fan out into (default: 100)
+	MaxSweepRuns int `yaml:"maxSweepRuns" json:"maxSweepRuns"`
 }
 
 // DefaultConfig represents a sane-default configuration.
@@ -69,8 +101,10 @@ func DefaultConfig() *Config {
 				ConsensusURL: "http://localhost:5052",
 			},
 		},
+		ClientPool:      clients.DefaultPoolConfig(),
 		GlobalVars:      make(map[string]any),
 		Coordinator:     &CoordinatorConfig{},
+		EventLog:        events.DefaultLogConfig(),
 		AI:              web_types.DefaultAIConfig(),
 		PlaybookLibrary: playbooklibrary.DefaultConfig(),
 		Tests:           []*types.TestConfig{},
@@ -114,11 +148,17 @@ func (c *Config) Validate() error {
 	}
 
 	// Validate endpoints
+	endpointNames := map[string]bool{}
+
 	for i, endpoint := range c.Endpoints {
 		if endpoint.Name == "" {
 			errs = append(errs, fmt.Errorf("endpoint[%d]: name cannot be empty", i))
+		} else if endpointNames[endpoint.Name] {
+			errs = append(errs, fmt.Errorf("endpoint[%d]: duplicate name '%s'", i, endpoint.Name))
 		}
 
+		endpointNames[endpoint.Name] = true
+
 		if endpoint.ConsensusURL == "" && endpoint.ExecutionURL == "" {
 			errs = append(errs, fmt.Errorf("endpoint[%d] '%s': must have at least one URL", i, endpoint.Name))
 		}
@@ -136,6 +176,28 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate client pool config
+	if c.ClientPool != nil {
+		if c.ClientPool.Consensus != nil {
+			if _, err := consensus.ParseSchedulerMode(c.ClientPool.Consensus.SchedulerMode); err != nil {
+				errs = append(errs, fmt.Errorf("clientPool.consensus: %v", err))
+			}
+		}
+
+		if c.ClientPool.Execution != nil {
+			if _, err := execution.ParseSchedulerMode(c.ClientPool.Execution.SchedulerMode); err != nil {
+				errs = append(errs, fmt.Errorf("clientPool.execution: %v", err))
+			}
+		}
+	}
+
+	// Validate endpoint discovery config
+	if c.EndpointDiscovery != nil {
+		if err := c.EndpointDiscovery.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("endpointDiscovery config: %v", err))
+		}
+	}
+
 	// Validate web config
 	if c.Web != nil {
 		if c.Web.Frontend != nil && c.Web.Frontend.Enabled {
@@ -157,6 +219,22 @@ func (c *Config) Validate() error {
 		}
 	}
 
+	// Validate secret variables
+	for name, source := range c.SecretVars {
+		if source == nil {
+			errs = append(errs, fmt.Errorf("secret variable %v: no source configured", name))
+		} else if err := source.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("secret variable %v: %v", name, err))
+		}
+	}
+
+	// Validate notifications config
+	if c.Notifications != nil {
+		if err := c.Notifications.Validate(); err != nil {
+			errs = append(errs, fmt.Errorf("notifications config: %v", err))
+		}
+	}
+
 	// Validate AI config
 	if c.AI != nil {
 		if err := c.AI.Validate(); err != nil {
@@ -217,5 +295,9 @@ func (c *CoordinatorConfig) Validate() error {
 		}
 	}
 
+	if c.MaxSweepRuns < 0 {
+		return fmt.Errorf("maxSweepRuns cannot be negative")
+	}
+
 	return nil
 }
@@ -11,15 +11,20 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/analytics"
 	"github.com/ethpandaops/assertoor/pkg/buildinfo"
 	"github.com/ethpandaops/assertoor/pkg/clients"
 	"github.com/ethpandaops/assertoor/pkg/clients/consensus"
+	"github.com/ethpandaops/assertoor/pkg/clients/discovery"
 	"github.com/ethpandaops/assertoor/pkg/db"
 	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
 	"github.com/ethpandaops/assertoor/pkg/logger"
 	"github.com/ethpandaops/assertoor/pkg/names"
+	"github.com/ethpandaops/assertoor/pkg/notifier"
 	"github.com/ethpandaops/assertoor/pkg/playbooklibrary"
 	"github.com/ethpandaops/assertoor/pkg/test"
+	"github.com/ethpandaops/assertoor/pkg/tracing"
 	"github.com/ethpandaops/assertoor/pkg/txmgr"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/ethpandaops/assertoor/pkg/vars"
@@ -84,6 +89,132 @@ func (c *Coordinator) Run(ctx context.Context) error {
 		c.log.GetLogger().Warnf("BLS key generation self test failed: %v", err)
 	}
 
+	stopServices, err := c.initServices(ctx)
+	defer stopServices()
+
+	if err != nil {
+		return err
+	}
+
+	// load state from database
+	lastTestRunID := uint64(0)
+	//nolint:errcheck // ignore missing state
+	c.database.GetAssertoorState("test.lastRunId", &lastTestRunID)
+
+	// init webserver
+	if c.Config.Web != nil && c.Config.Web.Server != nil {
+		c.webserver, err = web.NewWebServer(c.Config.Web.Server, c.log.GetLogger())
+		if err != nil {
+			return err
+		}
+
+		err = c.webserver.ConfigureRoutes(c.Config.Web, c.Config.AI, c, c.eventBus)
+		if err != nil {
+			return err
+		}
+	}
+
+	//nolint:errcheck // ignore
+	go c.startMetrics()
+
+	// init playbook library service (UI's Library tab)
+	c.playbookLibrary = playbooklibrary.NewService(
+		c.Config.PlaybookLibrary,
+		c.log.GetLogger(),
+		&coordinatorLocalTestProvider{coordinator: c},
+	)
+
+	// init test registry
+	c.registry = NewTestRegistry(c)
+	c.registry.LoadTests(ctx, c.Config.Tests, c.Config.ExternalTests)
+
+	// init test runner
+	c.runner = NewTestRunner(c, lastTestRunID)
+	c.runner.maxSweepRuns = c.Config.Coordinator.MaxSweepRuns
+
+	// init notifications
+	if c.Config.Notifications != nil {
+		notificationService, err := notifier.NewService(c.Config.Notifications, c, c.log.GetLogger())
+		if err != nil {
+			return err
+		}
+
+		notificationService.Start(ctx)
+		defer notificationService.Stop()
+	}
+
+	// init test history analytics
+	analyticsService := analytics.NewService(c.database, c.eventBus, c.log.GetLogger())
+	analyticsService.Start(ctx)
+
+	defer analyticsService.Stop()
+
+	// start expiry cleanup of the persistent key/value store
+	go kvstore.NewStore(c.database).RunCleanup(ctx, c.log.GetLogger().WithField("module", "kvstore"))
+
+	// resume or abort test runs that got interrupted by the last shutdown
+	resumedRunIDs := []uint64{}
+
+	if c.Config.Coordinator.ResumeTests {
+		uncleanTestRuns, err := c.database.GetUncleanTestRuns()
+		if err != nil {
+			return err
+		}
+
+		resumedRunIDs = c.runner.ResumeTestRuns(uncleanTestRuns)
+	}
+
+	err = c.database.RunTransaction(func(tx *sqlx.Tx) error {
+		return c.database.CleanupUncleanTestRuns(tx, resumedRunIDs...)
+	})
+	if err != nil {
+		return err
+	}
+
+	// start test scheduler
+	go c.runner.RunTestScheduler(ctx)
+
+	// start test cleanup routine
+	go c.runner.RunTestCleanup(ctx, c.Config.Coordinator.TestRetentionTime.Duration)
+
+	// start per epoch GC routine
+	go c.runEpochGC(ctx)
+
+	// start off queue test execution loop
+	go c.runner.RunOffQueueTestExecutionLoop(ctx)
+
+	// run test execution loop for queued tests
+	c.runner.RunTestExecutionLoop(ctx, c.Config.Coordinator.MaxConcurrentTests)
+
+	return nil
+}
+
+// initServices initializes tracing, the database, client pool, wallet manager, global variables,
+// event bus (with event log) and validator names. The returned function shuts down the services again
+// and needs to be called even if the initialization failed.
+func (c *Coordinator) initServices(ctx context.Context) (func(), error) {
+	stopFns := []func(){}
+	stopServices := func() {
+		for i := len(stopFns) - 1; i >= 0; i-- {
+			stopFns[i]()
+		}
+	}
+
+	// init tracing
+	stopTracing, err := tracing.Init(ctx, c.Config.Tracing)
+	if err != nil {
+		return stopServices, err
+	}
+
+	stopFns = append(stopFns, func() {
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+
+		if err := stopTracing(shutdownCtx); err != nil {
+			c.log.GetLogger().Warnf("failed flushing traces: %v", err)
+		}
+	})
+
 	// init database
 	database := db.NewDatabase(c.log.GetLogger())
 
@@ -97,40 +228,28 @@ func (c *Coordinator) Run(ctx context.Context) error {
 		}
 	}
 
-	err := database.InitDB(c.Config.Database)
+	err = database.InitDB(c.Config.Database)
 	if err != nil {
-		return err
+		return stopServices, err
 	}
 
 	err = database.ApplySchema(-2)
 	if err != nil {
-		return err
+		return stopServices, err
 	}
 
 	c.database = database
 
-	defer func() {
+	stopFns = append(stopFns, func() {
 		fmt.Println("Closing database")
 		//nolint:errcheck // ignore error
 		c.database.CloseDB()
-	}()
-
-	// load state from database
-	lastTestRunID := uint64(0)
-	//nolint:errcheck // ignore missing state
-	c.database.GetAssertoorState("test.lastRunId", &lastTestRunID)
-
-	err = c.database.RunTransaction(func(tx *sqlx.Tx) error {
-		return c.database.CleanupUncleanTestRuns(tx)
 	})
-	if err != nil {
-		return err
-	}
 
 	// init client pool
-	clientPool, err := clients.NewClientPool(c.log.GetLogger())
+	clientPool, err := clients.NewClientPool(c.log.GetLogger(), c.Config.ClientPool)
 	if err != nil {
-		return err
+		return stopServices, err
 	}
 
 	c.clientPool = clientPool
@@ -138,88 +257,86 @@ func (c *Coordinator) Run(ctx context.Context) error {
 	for idx := range c.Config.Endpoints {
 		err = clientPool.AddClient(&c.Config.Endpoints[idx])
 		if err != nil {
-			return err
+			return stopServices, err
 		}
 	}
 
+	// add endpoints from the discovery sources
+	var endpointDiscovery *discovery.Service
+
+	if c.Config.EndpointDiscovery != nil && len(c.Config.EndpointDiscovery.Sources) > 0 {
+		endpointDiscovery, err = discovery.NewService(c.Config.EndpointDiscovery, clientPool, c.log.GetLogger())
+		if err != nil {
+			return stopServices, err
+		}
+
+		endpointDiscovery.Sync(ctx)
+	}
+
 	// init spamoor
 	spamoorManager, err := txmgr.NewSpamoor(ctx, c.log.GetLogger(), clientPool.GetExecutionPool())
 	if err != nil {
-		return err
+		return stopServices, err
 	}
 
 	c.walletManager = spamoorManager
 
-	// init global variables
-	c.globalVars = vars.NewVariables(nil)
-	for name, value := range c.Config.GlobalVars {
-		c.globalVars.SetVar(name, value)
+	// create spamoor clients for endpoints added at runtime
+	clientPool.AddEndpointHook(spamoorManager)
+
+	// keep discovered endpoints in sync
+	if endpointDiscovery != nil {
+		go endpointDiscovery.Run(ctx)
+	}
+
+	// init global variables (already initialized when validating a single test)
+	if c.globalVars == nil {
+		if err := c.initGlobalVars(); err != nil {
+			return stopServices, err
+		}
 	}
 
 	// init event bus
 	c.eventBus = events.NewEventBus(c.log.GetLogger())
 
+	if c.Config.EventLog != nil && c.Config.EventLog.Enabled {
+		c.eventBus.SetEventLog(events.NewEventLog(c.Config.EventLog, c.database, c.log.GetLogger()))
+	}
+
 	err = c.eventBus.Start(ctx)
 	if err != nil {
-		return fmt.Errorf("failed to start event bus: %w", err)
+		return stopServices, fmt.Errorf("failed to start event bus: %w", err)
 	}
 
-	defer func() {
+	stopFns = append(stopFns, func() {
 		//nolint:errcheck // ignore error on shutdown
 		c.eventBus.Stop()
-	}()
+	})
 
 	// Hook event bus into client pool for client update events
 	c.clientPool.SetEventBus(c.eventBus)
 
-	// init webserver
-	if c.Config.Web != nil && c.Config.Web.Server != nil {
-		c.webserver, err = web.NewWebServer(c.Config.Web.Server, c.log.GetLogger())
-		if err != nil {
-			return err
-		}
-
-		err = c.webserver.ConfigureRoutes(c.Config.Web, c.Config.AI, c, c.eventBus)
-		if err != nil {
-			return err
-		}
-	}
-
-	//nolint:errcheck // ignore
-	go c.startMetrics()
-
 	// load validator names
 	c.validatorNames = names.NewValidatorNames(c.Config.ValidatorNames, c.log.GetLogger())
 	c.validatorNames.LoadValidatorNames()
 
-	// init playbook library service (UI's Library tab)
-	c.playbookLibrary = playbooklibrary.NewService(
-		c.Config.PlaybookLibrary,
-		c.log.GetLogger(),
-		&coordinatorLocalTestProvider{coordinator: c},
-	)
-
-	// init test registry
-	c.registry = NewTestRegistry(c)
-	c.registry.LoadTests(ctx, c.Config.Tests, c.Config.ExternalTests)
-
-	// init test runner
-	c.runner = NewTestRunner(c, lastTestRunID)
-
-	// start test scheduler
-	go c.runner.RunTestScheduler(ctx)
-
-	// start test cleanup routine
-	go c.runner.RunTestCleanup(ctx, c.Config.Coordinator.TestRetentionTime.Duration)
+	return stopServices, nil
+}
 
-	// start per epoch GC routine
-	go c.runEpochGC(ctx)
+func (c *Coordinator) initGlobalVars() error {
+	c.globalVars = vars.NewVariables(nil)
+	for name, value := range c.Config.GlobalVars {
+		c.globalVars.SetVar(name, value)
+	}
 
-	// start off queue test execution loop
-	go c.runner.RunOffQueueTestExecutionLoop(ctx)
+	for name, source := range c.Config.SecretVars {
+		value, err := source.Load()
+		if err != nil {
+			return fmt.Errorf("failed loading secret variable %v: %w", name, err)
+		}
 
-	// run test execution loop for queued tests
-	c.runner.RunTestExecutionLoop(ctx, c.Config.Coordinator.MaxConcurrentTests)
+		c.globalVars.SetSecretVar(name, value)
+	}
 
 	return nil
 }
@@ -324,6 +441,27 @@ func (c *Coordinator) DeleteTestRun(runID uint64) error {
 	return err
 }
 
+// ImportTestRun allocates a run ID for a test run exported by another instance, stores it as finished,
+// read-only run via importFn and returns its new run ID.
+func (c *Coordinator) ImportTestRun(importFn func(tx *sqlx.Tx, runID uint64) error) (uint64, error) {
+	runID, err := c.runner.ImportTestRun(func(runID uint64) error {
+		return c.database.RunTransaction(func(tx *sqlx.Tx) error {
+			if err := importFn(tx, runID); err != nil {
+				return err
+			}
+
+			return c.database.SetAssertoorState(tx, "test.lastRunId", runID)
+		})
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	c.log.GetLogger().Infof("imported test run #%v", runID)
+
+	return runID, nil
+}
+
 func (c *Coordinator) ScheduleTest(descriptor types.TestDescriptor, configOverrides map[string]any, allowDuplicate, skipQueue bool) (types.TestRunner, error) {
 	return c.runner.ScheduleTest(descriptor, configOverrides, allowDuplicate, skipQueue)
 }
@@ -332,6 +470,15 @@ func (c *Coordinator) ScheduleTestWithOptions(descriptor types.TestDescriptor, c
 	return c.runner.ScheduleTestWithOptions(descriptor, configOverrides, opts)
 }
 
+func (c *Coordinator) ScheduleTestSweep(descriptor types.TestDescriptor, configOverrides map[string]any, matrix map[string][]any, opts types.ScheduleOptions) (*types.TestSweep, error) {
+	return c.runner.ScheduleTestSweep(descriptor, configOverrides, matrix, opts)
+}
+
+// PlanTest expands the task tree of a test without executing it.
+func (c *Coordinator) PlanTest(descriptor types.TestDescriptor, configOverrides map[string]any) *types.TestPlan {
+	return test.PlanTest(descriptor, c.log.GetLogger().WithField("module", "plan"), c, configOverrides)
+}
+
 func (c *Coordinator) startMetrics() error {
 	c.log.GetLogger().
 		Info(fmt.Sprintf("Starting metrics server on :%v", c.metricsPort))
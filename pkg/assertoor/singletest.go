@@ -0,0 +1,368 @@
+package assertoor
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/report"
+	"github.com/ethpandaops/assertoor/pkg/test"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// SingleTestOptions configures a single test run started via RunSingleTest.
+type SingleTestOptions struct {
+	// EventFn gets called for all test & task events of the test run.
+	EventFn func(event *events.Event)
+	// OutputDir is the directory to write the task tree, outputs and result artifacts to (optional).
+	OutputDir string
+	// ReportFormat is the format of the test report to write (junit, tap or json-summary, optional).
+	ReportFormat string
+	// ReportFile is the file to write the test report to.
+	// Defaults to report.<ext> in the output directory, or stdout if no output directory is set.
+	ReportFile string
+}
+
+type singleTestSummary struct {
+	RunID     uint64            `json:"runId"`
+	TestID    string            `json:"testId"`
+	Name      string            `json:"name"`
+	Status    types.TestStatus  `json:"status"`
+	StartTime int64             `json:"startTime"`
+	StopTime  int64             `json:"stopTime"`
+	Tasks     []*singleTestTask `json:"tasks"`
+	Results   []*singleTestFile `json:"results,omitempty"`
+}
+
+type singleTestTask struct {
+	Index       uint64         `json:"index"`
+	ParentIndex uint64         `json:"parentIndex"`
+	ID          string         `json:"id,omitempty"`
+	Name        string         `json:"name"`
+	Title       string         `json:"title"`
+	Status      string         `json:"status"`
+	Result      string         `json:"result"`
+	Error       string         `json:"error,omitempty"`
+	StartTime   int64          `json:"startTime,omitempty"`
+	StopTime    int64          `json:"stopTime,omitempty"`
+	Outputs     map[string]any `json:"outputs,omitempty"`
+}
+
+type singleTestFile struct {
+	TaskIndex uint64 `json:"taskIndex"`
+	Type      string `json:"type"`
+	Name      string `json:"name"`
+	File      string `json:"file"`
+}
+
+var singleTestFileNameRegex = regexp.MustCompile(`[^a-zA-Z0-9._-]+`)
+
+// RunSingleTest initializes the coordinator services and runs a single test without the web server,
+// test registry or scheduler. It returns the finished test run.
+func (c *Coordinator) RunSingleTest(ctx context.Context, extTestCfg *types.ExternalTestConfig, opts *SingleTestOptions) (types.TestRunner, error) {
+	if opts == nil {
+		opts = &SingleTestOptions{}
+	}
+
+	// load & validate test config before any client or service gets started
+	if err := c.initGlobalVars(); err != nil {
+		return nil, err
+	}
+
+	descriptor, err := c.loadSingleTestDescriptor(ctx, extTestCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := c.validateSingleTest(descriptor); err != nil {
+		return nil, err
+	}
+
+	stopServices, err := c.initServices(ctx)
+	defer stopServices()
+
+	if err != nil {
+		return nil, err
+	}
+
+	// load state from database
+	lastTestRunID := uint64(0)
+	//nolint:errcheck // ignore missing state
+	c.database.GetAssertoorState("test.lastRunId", &lastTestRunID)
+
+	c.runner = NewTestRunner(c, lastTestRunID)
+
+	// subscribe to test & task events before the test gets created, so no task.created event is missed
+	var eventsDone chan struct{}
+
+	if opts.EventFn != nil {
+		eventSub := c.eventBus.Subscribe(events.CreateEventTypeFilter(
+			events.EventTestStarted, events.EventTestCompleted, events.EventTestFailed,
+			events.EventTestPaused, events.EventTestResumed,
+			events.EventTaskCreated, events.EventTaskStarted, events.EventTaskProgress,
+			events.EventTaskCompleted, events.EventTaskFailed,
+		))
+		defer c.eventBus.Unsubscribe(eventSub)
+
+		eventsDone = make(chan struct{})
+
+		go func() {
+			defer close(eventsDone)
+
+			for event := range eventSub.Channel() {
+				opts.EventFn(event)
+
+				if event.Type == events.EventTestCompleted || event.Type == events.EventTestFailed {
+					return
+				}
+			}
+		}()
+	}
+
+	testRef, err := c.runner.createTestRun(descriptor, nil, types.ScheduleOptions{SkipQueue: true})
+	if err != nil {
+		return nil, err
+	}
+
+	// test dependencies & triggers refer to other registered tests, so they are not resolved here
+	c.runner.executeTest(ctx, testRef)
+
+	if eventsDone != nil {
+		// wait for pending events to be delivered
+		select {
+		case <-eventsDone:
+		case <-time.After(2 * time.Second):
+		}
+	}
+
+	if opts.OutputDir != "" {
+		if err := c.writeSingleTestOutput(testRef, opts.OutputDir); err != nil {
+			return testRef, fmt.Errorf("failed writing test output: %w", err)
+		}
+	}
+
+	if opts.ReportFormat != "" {
+		if err := writeSingleTestReport(testRef, opts); err != nil {
+			return testRef, fmt.Errorf("failed writing test report: %w", err)
+		}
+	}
+
+	return testRef, nil
+}
+
+// writeSingleTestReport renders the test report while the task logs are still accessible.
+func writeSingleTestReport(testRef types.TestRunner, opts *SingleTestOptions) error {
+	reportData, _, err := report.Generate(testRef, opts.ReportFormat, true)
+	if err != nil {
+		return err
+	}
+
+	switch {
+	case opts.ReportFile != "":
+		if err := os.MkdirAll(filepath.Dir(opts.ReportFile), 0o755); err != nil {
+			return err
+		}
+
+		return os.WriteFile(opts.ReportFile, reportData, 0o600)
+	case opts.OutputDir != "":
+		return writeSingleTestFile(opts.OutputDir, fmt.Sprintf("report.%v", report.FileExtension(opts.ReportFormat)), reportData)
+	default:
+		_, err := os.Stdout.Write(reportData)
+		return err
+	}
+}
+
+// PlanSingleTest expands the task tree of a single test without executing it.
+// No services are initialized, the plan only depends on the test config and global variables.
+func (c *Coordinator) PlanSingleTest(ctx context.Context, extTestCfg *types.ExternalTestConfig) (*types.TestPlan, error) {
+	if err := c.initGlobalVars(); err != nil {
+		return nil, err
+	}
+
+	descriptor, err := c.loadSingleTestDescriptor(ctx, extTestCfg)
+	if err != nil {
+		return nil, err
+	}
+
+	return c.PlanTest(descriptor, nil), nil
+}
+
+// validateSingleTest checks the task definitions of a single test.
+// Task config errors are not checked, as config variables might only resolve at runtime.
+func (c *Coordinator) validateSingleTest(descriptor types.TestDescriptor) error {
+	testPlan := c.PlanTest(descriptor, nil)
+
+	if len(testPlan.Errors) > 0 {
+		return fmt.Errorf("invalid test config: %v", strings.Join(testPlan.Errors, ", "))
+	}
+
+	if len(testPlan.Tasks) == 0 {
+		return fmt.Errorf("test %s has no tasks", testPlan.Name)
+	}
+
+	return nil
+}
+
+func (c *Coordinator) loadSingleTestDescriptor(ctx context.Context, extTestCfg *types.ExternalTestConfig) (types.TestDescriptor, error) {
+	testConfig, testVars, basePath, _, err := test.LoadExternalTestConfig(ctx, c.globalVars, extTestCfg)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test config: %w", err)
+	}
+
+	testID := testConfig.ID
+	if testID == "" {
+		testID = extTestCfg.ID
+	}
+
+	return test.NewDescriptor(testID, fmt.Sprintf("external:%v", extTestCfg.File), basePath, testConfig, testVars), nil
+}
+
+// writeSingleTestOutput writes the task tree with outputs and all result artifacts of a test run to the output directory.
+func (c *Coordinator) writeSingleTestOutput(testRef types.TestRunner, outputDir string) error {
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return err
+	}
+
+	summary := &singleTestSummary{
+		RunID:  testRef.RunID(),
+		TestID: testRef.TestID(),
+		Name:   testRef.Name(),
+		Status: testRef.Status(),
+		Tasks:  []*singleTestTask{},
+	}
+
+	if !testRef.StartTime().IsZero() {
+		summary.StartTime = testRef.StartTime().UnixMilli()
+	}
+
+	if !testRef.StopTime().IsZero() {
+		summary.StopTime = testRef.StopTime().UnixMilli()
+	}
+
+	taskScheduler := testRef.GetTaskScheduler()
+	if taskScheduler != nil {
+		allTasks := append(taskScheduler.GetAllTasks(), taskScheduler.GetAllCleanupTasks()...)
+
+		for _, taskIndex := range allTasks {
+			summary.Tasks = append(summary.Tasks, getSingleTestTask(taskScheduler.GetTaskState(taskIndex)))
+		}
+	}
+
+	// write result artifacts
+	resultHeaders, err := c.database.GetAllTaskResultHeaders(testRef.RunID())
+	if err != nil {
+		return fmt.Errorf("failed loading result headers: %w", err)
+	}
+
+	for _, header := range resultHeaders {
+		result, err := c.database.GetTaskResultByIndex(testRef.RunID(), header.TaskID, header.Type, int(header.Index)) //nolint:gosec // no overflow
+		if err != nil {
+			return fmt.Errorf("failed loading result %v of task %v: %w", header.Name, header.TaskID, err)
+		}
+
+		fileName := path.Join(
+			"results",
+			fmt.Sprintf("task-%v", header.TaskID),
+			fmt.Sprintf("%v-%v-%v", header.Type, header.Index, singleTestFileNameRegex.ReplaceAllString(header.Name, "_")),
+		)
+
+		if err := writeSingleTestFile(outputDir, fileName, result.Data); err != nil {
+			return err
+		}
+
+		summary.Results = append(summary.Results, &singleTestFile{
+			TaskIndex: header.TaskID,
+			Type:      header.Type,
+			Name:      header.Name,
+			File:      fileName,
+		})
+	}
+
+	testResult, err := c.database.GetTestResult(testRef.RunID())
+	if err != nil {
+		return fmt.Errorf("failed loading test result: %w", err)
+	}
+
+	if testResult != nil {
+		if err := writeSingleTestFile(outputDir, "result.md", testResult.Data); err != nil {
+			return err
+		}
+	}
+
+	summaryJSON, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return fmt.Errorf("failed encoding summary: %w", err)
+	}
+
+	return writeSingleTestFile(outputDir, "summary.json", summaryJSON)
+}
+
+func getSingleTestTask(taskState types.TaskState) *singleTestTask {
+	taskStatus := taskState.GetTaskStatus()
+
+	taskData := &singleTestTask{
+		Index:       uint64(taskState.Index()),
+		ParentIndex: uint64(taskState.ParentIndex()),
+		ID:          taskState.ID(),
+		Name:        taskState.Name(),
+		Title:       taskState.Title(),
+	}
+
+	switch {
+	case !taskStatus.IsStarted:
+		taskData.Status = "pending"
+	case taskStatus.IsRunning:
+		taskData.Status = "running"
+		taskData.StartTime = taskStatus.StartTime.UnixMilli()
+	default:
+		taskData.Status = "complete"
+		taskData.StartTime = taskStatus.StartTime.UnixMilli()
+		taskData.StopTime = taskStatus.StopTime.UnixMilli()
+	}
+
+	switch taskStatus.Result {
+	case types.TaskResultSuccess:
+		taskData.Result = "success"
+	case types.TaskResultFailure:
+		taskData.Result = "failure"
+	default:
+		taskData.Result = "none"
+	}
+
+	if taskStatus.Error != nil {
+		taskData.Error = taskStatus.Error.Error()
+	}
+
+	// round-trip the outputs through yaml to get json compatible values
+	outputs := taskState.GetTaskStatusVars().GetSubScope("outputs").GetVarsMap(nil, false)
+	if len(outputs) > 0 {
+		if outputsYaml, err := yaml.Marshal(outputs); err == nil {
+			//nolint:errcheck // ignore errors, outputs are left empty
+			yaml.Unmarshal(outputsYaml, &taskData.Outputs)
+		}
+	}
+
+	return taskData
+}
+
+func writeSingleTestFile(outputDir, fileName string, data []byte) error {
+	filePath := filepath.Join(outputDir, filepath.FromSlash(fileName))
+
+	if err := os.MkdirAll(filepath.Dir(filePath), 0o755); err != nil {
+		return err
+	}
+
+	if err := os.WriteFile(filePath, data, 0o600); err != nil {
+		return fmt.Errorf("failed writing %v: %w", fileName, err)
+	}
+
+	return nil
+}
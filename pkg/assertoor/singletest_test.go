@@ -0,0 +1,51 @@
+package assertoor
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+func TestRunSingleTestValidation(t *testing.T) {
+	tests := []struct {
+		name    string
+		yaml    string
+		wantErr string
+	}{
+		{
+			name:    "no tasks",
+			yaml:    "id: empty\nname: empty test\n",
+			wantErr: "test empty test has no tasks",
+		},
+		{
+			name:    "unknown task",
+			yaml:    "id: unknown\nname: unknown task\ntasks:\n- name: not_a_task\n",
+			wantErr: "invalid test config: task #1:",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			coordinator := NewCoordinator(&Config{}, logrus.New(), 0)
+
+			testRef, err := coordinator.RunSingleTest(t.Context(), &types.ExternalTestConfig{YamlSource: tt.yaml}, nil)
+			if err == nil {
+				t.Fatalf("RunSingleTest() error = nil, want %q", tt.wantErr)
+			}
+
+			if !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("RunSingleTest() error = %q, want %q", err.Error(), tt.wantErr)
+			}
+
+			if testRef != nil {
+				t.Errorf("RunSingleTest() test = %v, want nil", testRef.RunID())
+			}
+
+			if coordinator.database != nil || coordinator.clientPool != nil {
+				t.Errorf("RunSingleTest() started services for an invalid test")
+			}
+		})
+	}
+}
@@ -0,0 +1,272 @@
+package assertoor
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/ethpandaops/assertoor/pkg/vars"
+)
+
+// maxTriggerDepth is the maximum number of consecutively triggered test runs in a trigger chain.
+const maxTriggerDepth = 10
+
+func (c *TestRunner) getTestDescriptor(testID string) types.TestDescriptor {
+	for _, testDescr := range c.coordinator.TestRegistry().GetTestDescriptors() {
+		if testDescr.ID() == testID {
+			return testDescr
+		}
+	}
+
+	return nil
+}
+
+func (c *TestRunner) getTestDependencies(testID string) []types.TestDependency {
+	testDescr := c.getTestDescriptor(testID)
+	if testDescr == nil || testDescr.Err() != nil {
+		return nil
+	}
+
+	return testDescr.Config().DependsOn
+}
+
+// getLatestTestRun returns the most recent run of the given test.
+// The caller must hold the testRegistryMutex.
+func (c *TestRunner) getLatestTestRun(testID string) types.Test {
+	var latestRun types.Test
+
+	for runID, testRun := range c.testRunMap {
+		if testRun.TestID() == testID && (latestRun == nil || runID > latestRun.RunID()) {
+			latestRun = testRun
+		}
+	}
+
+	return latestRun
+}
+
+// hasQueuedDependencies checks if the latest run of any upstream test is still waiting in the queue.
+// The caller must hold the testRegistryMutex.
+func (c *TestRunner) hasQueuedDependencies(testRef types.TestRunner) bool {
+	for _, dependency := range c.getTestDependencies(testRef.TestID()) {
+		upstreamRun := c.getLatestTestRun(dependency.Test)
+		if upstreamRun != nil && upstreamRun.Status() == types.TestStatusPending {
+			return true
+		}
+	}
+
+	return false
+}
+
+// waitTestDependencies waits for the latest runs of all upstream tests to complete.
+// It returns the merged outputs of the upstream runs, or an error if a dependency is not satisfied.
+func (c *TestRunner) waitTestDependencies(ctx context.Context, testRef types.TestRunner) (map[string]any, error) {
+	upstreamOutputs := map[string]any{}
+
+	for _, dependency := range c.getTestDependencies(testRef.TestID()) {
+		loggedWait := false
+
+		for {
+			c.testRegistryMutex.RLock()
+			upstreamRun := c.getLatestTestRun(dependency.Test)
+			c.testRegistryMutex.RUnlock()
+
+			if upstreamRun == nil {
+				return nil, fmt.Errorf("upstream test %v has not been run", dependency.Test)
+			}
+
+			upstreamStatus := upstreamRun.Status()
+			if upstreamStatus != types.TestStatusPending && upstreamStatus != types.TestStatusRunning {
+				if !types.MatchTestStatus(dependency.Status, upstreamStatus) {
+					return nil, fmt.Errorf("upstream test %v (run #%v) completed with status %v", dependency.Test, upstreamRun.RunID(), upstreamStatus)
+				}
+
+				maps.Copy(upstreamOutputs, getTestRunOutputs(upstreamRun))
+
+				break
+			}
+
+			if !loggedWait {
+				testRef.Logger().Infof("waiting for upstream test %v (run #%v)", dependency.Test, upstreamRun.RunID())
+				loggedWait = true
+			}
+
+			select {
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			case <-time.After(2 * time.Second):
+			}
+		}
+	}
+
+	return upstreamOutputs, nil
+}
+
+// scheduleTestTriggers schedules the downstream tests of a completed test run.
+// The outputs of the test run are passed to the downstream runs as config overrides.
+func (c *TestRunner) scheduleTestTriggers(testRef types.TestRunner) {
+	c.testRegistryMutex.Lock()
+	triggerDepth := c.triggerDepths[testRef.RunID()]
+	delete(c.triggerDepths, testRef.RunID())
+	c.testRegistryMutex.Unlock()
+
+	testDescr := c.getTestDescriptor(testRef.TestID())
+	if testDescr == nil || testDescr.Err() != nil {
+		return
+	}
+
+	var testOutputs map[string]any
+
+	for _, trigger := range testDescr.Config().Triggers {
+		if !types.MatchTestStatus(trigger.Status, testRef.Status()) {
+			continue
+		}
+
+		if triggerDepth >= maxTriggerDepth {
+			testRef.Logger().Errorf("could not schedule triggered test %v: trigger chain exceeds the maximum depth of %v runs", trigger.Test, maxTriggerDepth)
+			continue
+		}
+
+		triggerDescr := c.getTestDescriptor(trigger.Test)
+		if triggerDescr == nil {
+			testRef.Logger().Errorf("could not schedule triggered test %v: test not found", trigger.Test)
+			continue
+		}
+
+		if testOutputs == nil {
+			testOutputs = getTestRunOutputs(testRef)
+		}
+
+		triggerConfig := triggerDescr.Config()
+		skipQueue := triggerConfig.Schedule != nil && triggerConfig.Schedule.SkipQueue
+
+		triggerRuns, err := c.scheduleTest(triggerDescr, maps.Clone(testOutputs), types.ScheduleOptions{
+			SkipQueue:    skipQueue,
+			TriggerDepth: triggerDepth + 1,
+		})
+		if err != nil {
+			testRef.Logger().Errorf("could not schedule triggered test %v: %v", trigger.Test, err)
+			continue
+		}
+
+		for _, triggerRun := range triggerRuns {
+			testRef.Logger().Infof("scheduled triggered test %v (run #%v)", trigger.Test, triggerRun.RunID())
+		}
+	}
+}
+
+// findTestGraphCycle checks the triggers and dependencies between the given tests for cycles.
+// Trigger cycles would schedule test runs forever and dependency cycles would never run, so they're rejected.
+// It returns the kind ("trigger" or "dependency") and the test IDs of the first found cycle, or a nil cycle if there is none.
+func findTestGraphCycle(testConfigs map[string]*types.TestConfig) (kind string, cycle []string) {
+	triggerEdges := map[string][]string{}
+	dependencyEdges := map[string][]string{}
+
+	for testID, testConfig := range testConfigs {
+		for _, trigger := range testConfig.Triggers {
+			triggerEdges[testID] = append(triggerEdges[testID], trigger.Test)
+		}
+
+		for _, dependency := range testConfig.DependsOn {
+			dependencyEdges[testID] = append(dependencyEdges[testID], dependency.Test)
+		}
+	}
+
+	if cycle := findGraphCycle(triggerEdges); cycle != nil {
+		return "trigger", cycle
+	}
+
+	if cycle := findGraphCycle(dependencyEdges); cycle != nil {
+		return "dependency", cycle
+	}
+
+	return "", nil
+}
+
+// formatTestGraphCycle returns a readable description of a test graph cycle.
+func formatTestGraphCycle(kind string, cycle []string) string {
+	return fmt.Sprintf("%v cycle: %v", kind, strings.Join(cycle, " -> "))
+}
+
+// findGraphCycle returns the nodes of a cycle in the directed graph (first node repeated at the end), or nil if the graph is acyclic.
+func findGraphCycle(edges map[string][]string) []string {
+	const (
+		nodeUnvisited = iota
+		nodeInProgress
+		nodeDone
+	)
+
+	nodeStates := map[string]int{}
+	path := []string{}
+
+	var visit func(node string) []string
+
+	visit = func(node string) []string {
+		switch nodeStates[node] {
+		case nodeInProgress:
+			for idx, pathNode := range path {
+				if pathNode == node {
+					return append(append([]string{}, path[idx:]...), node)
+				}
+			}
+		case nodeDone:
+			return nil
+		}
+
+		nodeStates[node] = nodeInProgress
+		path = append(path, node)
+
+		for _, next := range edges[node] {
+			if cycle := visit(next); cycle != nil {
+				return cycle
+			}
+		}
+
+		path = path[:len(path)-1]
+		nodeStates[node] = nodeDone
+
+		return nil
+	}
+
+	// visit in a stable order, so the reported cycle is deterministic
+	nodes := make([]string, 0, len(edges))
+	for node := range edges {
+		nodes = append(nodes, node)
+	}
+
+	sort.Strings(nodes)
+
+	for _, node := range nodes {
+		if cycle := visit(node); cycle != nil {
+			return cycle
+		}
+	}
+
+	return nil
+}
+
+// getTestRunOutputs returns the outputs of all tasks with an ID of a test run.
+// Outputs of later tasks take precedence over outputs with the same name of earlier tasks.
+func getTestRunOutputs(testRun types.Test) map[string]any {
+	testOutputs := map[string]any{}
+
+	taskScheduler := testRun.GetTaskScheduler()
+	if taskScheduler == nil {
+		return testOutputs
+	}
+
+	for _, taskIndex := range taskScheduler.GetAllTasks() {
+		taskState := taskScheduler.GetTaskState(taskIndex)
+		if taskState == nil || taskState.ID() == "" {
+			continue
+		}
+
+		// outputs are passed on unmasked, secrets stay registered and get masked when the triggered test is shown or persisted
+		maps.Copy(testOutputs, vars.GetRawVarsMap(taskState.GetTaskStatusVars().GetSubScope("outputs"), true))
+	}
+
+	return testOutputs
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"slices"
 	"sort"
 	"sync"
 	"time"
@@ -85,7 +86,7 @@ func (c *TestRegistry) LoadTests(ctx context.Context, local []*types.TestConfig,
 		}
 
 		if dbTestConfig.Timeout > 0 {
-			externalTest.Timeout = &helper.Duration{Duration: time.Duration(dbTestConfig.Timeout) * time.Second}
+			externalTest.Timeout = &helper.ChainRelativeDuration{Duration: time.Duration(dbTestConfig.Timeout) * time.Second}
 		}
 
 		// When YamlSource is present, config/configVars are already in the YAML,
@@ -109,6 +110,14 @@ func (c *TestRegistry) LoadTests(ctx context.Context, local []*types.TestConfig,
 			}
 		}
 
+		if dbTestConfig.ScheduleChainYaml != "" {
+			externalTest.Schedule.Chain = &types.TestChainSchedule{}
+			if err := yaml.Unmarshal([]byte(dbTestConfig.ScheduleChainYaml), externalTest.Schedule.Chain); err != nil {
+				c.coordinator.Logger().Errorf("error decoding test chain schedule %v from db: %v", dbTestConfig.TestID, err)
+				continue
+			}
+		}
+
 		externalTests = append(externalTests, externalTest)
 	}
 
@@ -169,6 +178,31 @@ func (c *TestRegistry) LoadTests(ctx context.Context, local []*types.TestConfig,
 		}
 	}
 
+	// reject tests that are part of a trigger or dependency cycle
+	testConfigs := map[string]*types.TestConfig{}
+
+	for _, descriptor := range descriptors {
+		if descriptor.Err() == nil {
+			testConfigs[descriptor.ID()] = descriptor.Config()
+		}
+	}
+
+	for kind, cycle := findTestGraphCycle(testConfigs); cycle != nil; kind, cycle = findTestGraphCycle(testConfigs) {
+		cycleErr := errors.New(formatTestGraphCycle(kind, cycle))
+
+		for _, descriptor := range descriptors {
+			if !slices.Contains(cycle, descriptor.ID()) {
+				continue
+			}
+
+			if testDescriptor, ok := descriptor.(*test.Descriptor); ok {
+				testDescriptor.SetErr(cycleErr)
+			}
+
+			delete(testConfigs, descriptor.ID())
+		}
+	}
+
 	errCount := 0
 
 	c.testDescriptorsMutex.Lock()
@@ -220,6 +254,10 @@ func (c *TestRegistry) AddLocalTestWithYaml(testConfig *types.TestConfig, yamlSo
 		return nil, fmt.Errorf("failed getting working directory: %v", err)
 	}
 
+	if err := c.checkTestGraphCycles(testConfig); err != nil {
+		return nil, err
+	}
+
 	testDescriptor := test.NewDescriptor(testConfig.ID, "api-call", workingDir, testConfig, testVars)
 
 	// Persist to database
@@ -274,6 +312,15 @@ func (c *TestRegistry) localTestCfgToDB(testConfig *types.TestConfig, yamlSource
 
 			dbTestCfg.ScheduleCronYaml = string(cronYaml)
 		}
+
+		if testConfig.Schedule.Chain != nil {
+			chainYaml, err := yaml.Marshal(testConfig.Schedule.Chain)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding test chain schedule: %v", err)
+			}
+
+			dbTestCfg.ScheduleChainYaml = string(chainYaml)
+		}
 	}
 
 	// When yamlSource is provided, config/configVars are already in the YAML,
@@ -317,6 +364,10 @@ func (c *TestRegistry) AddExternalTest(ctx context.Context, extTestCfg *types.Ex
 		return nil, errors.New("test must have 1 or more tasks")
 	}
 
+	if err := c.checkTestGraphCycles(testConfig); err != nil {
+		return nil, err
+	}
+
 	testDescriptor := test.NewDescriptor(testConfig.ID, fmt.Sprintf("external:%v", extTestCfg.File), basePath, testConfig, testVars)
 	extTestCfg.ID = testDescriptor.ID()
 	extTestCfg.Name = testConfig.Name
@@ -350,6 +401,29 @@ func (c *TestRegistry) AddExternalTest(ctx context.Context, extTestCfg *types.Ex
 	return testDescriptor, nil
 }
 
+// checkTestGraphCycles returns an error if adding or replacing the given test creates a trigger or dependency cycle.
+func (c *TestRegistry) checkTestGraphCycles(testConfig *types.TestConfig) error {
+	testConfigs := map[string]*types.TestConfig{}
+
+	c.testDescriptorsMutex.RLock()
+
+	for testID, descriptorEntry := range c.testDescriptors {
+		if descriptorEntry.descriptor.Err() == nil {
+			testConfigs[testID] = descriptorEntry.descriptor.Config()
+		}
+	}
+
+	c.testDescriptorsMutex.RUnlock()
+
+	testConfigs[testConfig.ID] = testConfig
+
+	if kind, cycle := findTestGraphCycle(testConfigs); cycle != nil && slices.Contains(cycle, testConfig.ID) {
+		return errors.New(formatTestGraphCycle(kind, cycle))
+	}
+
+	return nil
+}
+
 func (c *TestRegistry) externalTestCfgToDB(cfgExternalTest *types.ExternalTestConfig, yamlSource string) (*db.TestConfig, error) {
 	dbTestCfg := &db.TestConfig{
 		TestID:     cfgExternalTest.ID,
@@ -373,6 +447,15 @@ func (c *TestRegistry) externalTestCfgToDB(cfgExternalTest *types.ExternalTestCo
 
 			dbTestCfg.ScheduleCronYaml = string(cronYaml)
 		}
+
+		if cfgExternalTest.Schedule.Chain != nil {
+			chainYaml, err := yaml.Marshal(cfgExternalTest.Schedule.Chain)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding test chain schedule %v: %v", cfgExternalTest.ID, err)
+			}
+
+			dbTestCfg.ScheduleChainYaml = string(chainYaml)
+		}
 	} else {
 		dbTestCfg.ScheduleStartup = true
 	}
@@ -394,10 +477,10 @@ func (c *TestRegistry) externalTestCfgToDB(cfgExternalTest *types.ExternalTestCo
 	return dbTestCfg, nil
 }
 
-// UpdateTestSchedule validates the schedule's cron expressions, then
-// swaps the schedule in on the registered descriptor and writes it
-// to the test_configs row. Returns an error without touching state
-// if any cron expression is invalid.
+// UpdateTestSchedule validates the schedule's cron expressions and
+// chain triggers, then swaps the schedule in on the registered
+// descriptor and writes it to the test_configs row. Returns an error
+// without touching state if any cron expression or trigger is invalid.
 func (c *TestRegistry) UpdateTestSchedule(testID string, schedule *types.TestSchedule) error {
 	// Validate up-front so we don't half-apply.
 	if schedule != nil {
@@ -406,6 +489,12 @@ func (c *TestRegistry) UpdateTestSchedule(testID string, schedule *types.TestSch
 				return fmt.Errorf("invalid cron expression %q: %w", expr, err)
 			}
 		}
+
+		if schedule.Chain != nil {
+			if err := schedule.Chain.Validate(); err != nil {
+				return err
+			}
+		}
 	}
 
 	c.testDescriptorsMutex.Lock()
@@ -441,6 +530,7 @@ func (c *TestRegistry) UpdateTestSchedule(testID string, schedule *types.TestSch
 
 	dbCfg.ScheduleStartup = false
 	dbCfg.ScheduleCronYaml = ""
+	dbCfg.ScheduleChainYaml = ""
 
 	if schedule != nil {
 		dbCfg.ScheduleStartup = schedule.Startup
@@ -453,6 +543,15 @@ func (c *TestRegistry) UpdateTestSchedule(testID string, schedule *types.TestSch
 
 			dbCfg.ScheduleCronYaml = string(cronYaml)
 		}
+
+		if schedule.Chain != nil {
+			chainYaml, err := yaml.Marshal(schedule.Chain)
+			if err != nil {
+				return fmt.Errorf("error encoding chain schedule: %w", err)
+			}
+
+			dbCfg.ScheduleChainYaml = string(chainYaml)
+		}
 	}
 
 	return c.coordinator.Database().RunTransaction(func(tx *sqlx.Tx) error {
@@ -4,11 +4,15 @@ import (
 	"context"
 	"fmt"
 	"runtime/debug"
+	"strings"
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/metrics"
 	"github.com/ethpandaops/assertoor/pkg/test"
 	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
 	"github.com/gorhill/cronexpr"
 )
 
@@ -17,24 +21,72 @@ type TestRunner struct {
 
 	runIDCounter       uint64
 	testSchedulerMutex sync.Mutex
+	resumedTestIDs     map[string]bool
+	sweepIDCounter     uint64
+	sweepMutex         sync.Mutex
+	maxSweepRuns       int
 
 	testRunMap               map[uint64]types.Test
 	testQueue                []types.TestRunner
 	testRegistryMutex        sync.RWMutex
+	triggerDepths            map[uint64]int
 	queueNotificationChan    chan bool
 	offQueueNotificationChan chan types.TestRunner
 }
 
 func NewTestRunner(coordinator types.Coordinator, lastRunID uint64) *TestRunner {
-	return &TestRunner{
+	runner := &TestRunner{
 		coordinator:  coordinator,
 		runIDCounter: lastRunID,
 
+		resumedTestIDs:           map[string]bool{},
 		testRunMap:               map[uint64]types.Test{},
 		testQueue:                []types.TestRunner{},
+		triggerDepths:            map[uint64]int{},
 		queueNotificationChan:    make(chan bool, 1),
 		offQueueNotificationChan: make(chan types.TestRunner, 10),
 	}
+
+	metrics.RegisterTestRunnerGauges(runner.getQueueDepth, runner.getRunningTestCount)
+
+	return runner
+}
+
+func (c *TestRunner) getQueueDepth() int {
+	c.testRegistryMutex.RLock()
+	defer c.testRegistryMutex.RUnlock()
+
+	return len(c.testQueue)
+}
+
+func (c *TestRunner) getRunningTestCount() int {
+	c.testRegistryMutex.RLock()
+	defer c.testRegistryMutex.RUnlock()
+
+	count := 0
+
+	for _, test := range c.testRunMap {
+		if test.Status() == types.TestStatusRunning {
+			count++
+		}
+	}
+
+	return count
+}
+
+// ImportTestRun allocates a run ID for an imported test run and stores the run via importFn.
+func (c *TestRunner) ImportTestRun(importFn func(runID uint64) error) (uint64, error) {
+	c.testSchedulerMutex.Lock()
+	defer c.testSchedulerMutex.Unlock()
+
+	c.runIDCounter++
+	runID := c.runIDCounter
+
+	if err := importFn(runID); err != nil {
+		return 0, err
+	}
+
+	return runID, nil
 }
 
 func (c *TestRunner) GetTestByRunID(runID uint64) types.Test {
@@ -123,14 +175,35 @@ func (c *TestRunner) createTestRun(descriptor types.TestDescriptor, configOverri
 	c.testSchedulerMutex.Lock()
 	defer c.testSchedulerMutex.Unlock()
 
-	if !opts.AllowDuplicate {
-		for _, queuedTest := range c.GetTestQueue() {
-			if queuedTest.TestID() == descriptor.ID() {
-				return nil, fmt.Errorf("test already in queue")
-			}
+	if !opts.AllowDuplicate && c.isTestQueued(descriptor.ID()) {
+		return nil, fmt.Errorf("test already in queue")
+	}
+
+	testRef, err := c.initTestRun(descriptor, configOverrides, opts.TriggerDepth)
+	if err != nil {
+		return nil, err
+	}
+
+	if !opts.SkipQueue {
+		c.enqueueTestRun(testRef, opts.AfterRunID)
+	}
+
+	return testRef, nil
+}
+
+func (c *TestRunner) isTestQueued(testID string) bool {
+	for _, queuedTest := range c.GetTestQueue() {
+		if queuedTest.TestID() == testID {
+			return true
 		}
 	}
 
+	return false
+}
+
+// initTestRun creates a new test run and registers it by run ID without adding it to the queue.
+// Must be called with testSchedulerMutex held.
+func (c *TestRunner) initTestRun(descriptor types.TestDescriptor, configOverrides map[string]any, triggerDepth int) (types.TestRunner, error) {
 	c.runIDCounter++
 	runID := c.runIDCounter
 
@@ -140,36 +213,107 @@ func (c *TestRunner) createTestRun(descriptor types.TestDescriptor, configOverri
 	}
 
 	c.testRegistryMutex.Lock()
+	defer c.testRegistryMutex.Unlock()
 
-	if !opts.SkipQueue {
-		insertIdx := -1
-
-		// When the user explicitly asked to slot in after a known
-		// queued run, locate it and insert just behind. If the target
-		// isn't queued any more (already started, was cancelled, etc.)
-		// we fall through to "append" — never silently drop the
-		// schedule request.
-		if opts.AfterRunID > 0 {
-			for idx, qt := range c.testQueue {
-				if qt.RunID() == opts.AfterRunID {
-					insertIdx = idx + 1
-					break
-				}
+	c.testRunMap[runID] = testRef
+
+	if triggerDepth > 0 {
+		c.triggerDepths[runID] = triggerDepth
+	}
+
+	return testRef, nil
+}
+
+// enqueueTestRun adds a registered test run to the pending queue.
+func (c *TestRunner) enqueueTestRun(testRef types.TestRunner, afterRunID uint64) {
+	c.testRegistryMutex.Lock()
+	defer c.testRegistryMutex.Unlock()
+
+	insertIdx := -1
+
+	// When the user explicitly asked to slot in after a known
+	// queued run, locate it and insert just behind. If the target
+	// isn't queued any more (already started, was cancelled, etc.)
+	// we fall through to "append" — never silently drop the
+	// schedule request.
+	if afterRunID > 0 {
+		for idx, qt := range c.testQueue {
+			if qt.RunID() == afterRunID {
+				insertIdx = idx + 1
+				break
 			}
 		}
+	}
 
-		if insertIdx < 0 || insertIdx > len(c.testQueue) {
-			c.testQueue = append(c.testQueue, testRef)
+	if insertIdx < 0 || insertIdx > len(c.testQueue) {
+		c.testQueue = append(c.testQueue, testRef)
+	} else {
+		c.testQueue = append(c.testQueue[:insertIdx], append([]types.TestRunner{testRef}, c.testQueue[insertIdx:]...)...)
+	}
+}
+
+// dropTestRun unregisters a test run that has been created but not queued.
+func (c *TestRunner) dropTestRun(runID uint64) {
+	c.testRegistryMutex.Lock()
+	defer c.testRegistryMutex.Unlock()
+
+	delete(c.testRunMap, runID)
+	delete(c.triggerDepths, runID)
+}
+
+// ResumeTestRuns recreates test runs that got interrupted by a restart and puts them back into the queue.
+// It returns the run IDs of all test runs that could be resumed. Tests with a resumed run are not scheduled again on startup.
+func (c *TestRunner) ResumeTestRuns(testRuns []*db.TestRun) []uint64 {
+	c.testSchedulerMutex.Lock()
+	defer c.testSchedulerMutex.Unlock()
+
+	resumedRunIDs := []uint64{}
+
+	for _, testRun := range testRuns {
+		var descriptor types.TestDescriptor
+
+		for _, testDescr := range c.coordinator.TestRegistry().GetTestDescriptors() {
+			if testDescr.ID() == testRun.TestID && testDescr.Err() == nil {
+				descriptor = testDescr
+				break
+			}
+		}
+
+		if descriptor == nil {
+			c.coordinator.Logger().Warnf("cannot resume test run #%v: test %v not found", testRun.RunID, testRun.TestID)
+			continue
+		}
+
+		testRef, err := test.ResumeTest(testRun, descriptor, c.coordinator.Logger().WithField("module", "test"), c.coordinator)
+		if err != nil {
+			c.coordinator.Logger().Warnf("cannot resume test run #%v '%v': %v", testRun.RunID, testRun.Name, err)
+			continue
+		}
+
+		c.testRegistryMutex.Lock()
+		c.testRunMap[testRun.RunID] = testRef
+
+		testConfig := descriptor.Config()
+		if testConfig.Schedule != nil && testConfig.Schedule.SkipQueue {
+			go func() {
+				c.offQueueNotificationChan <- testRef
+			}()
 		} else {
-			c.testQueue = append(c.testQueue[:insertIdx], append([]types.TestRunner{testRef}, c.testQueue[insertIdx:]...)...)
+			c.testQueue = append(c.testQueue, testRef)
 		}
-	}
 
-	c.testRunMap[runID] = testRef
+		c.testRegistryMutex.Unlock()
 
-	c.testRegistryMutex.Unlock()
+		c.resumedTestIDs[testRun.TestID] = true
+		resumedRunIDs = append(resumedRunIDs, testRun.RunID)
+	}
 
-	return testRef, nil
+	select {
+	case c.queueNotificationChan <- true:
+	default:
+	}
+
+	return resumedRunIDs
 }
 
 func (c *TestRunner) RunTestExecutionLoop(ctx context.Context, concurrencyLimit uint64) {
@@ -186,9 +330,16 @@ runLoop:
 
 		c.testRegistryMutex.Lock()
 
-		if len(c.testQueue) > 0 {
-			nextTest = c.testQueue[0]
-			c.testQueue = c.testQueue[1:]
+		for idx, queuedTest := range c.testQueue {
+			// hold back tests that depend on a queued upstream test run, so they don't block a concurrency slot
+			if c.hasQueuedDependencies(queuedTest) {
+				continue
+			}
+
+			nextTest = queuedTest
+			c.testQueue = append(c.testQueue[:idx], c.testQueue[idx+1:]...)
+
+			break
 		}
 
 		c.testRegistryMutex.Unlock()
@@ -239,6 +390,43 @@ func (c *TestRunner) RunOffQueueTestExecutionLoop(ctx context.Context) {
 }
 
 func (c *TestRunner) runTest(ctx context.Context, testRef types.TestRunner) {
+	// wait for the upstream test runs this test depends on.
+	// resumed test runs that already started got the upstream outputs restored from the persisted run config.
+	var err error
+
+	if testRef.StartTime().IsZero() {
+		var upstreamOutputs map[string]any
+
+		upstreamOutputs, err = c.waitTestDependencies(ctx, testRef)
+		if err == nil && len(upstreamOutputs) > 0 {
+			err = testRef.SetTestVariables(upstreamOutputs)
+		}
+	}
+
+	switch {
+	case ctx.Err() != nil:
+		return
+	case err != nil:
+		testRef.Skip(err.Error())
+		c.coordinator.EventBus().PublishTestCompleted(testRef.RunID(), testRef.TestID(), testRef.Name(), string(testRef.Status()))
+	default:
+		c.executeTest(ctx, testRef)
+	}
+
+	if ctx.Err() != nil {
+		return
+	}
+
+	c.scheduleTestTriggers(testRef)
+
+	// wake up the execution loop, queued tests might have been waiting for this test run
+	select {
+	case c.queueNotificationChan <- true:
+	default:
+	}
+}
+
+func (c *TestRunner) executeTest(ctx context.Context, testRef types.TestRunner) {
 	if err := testRef.Validate(); err != nil {
 		testRef.Logger().Errorf("test validation failed: %v", err)
 		return
@@ -291,12 +479,15 @@ func (c *TestRunner) RunTestScheduler(ctx context.Context) {
 		testConfig := testDescr.Config()
 		skipQueue := testConfig.Schedule != nil && testConfig.Schedule.SkipQueue
 
-		_, err := c.ScheduleTest(testDescr, nil, false, skipQueue)
+		_, err := c.scheduleTest(testDescr, nil, types.ScheduleOptions{SkipQueue: skipQueue})
 		if err != nil {
 			c.coordinator.Logger().Errorf("could not schedule startup test execution for %v (%v): %v", testDescr.ID(), testConfig.Name, err)
 		}
 	}
 
+	// chain event scheduler
+	go c.runChainScheduler(ctx)
+
 	// cron scheduler
 	cronTime := time.Unix((time.Now().Unix()/60)*60, 0)
 
@@ -316,7 +507,7 @@ func (c *TestRunner) RunTestScheduler(ctx context.Context) {
 			testConfig := testDescr.Config()
 			skipQueue := testConfig.Schedule != nil && testConfig.Schedule.SkipQueue
 
-			_, err := c.ScheduleTest(testDescr, nil, false, skipQueue)
+			_, err := c.scheduleTest(testDescr, nil, types.ScheduleOptions{SkipQueue: skipQueue})
 			if err != nil {
 				c.coordinator.Logger().Errorf("could not schedule cron test execution for %v (%v): %v", testDescr.ID(), testConfig.Name, err)
 			}
@@ -325,6 +516,9 @@ func (c *TestRunner) RunTestScheduler(ctx context.Context) {
 }
 
 func (c *TestRunner) getStartupTests() []types.TestDescriptor {
+	c.testSchedulerMutex.Lock()
+	defer c.testSchedulerMutex.Unlock()
+
 	descriptors := []types.TestDescriptor{}
 
 	for _, testDescr := range c.coordinator.TestRegistry().GetTestDescriptors() {
@@ -332,6 +526,11 @@ func (c *TestRunner) getStartupTests() []types.TestDescriptor {
 			continue
 		}
 
+		// the interrupted run of the test has been resumed already
+		if c.resumedTestIDs[testDescr.ID()] {
+			continue
+		}
+
 		testConfig := testDescr.Config()
 		if testConfig.Schedule == nil || testConfig.Schedule.Startup {
 			descriptors = append(descriptors, testDescr)
@@ -380,6 +579,88 @@ func (c *TestRunner) getCronTests(cronTime time.Time) []types.TestDescriptor {
 	return descriptors
 }
 
+// runChainScheduler triggers tests with a chain schedule on wallclock epoch transitions & finality updates.
+func (c *TestRunner) runChainScheduler(ctx context.Context) {
+	defer func() {
+		if err := recover(); err != nil {
+			var err2 error
+			if errval, errok := err.(error); errok {
+				err2 = errval
+			}
+
+			c.coordinator.Logger().Panicf("uncaught panic in TestRunner.runChainScheduler: %v, stack: %v", err2, string(debug.Stack()))
+		}
+	}()
+
+	blockCache := c.coordinator.ClientPool().GetConsensusPool().GetBlockCache()
+
+	epochSubscription := blockCache.SubscribeWallclockEpochEvent(10)
+	defer epochSubscription.Unsubscribe()
+
+	finalizedSubscription := blockCache.SubscribeFinalizedEvent(10)
+	defer finalizedSubscription.Unsubscribe()
+
+	scheduleState := newChainScheduleState()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case epoch := <-epochSubscription.Channel():
+			c.processChainScheduleEpoch(epoch.Number(), scheduleState)
+		case checkpoint := <-finalizedSubscription.Channel():
+			scheduleState.updateFinalized(uint64(checkpoint.Epoch))
+		}
+	}
+}
+
+func (c *TestRunner) processChainScheduleEpoch(epoch uint64, scheduleState *chainScheduleState) {
+	blockCache := c.coordinator.ClientPool().GetConsensusPool().GetBlockCache()
+
+	specs := blockCache.GetSpecs()
+	if specs == nil {
+		return
+	}
+
+	if !scheduleState.processEpoch(epoch) {
+		return
+	}
+
+	// finality is unknown until the clients reported the first finalized checkpoint
+	finalizedEpoch, finalizedRoot := blockCache.GetFinalizedCheckpoint()
+	finalityKnown := finalizedRoot != phase0.Root{}
+
+	for _, testDescr := range c.coordinator.TestRegistry().GetTestDescriptors() {
+		if testDescr.Err() != nil {
+			continue
+		}
+
+		testConfig := testDescr.Config()
+		if testConfig.Schedule == nil || testConfig.Schedule.Chain == nil {
+			continue
+		}
+
+		triggers := testConfig.Schedule.Chain.GetEpochTriggers(epoch, specs)
+
+		if finalityKnown {
+			if trigger := scheduleState.getFinalityLossTrigger(testDescr.ID(), testConfig.Schedule.Chain, epoch, uint64(finalizedEpoch)); trigger != "" {
+				triggers = append(triggers, trigger)
+			}
+		}
+
+		if len(triggers) == 0 {
+			continue
+		}
+
+		c.coordinator.Logger().Infof("chain schedule triggered test %v (%v) at epoch %v: %v", testDescr.ID(), testConfig.Name, epoch, strings.Join(triggers, ", "))
+
+		_, err := c.scheduleTest(testDescr, nil, types.ScheduleOptions{SkipQueue: testConfig.Schedule.SkipQueue})
+		if err != nil {
+			c.coordinator.Logger().Errorf("could not schedule chain triggered test execution for %v (%v): %v", testDescr.ID(), testConfig.Name, err)
+		}
+	}
+}
+
 func (c *TestRunner) RunTestCleanup(ctx context.Context, retentionTime time.Duration) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -0,0 +1,79 @@
+package assertoor
+
+import (
+	"errors"
+	"slices"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/test"
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+type testRunnerCoordinator struct {
+	types.Coordinator
+	registry types.TestRegistry
+}
+
+func (c *testRunnerCoordinator) TestRegistry() types.TestRegistry {
+	return c.registry
+}
+
+type testRunnerRegistry struct {
+	types.TestRegistry
+	descriptors []types.TestDescriptor
+}
+
+func (r *testRunnerRegistry) GetTestDescriptors() []types.TestDescriptor {
+	return r.descriptors
+}
+
+func TestGetStartupTests(t *testing.T) {
+	newDescriptor := func(testID string, schedule *types.TestSchedule) *test.Descriptor {
+		return test.NewDescriptor(testID, "", "", &types.TestConfig{ID: testID, Schedule: schedule}, nil)
+	}
+
+	invalidTest := newDescriptor("invalid", nil)
+	invalidTest.SetErr(errors.New("invalid config"))
+
+	descriptors := []types.TestDescriptor{
+		newDescriptor("default", nil),
+		newDescriptor("startup", &types.TestSchedule{Startup: true}),
+		newDescriptor("cron", &types.TestSchedule{Cron: []string{"* * * * *"}}),
+		newDescriptor("resumed", &types.TestSchedule{Startup: true}),
+		invalidTest,
+	}
+
+	tests := []struct {
+		name    string
+		resumed []string
+		want    []string
+	}{
+		{name: "no resumed runs", want: []string{"default", "startup", "resumed"}},
+		{name: "resumed run", resumed: []string{"resumed"}, want: []string{"default", "startup"}},
+		{name: "resumed run of test without startup schedule", resumed: []string{"cron", "default"}, want: []string{"startup", "resumed"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			runner := &TestRunner{
+				coordinator: &testRunnerCoordinator{
+					registry: &testRunnerRegistry{descriptors: descriptors},
+				},
+				resumedTestIDs: map[string]bool{},
+			}
+
+			for _, testID := range tt.resumed {
+				runner.resumedTestIDs[testID] = true
+			}
+
+			got := []string{}
+			for _, descriptor := range runner.getStartupTests() {
+				got = append(got, descriptor.ID())
+			}
+
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("startup tests %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
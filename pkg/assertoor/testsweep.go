@@ -0,0 +1,211 @@
+package assertoor
+
+import (
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/test"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/jmoiron/sqlx"
+	"gopkg.in/yaml.v3"
+)
+
+// defaultMaxSweepRuns is the default limit for the number of test runs a single sweep may fan out into.
+const defaultMaxSweepRuns = 100
+
+// ScheduleTestSweep schedules one independent test run per combination of the matrix values.
+// If matrix is empty, the matrix of the test config is used. The runs are grouped under a new sweep ID.
+// Either all runs of the sweep are scheduled or none: if a run can't be created, the runs created before are dropped again.
+func (c *TestRunner) ScheduleTestSweep(descriptor types.TestDescriptor, configOverrides map[string]any, matrix map[string][]any, opts types.ScheduleOptions) (*types.TestSweep, error) {
+	if descriptor.Err() != nil {
+		return nil, fmt.Errorf("cannot create test from failed test descriptor: %w", descriptor.Err())
+	}
+
+	if len(matrix) == 0 {
+		matrix = descriptor.Config().Matrix
+	}
+
+	maxSweepRuns := c.maxSweepRuns
+	if maxSweepRuns == 0 {
+		maxSweepRuns = defaultMaxSweepRuns
+	}
+
+	if combinationCount := test.CountTestMatrixCombinations(matrix, maxSweepRuns); combinationCount > maxSweepRuns {
+		return nil, fmt.Errorf("%w: more than %v combinations", types.ErrTestSweepTooLarge, maxSweepRuns)
+	}
+
+	combinations := test.ExpandTestMatrix(matrix)
+	if len(combinations) == 0 {
+		return nil, fmt.Errorf("test matrix has no combinations")
+	}
+
+	sweep, err := c.createTestSweep(descriptor, configOverrides, matrix, combinations, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	// queue or start all runs of the sweep, keeping them in matrix order
+	afterRunID := opts.AfterRunID
+	skipQueue := opts.SkipQueue && afterRunID == 0
+
+	for _, sweepRun := range sweep.Runs {
+		if skipQueue {
+			c.offQueueNotificationChan <- sweepRun.Test
+			continue
+		}
+
+		c.enqueueTestRun(sweepRun.Test, afterRunID)
+
+		if afterRunID > 0 {
+			afterRunID = sweepRun.Test.RunID()
+		}
+	}
+
+	select {
+	case c.queueNotificationChan <- true:
+	default:
+	}
+
+	c.coordinator.Logger().Infof("scheduled test sweep #%v for %v with %v runs", sweep.SweepID, descriptor.ID(), len(sweep.Runs))
+
+	return sweep, nil
+}
+
+// createTestSweep creates the test runs of a sweep and stores the sweep, without queueing the runs.
+// On failure, all test runs that have been created for the sweep are dropped again.
+func (c *TestRunner) createTestSweep(descriptor types.TestDescriptor, configOverrides map[string]any, matrix map[string][]any, combinations []map[string]any, opts types.ScheduleOptions) (*types.TestSweep, error) {
+	c.testSchedulerMutex.Lock()
+	defer c.testSchedulerMutex.Unlock()
+
+	if !opts.AllowDuplicate && c.isTestQueued(descriptor.ID()) {
+		return nil, fmt.Errorf("test already in queue")
+	}
+
+	matrixYaml, err := yaml.Marshal(matrix)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding test matrix: %w", err)
+	}
+
+	database := c.coordinator.Database()
+
+	c.sweepMutex.Lock()
+	defer c.sweepMutex.Unlock()
+
+	if c.sweepIDCounter == 0 {
+		//nolint:errcheck // ignore missing state
+		database.GetAssertoorState("test.lastSweepId", &c.sweepIDCounter)
+	}
+
+	c.sweepIDCounter++
+
+	sweep := &types.TestSweep{
+		SweepID: c.sweepIDCounter,
+		TestID:  descriptor.ID(),
+		Runs:    make([]*types.TestSweepRun, 0, len(combinations)),
+	}
+
+	dbSweepRuns := make([]*db.TestSweepRun, 0, len(combinations))
+
+	err = func() error {
+		for _, combination := range combinations {
+			runOverrides := map[string]any{}
+			maps.Copy(runOverrides, configOverrides)
+			maps.Copy(runOverrides, combination)
+
+			testRef, err := c.initTestRun(descriptor, runOverrides, opts.TriggerDepth)
+			if err != nil {
+				return fmt.Errorf("failed creating test run for combination %v: %w", combination, err)
+			}
+
+			sweep.Runs = append(sweep.Runs, &types.TestSweepRun{
+				Combination: combination,
+				Test:        testRef,
+			})
+
+			combinationYaml, err := yaml.Marshal(combination)
+			if err != nil {
+				return fmt.Errorf("failed encoding matrix combination: %w", err)
+			}
+
+			dbSweepRuns = append(dbSweepRuns, &db.TestSweepRun{
+				SweepID:     sweep.SweepID,
+				RunID:       testRef.RunID(),
+				Combination: string(combinationYaml),
+			})
+		}
+
+		return database.RunTransaction(func(tx *sqlx.Tx) error {
+			err := database.InsertTestSweep(tx, &db.TestSweep{
+				SweepID:    sweep.SweepID,
+				TestID:     sweep.TestID,
+				CreateTime: time.Now().UnixMilli(),
+				Matrix:     string(matrixYaml),
+			})
+			if err != nil {
+				return fmt.Errorf("failed storing test sweep: %w", err)
+			}
+
+			for _, dbSweepRun := range dbSweepRuns {
+				if err := database.InsertTestSweepRun(tx, dbSweepRun); err != nil {
+					return fmt.Errorf("failed storing test sweep run: %w", err)
+				}
+			}
+
+			return database.SetAssertoorState(tx, "test.lastSweepId", sweep.SweepID)
+		})
+	}()
+	if err != nil {
+		c.dropTestSweepRuns(sweep)
+		return nil, err
+	}
+
+	return sweep, nil
+}
+
+// dropTestSweepRuns removes the test runs of a sweep that could not be created completely.
+func (c *TestRunner) dropTestSweepRuns(sweep *types.TestSweep) {
+	database := c.coordinator.Database()
+
+	for _, sweepRun := range sweep.Runs {
+		c.dropTestRun(sweepRun.Test.RunID())
+	}
+
+	err := database.RunTransaction(func(tx *sqlx.Tx) error {
+		for _, sweepRun := range sweep.Runs {
+			if err := database.DeleteTestRun(tx, sweepRun.Test.RunID()); err != nil {
+				return err
+			}
+		}
+
+		return nil
+	})
+	if err != nil {
+		c.coordinator.Logger().Errorf("failed deleting test runs of incomplete test sweep #%v: %v", sweep.SweepID, err)
+	}
+}
+
+// scheduleTest schedules a test run, or a sweep with one test run per matrix combination if the test config defines a matrix.
+func (c *TestRunner) scheduleTest(descriptor types.TestDescriptor, configOverrides map[string]any, opts types.ScheduleOptions) ([]types.TestRunner, error) {
+	if len(descriptor.Config().Matrix) == 0 {
+		testRef, err := c.ScheduleTestWithOptions(descriptor, configOverrides, opts)
+		if err != nil {
+			return nil, err
+		}
+
+		return []types.TestRunner{testRef}, nil
+	}
+
+	sweep, err := c.ScheduleTestSweep(descriptor, configOverrides, nil, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	testRefs := make([]types.TestRunner, len(sweep.Runs))
+	for idx, sweepRun := range sweep.Runs {
+		testRefs[idx] = sweepRun.Test
+	}
+
+	return testRefs, nil
+}
@@ -0,0 +1,221 @@
+// Package bundle packages a finished test run (test config, task states, logs, task results
+// and the test result markdown) into a single archive, so it can be moved from an ephemeral
+// assertoor instance to a long-lived archive instance.
+package bundle
+
+import (
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+// FormatVersion is the version of the archive layout written by Export.
+const FormatVersion = 1
+
+// Archive file names.
+const (
+	manifestFile   = "manifest.json"
+	testRunFile    = "test_run.json"
+	testYamlFile   = "test.yaml"
+	taskStatesFile = "task_states.json"
+	taskLogsFile   = "task_logs.jsonl"
+	taskResultFile = "task_results.json"
+	testResultFile = "result.md"
+	resultsDir     = "results/"
+)
+
+// Manifest describes the exported test run.
+type Manifest struct {
+	FormatVersion    int    `json:"format_version"`
+	AssertoorVersion string `json:"assertoor_version"`
+	ExportTime       int64  `json:"export_time"`
+	RunID            uint64 `json:"run_id"`
+	TestID           string `json:"test_id"`
+}
+
+// TestRun is the exported test run.
+type TestRun struct {
+	TestID         string `json:"test_id"`
+	Name           string `json:"name"`
+	Source         string `json:"source"`
+	Config         string `json:"config"`
+	StartTime      int64  `json:"start_time"`
+	StopTime       int64  `json:"stop_time"`
+	Timeout        int32  `json:"timeout"`
+	Status         string `json:"status"`
+	ClientVersions string `json:"client_versions"`
+}
+
+// TaskState is the exported state of a task.
+type TaskState struct {
+	TaskID     uint64 `json:"task_id"`
+	ParentTask uint64 `json:"parent_task"`
+	Name       string `json:"name"`
+	Title      string `json:"title"`
+	RefID      string `json:"ref_id"`
+	Timeout    int64  `json:"timeout"`
+	IfCond     string `json:"if_cond"`
+	DependsOn  string `json:"depends_on"`
+	RunFlags   uint32 `json:"run_flags"`
+	StartTime  int64  `json:"start_time"`
+	StopTime   int64  `json:"stop_time"`
+	ScopeOwner uint64 `json:"scope_owner"`
+	TaskConfig string `json:"task_config"`
+	TaskStatus string `json:"task_status"`
+	TaskResult int    `json:"task_result"`
+	TaskError  string `json:"task_error"`
+	TaskVars   string `json:"task_vars"`
+}
+
+// TaskLog is an exported task log line.
+type TaskLog struct {
+	TaskID     uint64 `json:"task_id"`
+	LogIndex   uint64 `json:"log_idx"`
+	LogTime    int64  `json:"log_time"`
+	LogLevel   uint32 `json:"log_level"`
+	LogFields  string `json:"log_fields"`
+	LogMessage string `json:"log_message"`
+}
+
+// TaskResult is an exported task result file. The data is stored as separate archive file.
+type TaskResult struct {
+	TaskID uint64 `json:"task_id"`
+	Type   string `json:"result_type"`
+	Index  uint64 `json:"result_index"`
+	Name   string `json:"name"`
+	Size   uint64 `json:"size"`
+	File   string `json:"file"`
+	Data   []byte `json:"-"`
+}
+
+// Bundle is the content of a test run archive.
+type Bundle struct {
+	Manifest    *Manifest
+	TestRun     *TestRun
+	TestYaml    []byte
+	TaskStates  []*TaskState
+	TaskLogs    []*TaskLog
+	TaskResults []*TaskResult
+	TestResult  []byte
+}
+
+// isFinished returns true if the test run status is final.
+func isFinished(status string) bool {
+	switch types.TestStatus(status) {
+	case types.TestStatusSuccess, types.TestStatusFailure, types.TestStatusSkipped, types.TestStatusAborted:
+		return true
+	default:
+		return false
+	}
+}
+
+// newTestRun, newTaskState and newTaskLog mask all registered secrets, so they don't leave the instance
+// with exported bundles. Imported bundles are stored as they are.
+func newTestRun(run *db.TestRun) *TestRun {
+	return &TestRun{
+		TestID:         run.TestID,
+		Name:           run.Name,
+		Source:         run.Source,
+		Config:         secrets.Redact(run.Config),
+		StartTime:      run.StartTime,
+		StopTime:       run.StopTime,
+		Timeout:        run.Timeout,
+		Status:         run.Status,
+		ClientVersions: run.ClientVersions,
+	}
+}
+
+func (r *TestRun) toDB(runID uint64) *db.TestRun {
+	return &db.TestRun{
+		RunID:          runID,
+		TestID:         r.TestID,
+		Name:           r.Name,
+		Source:         r.Source,
+		Config:         r.Config,
+		StartTime:      r.StartTime,
+		StopTime:       r.StopTime,
+		Timeout:        r.Timeout,
+		Status:         r.Status,
+		ClientVersions: r.ClientVersions,
+	}
+}
+
+func newTaskState(state *db.TaskState) *TaskState {
+	return &TaskState{
+		TaskID:     state.TaskID,
+		ParentTask: state.ParentTask,
+		Name:       state.Name,
+		Title:      state.Title,
+		RefID:      state.RefID,
+		Timeout:    state.Timeout,
+		IfCond:     state.IfCond,
+		DependsOn:  state.DependsOn,
+		RunFlags:   state.RunFlags,
+		StartTime:  state.StartTime,
+		StopTime:   state.StopTime,
+		ScopeOwner: state.ScopeOwner,
+		TaskConfig: secrets.Redact(state.TaskConfig),
+		TaskStatus: secrets.Redact(state.TaskStatus),
+		TaskResult: state.TaskResult,
+		TaskError:  secrets.Redact(state.TaskError),
+		TaskVars:   secrets.Redact(state.TaskVars),
+	}
+}
+
+func (s *TaskState) toDB(runID uint64) *db.TaskState {
+	return &db.TaskState{
+		RunID:      runID,
+		TaskID:     s.TaskID,
+		ParentTask: s.ParentTask,
+		Name:       s.Name,
+		Title:      s.Title,
+		RefID:      s.RefID,
+		Timeout:    s.Timeout,
+		IfCond:     s.IfCond,
+		DependsOn:  s.DependsOn,
+		RunFlags:   s.RunFlags,
+		StartTime:  s.StartTime,
+		StopTime:   s.StopTime,
+		ScopeOwner: s.ScopeOwner,
+		TaskConfig: s.TaskConfig,
+		TaskStatus: s.TaskStatus,
+		TaskResult: s.TaskResult,
+		TaskError:  s.TaskError,
+		TaskVars:   s.TaskVars,
+	}
+}
+
+func newTaskLog(log *db.TaskLog) *TaskLog {
+	return &TaskLog{
+		TaskID:     log.TaskID,
+		LogIndex:   log.LogIndex,
+		LogTime:    log.LogTime,
+		LogLevel:   log.LogLevel,
+		LogFields:  secrets.Redact(log.LogFields),
+		LogMessage: secrets.Redact(log.LogMessage),
+	}
+}
+
+func (l *TaskLog) toDB(runID uint64) *db.TaskLog {
+	return &db.TaskLog{
+		RunID:      runID,
+		TaskID:     l.TaskID,
+		LogIndex:   l.LogIndex,
+		LogTime:    l.LogTime,
+		LogLevel:   l.LogLevel,
+		LogFields:  l.LogFields,
+		LogMessage: l.LogMessage,
+	}
+}
+
+func (r *TaskResult) toDB(runID uint64) *db.TaskResult {
+	return &db.TaskResult{
+		RunID:  runID,
+		TaskID: r.TaskID,
+		Type:   r.Type,
+		Index:  r.Index,
+		Name:   r.Name,
+		Size:   uint64(len(r.Data)),
+		Data:   r.Data,
+	}
+}
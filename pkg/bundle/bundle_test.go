@@ -0,0 +1,142 @@
+package bundle
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+)
+
+func newTestBundle(status string) *Bundle {
+	return &Bundle{
+		Manifest: &Manifest{
+			FormatVersion:    FormatVersion,
+			AssertoorVersion: "v1.0.0",
+			ExportTime:       1700000000,
+			RunID:            12,
+			TestID:           "test1",
+		},
+		TestRun: &TestRun{
+			TestID:    "test1",
+			Name:      "Test 1",
+			Source:    "local",
+			Config:    "walletAddress: \"0x01\"\n",
+			StartTime: 1000,
+			StopTime:  2000,
+			Timeout:   60,
+			Status:    status,
+		},
+		TestYaml: []byte("id: test1\nname: Test 1\n"),
+		TaskStates: []*TaskState{
+			{TaskID: 1, Name: "sleep", Title: "wait", RunFlags: 1, StartTime: 1000, StopTime: 1500, TaskStatus: "{}\n", TaskResult: 1},
+			{TaskID: 2, ParentTask: 1, Name: "run_shell", TaskError: "exit code 1", TaskVars: "result: 1\n", TaskResult: 2},
+		},
+		TaskLogs: []*TaskLog{
+			{TaskID: 1, LogIndex: 1, LogTime: 1100, LogLevel: 4, LogFields: "{}", LogMessage: "sleeping"},
+			{TaskID: 2, LogIndex: 1, LogTime: 1600, LogLevel: 2, LogFields: "{\"code\":1}", LogMessage: "command failed"},
+		},
+		TaskResults: []*TaskResult{
+			{TaskID: 2, Type: "summary", Index: 0, Name: "summary.md", Size: 5, File: resultsDir + "1", Data: []byte("# out")},
+		},
+		TestResult: []byte("# Test 1\nfailed\n"),
+	}
+}
+
+func TestWriteRead(t *testing.T) {
+	written := newTestBundle("failure")
+
+	buf := &bytes.Buffer{}
+	if err := written.Write(buf); err != nil {
+		t.Fatalf("failed writing bundle: %v", err)
+	}
+
+	read, err := Read(buf)
+	if err != nil {
+		t.Fatalf("failed reading bundle: %v", err)
+	}
+
+	if !reflect.DeepEqual(read, written) {
+		t.Errorf("read bundle differs from written bundle:\n got: %+v\nwant: %+v", read, written)
+	}
+}
+
+func TestReadInvalid(t *testing.T) {
+	tests := []struct {
+		name    string
+		bundle  *Bundle
+		data    []byte
+		wantErr string
+	}{
+		{
+			name:    "unfinished test run",
+			bundle:  newTestBundle("running"),
+			wantErr: "unfinished test run",
+		},
+		{
+			name: "unsupported format version",
+			bundle: func() *Bundle {
+				testBundle := newTestBundle("success")
+				testBundle.Manifest.FormatVersion = FormatVersion + 1
+
+				return testBundle
+			}(),
+			wantErr: "unsupported archive format version",
+		},
+		{
+			name:    "no archive",
+			data:    []byte("not an archive"),
+			wantErr: "invalid archive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf := bytes.NewBuffer(tt.data)
+
+			if tt.bundle != nil {
+				if err := tt.bundle.Write(buf); err != nil {
+					t.Fatalf("failed writing bundle: %v", err)
+				}
+			}
+
+			_, err := Read(buf)
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("Read() error = %v, want %q", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestExportRedactsSecrets(t *testing.T) {
+	secret := "bundle-test-secret"
+	secrets.Register(secret)
+
+	testRun := newTestRun(&db.TestRun{Config: "token: " + secret + "\n", Status: "success"})
+	taskState := newTaskState(&db.TaskState{
+		TaskConfig: "token: " + secret + "\n",
+		TaskStatus: "outputs:\n  token: " + secret + "\n",
+		TaskError:  "invalid token " + secret,
+		TaskVars:   "token: " + secret + "\n",
+	})
+	taskLog := newTaskLog(&db.TaskLog{
+		LogFields:  "{\"token\":\"" + secret + "\"}",
+		LogMessage: "using token " + secret,
+	})
+
+	for name, value := range map[string]string{
+		"test run config": testRun.Config,
+		"task config":     taskState.TaskConfig,
+		"task status":     taskState.TaskStatus,
+		"task error":      taskState.TaskError,
+		"task vars":       taskState.TaskVars,
+		"log fields":      taskLog.LogFields,
+		"log message":     taskLog.LogMessage,
+	} {
+		if strings.Contains(value, secret) || !strings.Contains(value, secrets.Mask) {
+			t.Errorf("%v not redacted: %q", name, value)
+		}
+	}
+}
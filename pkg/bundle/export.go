@@ -0,0 +1,194 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/buildinfo"
+	"github.com/ethpandaops/assertoor/pkg/db"
+)
+
+// Load collects a finished test run from the database.
+func Load(database *db.Database, runID uint64) (*Bundle, error) {
+	testRun, err := database.GetTestRunByRunID(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test run: %w", err)
+	}
+
+	if !isFinished(testRun.Status) {
+		return nil, fmt.Errorf("test run has not finished yet (status: %v)", testRun.Status)
+	}
+
+	bundle := &Bundle{
+		Manifest: &Manifest{
+			FormatVersion:    FormatVersion,
+			AssertoorVersion: buildinfo.GetVersion(),
+			ExportTime:       time.Now().Unix(),
+			RunID:            runID,
+			TestID:           testRun.TestID,
+		},
+		TestRun:     newTestRun(testRun),
+		TaskStates:  []*TaskState{},
+		TaskLogs:    []*TaskLog{},
+		TaskResults: []*TaskResult{},
+	}
+
+	// the test yaml is taken from the registered test or from a previous import
+	if testConfig, err2 := database.GetTestConfig(testRun.TestID); err2 == nil && testConfig.YamlSource != "" {
+		bundle.TestYaml = []byte(testConfig.YamlSource)
+	} else if testYaml, _ := database.GetTestYaml(runID); testYaml != nil {
+		bundle.TestYaml = testYaml.Data
+	}
+
+	taskStates, err := database.GetTaskStatesByRunID(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading task states: %w", err)
+	}
+
+	for _, taskState := range taskStates {
+		bundle.TaskStates = append(bundle.TaskStates, newTaskState(taskState))
+	}
+
+	taskLogs, err := database.GetTaskLogsByRunID(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading task logs: %w", err)
+	}
+
+	for _, taskLog := range taskLogs {
+		bundle.TaskLogs = append(bundle.TaskLogs, newTaskLog(taskLog))
+	}
+
+	resultHeaders, err := database.GetAllTaskResultHeaders(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading task results: %w", err)
+	}
+
+	for idx, header := range resultHeaders {
+		result, err := database.GetTaskResultByIndex(runID, header.TaskID, header.Type, int(header.Index))
+		if err != nil {
+			return nil, fmt.Errorf("failed loading task result %v/%v/%v: %w", header.TaskID, header.Type, header.Index, err)
+		}
+
+		bundle.TaskResults = append(bundle.TaskResults, &TaskResult{
+			TaskID: header.TaskID,
+			Type:   header.Type,
+			Index:  header.Index,
+			Name:   header.Name,
+			Size:   uint64(len(result.Data)),
+			File:   fmt.Sprintf("%v%v", resultsDir, idx+1),
+			Data:   result.Data,
+		})
+	}
+
+	testResult, err := database.GetTestResult(runID)
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test result: %w", err)
+	}
+
+	if testResult != nil {
+		bundle.TestResult = testResult.Data
+	}
+
+	return bundle, nil
+}
+
+// Write writes the bundle as gzip compressed tar archive.
+func (b *Bundle) Write(w io.Writer) error {
+	gzipWriter := gzip.NewWriter(w)
+	tarWriter := tar.NewWriter(gzipWriter)
+	writer := &archiveWriter{
+		writer:  tarWriter,
+		modTime: time.Unix(b.Manifest.ExportTime, 0),
+	}
+
+	writer.writeJSON(manifestFile, b.Manifest)
+	writer.writeJSON(testRunFile, b.TestRun)
+
+	if len(b.TestYaml) > 0 {
+		writer.writeFile(testYamlFile, b.TestYaml)
+	}
+
+	writer.writeJSON(taskStatesFile, b.TaskStates)
+
+	logBuffer := &bytes.Buffer{}
+	logEncoder := json.NewEncoder(logBuffer)
+
+	for _, taskLog := range b.TaskLogs {
+		if err := logEncoder.Encode(taskLog); err != nil {
+			return fmt.Errorf("failed encoding task log: %w", err)
+		}
+	}
+
+	writer.writeFile(taskLogsFile, logBuffer.Bytes())
+	writer.writeJSON(taskResultFile, b.TaskResults)
+
+	for _, taskResult := range b.TaskResults {
+		writer.writeFile(taskResult.File, taskResult.Data)
+	}
+
+	if len(b.TestResult) > 0 {
+		writer.writeFile(testResultFile, b.TestResult)
+	}
+
+	if writer.err != nil {
+		return writer.err
+	}
+
+	if err := tarWriter.Close(); err != nil {
+		return fmt.Errorf("failed closing archive: %w", err)
+	}
+
+	if err := gzipWriter.Close(); err != nil {
+		return fmt.Errorf("failed closing archive: %w", err)
+	}
+
+	return nil
+}
+
+// archiveWriter writes files to a tar archive and keeps the first error.
+type archiveWriter struct {
+	writer  *tar.Writer
+	modTime time.Time
+	err     error
+}
+
+func (w *archiveWriter) writeJSON(name string, value any) {
+	if w.err != nil {
+		return
+	}
+
+	data, err := json.MarshalIndent(value, "", "  ")
+	if err != nil {
+		w.err = fmt.Errorf("failed encoding %v: %w", name, err)
+		return
+	}
+
+	w.writeFile(name, data)
+}
+
+func (w *archiveWriter) writeFile(name string, data []byte) {
+	if w.err != nil {
+		return
+	}
+
+	err := w.writer.WriteHeader(&tar.Header{
+		Typeflag: tar.TypeReg,
+		Name:     name,
+		Mode:     0o644,
+		Size:     int64(len(data)),
+		ModTime:  w.modTime,
+	})
+	if err != nil {
+		w.err = fmt.Errorf("failed writing %v: %w", name, err)
+		return
+	}
+
+	if _, err := w.writer.Write(data); err != nil {
+		w.err = fmt.Errorf("failed writing %v: %w", name, err)
+	}
+}
@@ -0,0 +1,170 @@
+package bundle
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/jmoiron/sqlx"
+)
+
+// MaxArchiveSize limits the total uncompressed size of an archive that is read.
+// The archive is decompressed into memory, so the limit is kept well below the size of uploaded archives.
+const MaxArchiveSize = 64 * 1024 * 1024
+
+// Read reads and validates a gzip compressed tar archive written by Write.
+func Read(r io.Reader) (*Bundle, error) {
+	gzipReader, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, fmt.Errorf("invalid archive: %w", err)
+	}
+
+	defer func() {
+		//nolint:errcheck // ignore close error
+		gzipReader.Close()
+	}()
+
+	files := map[string][]byte{}
+	tarReader := tar.NewReader(gzipReader)
+	remainingSize := int64(MaxArchiveSize)
+
+	for {
+		header, err := tarReader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, fmt.Errorf("invalid archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		if header.Size > remainingSize {
+			return nil, fmt.Errorf("archive exceeds the maximum size of %v bytes", MaxArchiveSize)
+		}
+
+		data, err := io.ReadAll(io.LimitReader(tarReader, header.Size))
+		if err != nil {
+			return nil, fmt.Errorf("failed reading %v: %w", header.Name, err)
+		}
+
+		remainingSize -= int64(len(data))
+		files[header.Name] = data
+	}
+
+	bundle := &Bundle{
+		TestYaml:   files[testYamlFile],
+		TestResult: files[testResultFile],
+	}
+
+	if err := readJSON(files, manifestFile, &bundle.Manifest); err != nil {
+		return nil, err
+	}
+
+	if bundle.Manifest.FormatVersion < 1 || bundle.Manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("unsupported archive format version %v", bundle.Manifest.FormatVersion)
+	}
+
+	if err := readJSON(files, testRunFile, &bundle.TestRun); err != nil {
+		return nil, err
+	}
+
+	if !isFinished(bundle.TestRun.Status) {
+		return nil, fmt.Errorf("archive contains an unfinished test run (status: %v)", bundle.TestRun.Status)
+	}
+
+	if err := readJSON(files, taskStatesFile, &bundle.TaskStates); err != nil {
+		return nil, err
+	}
+
+	if err := readJSON(files, taskResultFile, &bundle.TaskResults); err != nil {
+		return nil, err
+	}
+
+	for _, taskResult := range bundle.TaskResults {
+		data, found := files[taskResult.File]
+		if !found {
+			return nil, fmt.Errorf("archive is missing result file %v", taskResult.File)
+		}
+
+		taskResult.Data = data
+	}
+
+	logScanner := bufio.NewScanner(bytes.NewReader(files[taskLogsFile]))
+	logScanner.Buffer(make([]byte, 0, 64*1024), MaxArchiveSize)
+
+	for logScanner.Scan() {
+		taskLog := &TaskLog{}
+		if err := json.Unmarshal(logScanner.Bytes(), taskLog); err != nil {
+			return nil, fmt.Errorf("invalid task log in archive: %w", err)
+		}
+
+		bundle.TaskLogs = append(bundle.TaskLogs, taskLog)
+	}
+
+	if err := logScanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed reading task logs: %w", err)
+	}
+
+	return bundle, nil
+}
+
+func readJSON(files map[string][]byte, name string, target any) error {
+	data, found := files[name]
+	if !found {
+		return fmt.Errorf("archive is missing %v", name)
+	}
+
+	if err := json.Unmarshal(data, target); err != nil {
+		return fmt.Errorf("invalid %v in archive: %w", name, err)
+	}
+
+	return nil
+}
+
+// Import inserts the test run of the bundle with the given run ID.
+// The run is added as finished run only, so it shows up read-only.
+func (b *Bundle) Import(database *db.Database, tx *sqlx.Tx, runID uint64) error {
+	if err := database.InsertTestRun(tx, b.TestRun.toDB(runID)); err != nil {
+		return fmt.Errorf("failed inserting test run: %w", err)
+	}
+
+	if len(b.TestYaml) > 0 {
+		if err := database.UpsertTestYaml(tx, runID, b.TestYaml); err != nil {
+			return fmt.Errorf("failed inserting test yaml: %w", err)
+		}
+	}
+
+	for _, taskState := range b.TaskStates {
+		if err := database.InsertTaskState(tx, taskState.toDB(runID)); err != nil {
+			return fmt.Errorf("failed inserting task state %v: %w", taskState.TaskID, err)
+		}
+	}
+
+	for _, taskLog := range b.TaskLogs {
+		if err := database.InsertTaskLog(tx, taskLog.toDB(runID)); err != nil {
+			return fmt.Errorf("failed inserting task log: %w", err)
+		}
+	}
+
+	for _, taskResult := range b.TaskResults {
+		if err := database.UpsertTaskResult(tx, taskResult.toDB(runID)); err != nil {
+			return fmt.Errorf("failed inserting task result %v/%v: %w", taskResult.TaskID, taskResult.Type, err)
+		}
+	}
+
+	if len(b.TestResult) > 0 {
+		if err := database.UpsertTestResultTx(tx, runID, b.TestResult); err != nil {
+			return fmt.Errorf("failed inserting test result: %w", err)
+		}
+	}
+
+	return nil
+}
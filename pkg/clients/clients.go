@@ -5,8 +5,8 @@ import (
 	"encoding/hex"
 	"fmt"
 	"math/rand"
-	"regexp"
 	"runtime/debug"
+	"sync"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -24,14 +24,25 @@ type ClientPool struct {
 	ctxCancel     context.CancelFunc
 	consensusPool *consensus.Pool
 	executionPool *execution.Pool
+	clientsMutex  sync.RWMutex
 	clients       []*PoolClient
 	eventBus      *events.EventBus
+	manageMutex   sync.Mutex
+	endpointHooks []EndpointHook
 }
 
 type PoolClient struct {
 	Config          *ClientConfig
 	ConsensusClient *consensus.Client
 	ExecutionClient *execution.Client
+	ctx             context.Context
+	ctxCancel       context.CancelFunc
+}
+
+// EndpointHook is notified about endpoints that are added to or removed from the pool at runtime.
+type EndpointHook interface {
+	OnEndpointAdded(client *PoolClient) error
+	OnEndpointRemoved(client *PoolClient)
 }
 
 type ClientConfig struct {
@@ -40,28 +51,56 @@ type ClientConfig struct {
 	ConsensusHeaders map[string]string `yaml:"consensusHeaders"`
 	ExecutionURL     string            `yaml:"executionUrl"`
 	ExecutionHeaders map[string]string `yaml:"executionHeaders"`
+	Labels           map[string]string `yaml:"labels"`
 }
 
-func NewClientPool(logger logrus.FieldLogger) (*ClientPool, error) {
-	return NewClientPoolWithContext(context.Background(), logger)
+// PoolConfig holds the settings of the consensus & execution client pools.
+type PoolConfig struct {
+	Consensus *consensus.PoolConfig `yaml:"consensus" json:"consensus"`
+	Execution *execution.PoolConfig `yaml:"execution" json:"execution"`
+}
+
+// DefaultPoolConfig returns the default client pool settings.
+func DefaultPoolConfig() *PoolConfig {
+	return &PoolConfig{
+		Consensus: &consensus.PoolConfig{
+			FollowDistance: 10,
+			ForkDistance:   1,
+		},
+		Execution: &execution.PoolConfig{
+			FollowDistance: 10,
+			ForkDistance:   1,
+		},
+	}
 }
 
-func NewClientPoolWithContext(ctx context.Context, logger logrus.FieldLogger) (*ClientPool, error) {
+func NewClientPool(logger logrus.FieldLogger, config *PoolConfig) (*ClientPool, error) {
+	return NewClientPoolWithContext(context.Background(), logger, config)
+}
+
+func NewClientPoolWithContext(ctx context.Context, logger logrus.FieldLogger, config *PoolConfig) (*ClientPool, error) {
+	defaultConfig := DefaultPoolConfig()
+	if config == nil {
+		config = defaultConfig
+	}
+
+	if config.Consensus == nil {
+		config.Consensus = defaultConfig.Consensus
+	}
+
+	if config.Execution == nil {
+		config.Execution = defaultConfig.Execution
+	}
+
 	poolCtx, ctxCancel := context.WithCancel(ctx)
 
-	consensusPool, err := consensus.NewPool(poolCtx, &consensus.PoolConfig{
-		FollowDistance: 10,
-		ForkDistance:   1,
-	}, logger.WithField("module", "consensus"))
+	consensusPool, err := consensus.NewPool(poolCtx, config.Consensus, logger.WithField("module", "consensus"))
 	if err != nil {
 		ctxCancel()
 		return nil, fmt.Errorf("could not init consensus pool: %w", err)
 	}
 
-	executionPool, err := execution.NewPool(poolCtx, &execution.PoolConfig{
-		FollowDistance: 10,
-		ForkDistance:   1,
-	}, logger.WithField("module", "execution"))
+	executionPool, err := execution.NewPool(poolCtx, config.Execution, logger.WithField("module", "execution"))
 	if err != nil {
 		ctxCancel()
 		return nil, fmt.Errorf("could not init execution pool: %w", err)
@@ -81,7 +120,24 @@ func (pool *ClientPool) Close() {
 	pool.ctxCancel()
 }
 
+// AddEndpointHook registers a hook that is notified about endpoints added or removed at runtime.
+func (pool *ClientPool) AddEndpointHook(hook EndpointHook) {
+	pool.manageMutex.Lock()
+	defer pool.manageMutex.Unlock()
+
+	pool.endpointHooks = append(pool.endpointHooks, hook)
+}
+
+// AddClient adds a consensus & execution client pair to the pool.
+// It is safe to add clients while tests are running.
 func (pool *ClientPool) AddClient(config *ClientConfig) error {
+	pool.manageMutex.Lock()
+	defer pool.manageMutex.Unlock()
+
+	if pool.GetClientByName(config.Name) != nil {
+		return fmt.Errorf("client with name %v already exists", config.Name)
+	}
+
 	consensusClient, err := pool.consensusPool.AddEndpoint(&consensus.ClientConfig{
 		Name:    config.Name,
 		URL:     config.ConsensusURL,
@@ -97,7 +153,8 @@ func (pool *ClientPool) AddClient(config *ClientConfig) error {
 		Headers: config.ExecutionHeaders,
 	})
 	if err != nil {
-		return fmt.Errorf("could not init consensus client: %w", err)
+		pool.consensusPool.RemoveEndpoint(consensusClient)
+		return fmt.Errorf("could not init execution client: %w", err)
 	}
 
 	poolClient := &PoolClient{
@@ -105,14 +162,92 @@ func (pool *ClientPool) AddClient(config *ClientConfig) error {
 		ConsensusClient: consensusClient,
 		ExecutionClient: executionClient,
 	}
+	poolClient.ctx, poolClient.ctxCancel = context.WithCancel(pool.ctx)
+
+	for idx, hook := range pool.endpointHooks {
+		if err := hook.OnEndpointAdded(poolClient); err != nil {
+			for _, addedHook := range pool.endpointHooks[:idx] {
+				addedHook.OnEndpointRemoved(poolClient)
+			}
+
+			pool.stopClient(poolClient)
+
+			return fmt.Errorf("could not add client: %w", err)
+		}
+	}
 
 	go pool.processConsensusBlockNotification(poolClient)
 
+	if pool.eventBus != nil {
+		go pool.watchClientEvents(poolClient)
+	}
+
+	pool.clientsMutex.Lock()
 	pool.clients = append(pool.clients, poolClient)
+	pool.clientsMutex.Unlock()
+
+	return nil
+}
+
+// RemoveClient stops the client pair with the given name and removes it from the pool.
+// Tasks that already hold a reference to the client see it going offline.
+func (pool *ClientPool) RemoveClient(name string) error {
+	pool.manageMutex.Lock()
+	defer pool.manageMutex.Unlock()
+
+	poolClient := pool.GetClientByName(name)
+	if poolClient == nil {
+		return fmt.Errorf("client %v not found", name)
+	}
+
+	pool.clientsMutex.Lock()
+
+	for i, client := range pool.clients {
+		if client == poolClient {
+			pool.clients = append(pool.clients[:i:i], pool.clients[i+1:]...)
+			break
+		}
+	}
+
+	pool.clientsMutex.Unlock()
+
+	for _, hook := range pool.endpointHooks {
+		hook.OnEndpointRemoved(poolClient)
+	}
+
+	pool.stopClient(poolClient)
+
+	return nil
+}
+
+func (pool *ClientPool) stopClient(poolClient *PoolClient) {
+	poolClient.ctxCancel()
+	pool.consensusPool.RemoveEndpoint(poolClient.ConsensusClient)
+	pool.executionPool.RemoveEndpoint(poolClient.ExecutionClient)
+}
+
+// SetClientEnabled disables or re-enables the client pair with the given name.
+// Disabled clients keep following the chain, but are excluded from the ready endpoints and name pattern matches.
+func (pool *ClientPool) SetClientEnabled(name string, enabled bool) error {
+	pool.manageMutex.Lock()
+	defer pool.manageMutex.Unlock()
+
+	poolClient := pool.GetClientByName(name)
+	if poolClient == nil {
+		return fmt.Errorf("client %v not found", name)
+	}
+
+	poolClient.ConsensusClient.SetDisabled(!enabled)
+	poolClient.ExecutionClient.SetDisabled(!enabled)
 
 	return nil
 }
 
+// IsDisabled returns true if the client pair has been disabled.
+func (client *PoolClient) IsDisabled() bool {
+	return client.ConsensusClient.IsDisabled()
+}
+
 func (pool *ClientPool) processConsensusBlockNotification(poolClient *PoolClient) {
 	defer func() {
 		if err := recover(); err != nil {
@@ -133,7 +268,7 @@ func (pool *ClientPool) processConsensusBlockNotification(poolClient *PoolClient
 
 	for {
 		select {
-		case <-pool.ctx.Done():
+		case <-poolClient.ctx.Done():
 			return
 		case block := <-blockSubscription.Channel():
 			pool.notifyELBlockFromBeaconBlock(poolClient, block)
@@ -202,27 +337,44 @@ func (pool *ClientPool) GetExecutionPool() *execution.Pool {
 }
 
 func (pool *ClientPool) GetAllClients() []*PoolClient {
+	pool.clientsMutex.RLock()
+	defer pool.clientsMutex.RUnlock()
+
 	clients := make([]*PoolClient, len(pool.clients))
 	copy(clients, pool.clients)
 
 	return clients
 }
 
+// GetClientByName returns the client pair with the given name or nil if there is none.
+func (pool *ClientPool) GetClientByName(name string) *PoolClient {
+	pool.clientsMutex.RLock()
+	defer pool.clientsMutex.RUnlock()
+
+	for _, client := range pool.clients {
+		if client.Config.Name == name {
+			return client
+		}
+	}
+
+	return nil
+}
+
+// GetClientsByNamePatterns returns the enabled clients in random order that match the include pattern
+// and do not match the exclude pattern. Both patterns can either be a client name regex or a label selector.
 func (pool *ClientPool) GetClientsByNamePatterns(includePattern, excludePattern string) []*PoolClient {
 	clients := []*PoolClient{}
-	for _, client := range pool.clients {
-		if includePattern != "" {
-			matched, _ := regexp.MatchString(includePattern, client.Config.Name)
-			if !matched {
-				continue
-			}
+	for _, client := range pool.GetAllClients() {
+		if client.IsDisabled() {
+			continue
 		}
 
-		if excludePattern != "" {
-			matched, _ := regexp.MatchString(excludePattern, client.Config.Name)
-			if matched {
-				continue
-			}
+		if includePattern != "" && !client.MatchesPattern(includePattern) {
+			continue
+		}
+
+		if excludePattern != "" && client.MatchesPattern(excludePattern) {
+			continue
 		}
 
 		clients = append(clients, client)
@@ -240,7 +392,7 @@ func (pool *ClientPool) SetEventBus(eventBus *events.EventBus) {
 	pool.eventBus = eventBus
 
 	// Start watching all existing clients for head/status changes
-	for _, client := range pool.clients {
+	for _, client := range pool.GetAllClients() {
 		go pool.watchClientEvents(client)
 	}
 }
@@ -272,7 +424,7 @@ func (pool *ClientPool) watchClientEvents(poolClient *PoolClient) {
 
 	for {
 		select {
-		case <-pool.ctx.Done():
+		case <-poolClient.ctx.Done():
 			return
 		case block := <-subscription.Channel():
 			if pool.eventBus == nil {
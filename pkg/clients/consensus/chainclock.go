@@ -0,0 +1,42 @@
+package consensus
+
+import (
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+type chainClock struct {
+	genesisTime   time.Time
+	slotDuration  time.Duration
+	slotsPerEpoch uint64
+}
+
+func (c *chainClock) GenesisTime() time.Time {
+	return c.genesisTime
+}
+
+func (c *chainClock) SlotDuration() time.Duration {
+	return c.slotDuration
+}
+
+func (c *chainClock) SlotsPerEpoch() uint64 {
+	return c.slotsPerEpoch
+}
+
+// GetChainClock returns the chain clock used to resolve chain-relative durations.
+// Returns nil if genesis or specs are not known yet.
+func (cache *BlockCache) GetChainClock() helper.ChainClock {
+	specs := cache.GetSpecs()
+	genesis := cache.GetGenesis()
+
+	if specs == nil || genesis == nil || specs.SlotDurationMs == 0 || specs.SlotsPerEpoch == 0 {
+		return nil
+	}
+
+	return &chainClock{
+		genesisTime:   genesis.GenesisTime,
+		slotDuration:  time.Duration(specs.SlotDurationMs) * time.Millisecond, //nolint:gosec // G115: slot duration values won't overflow int64
+		slotsPerEpoch: specs.SlotsPerEpoch,
+	}
+}
@@ -1,7 +1,9 @@
 package consensus
 
 import (
+	"math"
 	"reflect"
+	"strings"
 	"time"
 
 	"github.com/ethpandaops/go-eth2-client/spec/phase0"
@@ -43,6 +45,38 @@ func (chain *ChainSpec) IsGloasActive(slot phase0.Slot) bool {
 	return uint64(slot) >= chain.GloasForkEpoch*chain.SlotsPerEpoch
 }
 
+// ForkNames contains the names of all forks that can be resolved via GetForkEpoch.
+var ForkNames = []string{"altair", "bellatrix", "capella", "deneb", "electra", "fulu", "gloas"}
+
+// GetForkEpoch returns the activation epoch of the fork with the given name.
+// ok is false if the fork name is unknown or the fork is not scheduled.
+func (chain *ChainSpec) GetForkEpoch(forkName string) (epoch uint64, ok bool) {
+	switch strings.ToLower(forkName) {
+	case "altair":
+		epoch = chain.AltairForkEpoch
+	case "bellatrix":
+		epoch = chain.BellatrixForkEpoch
+	case "capella":
+		epoch = chain.CappellaForkEpoch
+	case "deneb":
+		epoch = chain.DenebForkEpoch
+	case "electra":
+		epoch = chain.ElectraForkEpoch
+	case "fulu":
+		epoch = chain.FuluForkEpoch
+	case "gloas":
+		epoch = chain.GloasForkEpoch
+	default:
+		return 0, false
+	}
+
+	if epoch == math.MaxUint64 {
+		return 0, false
+	}
+
+	return epoch, true
+}
+
 func (chain *ChainSpec) CheckMismatch(chain2 *ChainSpec) []string {
 	mismatches := []string{}
 
@@ -3,6 +3,7 @@ package consensus
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/clients/consensus/rpc"
@@ -33,7 +34,8 @@ type Client struct {
 	clientCtxCancel      context.CancelFunc
 	rpcClient            *rpc.BeaconClient
 	logger               *logrus.Entry
-	isOnline             bool
+	isOnline             atomic.Bool
+	isDisabled           atomic.Bool
 	isSyncing            bool
 	isOptimistic         bool
 	versionStr           string
@@ -129,13 +131,25 @@ func (client *Client) GetLastEventTime() time.Time {
 	return client.lastEvent
 }
 
+// IsDisabled returns true if the client has been disabled, which excludes it from the ready endpoints.
+func (client *Client) IsDisabled() bool {
+	return client.isDisabled.Load()
+}
+
+// SetDisabled disables or re-enables the client. Disabled clients keep following the chain,
+// but are not returned as ready endpoints.
+func (client *Client) SetDisabled(disabled bool) {
+	client.isDisabled.Store(disabled)
+	client.pool.resetHeadForkCache()
+}
+
 func (client *Client) GetStatus() ClientStatus {
 	switch {
 	case client.isSyncing:
 		return ClientStatusSynchronizing
 	case client.isOptimistic:
 		return ClientStatusOptimistic
-	case client.isOnline:
+	case client.isOnline.Load():
 		return ClientStatusOnline
 	default:
 		return ClientStatusOffline
@@ -38,7 +38,12 @@ func (client *Client) runClientLoop() {
 			return
 		}
 
-		client.isOnline = false
+		if client.clientCtx.Err() != nil {
+			// client has been removed from the pool
+			return
+		}
+
+		client.isOnline.Store(false)
 		client.lastError = err
 		client.lastEvent = time.Now()
 		client.retryCounter++
@@ -50,7 +55,12 @@ func (client *Client) runClientLoop() {
 		}
 
 		client.logger.Warnf("upstream client error: %v, retrying in %v sec...", err, waitTime)
-		time.Sleep(time.Duration(waitTime) * time.Second)
+
+		select {
+		case <-client.clientCtx.Done():
+			return
+		case <-time.After(time.Duration(waitTime) * time.Second):
+		}
 	}
 }
 
@@ -186,8 +196,8 @@ func (client *Client) runClientLogic() error {
 			client.logger.Tracef("event (%v) processing time: %v ms", evt.Event, time.Since(now).Milliseconds())
 			client.lastEvent = time.Now()
 		case ready := <-blockStream.ReadyChan:
-			if client.isOnline != ready {
-				client.isOnline = ready
+			if client.isOnline.Load() != ready {
+				client.isOnline.Store(ready)
 				if ready {
 					client.logger.Debug("RPC event stream connected")
 				} else {
@@ -199,7 +209,7 @@ func (client *Client) runClientLogic() error {
 
 			err := client.pollClientHead()
 			if err != nil {
-				client.isOnline = false
+				client.isOnline.Store(false)
 				return err
 			}
 
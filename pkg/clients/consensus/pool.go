@@ -2,10 +2,10 @@ package consensus
 
 import (
 	"context"
-	"fmt"
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/helper"
 	v1 "github.com/ethpandaops/go-eth2-client/api/v1"
 	"github.com/ethpandaops/go-eth2-client/spec"
 	"github.com/ethpandaops/go-eth2-client/spec/gloas"
@@ -17,6 +17,8 @@ type SchedulerMode uint8
 
 var (
 	RoundRobinScheduler SchedulerMode = 1
+	LatencyScheduler    SchedulerMode = 2
+	StickyScheduler     SchedulerMode = 3
 )
 
 type PoolConfig struct {
@@ -30,6 +32,7 @@ type Pool struct {
 	ctx            context.Context
 	logger         logrus.FieldLogger
 	clientCounter  uint16
+	clientsMutex   sync.RWMutex
 	clients        []*Client
 	blockCache     *BlockCache
 	forkCacheMutex sync.Mutex
@@ -38,6 +41,7 @@ type Pool struct {
 	schedulerMode  SchedulerMode
 	schedulerMutex sync.Mutex
 	rrLastIndexes  map[ClientType]uint16
+	stickyPins     map[string]*stickyPin
 }
 
 func NewPool(ctx context.Context, config *PoolConfig, logger logrus.FieldLogger) (*Pool, error) {
@@ -50,13 +54,12 @@ func NewPool(ctx context.Context, config *PoolConfig, logger logrus.FieldLogger)
 		clients:       make([]*Client, 0),
 		forkCache:     map[int64][]*HeadFork{},
 		rrLastIndexes: map[ClientType]uint16{},
+		stickyPins:    map[string]*stickyPin{},
 	}
 
-	switch config.SchedulerMode {
-	case "", "rr", "roundrobin":
-		pool.schedulerMode = RoundRobinScheduler
-	default:
-		return nil, fmt.Errorf("unknown pool schedulerMode: %v", config.SchedulerMode)
+	pool.schedulerMode, err = ParseSchedulerMode(config.SchedulerMode)
+	if err != nil {
+		return nil, err
 	}
 
 	pool.blockCache, err = NewBlockCache(ctx, logger, config.FollowDistance)
@@ -204,6 +207,9 @@ func computeValidatorStatus(val *phase0.Validator, epoch phase0.Epoch) v1.Valida
 }
 
 func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
+
 	clientIdx := pool.clientCounter
 	pool.clientCounter++
 
@@ -217,11 +223,36 @@ func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
 	return client, nil
 }
 
+// RemoveEndpoint stops the client and removes it from the pool.
+func (pool *Pool) RemoveEndpoint(client *Client) {
+	pool.clientsMutex.Lock()
+
+	for i, poolClient := range pool.clients {
+		if poolClient == client {
+			pool.clients = append(pool.clients[:i:i], pool.clients[i+1:]...)
+			break
+		}
+	}
+
+	pool.clientsMutex.Unlock()
+
+	client.clientCtxCancel()
+	client.isOnline.Store(false)
+	pool.resetHeadForkCache()
+}
+
 func (pool *Pool) GetAllEndpoints() []*Client {
-	return pool.clients
+	pool.clientsMutex.RLock()
+	defer pool.clientsMutex.RUnlock()
+
+	clients := make([]*Client, len(pool.clients))
+	copy(clients, pool.clients)
+
+	return clients
 }
 
-func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
+// GetReadyEndpoint selects a ready endpoint via the pool scheduler. The scheduling can be customized per call via options.
+func (pool *Pool) GetReadyEndpoint(clientType ClientType, opts ...SchedulerOption) *Client {
 	canonicalFork := pool.GetCanonicalFork(-1)
 	if canonicalFork == nil {
 		return nil
@@ -232,14 +263,18 @@ func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
 		return nil
 	}
 
-	selectedClient := pool.runClientScheduler(readyClients, clientType)
+	selectedClient := pool.runClientScheduler(readyClients, clientType, opts)
 
 	return selectedClient
 }
 
-func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType) *Client {
+func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType, opts ...SchedulerOption) *Client {
+	if stickyKey := helper.GetStickyKey(ctx); stickyKey != "" {
+		opts = append([]SchedulerOption{WithStickyKey(stickyKey)}, opts...)
+	}
+
 	for {
-		client := pool.GetReadyEndpoint(clientType)
+		client := pool.GetReadyEndpoint(clientType, opts...)
 		if client != nil {
 			return client
 		}
@@ -271,37 +306,3 @@ func (pool *Pool) IsClientReady(client *Client) bool {
 
 	return false
 }
-
-func (pool *Pool) runClientScheduler(readyClients []*Client, clientType ClientType) *Client {
-	pool.schedulerMutex.Lock()
-	defer pool.schedulerMutex.Unlock()
-
-	if pool.schedulerMode == RoundRobinScheduler {
-		var firstReadyClient *Client
-
-		for _, client := range readyClients {
-			if clientType != AnyClient && clientType != client.clientType {
-				continue
-			}
-
-			if firstReadyClient == nil {
-				firstReadyClient = client
-			}
-
-			if client.clientIdx > pool.rrLastIndexes[clientType] {
-				pool.rrLastIndexes[clientType] = client.clientIdx
-				return client
-			}
-		}
-
-		if firstReadyClient == nil {
-			return nil
-		}
-
-		pool.rrLastIndexes[clientType] = firstReadyClient.clientIdx
-
-		return firstReadyClient
-	}
-
-	return readyClients[0]
-}
@@ -6,10 +6,12 @@ import (
 	"encoding/json"
 	"fmt"
 	"io"
+	"net"
 	nethttp "net/http"
 	"strings"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/metrics"
 	eth2client "github.com/ethpandaops/go-eth2-client"
 	"github.com/ethpandaops/go-eth2-client/api"
 	v1 "github.com/ethpandaops/go-eth2-client/api/v1"
@@ -28,14 +30,29 @@ type BeaconClient struct {
 	endpoint  string
 	headers   map[string]string
 	clientSvc eth2client.Service
+	transport nethttp.RoundTripper
+	stats     *metrics.RPCStats
 }
 
 // NewBeaconClient is used to create a new beacon client
 func NewBeaconClient(name, url string, headers map[string]string) (*BeaconClient, error) {
+	stats := metrics.NewRPCStats()
+	baseTransport := &nethttp.Transport{
+		DialContext: (&net.Dialer{
+			Timeout:   30 * time.Second,
+			KeepAlive: 30 * time.Second,
+		}).DialContext,
+		MaxIdleConns:        64,
+		MaxConnsPerHost:     64,
+		MaxIdleConnsPerHost: 64,
+		IdleConnTimeout:     600 * time.Second,
+	}
 	client := &BeaconClient{
-		name:     name,
-		endpoint: url,
-		headers:  headers,
+		name:      name,
+		endpoint:  url,
+		headers:   headers,
+		transport: metrics.NewClientTransport(baseTransport, "consensus", name, metrics.BeaconAPIMethod, stats),
+		stats:     stats,
 	}
 
 	return client, nil
@@ -53,6 +70,9 @@ func (bc *BeaconClient) Initialize(ctx context.Context) error {
 		// TODO (when upstream PR is merged)
 		// http.WithConnectionCheck(false),
 		http.WithCustomSpecSupport(true),
+		http.WithHTTPClient(&nethttp.Client{
+			Transport: bc.transport,
+		}),
 	}
 
 	// set extra endpoint headers
@@ -70,6 +90,11 @@ func (bc *BeaconClient) Initialize(ctx context.Context) error {
 	return nil
 }
 
+// GetRPCStats returns the rolling latency & error stats of the requests to the endpoint.
+func (bc *BeaconClient) GetRPCStats() *metrics.RPCStats {
+	return bc.stats
+}
+
 func (bc *BeaconClient) getJSON(ctx context.Context, requrl string, returnValue interface{}) error {
 	logurl := getRedactedURL(requrl)
 
@@ -82,7 +107,7 @@ func (bc *BeaconClient) getJSON(ctx context.Context, requrl string, returnValue
 		req.Header.Set(headerKey, headerVal)
 	}
 
-	client := &nethttp.Client{Timeout: time.Second * 300}
+	client := &nethttp.Client{Timeout: time.Second * 300, Transport: bc.transport}
 
 	resp, err := client.Do(req)
 	if err != nil {
@@ -145,7 +170,7 @@ func (bc *BeaconClient) postJSONWithHeaders(ctx context.Context, requrl string,
 		req.Header.Set(headerKey, headerVal)
 	}
 
-	client := &nethttp.Client{Timeout: time.Second * 300}
+	client := &nethttp.Client{Timeout: time.Second * 300, Transport: bc.transport}
 
 	resp, err := client.Do(req)
 	if err != nil {
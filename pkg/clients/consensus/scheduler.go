@@ -0,0 +1,195 @@
+package consensus
+
+import (
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/go-eth2-client/spec/phase0"
+)
+
+const (
+	// stickyPinTimeout is the time after which unused sticky pins are dropped.
+	stickyPinTimeout = 1 * time.Hour
+
+	// latencyDefault is the assumed latency of endpoints without recorded requests.
+	latencyDefault = 100 * time.Millisecond
+	// latencyErrorPenalty scales the latency by the error rate (a 100% error rate makes an endpoint 5x slower).
+	latencyErrorPenalty = 4
+	// latencyHeadLagPenalty is added to the latency for every slot an endpoint is behind the canonical head.
+	latencyHeadLagPenalty = 250 * time.Millisecond
+)
+
+// SchedulerOption customizes the endpoint selection of a single GetReadyEndpoint call.
+type SchedulerOption func(opts *schedulerOptions)
+
+type schedulerOptions struct {
+	mode      SchedulerMode
+	stickyKey string
+}
+
+// WithSchedulerMode overrides the scheduler mode of the pool.
+func WithSchedulerMode(mode SchedulerMode) SchedulerOption {
+	return func(opts *schedulerOptions) {
+		opts.mode = mode
+	}
+}
+
+// WithStickyKey sets the key (e.g. a test run or task identifier) that is pinned to one endpoint by the sticky scheduler.
+// AwaitReadyEndpoint derives the key from the context (see helper.WithStickyKey), which is set for all task contexts.
+// Calls without a sticky key fall back to round robin.
+func WithStickyKey(key string) SchedulerOption {
+	return func(opts *schedulerOptions) {
+		opts.stickyKey = key
+	}
+}
+
+// WithTestRunStickyKey pins all calls of a test run to one endpoint when using the sticky scheduler.
+func WithTestRunStickyKey(runID uint64) SchedulerOption {
+	return WithStickyKey(helper.TestRunStickyKey(runID))
+}
+
+type stickyPin struct {
+	clientIdx uint16
+	lastUse   time.Time
+}
+
+// ParseSchedulerMode parses the name of a scheduler mode.
+func ParseSchedulerMode(mode string) (SchedulerMode, error) {
+	switch mode {
+	case "", "rr", "roundrobin":
+		return RoundRobinScheduler, nil
+	case "latency":
+		return LatencyScheduler, nil
+	case "sticky":
+		return StickyScheduler, nil
+	default:
+		return 0, fmt.Errorf("unknown pool schedulerMode: %v", mode)
+	}
+}
+
+func (pool *Pool) runClientScheduler(readyClients []*Client, clientType ClientType, opts []SchedulerOption) *Client {
+	options := &schedulerOptions{
+		mode: pool.schedulerMode,
+	}
+	for _, opt := range opts {
+		opt(options)
+	}
+
+	candidates := make([]*Client, 0, len(readyClients))
+
+	for _, client := range readyClients {
+		if clientType != AnyClient && clientType != client.clientType {
+			continue
+		}
+
+		candidates = append(candidates, client)
+	}
+
+	if len(candidates) == 0 {
+		return nil
+	}
+
+	pool.schedulerMutex.Lock()
+	defer pool.schedulerMutex.Unlock()
+
+	switch options.mode {
+	case LatencyScheduler:
+		return pool.runLatencyScheduler(candidates)
+	case StickyScheduler:
+		if options.stickyKey != "" {
+			return pool.runStickyScheduler(candidates, clientType, options.stickyKey)
+		}
+
+		return pool.runRoundRobinScheduler(candidates, clientType)
+	default:
+		return pool.runRoundRobinScheduler(candidates, clientType)
+	}
+}
+
+func (pool *Pool) runRoundRobinScheduler(candidates []*Client, clientType ClientType) *Client {
+	for _, client := range candidates {
+		if client.clientIdx > pool.rrLastIndexes[clientType] {
+			pool.rrLastIndexes[clientType] = client.clientIdx
+			return client
+		}
+	}
+
+	pool.rrLastIndexes[clientType] = candidates[0].clientIdx
+
+	return candidates[0]
+}
+
+// runLatencyScheduler selects a random endpoint, weighted by the rolling RPC latency, error rate and head lag of the endpoints.
+func (pool *Pool) runLatencyScheduler(candidates []*Client) *Client {
+	headSlot := phase0.Slot(0)
+	if canonicalFork := pool.GetCanonicalFork(-1); canonicalFork != nil {
+		headSlot = canonicalFork.Slot
+	}
+
+	weights := make([]float64, len(candidates))
+	totalWeight := float64(0)
+
+	for i, client := range candidates {
+		weights[i] = 1 / getSchedulerCost(client, headSlot)
+		totalWeight += weights[i]
+	}
+
+	//nolint:gosec // G404: no cryptographic randomness needed for load balancing
+	target := rand.Float64() * totalWeight
+
+	for i, client := range candidates {
+		target -= weights[i]
+		if target < 0 {
+			return client
+		}
+	}
+
+	return candidates[len(candidates)-1]
+}
+
+func getSchedulerCost(client *Client, headSlot phase0.Slot) float64 {
+	latency, errorRate, samples := client.rpcClient.GetRPCStats().Get()
+	if samples == 0 || latency <= 0 {
+		latency = latencyDefault
+	}
+
+	cost := float64(latency) * (1 + errorRate*latencyErrorPenalty)
+
+	if clientHead, _ := client.GetLastHead(); headSlot > clientHead {
+		cost += float64(headSlot-clientHead) * float64(latencyHeadLagPenalty)
+	}
+
+	return cost
+}
+
+// runStickyScheduler returns the endpoint pinned to the sticky key. The key is (re-)pinned to the next
+// round robin endpoint if it is new or its endpoint is not ready anymore.
+func (pool *Pool) runStickyScheduler(candidates []*Client, clientType ClientType, stickyKey string) *Client {
+	now := time.Now()
+	pinKey := fmt.Sprintf("%v:%v", clientType, stickyKey)
+
+	if pin := pool.stickyPins[pinKey]; pin != nil {
+		for _, client := range candidates {
+			if client.clientIdx == pin.clientIdx {
+				pin.lastUse = now
+				return client
+			}
+		}
+	}
+
+	for key, pin := range pool.stickyPins {
+		if now.Sub(pin.lastUse) > stickyPinTimeout {
+			delete(pool.stickyPins, key)
+		}
+	}
+
+	client := pool.runRoundRobinScheduler(candidates, clientType)
+	pool.stickyPins[pinKey] = &stickyPin{
+		clientIdx: client.clientIdx,
+		lastUse:   now,
+	}
+
+	return client
+}
@@ -0,0 +1,101 @@
+package discovery
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+// Discovery source types.
+const (
+	SourceTypeFile = "file"
+	SourceTypeHTTP = "http"
+	SourceTypeDNS  = "dns"
+)
+
+const defaultRefreshInterval = 15 * time.Second
+
+// Config controls the endpoint discovery, which keeps the client pool in sync with external inventories.
+type Config struct {
+	// Sources is the list of inventories the endpoints are discovered from.
+	Sources []*SourceConfig `yaml:"sources" json:"sources"`
+
+	// RefreshInterval is the interval the sources are re-read in. Defaults to 15s.
+	RefreshInterval helper.Duration `yaml:"refreshInterval" json:"refreshInterval"`
+}
+
+// SourceConfig describes a single endpoint inventory.
+type SourceConfig struct {
+	// Type of the source (file, http or dns).
+	Type string `yaml:"type" json:"type"`
+
+	// Path of the JSON / YAML inventory file (file sources).
+	Path string `yaml:"path" json:"path,omitempty"`
+
+	// URL of the JSON / YAML inventory (http sources).
+	URL string `yaml:"url" json:"url,omitempty"`
+
+	// Headers are added to the inventory request (http sources).
+	Headers map[string]string `yaml:"headers" json:"headers,omitempty"`
+
+	// ConsensusSRV is the DNS SRV name of the consensus clients, e.g. "_beacon._tcp.devnet.example.com" (dns sources).
+	ConsensusSRV string `yaml:"consensusSrv" json:"consensusSrv,omitempty"`
+
+	// ExecutionSRV is the DNS SRV name of the execution clients, e.g. "_rpc._tcp.devnet.example.com" (dns sources).
+	ExecutionSRV string `yaml:"executionSrv" json:"executionSrv,omitempty"`
+
+	// Scheme of the URLs built from SRV records. Defaults to http (dns sources).
+	Scheme string `yaml:"scheme" json:"scheme,omitempty"`
+
+	// Labels are added to all endpoints of the source.
+	Labels map[string]string `yaml:"labels" json:"labels,omitempty"`
+}
+
+// GetRefreshInterval returns the configured refresh interval or the default.
+func (c *Config) GetRefreshInterval() time.Duration {
+	if c.RefreshInterval.Duration <= 0 {
+		return defaultRefreshInterval
+	}
+
+	return c.RefreshInterval.Duration
+}
+
+// Validate checks the discovery sources for missing or invalid settings.
+func (c *Config) Validate() error {
+	for idx, sourceConfig := range c.Sources {
+		if sourceConfig == nil {
+			return fmt.Errorf("source[%d]: empty source", idx)
+		}
+
+		if err := sourceConfig.Validate(); err != nil {
+			return fmt.Errorf("source[%d]: %w", idx, err)
+		}
+	}
+
+	return nil
+}
+
+// Validate checks the source config for missing or invalid settings.
+func (c *SourceConfig) Validate() error {
+	switch c.Type {
+	case SourceTypeFile:
+		if c.Path == "" {
+			return errors.New("path cannot be empty")
+		}
+	case SourceTypeHTTP:
+		if _, err := url.ParseRequestURI(c.URL); err != nil {
+			return fmt.Errorf("invalid url: %w", err)
+		}
+	case SourceTypeDNS:
+		if c.ConsensusSRV == "" && c.ExecutionSRV == "" {
+			return errors.New("consensusSrv or executionSrv must be set")
+		}
+	default:
+		return fmt.Errorf("invalid type: %s", c.Type)
+	}
+
+	return nil
+}
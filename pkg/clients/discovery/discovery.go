@@ -0,0 +1,224 @@
+package discovery
+
+import (
+	"context"
+	"path/filepath"
+	"reflect"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+	"github.com/fsnotify/fsnotify"
+	"github.com/sirupsen/logrus"
+)
+
+// watchDebounceDelay is the time a watched inventory file needs to be unchanged before it is re-read,
+// so files that are written in multiple steps are not loaded half-written.
+const watchDebounceDelay = 500 * time.Millisecond
+
+// source loads the current endpoint list of an inventory.
+type source interface {
+	Name() string
+	Load(ctx context.Context) ([]*clients.ClientConfig, error)
+}
+
+// endpointPool is the part of the client pool the discovered endpoints are synced to.
+type endpointPool interface {
+	AddClient(config *clients.ClientConfig) error
+	RemoveClient(name string) error
+	GetClientByName(name string) *clients.PoolClient
+}
+
+// Service keeps the client pool in sync with the configured discovery sources.
+// Endpoints that appear in a source are added to the pool, endpoints that disappear are removed again
+// and endpoints with changed settings are replaced.
+type Service struct {
+	config     *Config
+	clientPool endpointPool
+	logger     logrus.FieldLogger
+	sources    []source
+
+	syncMutex sync.Mutex
+	// last successfully loaded endpoints per source
+	sourceEndpoints map[string][]*clients.ClientConfig
+	// endpoints added by the discovery, keyed by client name
+	managed map[string]*managedEndpoint
+}
+
+type managedEndpoint struct {
+	source string
+	config *clients.ClientConfig
+}
+
+// NewService creates the discovery service for the given sources.
+func NewService(config *Config, clientPool *clients.ClientPool, logger logrus.FieldLogger) (*Service, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	service := &Service{
+		config:          config,
+		clientPool:      clientPool,
+		logger:          logger.WithField("module", "discovery"),
+		sourceEndpoints: map[string][]*clients.ClientConfig{},
+		managed:         map[string]*managedEndpoint{},
+	}
+
+	for _, sourceConfig := range config.Sources {
+		switch sourceConfig.Type {
+		case SourceTypeFile:
+			service.sources = append(service.sources, &fileSource{config: sourceConfig})
+		case SourceTypeHTTP:
+			service.sources = append(service.sources, &httpSource{config: sourceConfig})
+		case SourceTypeDNS:
+			service.sources = append(service.sources, &dnsSource{config: sourceConfig})
+		}
+	}
+
+	return service, nil
+}
+
+// Run re-syncs the client pool in the configured interval and whenever a watched inventory file changes,
+// until the context is cancelled.
+func (s *Service) Run(ctx context.Context) {
+	trigger := make(chan struct{}, 1)
+
+	for _, sourceConfig := range s.config.Sources {
+		if sourceConfig.Type == SourceTypeFile {
+			go s.watchFile(ctx, sourceConfig.Path, trigger)
+		}
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-trigger:
+		case <-time.After(s.config.GetRefreshInterval()):
+		}
+
+		s.Sync(ctx)
+	}
+}
+
+// watchFile triggers a re-sync when the inventory file changes. Bursts of file events are debounced.
+// The parent directory is watched, so files that are replaced atomically (e.g. mounted config maps) are picked up too.
+func (s *Service) watchFile(ctx context.Context, path string, trigger chan<- struct{}) {
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		s.logger.Warnf("failed creating file watcher, falling back to polling %v: %v", path, err)
+		return
+	}
+
+	defer watcher.Close()
+
+	if err := watcher.Add(filepath.Dir(path)); err != nil {
+		s.logger.Warnf("failed watching %v, falling back to polling: %v", path, err)
+		return
+	}
+
+	debounceTimer := time.NewTimer(watchDebounceDelay)
+	debounceTimer.Stop()
+
+	defer debounceTimer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case event, ok := <-watcher.Events:
+			if !ok {
+				return
+			}
+
+			if filepath.Clean(event.Name) != filepath.Clean(path) && !event.Has(fsnotify.Create) {
+				continue
+			}
+
+			debounceTimer.Reset(watchDebounceDelay)
+		case <-debounceTimer.C:
+			select {
+			case trigger <- struct{}{}:
+			default:
+			}
+		case watchErr, ok := <-watcher.Errors:
+			if !ok {
+				return
+			}
+
+			s.logger.Warnf("file watcher error for %v: %v", path, watchErr)
+		}
+	}
+}
+
+// Sync loads all sources and applies the differences to the client pool.
+// Sources that fail to load keep their previously discovered endpoints.
+func (s *Service) Sync(ctx context.Context) {
+	s.syncMutex.Lock()
+	defer s.syncMutex.Unlock()
+
+	for _, src := range s.sources {
+		endpoints, err := src.Load(ctx)
+		if err != nil {
+			s.logger.Warnf("failed loading endpoints from %v: %v", src.Name(), err)
+			continue
+		}
+
+		s.sourceEndpoints[src.Name()] = endpoints
+	}
+
+	// collect the wanted endpoints, the first source providing a name wins
+	wanted := map[string]*managedEndpoint{}
+
+	for _, src := range s.sources {
+		for _, endpoint := range s.sourceEndpoints[src.Name()] {
+			if existing := wanted[endpoint.Name]; existing != nil {
+				if existing.source != src.Name() {
+					s.logger.Warnf("skipping endpoint %v from %v: name is already used by %v", endpoint.Name, src.Name(), existing.source)
+				}
+
+				continue
+			}
+
+			wanted[endpoint.Name] = &managedEndpoint{
+				source: src.Name(),
+				config: endpoint,
+			}
+		}
+	}
+
+	// remove vanished or changed endpoints
+	for name, managed := range s.managed {
+		if endpoint := wanted[name]; endpoint != nil && reflect.DeepEqual(endpoint.config, managed.config) {
+			continue
+		}
+
+		if err := s.clientPool.RemoveClient(name); err != nil {
+			s.logger.Warnf("failed removing endpoint %v: %v", name, err)
+		} else {
+			s.logger.Infof("removed endpoint %v (discovered from %v)", name, managed.source)
+		}
+
+		delete(s.managed, name)
+	}
+
+	// add new or changed endpoints
+	for name, endpoint := range wanted {
+		if s.managed[name] != nil {
+			continue
+		}
+
+		if s.clientPool.GetClientByName(name) != nil {
+			s.logger.Warnf("skipping endpoint %v from %v: name is already used by a static endpoint", name, endpoint.source)
+			continue
+		}
+
+		if err := s.clientPool.AddClient(endpoint.config); err != nil {
+			s.logger.Warnf("failed adding endpoint %v from %v: %v", name, endpoint.source, err)
+			continue
+		}
+
+		s.logger.Infof("added endpoint %v (discovered from %v)", name, endpoint.source)
+		s.managed[name] = endpoint
+	}
+}
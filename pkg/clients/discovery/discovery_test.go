@@ -0,0 +1,187 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"sort"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+	"github.com/sirupsen/logrus"
+)
+
+type testSource struct {
+	name      string
+	endpoints []*clients.ClientConfig
+	err       error
+}
+
+func (s *testSource) Name() string {
+	return s.name
+}
+
+func (s *testSource) Load(_ context.Context) ([]*clients.ClientConfig, error) {
+	return s.endpoints, s.err
+}
+
+type testPool struct {
+	static  map[string]bool
+	clients map[string]*clients.ClientConfig
+	added   []string
+	removed []string
+}
+
+func newTestPool(static ...string) *testPool {
+	pool := &testPool{
+		static:  map[string]bool{},
+		clients: map[string]*clients.ClientConfig{},
+	}
+
+	for _, name := range static {
+		pool.static[name] = true
+	}
+
+	return pool
+}
+
+func (p *testPool) AddClient(config *clients.ClientConfig) error {
+	p.clients[config.Name] = config
+	p.added = append(p.added, config.Name)
+
+	return nil
+}
+
+func (p *testPool) RemoveClient(name string) error {
+	if p.clients[name] == nil {
+		return errors.New("client not found")
+	}
+
+	delete(p.clients, name)
+	p.removed = append(p.removed, name)
+
+	return nil
+}
+
+func (p *testPool) GetClientByName(name string) *clients.PoolClient {
+	if p.static[name] || p.clients[name] != nil {
+		return &clients.PoolClient{}
+	}
+
+	return nil
+}
+
+// reset clears the recorded changes of the last sync.
+func (p *testPool) reset() {
+	p.added = nil
+	p.removed = nil
+}
+
+func testEndpoint(name, url string) *clients.ClientConfig {
+	return &clients.ClientConfig{
+		Name:         name,
+		ExecutionURL: url,
+	}
+}
+
+func sortedNames(names []string) []string {
+	sorted := append([]string{}, names...)
+	sort.Strings(sorted)
+
+	return sorted
+}
+
+func TestServiceSync(t *testing.T) {
+	logger := logrus.New()
+	logger.SetLevel(logrus.PanicLevel)
+
+	sourceA := &testSource{name: "a"}
+	sourceB := &testSource{name: "b"}
+	pool := newTestPool("static-1")
+	service := &Service{
+		clientPool:      pool,
+		logger:          logger,
+		sources:         []source{sourceA, sourceB},
+		sourceEndpoints: map[string][]*clients.ClientConfig{},
+		managed:         map[string]*managedEndpoint{},
+	}
+
+	steps := []struct {
+		name        string
+		endpointsA  []*clients.ClientConfig
+		errA        error
+		endpointsB  []*clients.ClientConfig
+		wantAdded   []string
+		wantRemoved []string
+		wantClients []string
+	}{
+		{
+			name:        "initial endpoints are added",
+			endpointsA:  []*clients.ClientConfig{testEndpoint("node-1", "http://node-1"), testEndpoint("node-2", "http://node-2")},
+			endpointsB:  []*clients.ClientConfig{testEndpoint("node-3", "http://node-3")},
+			wantAdded:   []string{"node-1", "node-2", "node-3"},
+			wantClients: []string{"node-1", "node-2", "node-3"},
+		},
+		{
+			name:        "unchanged endpoints are kept",
+			endpointsA:  []*clients.ClientConfig{testEndpoint("node-1", "http://node-1"), testEndpoint("node-2", "http://node-2")},
+			endpointsB:  []*clients.ClientConfig{testEndpoint("node-3", "http://node-3")},
+			wantClients: []string{"node-1", "node-2", "node-3"},
+		},
+		{
+			name:        "vanished endpoints are removed and changed endpoints replaced",
+			endpointsA:  []*clients.ClientConfig{testEndpoint("node-1", "http://node-1-new")},
+			endpointsB:  []*clients.ClientConfig{testEndpoint("node-3", "http://node-3")},
+			wantAdded:   []string{"node-1"},
+			wantRemoved: []string{"node-1", "node-2"},
+			wantClients: []string{"node-1", "node-3"},
+		},
+		{
+			name:        "failing sources keep their endpoints",
+			errA:        errors.New("inventory is empty"),
+			endpointsB:  []*clients.ClientConfig{testEndpoint("node-3", "http://node-3")},
+			wantClients: []string{"node-1", "node-3"},
+		},
+		{
+			name:        "duplicate and static names are skipped",
+			endpointsA:  []*clients.ClientConfig{testEndpoint("node-1", "http://node-1-new"), testEndpoint("static-1", "http://static")},
+			endpointsB:  []*clients.ClientConfig{testEndpoint("node-3", "http://node-3"), testEndpoint("node-1", "http://other")},
+			wantClients: []string{"node-1", "node-3"},
+		},
+		{
+			name:        "explicitly empty sources remove their endpoints",
+			endpointsA:  []*clients.ClientConfig{},
+			endpointsB:  []*clients.ClientConfig{},
+			wantRemoved: []string{"node-1", "node-3"},
+			wantClients: []string{},
+		},
+	}
+
+	for _, step := range steps {
+		t.Run(step.name, func(t *testing.T) {
+			pool.reset()
+
+			sourceA.endpoints, sourceA.err = step.endpointsA, step.errA
+			sourceB.endpoints = step.endpointsB
+
+			service.Sync(context.Background())
+
+			if got := sortedNames(pool.added); !reflect.DeepEqual(got, sortedNames(step.wantAdded)) {
+				t.Errorf("added = %v, want %v", got, step.wantAdded)
+			}
+
+			if got := sortedNames(pool.removed); !reflect.DeepEqual(got, sortedNames(step.wantRemoved)) {
+				t.Errorf("removed = %v, want %v", got, step.wantRemoved)
+			}
+
+			clientNames := make([]string, 0, len(pool.clients))
+			for name := range pool.clients {
+				clientNames = append(clientNames, name)
+			}
+
+			if got := sortedNames(clientNames); !reflect.DeepEqual(got, sortedNames(step.wantClients)) {
+				t.Errorf("clients = %v, want %v", got, step.wantClients)
+			}
+		})
+	}
+}
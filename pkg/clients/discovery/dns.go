@@ -0,0 +1,94 @@
+package discovery
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+)
+
+type dnsSource struct {
+	config *SourceConfig
+}
+
+func (s *dnsSource) Name() string {
+	return fmt.Sprintf("dns:%v", strings.Trim(s.config.ConsensusSRV+","+s.config.ExecutionSRV, ","))
+}
+
+// Load resolves the SRV records and pairs consensus & execution records by their target host.
+// The first label of the target host is used as the client name.
+func (s *dnsSource) Load(ctx context.Context) ([]*clients.ClientConfig, error) {
+	endpointMap := map[string]*clients.ClientConfig{}
+
+	getEndpoint := func(target string) *clients.ClientConfig {
+		host := strings.TrimSuffix(target, ".")
+
+		endpoint := endpointMap[host]
+		if endpoint == nil {
+			endpoint = &clients.ClientConfig{
+				Name:   strings.SplitN(host, ".", 2)[0],
+				Labels: mergeLabels(s.config.Labels),
+			}
+			endpointMap[host] = endpoint
+		}
+
+		return endpoint
+	}
+
+	if s.config.ConsensusSRV != "" {
+		records, err := s.lookupSRV(ctx, s.config.ConsensusSRV)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			getEndpoint(record.Target).ConsensusURL = s.getURL(record)
+		}
+	}
+
+	if s.config.ExecutionSRV != "" {
+		records, err := s.lookupSRV(ctx, s.config.ExecutionSRV)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, record := range records {
+			getEndpoint(record.Target).ExecutionURL = s.getURL(record)
+		}
+	}
+
+	hosts := make([]string, 0, len(endpointMap))
+	for host := range endpointMap {
+		hosts = append(hosts, host)
+	}
+
+	sort.Strings(hosts)
+
+	endpoints := make([]*clients.ClientConfig, 0, len(hosts))
+	for _, host := range hosts {
+		endpoints = append(endpoints, endpointMap[host])
+	}
+
+	return endpoints, nil
+}
+
+func (s *dnsSource) lookupSRV(ctx context.Context, name string) ([]*net.SRV, error) {
+	_, records, err := net.DefaultResolver.LookupSRV(ctx, "", "", name)
+	if err != nil {
+		return nil, fmt.Errorf("failed resolving SRV record %v: %w", name, err)
+	}
+
+	return records, nil
+}
+
+func (s *dnsSource) getURL(record *net.SRV) string {
+	scheme := s.config.Scheme
+	if scheme == "" {
+		scheme = "http"
+	}
+
+	return fmt.Sprintf("%v://%v", scheme, net.JoinHostPort(strings.TrimSuffix(record.Target, "."), fmt.Sprintf("%d", record.Port)))
+}
@@ -0,0 +1,190 @@
+package discovery
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	inventoryRequestTimeout = 30 * time.Second
+	inventoryMaxSize        = 10 * 1024 * 1024
+)
+
+// Labels derived from the inventory metadata.
+const (
+	LabelConsensusType = "cl"
+	LabelExecutionType = "el"
+	LabelGroup         = "group"
+)
+
+// inventoryEntry is a single endpoint in a JSON / YAML inventory.
+// Besides the regular endpoint settings, the client types and group are turned into labels.
+type inventoryEntry struct {
+	clients.ClientConfig `yaml:",inline"`
+
+	ConsensusType string `yaml:"consensusType"`
+	ExecutionType string `yaml:"executionType"`
+	Group         string `yaml:"group"`
+}
+
+// inventoryFile is the object form of an inventory, the plain list form is accepted as well.
+type inventoryFile struct {
+	Endpoints *[]*inventoryEntry `yaml:"endpoints"`
+}
+
+type fileSource struct {
+	config *SourceConfig
+}
+
+func (s *fileSource) Name() string {
+	return fmt.Sprintf("file:%v", s.config.Path)
+}
+
+func (s *fileSource) Load(_ context.Context) ([]*clients.ClientConfig, error) {
+	data, err := os.ReadFile(s.config.Path)
+	if err != nil {
+		return nil, fmt.Errorf("failed reading inventory file: %w", err)
+	}
+
+	return parseInventory(data, s.config.Labels)
+}
+
+type httpSource struct {
+	config *SourceConfig
+}
+
+func (s *httpSource) Name() string {
+	return fmt.Sprintf("http:%v", s.config.URL)
+}
+
+func (s *httpSource) Load(ctx context.Context) ([]*clients.ClientConfig, error) {
+	reqCtx, cancel := context.WithTimeout(ctx, inventoryRequestTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(reqCtx, http.MethodGet, s.config.URL, http.NoBody)
+	if err != nil {
+		return nil, err
+	}
+
+	for key, value := range s.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	//nolint:gosec // G704: URL is given by the assertoor config
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("inventory request failed: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("inventory request failed with status %v", resp.StatusCode)
+	}
+
+	// read one byte more than allowed, so oversized inventories are rejected instead of being parsed truncated
+	data, err := io.ReadAll(io.LimitReader(resp.Body, inventoryMaxSize+1))
+	if err != nil {
+		return nil, fmt.Errorf("failed reading inventory: %w", err)
+	}
+
+	if len(data) > inventoryMaxSize {
+		return nil, fmt.Errorf("inventory exceeds the maximum size of %v bytes", inventoryMaxSize)
+	}
+
+	return parseInventory(data, s.config.Labels)
+}
+
+// parseInventory parses a JSON / YAML inventory, which is either a list of endpoints or an object with an `endpoints` list.
+// Empty documents and objects without `endpoints` list are rejected, so a file that is read while being written
+// doesn't remove all endpoints. An explicitly empty list is valid.
+func parseInventory(data []byte, sourceLabels map[string]string) ([]*clients.ClientConfig, error) {
+	document := yaml.Node{}
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("failed parsing inventory: %w", err)
+	}
+
+	if len(document.Content) == 0 {
+		return nil, errors.New("inventory is empty")
+	}
+
+	var entries []*inventoryEntry
+
+	root := document.Content[0]
+
+	switch root.Kind {
+	case yaml.SequenceNode:
+		if err := root.Decode(&entries); err != nil {
+			return nil, fmt.Errorf("failed parsing inventory: %w", err)
+		}
+	case yaml.MappingNode:
+		inventory := &inventoryFile{}
+		if err := root.Decode(inventory); err != nil {
+			return nil, fmt.Errorf("failed parsing inventory: %w", err)
+		}
+
+		if inventory.Endpoints == nil {
+			return nil, errors.New("inventory object has no endpoints list")
+		}
+
+		entries = *inventory.Endpoints
+	case yaml.DocumentNode, yaml.ScalarNode, yaml.AliasNode:
+		return nil, errors.New("inventory must be a list of endpoints or an object with an endpoints list")
+	}
+
+	endpoints := make([]*clients.ClientConfig, 0, len(entries))
+
+	for idx, entry := range entries {
+		if entry == nil {
+			continue
+		}
+
+		if entry.Name == "" {
+			return nil, fmt.Errorf("inventory endpoint %d: name cannot be empty", idx)
+		}
+
+		if entry.ConsensusURL == "" && entry.ExecutionURL == "" {
+			return nil, fmt.Errorf("inventory endpoint %v: must have at least one URL", entry.Name)
+		}
+
+		endpoint := entry.ClientConfig
+		endpoint.Labels = mergeLabels(sourceLabels, entry.Labels)
+
+		if entry.ConsensusType != "" {
+			endpoint.Labels[LabelConsensusType] = entry.ConsensusType
+		}
+
+		if entry.ExecutionType != "" {
+			endpoint.Labels[LabelExecutionType] = entry.ExecutionType
+		}
+
+		if entry.Group != "" {
+			endpoint.Labels[LabelGroup] = entry.Group
+		}
+
+		endpoints = append(endpoints, &endpoint)
+	}
+
+	return endpoints, nil
+}
+
+// mergeLabels returns a new label set with the labels of all given sets, later sets take precedence.
+func mergeLabels(labelSets ...map[string]string) map[string]string {
+	labels := map[string]string{}
+
+	for _, labelSet := range labelSets {
+		for key, value := range labelSet {
+			labels[key] = value
+		}
+	}
+
+	return labels
+}
@@ -0,0 +1,151 @@
+package discovery
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+)
+
+func TestParseInventory(t *testing.T) {
+	tests := []struct {
+		name         string
+		data         string
+		sourceLabels map[string]string
+		want         []*clients.ClientConfig
+		wantErr      string
+	}{
+		{
+			name: "yaml list",
+			data: `
+- name: node-1
+  consensusUrl: http://node-1:5052
+  executionUrl: http://node-1:8545
+- name: node-2
+  executionUrl: http://node-2:8545
+`,
+			want: []*clients.ClientConfig{
+				{Name: "node-1", ConsensusURL: "http://node-1:5052", ExecutionURL: "http://node-1:8545", Labels: map[string]string{}},
+				{Name: "node-2", ExecutionURL: "http://node-2:8545", Labels: map[string]string{}},
+			},
+		},
+		{
+			name: "json object",
+			data: `{"endpoints": [{"name": "node-1", "consensusUrl": "http://node-1:5052", "consensusHeaders": {"X-Key": "abc"}}]}`,
+			want: []*clients.ClientConfig{
+				{Name: "node-1", ConsensusURL: "http://node-1:5052", ConsensusHeaders: map[string]string{"X-Key": "abc"}, Labels: map[string]string{}},
+			},
+		},
+		{
+			name: "inventory metadata and labels",
+			data: `
+- name: lighthouse-geth-1
+  consensusUrl: http://lh:5052
+  consensusType: lighthouse
+  executionType: geth
+  group: bootnodes
+  labels:
+    env: devnet
+    group: overridden
+`,
+			sourceLabels: map[string]string{"source": "file", "env": "default"},
+			want: []*clients.ClientConfig{
+				{
+					Name:         "lighthouse-geth-1",
+					ConsensusURL: "http://lh:5052",
+					Labels: map[string]string{
+						"source":           "file",
+						"env":              "devnet",
+						LabelConsensusType: "lighthouse",
+						LabelExecutionType: "geth",
+						LabelGroup:         "bootnodes",
+					},
+				},
+			},
+		},
+		{
+			name: "explicitly empty list",
+			data: `[]`,
+			want: []*clients.ClientConfig{},
+		},
+		{
+			name: "explicitly empty endpoints list",
+			data: `endpoints: []`,
+			want: []*clients.ClientConfig{},
+		},
+		{
+			name: "null entries are skipped",
+			data: `[null, {"name": "node-1", "executionUrl": "http://node-1:8545"}]`,
+			want: []*clients.ClientConfig{
+				{Name: "node-1", ExecutionURL: "http://node-1:8545", Labels: map[string]string{}},
+			},
+		},
+		{
+			name:    "empty document",
+			data:    "",
+			wantErr: "inventory is empty",
+		},
+		{
+			name:    "whitespace and comments only",
+			data:    "\n  # nothing here\n",
+			wantErr: "inventory is empty",
+		},
+		{
+			name:    "object without endpoints",
+			data:    `{"nodes": []}`,
+			wantErr: "inventory object has no endpoints list",
+		},
+		{
+			name:    "truncated endpoints key",
+			data:    "endpoints:\n",
+			wantErr: "inventory object has no endpoints list",
+		},
+		{
+			name:    "truncated json",
+			data:    `[{"name": "node-1", "executionUrl": "http://no`,
+			wantErr: "failed parsing inventory",
+		},
+		{
+			name:    "scalar document",
+			data:    `node-1`,
+			wantErr: "inventory must be a list of endpoints",
+		},
+		{
+			name:    "missing name",
+			data:    `[{"executionUrl": "http://node-1:8545"}]`,
+			wantErr: "inventory endpoint 0: name cannot be empty",
+		},
+		{
+			name:    "missing urls",
+			data:    `[{"name": "node-1"}]`,
+			wantErr: "inventory endpoint node-1: must have at least one URL",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseInventory([]byte(tt.data), tt.sourceLabels)
+
+			if tt.wantErr != "" {
+				if err == nil {
+					t.Fatalf("expected error containing %q, got %v endpoints", tt.wantErr, len(got))
+				}
+
+				if !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("expected error containing %q, got %q", tt.wantErr, err.Error())
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseInventory() mismatch\n got: %+v\nwant: %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -3,6 +3,7 @@ package execution
 import (
 	"context"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
@@ -33,7 +34,8 @@ type Client struct {
 	rpcClient       *rpc.ExecutionClient
 	updateChan      chan *clientBlockNotification
 	logger          *logrus.Entry
-	isOnline        bool
+	isOnline        atomic.Bool
+	isDisabled      atomic.Bool
 	isSyncing       bool
 	versionStr      string
 	clientType      ClientType
@@ -114,11 +116,23 @@ func (client *Client) GetRPCClient() *rpc.ExecutionClient {
 	return client.rpcClient
 }
 
+// IsDisabled returns true if the client has been disabled, which excludes it from the ready endpoints.
+func (client *Client) IsDisabled() bool {
+	return client.isDisabled.Load()
+}
+
+// SetDisabled disables or re-enables the client. Disabled clients keep following the chain,
+// but are not returned as ready endpoints.
+func (client *Client) SetDisabled(disabled bool) {
+	client.isDisabled.Store(disabled)
+	client.pool.resetHeadForkCache()
+}
+
 func (client *Client) GetStatus() ClientStatus {
 	switch {
 	case client.isSyncing:
 		return ClientStatusSynchronizing
-	case client.isOnline:
+	case client.isOnline.Load():
 		return ClientStatusOnline
 	default:
 		return ClientStatusOffline
@@ -126,7 +140,7 @@ func (client *Client) GetStatus() ClientStatus {
 }
 
 func (client *Client) NotifyNewBlock(hash common.Hash, number uint64) {
-	if client.isOnline {
+	if client.isOnline.Load() {
 		client.updateChan <- &clientBlockNotification{
 			hash:   hash,
 			number: number,
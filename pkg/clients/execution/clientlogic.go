@@ -7,10 +7,14 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/ethereum/go-ethereum"
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core/types"
 )
 
+// maxParentBackfill limits the number of missing parent blocks loaded for a subscribed head.
+const maxParentBackfill = 16
+
 func (client *Client) runClientLoop() {
 	defer func() {
 		if err := recover(); err != nil {
@@ -34,7 +38,12 @@ func (client *Client) runClientLoop() {
 			return
 		}
 
-		client.isOnline = false
+		if client.clientCtx.Err() != nil {
+			// client has been removed from the pool
+			return
+		}
+
+		client.isOnline.Store(false)
 		client.lastError = err
 		client.lastEvent = time.Now()
 		client.retryCounter++
@@ -47,7 +56,12 @@ func (client *Client) runClientLoop() {
 		}
 
 		client.logger.Warnf("execution client error: %v, retrying in %v sec...", err, waitTime)
-		time.Sleep(time.Duration(waitTime) * time.Second)
+
+		select {
+		case <-client.clientCtx.Done():
+			return
+		case <-time.After(time.Duration(waitTime) * time.Second):
+		}
 	}
 }
 
@@ -109,10 +123,25 @@ func (client *Client) runClientLogic() error {
 
 	// process events
 	client.lastEvent = time.Now()
-	client.isOnline = true
+	client.isOnline.Store(true)
 	client.updateChan = make(chan *clientBlockNotification, 10)
 
+	// subscribe to new heads on websocket & IPC endpoints, polling is used as fallback
+	headChan := make(chan *types.Header, 10)
+	headSub := client.subscribeNewHeads(headChan)
+
+	defer func() {
+		if headSub != nil {
+			headSub.Unsubscribe()
+		}
+	}()
+
 	for {
+		var headSubErrChan <-chan error
+		if headSub != nil {
+			headSubErrChan = headSub.Err()
+		}
+
 		eventTimeout := time.Since(client.lastEvent)
 		if eventTimeout > 30*time.Second {
 			eventTimeout = 0
@@ -144,18 +173,111 @@ func (client *Client) runClientLogic() error {
 			} else {
 				client.lastEvent = time.Now()
 			}
+		case header := <-headChan:
+			err := client.processSubscribedHead(header)
+			if err != nil {
+				client.logger.Warnf("error processing subscribed execution block: %v", err)
+			} else {
+				client.lastEvent = time.Now()
+			}
+		case err := <-headSubErrChan:
+			client.logger.Warnf("new heads subscription failed, falling back to polling: %v", err)
+
+			headSub.Unsubscribe()
+			headSub = nil
 		case <-time.After(eventTimeout):
 			err := client.pollClientHead()
 			if err != nil {
-				client.isOnline = false
+				client.isOnline.Store(false)
 				return err
 			}
 
 			client.lastEvent = time.Now()
+
+			if headSub == nil {
+				headSub = client.subscribeNewHeads(headChan)
+			}
 		}
 	}
 }
 
+func (client *Client) subscribeNewHeads(headChan chan *types.Header) ethereum.Subscription {
+	if !client.rpcClient.SupportsSubscriptions() {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(client.clientCtx, 10*time.Second)
+	defer cancel()
+
+	headSub, err := client.rpcClient.SubscribeNewHeads(ctx, headChan)
+	if err != nil {
+		client.logger.Warnf("could not subscribe to new heads, falling back to polling: %v", err)
+		return nil
+	}
+
+	client.logger.Debugf("subscribed to new heads")
+
+	return headSub
+}
+
+// processSubscribedHead processes a head received via subscription. Unlike polled heads, subscribed heads
+// include short-lived reorg heads, so missing parent blocks are loaded first to link them to the known chain.
+func (client *Client) processSubscribedHead(header *types.Header) error {
+	client.loadMissingParents(header.ParentHash, header.Number.Uint64())
+
+	return client.processBlock(header.Hash(), header.Number.Uint64(), nil, "subscribed")
+}
+
+// loadMissingParents loads parent blocks into the block cache until a cached block is reached,
+// so the fork tracking can link the head to the chain of other clients.
+func (client *Client) loadMissingParents(parentHash common.Hash, number uint64) {
+	loadedBlocks := []*Block{}
+
+	// notify loaded blocks in chain order
+	defer func() {
+		for i := len(loadedBlocks) - 1; i >= 0; i-- {
+			client.pool.blockCache.notifyBlockReady(loadedBlocks[i])
+		}
+	}()
+
+	for i := uint64(0); i < maxParentBackfill && number > 0; i++ {
+		if client.pool.blockCache.GetCachedBlockByRoot(parentHash) != nil {
+			return
+		}
+
+		number--
+
+		cachedBlock, _ := client.pool.blockCache.AddBlock(parentHash, number)
+		if cachedBlock == nil {
+			return
+		}
+
+		cachedBlock.SetSeenBy(client)
+
+		loaded, err := cachedBlock.EnsureBlock(func() (*types.Block, error) {
+			ctx, cancel := context.WithTimeout(client.clientCtx, 10*time.Second)
+			defer cancel()
+
+			return client.rpcClient.GetBlockByHash(ctx, cachedBlock.Hash)
+		})
+		if err != nil {
+			client.logger.Warnf("could not load parent block %v [0x%x]: %v", number, parentHash, err)
+			return
+		}
+
+		if loaded {
+			loadedBlocks = append(loadedBlocks, cachedBlock)
+		}
+
+		block := cachedBlock.GetBlock()
+		if block == nil {
+			return
+		}
+
+		parentHash = block.ParentHash()
+	}
+}
+
 func (client *Client) pollClientHead() error {
 	ctx, cancel := context.WithTimeout(client.clientCtx, 10*time.Second)
 	defer cancel()
@@ -0,0 +1,415 @@
+package execution
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"math/big"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/core/types"
+	gethrpc "github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethpandaops/assertoor/pkg/clients/execution/rpc"
+	"github.com/sirupsen/logrus"
+)
+
+// testNode is a minimal execution node that serves a static chain of blocks.
+type testNode struct {
+	mutex  sync.Mutex
+	blocks map[common.Hash]*types.Block
+	latest *types.Block
+	heads  chan *types.Header
+}
+
+// testEthAPI serves the eth namespace without subscription support.
+type testEthAPI struct {
+	node *testNode
+}
+
+func (api *testEthAPI) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(big.NewInt(1337))
+}
+
+func (api *testEthAPI) GetBlockByNumber(_ string, _ bool) (map[string]any, error) {
+	api.node.mutex.Lock()
+	defer api.node.mutex.Unlock()
+
+	return newTestBlockJSON(api.node.latest)
+}
+
+func (api *testEthAPI) GetBlockByHash(hash common.Hash, _ bool) (map[string]any, error) {
+	api.node.mutex.Lock()
+	defer api.node.mutex.Unlock()
+
+	return newTestBlockJSON(api.node.blocks[hash])
+}
+
+// testEthSubAPI serves the eth namespace with new heads subscriptions.
+type testEthSubAPI struct {
+	testEthAPI
+}
+
+func (api *testEthSubAPI) NewHeads(ctx context.Context) (*gethrpc.Subscription, error) {
+	notifier, supported := gethrpc.NotifierFromContext(ctx)
+	if !supported {
+		return nil, gethrpc.ErrNotificationsUnsupported
+	}
+
+	subscription := notifier.CreateSubscription()
+
+	go func() {
+		for {
+			select {
+			case header := <-api.node.heads:
+				//nolint:errcheck // ignore
+				notifier.Notify(subscription.ID, header)
+			case <-subscription.Err():
+				return
+			}
+		}
+	}()
+
+	return subscription, nil
+}
+
+func newTestBlockJSON(block *types.Block) (map[string]any, error) {
+	if block == nil {
+		return nil, nil
+	}
+
+	headerJSON, err := json.Marshal(block.Header())
+	if err != nil {
+		return nil, err
+	}
+
+	fields := map[string]any{}
+	if err := json.Unmarshal(headerJSON, &fields); err != nil {
+		return nil, err
+	}
+
+	fields["transactions"] = []any{}
+	fields["uncles"] = []any{}
+
+	return fields, nil
+}
+
+// newTestChain creates a chain of empty blocks, starting at the genesis block.
+func newTestChain(length int) []*types.Block {
+	blocks := make([]*types.Block, length)
+	parentHash := common.Hash{}
+
+	for idx := range blocks {
+		blocks[idx] = types.NewBlockWithHeader(&types.Header{
+			ParentHash:  parentHash,
+			Number:      big.NewInt(int64(idx)),
+			Difficulty:  big.NewInt(0),
+			UncleHash:   types.EmptyUncleHash,
+			TxHash:      types.EmptyTxsHash,
+			ReceiptHash: types.EmptyReceiptsHash,
+			Time:        uint64(idx),
+		})
+		parentHash = blocks[idx].Hash()
+	}
+
+	return blocks
+}
+
+// newTestNode starts a node serving the given blocks via websocket (with or without new heads subscriptions) or http.
+func newTestNode(t *testing.T, blocks []*types.Block, transport string, subscriptions bool) (*testNode, string) {
+	t.Helper()
+
+	node := &testNode{
+		blocks: map[common.Hash]*types.Block{},
+		heads:  make(chan *types.Header, 10),
+	}
+
+	for _, block := range blocks {
+		node.blocks[block.Hash()] = block
+	}
+
+	if len(blocks) > 0 {
+		node.latest = blocks[len(blocks)-1]
+	}
+
+	var ethAPI any = &testEthAPI{node: node}
+	if subscriptions {
+		ethAPI = &testEthSubAPI{testEthAPI{node: node}}
+	}
+
+	server := gethrpc.NewServer()
+	if err := server.RegisterName("eth", ethAPI); err != nil {
+		t.Fatalf("failed registering eth api: %v", err)
+	}
+
+	t.Cleanup(server.Stop)
+
+	if transport == "http" {
+		httpServer := httptest.NewServer(server)
+		t.Cleanup(httpServer.Close)
+
+		return node, httpServer.URL
+	}
+
+	wsServer := httptest.NewServer(server.WebsocketHandler([]string{"*"}))
+	t.Cleanup(wsServer.Close)
+
+	return node, "ws://" + strings.TrimPrefix(wsServer.URL, "http://")
+}
+
+func newTestClient(t *testing.T, url string) *Client {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	pool, err := NewPool(t.Context(), &PoolConfig{FollowDistance: 64}, logger)
+	if err != nil {
+		t.Fatalf("failed creating pool: %v", err)
+	}
+
+	rpcClient, err := rpc.NewExecutionClient("test", url, nil)
+	if err != nil {
+		t.Fatalf("failed creating rpc client: %v", err)
+	}
+
+	if err := rpcClient.Initialize(t.Context()); err != nil {
+		t.Fatalf("failed initializing rpc client: %v", err)
+	}
+
+	client := &Client{
+		pool:           pool,
+		endpointConfig: &ClientConfig{Name: "test", URL: url},
+		rpcClient:      rpcClient,
+		logger:         logger.WithField("client", "test"),
+	}
+	client.resetContext()
+
+	t.Cleanup(client.clientCtxCancel)
+
+	return client
+}
+
+// addTestBlocks adds loaded blocks to the block cache of the pool.
+func addTestBlocks(client *Client, blocks []*types.Block) {
+	for _, block := range blocks {
+		cachedBlock, _ := client.pool.blockCache.AddBlock(block.Hash(), block.NumberU64())
+
+		//nolint:errcheck // static block
+		cachedBlock.EnsureBlock(func() (*types.Block, error) {
+			return block, nil
+		})
+	}
+}
+
+// getNotifiedBlocks returns the numbers of all blocks notified to the subscription so far.
+func getNotifiedBlocks(subscription *Subscription[*Block]) []uint64 {
+	numbers := []uint64{}
+
+	for {
+		select {
+		case block := <-subscription.Channel():
+			numbers = append(numbers, block.Number)
+		default:
+			return numbers
+		}
+	}
+}
+
+func TestProcessSubscribedHead(t *testing.T) {
+	chain := newTestChain(maxParentBackfill + 6)
+
+	tests := []struct {
+		name       string
+		nodeBlocks []*types.Block
+		cached     []*types.Block
+		head       *types.Block
+		want       []uint64
+	}{
+		{
+			name:       "known parent",
+			nodeBlocks: chain[:4],
+			cached:     chain[:3],
+			head:       chain[3],
+			want:       []uint64{3},
+		},
+		{
+			name:       "missing parents are loaded until a cached block",
+			nodeBlocks: chain[:6],
+			cached:     chain[:2],
+			head:       chain[5],
+			want:       []uint64{2, 3, 4, 5},
+		},
+		{
+			name:       "backfill is limited",
+			nodeBlocks: chain,
+			head:       chain[len(chain)-1],
+			want: func() []uint64 {
+				numbers := []uint64{}
+				for number := len(chain) - 1 - maxParentBackfill; number < len(chain); number++ {
+					numbers = append(numbers, uint64(number))
+				}
+
+				return numbers
+			}(),
+		},
+		{
+			name:       "backfill stops at unknown parent",
+			nodeBlocks: []*types.Block{chain[3], chain[4], chain[5]},
+			cached:     chain[:2],
+			head:       chain[5],
+			want:       []uint64{3, 4, 5},
+		},
+		{
+			name:       "genesis head",
+			nodeBlocks: chain[:1],
+			head:       chain[0],
+			want:       []uint64{0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, url := newTestNode(t, tt.nodeBlocks, "ws", true)
+			client := newTestClient(t, url)
+
+			addTestBlocks(client, tt.cached)
+
+			subscription := client.pool.blockCache.SubscribeBlockEvent(100)
+			defer subscription.Unsubscribe()
+
+			if err := client.processSubscribedHead(tt.head.Header()); err != nil {
+				t.Fatalf("failed processing head: %v", err)
+			}
+
+			got := getNotifiedBlocks(subscription)
+			if len(got) != len(tt.want) {
+				t.Fatalf("notified blocks %v, want %v", got, tt.want)
+			}
+
+			for idx := range got {
+				if got[idx] != tt.want[idx] {
+					t.Fatalf("notified blocks %v, want %v", got, tt.want)
+				}
+			}
+
+			if headNumber, headHash := client.GetLastHead(); headNumber != tt.head.NumberU64() || headHash != tt.head.Hash() {
+				t.Errorf("client head = %v [%v], want %v", headNumber, headHash, tt.head.NumberU64())
+			}
+		})
+	}
+}
+
+func TestSubscribeNewHeads(t *testing.T) {
+	chain := newTestChain(3)
+
+	tests := []struct {
+		name          string
+		transport     string
+		subscriptions bool
+		wantSub       bool
+	}{
+		{name: "websocket endpoint", transport: "ws", subscriptions: true, wantSub: true},
+		{name: "websocket endpoint without subscription support", transport: "ws", subscriptions: false, wantSub: false},
+		{name: "http endpoint", transport: "http", subscriptions: true, wantSub: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			node, url := newTestNode(t, chain, tt.transport, tt.subscriptions)
+			client := newTestClient(t, url)
+
+			headChan := make(chan *types.Header, 10)
+
+			headSub := client.subscribeNewHeads(headChan)
+			if (headSub != nil) != tt.wantSub {
+				t.Fatalf("subscribeNewHeads() returned subscription: %v, want %v", headSub != nil, tt.wantSub)
+			}
+
+			if headSub == nil {
+				return
+			}
+
+			defer headSub.Unsubscribe()
+
+			node.heads <- chain[2].Header()
+
+			select {
+			case header := <-headChan:
+				if header.Hash() != chain[2].Hash() {
+					t.Errorf("received head %v, want %v", header.Hash(), chain[2].Hash())
+				}
+			case err := <-headSub.Err():
+				t.Fatalf("subscription failed: %v", err)
+			case <-time.After(5 * time.Second):
+				t.Fatalf("no head received")
+			}
+		})
+	}
+}
+
+func TestPollClientHead(t *testing.T) {
+	chain := newTestChain(4)
+
+	for _, transport := range []string{"http", "ws"} {
+		t.Run(transport, func(t *testing.T) {
+			_, url := newTestNode(t, chain, transport, false)
+			client := newTestClient(t, url)
+
+			if err := client.pollClientHead(); err != nil {
+				t.Fatalf("failed polling head: %v", err)
+			}
+
+			cachedBlock := client.pool.blockCache.GetCachedBlockByRoot(chain[3].Hash())
+			if cachedBlock == nil || cachedBlock.GetBlock() == nil {
+				t.Fatalf("polled head not in block cache")
+			}
+
+			if headNumber, _ := client.GetLastHead(); headNumber != 3 {
+				t.Errorf("client head = %v, want 3", headNumber)
+			}
+		})
+	}
+}
+
+func TestExecutionRPCStats(t *testing.T) {
+	chain := newTestChain(2)
+
+	for _, transport := range []string{"http", "ws"} {
+		t.Run(transport, func(t *testing.T) {
+			_, url := newTestNode(t, chain, transport, false)
+			client := newTestClient(t, url)
+
+			if _, err := client.rpcClient.GetLatestBlock(t.Context()); err != nil {
+				t.Fatalf("failed loading latest block: %v", err)
+			}
+
+			// missing results are valid responses
+			if _, err := client.rpcClient.GetBlockByHash(t.Context(), common.Hash{1}); !errors.Is(err, ethereum.NotFound) {
+				t.Fatalf("GetBlockByHash() error = %v, want not found", err)
+			}
+
+			if _, errorRate, samples := client.rpcClient.GetRPCStats().Get(); samples != 2 || errorRate != 0 {
+				t.Errorf("stats after successful requests: %v samples, error rate %v, want 2 samples without errors", samples, errorRate)
+			}
+
+			cancelledCtx, cancel := context.WithCancel(t.Context())
+			cancel()
+
+			if _, err := client.rpcClient.GetLatestBlock(cancelledCtx); err == nil {
+				t.Fatalf("expected error for cancelled request")
+			}
+
+			if _, errorRate, samples := client.rpcClient.GetRPCStats().Get(); samples != 3 || errorRate == 0 {
+				t.Errorf("stats after failed request: %v samples, error rate %v, want 3 samples with errors", samples, errorRate)
+			}
+		})
+	}
+}
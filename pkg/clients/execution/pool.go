@@ -2,11 +2,11 @@ package execution
 
 import (
 	"context"
-	"fmt"
 	"math/rand"
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/helper"
 	"github.com/sirupsen/logrus"
 )
 
@@ -14,6 +14,8 @@ type SchedulerMode uint8
 
 var (
 	RoundRobinScheduler SchedulerMode = 1
+	LatencyScheduler    SchedulerMode = 2
+	StickyScheduler     SchedulerMode = 3
 )
 
 type PoolConfig struct {
@@ -27,6 +29,7 @@ type Pool struct {
 	ctx            context.Context
 	logger         logrus.FieldLogger
 	clientCounter  uint16
+	clientsMutex   sync.RWMutex
 	clients        []*Client
 	blockCache     *BlockCache
 	forkCacheMutex sync.Mutex
@@ -35,6 +38,7 @@ type Pool struct {
 	schedulerMode  SchedulerMode
 	schedulerMutex sync.Mutex
 	rrLastIndexes  map[ClientType]uint16
+	stickyPins     map[string]*stickyPin
 }
 
 func NewPool(ctx context.Context, config *PoolConfig, logger logrus.FieldLogger) (*Pool, error) {
@@ -45,15 +49,14 @@ func NewPool(ctx context.Context, config *PoolConfig, logger logrus.FieldLogger)
 		clients:       make([]*Client, 0),
 		forkCache:     map[int64][]*HeadFork{},
 		rrLastIndexes: map[ClientType]uint16{},
+		stickyPins:    map[string]*stickyPin{},
 	}
 
 	var err error
 
-	switch config.SchedulerMode {
-	case "", "rr", "roundrobin":
-		pool.schedulerMode = RoundRobinScheduler
-	default:
-		return nil, fmt.Errorf("unknown pool schedulerMode: %v", config.SchedulerMode)
+	pool.schedulerMode, err = ParseSchedulerMode(config.SchedulerMode)
+	if err != nil {
+		return nil, err
 	}
 
 	pool.blockCache, err = NewBlockCache(ctx, logger, config.FollowDistance)
@@ -69,6 +72,9 @@ func (pool *Pool) GetBlockCache() *BlockCache {
 }
 
 func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
+	pool.clientsMutex.Lock()
+	defer pool.clientsMutex.Unlock()
+
 	clientIdx := pool.clientCounter
 	pool.clientCounter++
 
@@ -82,20 +88,49 @@ func (pool *Pool) AddEndpoint(endpoint *ClientConfig) (*Client, error) {
 	return client, nil
 }
 
+// RemoveEndpoint stops the client and removes it from the pool.
+func (pool *Pool) RemoveEndpoint(client *Client) {
+	pool.clientsMutex.Lock()
+
+	for i, poolClient := range pool.clients {
+		if poolClient == client {
+			pool.clients = append(pool.clients[:i:i], pool.clients[i+1:]...)
+			break
+		}
+	}
+
+	pool.clientsMutex.Unlock()
+
+	client.clientCtxCancel()
+	client.isOnline.Store(false)
+	pool.resetHeadForkCache()
+}
+
 func (pool *Pool) GetAllEndpoints() []*Client {
-	return pool.clients
+	pool.clientsMutex.RLock()
+	defer pool.clientsMutex.RUnlock()
+
+	clients := make([]*Client, len(pool.clients))
+	copy(clients, pool.clients)
+
+	return clients
 }
 
-func (pool *Pool) GetReadyEndpoint(clientType ClientType) *Client {
+// GetReadyEndpoint selects a ready endpoint via the pool scheduler. The scheduling can be customized per call via options.
+func (pool *Pool) GetReadyEndpoint(clientType ClientType, opts ...SchedulerOption) *Client {
 	readyClients := pool.GetReadyEndpoints(true)
-	selectedClient := pool.runClientScheduler(readyClients, clientType)
+	selectedClient := pool.runClientScheduler(readyClients, clientType, opts)
 
 	return selectedClient
 }
 
-func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType) *Client {
+func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType, opts ...SchedulerOption) *Client {
+	if stickyKey := helper.GetStickyKey(ctx); stickyKey != "" {
+		opts = append([]SchedulerOption{WithStickyKey(stickyKey)}, opts...)
+	}
+
 	for {
-		client := pool.GetReadyEndpoint(clientType)
+		client := pool.GetReadyEndpoint(clientType, opts...)
 		if client != nil {
 			return client
 		}
@@ -108,10 +143,16 @@ func (pool *Pool) AwaitReadyEndpoint(ctx context.Context, clientType ClientType)
 	}
 }
 
+// AwaitReadyEndpoints waits for ready endpoints and returns all of them. With the sticky scheduler, the endpoint
+// pinned to the sticky key of the context is returned first, so callers trying the endpoints in order prefer it.
 func (pool *Pool) AwaitReadyEndpoints(ctx context.Context, shuffle bool) []*Client {
 	for {
 		clients := pool.GetReadyEndpoints(shuffle)
 		if len(clients) > 0 {
+			if stickyKey := helper.GetStickyKey(ctx); stickyKey != "" && pool.schedulerMode == StickyScheduler {
+				clients = pool.sortStickyEndpoint(clients, stickyKey)
+			}
+
 			return clients
 		}
 
@@ -167,37 +208,3 @@ func (pool *Pool) IsClientReady(client *Client) bool {
 
 	return false
 }
-
-func (pool *Pool) runClientScheduler(readyClients []*Client, clientType ClientType) *Client {
-	pool.schedulerMutex.Lock()
-	defer pool.schedulerMutex.Unlock()
-
-	if pool.schedulerMode == RoundRobinScheduler {
-		var firstReadyClient *Client
-
-		for _, client := range readyClients {
-			if clientType != AnyClient && clientType != client.clientType {
-				continue
-			}
-
-			if firstReadyClient == nil {
-				firstReadyClient = client
-			}
-
-			if client.clientIdx > pool.rrLastIndexes[clientType] {
-				pool.rrLastIndexes[clientType] = client.clientIdx
-				return client
-			}
-		}
-
-		if firstReadyClient == nil {
-			return nil
-		}
-
-		pool.rrLastIndexes[clientType] = firstReadyClient.clientIdx
-
-		return firstReadyClient
-	}
-
-	return readyClients[0]
-}
@@ -36,7 +36,10 @@ func (ec *ExecutionClient) GetEthConfig(ctx context.Context) (*EthConfigResponse
 
 	var result json.RawMessage
 
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_config")
 	err := ec.rpcClient.CallContext(reqCtx, &result, "eth_config")
+	doneFn(err)
+
 	if err != nil {
 		return nil, err
 	}
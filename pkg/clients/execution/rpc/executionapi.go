@@ -2,8 +2,11 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math/big"
+	"net/http"
+	"net/url"
 	"time"
 
 	"github.com/ethereum/go-ethereum"
@@ -12,6 +15,10 @@ import (
 	"github.com/ethereum/go-ethereum/core/types"
 	"github.com/ethereum/go-ethereum/ethclient"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/ethpandaops/assertoor/pkg/metrics"
+	"github.com/ethpandaops/assertoor/pkg/tracing"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 type ExecutionClient struct {
@@ -23,6 +30,8 @@ type ExecutionClient struct {
 	concurrencyLimit int
 	requestTimeout   time.Duration
 	concurrencyChan  chan struct{}
+	stats            *metrics.RPCStats
+	isHTTP           bool
 }
 
 // NewExecutionClient is used to create a new execution client
@@ -33,6 +42,7 @@ func NewExecutionClient(name, url string, headers map[string]string) (*Execution
 		headers:          headers,
 		concurrencyLimit: 50,
 		requestTimeout:   30 * time.Second,
+		stats:            metrics.NewRPCStats(),
 	}
 
 	client.concurrencyChan = make(chan struct{}, client.concurrencyLimit)
@@ -45,13 +55,26 @@ func (ec *ExecutionClient) Initialize(ctx context.Context) error {
 		return nil
 	}
 
-	rpcClient, err := rpc.DialContext(ctx, ec.endpoint)
-	if err != nil {
-		return err
+	headers := http.Header{}
+	for hKey, hVal := range ec.headers {
+		headers.Set(hKey, hVal)
 	}
 
-	for hKey, hVal := range ec.headers {
-		rpcClient.SetHeader(hKey, hVal)
+	dialOpts := []rpc.ClientOption{
+		rpc.WithHeaders(headers),
+	}
+
+	// websocket & IPC endpoints use their own transport, their requests are recorded by observeCall instead
+	if endpointURL, err := url.Parse(ec.endpoint); err == nil && (endpointURL.Scheme == "http" || endpointURL.Scheme == "https") {
+		ec.isHTTP = true
+		dialOpts = append(dialOpts, rpc.WithHTTPClient(&http.Client{
+			Transport: metrics.NewClientTransport(nil, "execution", ec.name, metrics.JSONRPCMethod, ec.stats),
+		}))
+	}
+
+	rpcClient, err := rpc.DialOptions(ctx, ec.endpoint, dialOpts...)
+	if err != nil {
+		return err
 	}
 
 	ec.rpcClient = rpcClient
@@ -71,20 +94,82 @@ func (ec *ExecutionClient) enforceConcurrencyLimit(ctx context.Context) func() {
 	}
 }
 
+// observeCall records the latency & result of a request to a websocket or IPC endpoint and traces it as child
+// of the span in ctx. The returned function has to be called with the result of the request.
+// Requests to http endpoints are recorded by the instrumented http transport, so they're passed through.
+func (ec *ExecutionClient) observeCall(ctx context.Context, method string) (context.Context, func(err error)) {
+	if ec.isHTTP {
+		return ctx, func(error) {}
+	}
+
+	var span trace.Span
+
+	// like the http transport, requests without a traced context don't produce root spans
+	if trace.SpanContextFromContext(ctx).IsValid() {
+		ctx, span = tracing.StartSpan(ctx, "execution "+method,
+			trace.WithSpanKind(trace.SpanKindClient),
+			trace.WithAttributes(
+				attribute.String("rpc.client_type", "execution"),
+				attribute.String("rpc.client", ec.name),
+				attribute.String("rpc.method", method),
+			),
+		)
+	}
+
+	startTime := time.Now()
+
+	return ctx, func(err error) {
+		// errors returned by the node & missing results are valid responses, like http responses with error status 404
+		var rpcErr rpc.Error
+
+		failed := err != nil && !errors.As(err, &rpcErr) && !errors.Is(err, ethereum.NotFound)
+		duration := time.Since(startTime)
+
+		metrics.ObserveRPCRequest("execution", ec.name, method, duration, failed)
+		ec.stats.Observe(duration, failed)
+
+		if span != nil {
+			tracing.EndSpan(span, err)
+		}
+	}
+}
+
+// GetRPCStats returns the rolling latency & error stats of the requests to the endpoint.
+// Requests of other users of the eth client (GetEthClient) are not recorded for websocket & IPC endpoints.
+func (ec *ExecutionClient) GetRPCStats() *metrics.RPCStats {
+	return ec.stats
+}
+
 func (ec *ExecutionClient) GetEthClient() *ethclient.Client {
 	return ec.ethClient
 }
 
+// SupportsSubscriptions returns true if the endpoint is connected via websocket or IPC,
+// which allows subscribing to events instead of polling.
+func (ec *ExecutionClient) SupportsSubscriptions() bool {
+	return ec.rpcClient != nil && ec.rpcClient.SupportsSubscriptions()
+}
+
+// SubscribeNewHeads subscribes to new head notifications (eth_subscribe "newHeads").
+func (ec *ExecutionClient) SubscribeNewHeads(ctx context.Context, ch chan<- *types.Header) (ethereum.Subscription, error) {
+	return ec.ethClient.SubscribeNewHead(ctx, ch)
+}
+
 func (ec *ExecutionClient) GetClientVersion(ctx context.Context) (string, error) {
 	var result string
 
+	ctx, doneFn := ec.observeCall(ctx, "web3_clientVersion")
 	err := ec.rpcClient.CallContext(ctx, &result, "web3_clientVersion")
+	doneFn(err)
 
 	return result, err
 }
 
 func (ec *ExecutionClient) GetChainSpec(ctx context.Context) (*ChainSpec, error) {
+	ctx, doneFn := ec.observeCall(ctx, "eth_chainId")
 	chainID, err := ec.ethClient.ChainID(ctx)
+	doneFn(err)
+
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +180,10 @@ func (ec *ExecutionClient) GetChainSpec(ctx context.Context) (*ChainSpec, error)
 }
 
 func (ec *ExecutionClient) GetNodeSyncing(ctx context.Context) (*SyncStatus, error) {
+	ctx, doneFn := ec.observeCall(ctx, "eth_syncing")
 	status, err := ec.ethClient.SyncProgress(ctx)
+	doneFn(err)
+
 	if err != nil {
 		return nil, err
 	}
@@ -120,7 +208,10 @@ func (ec *ExecutionClient) GetLatestBlock(ctx context.Context) (*types.Block, er
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_getBlockByNumber")
 	block, err := ec.ethClient.BlockByNumber(reqCtx, nil)
+	doneFn(err)
+
 	if err != nil {
 		return nil, err
 	}
@@ -132,7 +223,10 @@ func (ec *ExecutionClient) GetBlockByHash(ctx context.Context, hash common.Hash)
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_getBlockByHash")
 	block, err := ec.ethClient.BlockByHash(reqCtx, hash)
+	doneFn(err)
+
 	if err != nil {
 		return nil, err
 	}
@@ -151,7 +245,11 @@ func (ec *ExecutionClient) GetNonceAt(ctx context.Context, wallet common.Address
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
-	return ec.ethClient.NonceAt(reqCtx, wallet, blockNumber)
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_getTransactionCount")
+	nonce, err := ec.ethClient.NonceAt(reqCtx, wallet, blockNumber)
+	doneFn(err)
+
+	return nonce, err
 }
 
 func (ec *ExecutionClient) GetBalanceAt(ctx context.Context, wallet common.Address, blockNumber *big.Int) (*big.Int, error) {
@@ -165,7 +263,11 @@ func (ec *ExecutionClient) GetBalanceAt(ctx context.Context, wallet common.Addre
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
-	return ec.ethClient.BalanceAt(reqCtx, wallet, blockNumber)
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_getBalance")
+	balance, err := ec.ethClient.BalanceAt(reqCtx, wallet, blockNumber)
+	doneFn(err)
+
+	return balance, err
 }
 
 func (ec *ExecutionClient) GetTransactionReceipt(ctx context.Context, txHash common.Hash) (*types.Receipt, error) {
@@ -179,7 +281,11 @@ func (ec *ExecutionClient) GetTransactionReceipt(ctx context.Context, txHash com
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
-	return ec.ethClient.TransactionReceipt(reqCtx, txHash)
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_getTransactionReceipt")
+	receipt, err := ec.ethClient.TransactionReceipt(reqCtx, txHash)
+	doneFn(err)
+
+	return receipt, err
 }
 
 func (ec *ExecutionClient) GetBlockReceipts(ctx context.Context, blockHash common.Hash) ([]*types.Receipt, error) {
@@ -193,9 +299,13 @@ func (ec *ExecutionClient) GetBlockReceipts(ctx context.Context, blockHash commo
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
-	return ec.ethClient.BlockReceipts(reqCtx, rpc.BlockNumberOrHash{
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_getBlockReceipts")
+	receipts, err := ec.ethClient.BlockReceipts(reqCtx, rpc.BlockNumberOrHash{
 		BlockHash: &blockHash,
 	})
+	doneFn(err)
+
+	return receipts, err
 }
 
 func (ec *ExecutionClient) SendTransaction(ctx context.Context, tx *types.Transaction) error {
@@ -209,7 +319,11 @@ func (ec *ExecutionClient) SendTransaction(ctx context.Context, tx *types.Transa
 	reqCtx, reqCtxCancel := context.WithTimeout(ctx, ec.requestTimeout)
 	defer reqCtxCancel()
 
-	return ec.ethClient.SendTransaction(reqCtx, tx)
+	reqCtx, doneFn := ec.observeCall(reqCtx, "eth_sendRawTransaction")
+	err := ec.ethClient.SendTransaction(reqCtx, tx)
+	doneFn(err)
+
+	return err
 }
 
 func (ec *ExecutionClient) GetEthCall(ctx context.Context, msg *ethereum.CallMsg, blockNumber *big.Int) ([]byte, error) {
@@ -220,5 +334,9 @@ func (ec *ExecutionClient) GetEthCall(ctx context.Context, msg *ethereum.CallMsg
 
 	defer closeFn()
 
-	return ec.ethClient.CallContract(ctx, *msg, blockNumber)
+	ctx, doneFn := ec.observeCall(ctx, "eth_call")
+	result, err := ec.ethClient.CallContract(ctx, *msg, blockNumber)
+	doneFn(err)
+
+	return result, err
 }
@@ -0,0 +1,378 @@
+package execution
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/clients/execution/rpc"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+// newTestSchedulerPool creates a pool with the given ready clients, all following a canonical head at headNumber.
+func newTestSchedulerPool(t *testing.T, mode SchedulerMode, clientTypes []ClientType, headNumber uint64) (*Pool, []*Client) {
+	t.Helper()
+
+	pool := &Pool{
+		config:        &PoolConfig{},
+		forkCache:     map[int64][]*HeadFork{},
+		schedulerMode: mode,
+		rrLastIndexes: map[ClientType]uint16{},
+		stickyPins:    map[string]*stickyPin{},
+	}
+
+	clients := make([]*Client, len(clientTypes))
+
+	for idx, clientType := range clientTypes {
+		rpcClient, err := rpc.NewExecutionClient(fmt.Sprintf("client%v", idx), "http://127.0.0.1:8545", nil)
+		if err != nil {
+			t.Fatalf("failed creating rpc client: %v", err)
+		}
+
+		clients[idx] = &Client{
+			pool:       pool,
+			clientIdx:  uint16(idx),
+			clientType: clientType,
+			rpcClient:  rpcClient,
+			headNumber: headNumber,
+		}
+	}
+
+	// inject the canonical fork instead of deriving it from the client heads
+	pool.forkCache[int64(pool.config.ForkDistance)] = []*HeadFork{{
+		Number:       headNumber,
+		ReadyClients: clients,
+		AllClients:   clients,
+	}}
+
+	return pool, clients
+}
+
+// selectTestClient runs the scheduler on the clients in the given order (GetReadyEndpoint shuffles the ready clients).
+func selectTestClient(pool *Pool, clients []*Client, clientType ClientType, opts ...SchedulerOption) *Client {
+	return pool.runClientScheduler(clients, clientType, opts)
+}
+
+func getTestClientIndexes(clients []*Client) []uint16 {
+	indexes := make([]uint16, len(clients))
+	for idx, client := range clients {
+		indexes[idx] = client.clientIdx
+	}
+
+	return indexes
+}
+
+func TestParseSchedulerMode(t *testing.T) {
+	tests := []struct {
+		mode    string
+		want    SchedulerMode
+		wantErr bool
+	}{
+		{mode: "", want: RoundRobinScheduler},
+		{mode: "rr", want: RoundRobinScheduler},
+		{mode: "roundrobin", want: RoundRobinScheduler},
+		{mode: "latency", want: LatencyScheduler},
+		{mode: "sticky", want: StickyScheduler},
+		{mode: "random", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.mode, func(t *testing.T) {
+			got, err := ParseSchedulerMode(tt.mode)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("ParseSchedulerMode() error = %v, wantErr %v", err, tt.wantErr)
+			}
+
+			if got != tt.want {
+				t.Errorf("ParseSchedulerMode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRoundRobinScheduler(t *testing.T) {
+	pool, clients := newTestSchedulerPool(t, RoundRobinScheduler, []ClientType{GethClient, BesuClient, GethClient, RethClient}, 10)
+
+	tests := []struct {
+		name       string
+		clientType ClientType
+		want       []uint16
+	}{
+		{name: "any client", clientType: AnyClient, want: []uint16{1, 2, 3, 0, 1}},
+		{name: "client type", clientType: GethClient, want: []uint16{2, 0, 2}},
+		{name: "single client type", clientType: BesuClient, want: []uint16{1, 1}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := []uint16{}
+
+			for range tt.want {
+				client := selectTestClient(pool, clients, tt.clientType)
+				if client == nil {
+					t.Fatalf("no client selected")
+				}
+
+				got = append(got, client.clientIdx)
+			}
+
+			if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+				t.Errorf("selected clients %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	if client := pool.GetReadyEndpoint(NethermindClient); client != nil {
+		t.Errorf("selected client %v for missing client type", client.clientIdx)
+	}
+}
+
+func TestStickyScheduler(t *testing.T) {
+	clientTypes := []ClientType{GethClient, BesuClient, GethClient}
+
+	t.Run("same key selects same client", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, StickyScheduler, clientTypes, 10)
+
+		first := selectTestClient(pool, clients, AnyClient, WithStickyKey("run-1"))
+		for range 10 {
+			if client := selectTestClient(pool, clients, AnyClient, WithStickyKey("run-1")); client != first {
+				t.Fatalf("selected client %v, want pinned client %v", client.clientIdx, first.clientIdx)
+			}
+		}
+	})
+
+	t.Run("keys are spread over clients", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, StickyScheduler, clientTypes, 10)
+
+		selected := map[uint16]bool{}
+		for idx := range 3 {
+			selected[selectTestClient(pool, clients, AnyClient, WithStickyKey(fmt.Sprintf("run-%v", idx))).clientIdx] = true
+		}
+
+		if len(selected) != 3 {
+			t.Errorf("3 keys pinned to %v clients, want 3", len(selected))
+		}
+	})
+
+	t.Run("keys are pinned per client type", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, StickyScheduler, clientTypes, 10)
+
+		selectTestClient(pool, clients, AnyClient, WithStickyKey("run-1"))
+
+		for range 3 {
+			if client := selectTestClient(pool, clients, BesuClient, WithStickyKey("run-1")); client.clientType != BesuClient {
+				t.Fatalf("selected client type %v, want %v", client.clientType, BesuClient)
+			}
+		}
+
+		if len(pool.stickyPins) != 2 {
+			t.Errorf("pool has %v pins, want 2", len(pool.stickyPins))
+		}
+	})
+
+	t.Run("empty key falls back to round robin", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, StickyScheduler, clientTypes, 10)
+
+		got := []uint16{}
+		for range 4 {
+			got = append(got, selectTestClient(pool, clients, AnyClient, WithStickyKey("")).clientIdx)
+		}
+
+		if fmt.Sprint(got) != fmt.Sprint([]uint16{1, 2, 0, 1}) {
+			t.Errorf("selected clients %v, want round robin", got)
+		}
+
+		if len(pool.stickyPins) != 0 {
+			t.Errorf("pool has %v pins, want none", len(pool.stickyPins))
+		}
+	})
+
+	t.Run("key is repinned if client is not ready", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, StickyScheduler, clientTypes, 10)
+
+		pinned := selectTestClient(pool, clients, AnyClient, WithStickyKey("run-1"))
+
+		readyClients := []*Client{}
+		for _, client := range clients {
+			if client != pinned {
+				readyClients = append(readyClients, client)
+			}
+		}
+
+		repinned := selectTestClient(pool, readyClients, AnyClient, WithStickyKey("run-1"))
+		if repinned == pinned {
+			t.Fatalf("selected client %v, which is not ready", pinned.clientIdx)
+		}
+
+		if client := selectTestClient(pool, clients, AnyClient, WithStickyKey("run-1")); client != repinned {
+			t.Errorf("selected client %v, want repinned client %v", client.clientIdx, repinned.clientIdx)
+		}
+	})
+
+	t.Run("expired pins are dropped", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, StickyScheduler, clientTypes, 10)
+		pinKey := func(key string) string {
+			return fmt.Sprintf("%v:%v", AnyClient, key)
+		}
+
+		pool.stickyPins[pinKey("expired")] = &stickyPin{clientIdx: 0, lastUse: time.Now().Add(-stickyPinTimeout - time.Minute)}
+		pool.stickyPins[pinKey("recent")] = &stickyPin{clientIdx: 1, lastUse: time.Now().Add(-time.Minute)}
+
+		selectTestClient(pool, clients, AnyClient, WithStickyKey("run-1"))
+
+		if pool.stickyPins[pinKey("expired")] != nil {
+			t.Errorf("expired pin not dropped")
+		}
+
+		if pool.stickyPins[pinKey("recent")] == nil {
+			t.Errorf("recent pin dropped")
+		}
+
+		if pool.stickyPins[pinKey("run-1")] == nil {
+			t.Errorf("new pin not created")
+		}
+	})
+
+	t.Run("mode option overrides pool mode", func(t *testing.T) {
+		pool, clients := newTestSchedulerPool(t, RoundRobinScheduler, clientTypes, 10)
+
+		first := selectTestClient(pool, clients, AnyClient, WithSchedulerMode(StickyScheduler), WithStickyKey("run-1"))
+		if client := selectTestClient(pool, clients, AnyClient, WithSchedulerMode(StickyScheduler), WithStickyKey("run-1")); client != first {
+			t.Errorf("selected client %v, want pinned client %v", client.clientIdx, first.clientIdx)
+		}
+	})
+}
+
+func TestAwaitReadyEndpointsSticky(t *testing.T) {
+	tests := []struct {
+		name       string
+		mode       SchedulerMode
+		stickyKey  string
+		wantPinned bool
+	}{
+		{name: "sticky scheduler", mode: StickyScheduler, stickyKey: "run-1", wantPinned: true},
+		{name: "sticky scheduler without key", mode: StickyScheduler},
+		{name: "round robin scheduler", mode: RoundRobinScheduler, stickyKey: "run-1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, clients := newTestSchedulerPool(t, tt.mode, []ClientType{GethClient, BesuClient, RethClient}, 10)
+			ctx := helper.WithStickyKey(t.Context(), tt.stickyKey)
+
+			// move the pin away from the first client
+			pool.rrLastIndexes[AnyClient] = 1
+
+			got := pool.AwaitReadyEndpoints(ctx, false)
+			if len(got) != len(clients) {
+				t.Fatalf("got %v clients, want %v", len(got), len(clients))
+			}
+
+			if !tt.wantPinned {
+				if fmt.Sprint(getTestClientIndexes(got)) != fmt.Sprint(getTestClientIndexes(clients)) {
+					t.Errorf("clients reordered to %v", getTestClientIndexes(got))
+				}
+
+				return
+			}
+
+			pinned := pool.GetReadyEndpoint(AnyClient, WithStickyKey(tt.stickyKey))
+			if got[0] != pinned {
+				t.Errorf("first client %v, want pinned client %v", got[0].clientIdx, pinned.clientIdx)
+			}
+
+			if fmt.Sprint(getTestClientIndexes(got)) != "[2 0 1]" {
+				t.Errorf("clients ordered %v, want [2 0 1]", getTestClientIndexes(got))
+			}
+
+			if fmt.Sprint(getTestClientIndexes(clients)) != "[0 1 2]" {
+				t.Errorf("ready clients of the pool modified to %v", getTestClientIndexes(clients))
+			}
+		})
+	}
+}
+
+func TestSchedulerCost(t *testing.T) {
+	tests := []struct {
+		name       string
+		latencies  []time.Duration
+		failed     bool
+		clientHead uint64
+		want       time.Duration
+	}{
+		{name: "no samples", clientHead: 10, want: latencyDefault},
+		{name: "measured latency", latencies: []time.Duration{40 * time.Millisecond}, clientHead: 10, want: 40 * time.Millisecond},
+		{name: "failed requests", latencies: []time.Duration{40 * time.Millisecond}, failed: true, clientHead: 10, want: 40 * time.Millisecond * (1 + latencyErrorPenalty)},
+		{name: "head lag", latencies: []time.Duration{40 * time.Millisecond}, clientHead: 8, want: 40*time.Millisecond + 2*latencyHeadLagPenalty},
+		{name: "head ahead of canonical head", clientHead: 12, want: latencyDefault},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, clients := newTestSchedulerPool(t, LatencyScheduler, []ClientType{GethClient}, 10)
+			client := clients[0]
+			client.headNumber = tt.clientHead
+
+			for _, latency := range tt.latencies {
+				client.rpcClient.GetRPCStats().Observe(latency, tt.failed)
+			}
+
+			if got := getSchedulerCost(client, 10); got != float64(tt.want) {
+				t.Errorf("getSchedulerCost() = %v, want %v", time.Duration(got), tt.want)
+			}
+		})
+	}
+}
+
+func TestLatencyScheduler(t *testing.T) {
+	tests := []struct {
+		name      string
+		latencies []time.Duration
+		failed    []bool
+		heads     []uint64
+		wantIdx   uint16
+	}{
+		{
+			name:      "fast client",
+			latencies: []time.Duration{500 * time.Millisecond, 5 * time.Millisecond, 500 * time.Millisecond},
+			failed:    []bool{false, false, false},
+			heads:     []uint64{10, 10, 10},
+			wantIdx:   1,
+		},
+		{
+			name:      "failing client",
+			latencies: []time.Duration{20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond},
+			failed:    []bool{true, true, false},
+			heads:     []uint64{10, 10, 10},
+			wantIdx:   2,
+		},
+		{
+			name:      "lagging clients",
+			latencies: []time.Duration{20 * time.Millisecond, 20 * time.Millisecond, 20 * time.Millisecond},
+			failed:    []bool{false, false, false},
+			heads:     []uint64{10, 6, 4},
+			wantIdx:   0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pool, clients := newTestSchedulerPool(t, LatencyScheduler, []ClientType{GethClient, BesuClient, RethClient}, 10)
+
+			for idx, client := range clients {
+				client.headNumber = tt.heads[idx]
+				client.rpcClient.GetRPCStats().Observe(tt.latencies[idx], tt.failed[idx])
+			}
+
+			selected := map[uint16]int{}
+			for range 1000 {
+				selected[pool.GetReadyEndpoint(AnyClient).clientIdx]++
+			}
+
+			// the preferred client has more than 70% of the total weight in all cases
+			if selected[tt.wantIdx] < 600 {
+				t.Errorf("client %v selected %v times out of 1000, want preferred selection (%v)", tt.wantIdx, selected[tt.wantIdx], selected)
+			}
+		})
+	}
+}
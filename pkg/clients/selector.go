@@ -0,0 +1,132 @@
+package clients
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// LabelName is the implicit label holding the client name.
+const LabelName = "name"
+
+var (
+	selectorKeyPattern   = regexp.MustCompile(`^(!?)([a-zA-Z0-9_./-]+)$`)
+	selectorValuePattern = regexp.MustCompile(`^([a-zA-Z0-9_./-]+)\s*(!=|=)\s*([a-zA-Z0-9_./|-]*)$`)
+)
+
+// Selector matches clients by their labels.
+//
+// A selector is a comma separated list of requirements, which all need to match:
+//   - `key=value` / `key!=value`: the label equals / does not equal the value
+//   - `key=a|b`: the label equals one of the values
+//   - `key` / `!key`: the label is set / not set
+//
+// Example: `cl=lighthouse|prysm,supernode=true,!builder`
+type Selector struct {
+	requirements []*selectorRequirement
+}
+
+type selectorRequirement struct {
+	key     string
+	negated bool
+	values  []string // nil for existence checks
+}
+
+// ParseSelector parses a label selector.
+func ParseSelector(selector string) (*Selector, error) {
+	result := &Selector{}
+
+	for _, term := range strings.Split(selector, ",") {
+		term = strings.TrimSpace(term)
+		if term == "" {
+			continue
+		}
+
+		if match := selectorValuePattern.FindStringSubmatch(term); match != nil {
+			result.requirements = append(result.requirements, &selectorRequirement{
+				key:     match[1],
+				negated: match[2] == "!=",
+				values:  strings.Split(match[3], "|"),
+			})
+
+			continue
+		}
+
+		if match := selectorKeyPattern.FindStringSubmatch(term); match != nil {
+			result.requirements = append(result.requirements, &selectorRequirement{
+				key:     match[2],
+				negated: match[1] == "!",
+			})
+
+			continue
+		}
+
+		return nil, fmt.Errorf("invalid selector requirement: %v", term)
+	}
+
+	return result, nil
+}
+
+// isLabelSelector returns true if the selector can't be a client name regex: it contains a `key=value` or `key!=value`
+// requirement, a negated `!key` requirement or more than one requirement. A single bare key is treated as a name regex.
+func (s *Selector) isLabelSelector() bool {
+	if len(s.requirements) > 1 {
+		return true
+	}
+
+	for _, requirement := range s.requirements {
+		if requirement.values != nil || requirement.negated {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Matches returns true if the labels fulfill all requirements of the selector.
+func (s *Selector) Matches(labels map[string]string) bool {
+	for _, requirement := range s.requirements {
+		value, isSet := labels[requirement.key]
+
+		matched := isSet
+		if requirement.values != nil {
+			matched = false
+
+			for _, expected := range requirement.values {
+				if isSet && value == expected {
+					matched = true
+					break
+				}
+			}
+		}
+
+		if matched == requirement.negated {
+			return false
+		}
+	}
+
+	return true
+}
+
+// GetLabels returns the labels of the client pair, including the implicit `name` label.
+func (client *PoolClient) GetLabels() map[string]string {
+	labels := make(map[string]string, len(client.Config.Labels)+1)
+	for key, value := range client.Config.Labels {
+		labels[key] = value
+	}
+
+	labels[LabelName] = client.Config.Name
+
+	return labels
+}
+
+// MatchesPattern returns true if the client matches the pattern, which is either a label selector or a client name regex.
+func (client *PoolClient) MatchesPattern(pattern string) bool {
+	if selector, err := ParseSelector(pattern); err == nil && selector.isLabelSelector() {
+		return selector.Matches(client.GetLabels())
+	}
+
+	matched, _ := regexp.MatchString(pattern, client.Config.Name)
+
+	return matched
+}
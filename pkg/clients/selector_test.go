@@ -0,0 +1,143 @@
+package clients
+
+import (
+	"testing"
+)
+
+func TestParseSelector(t *testing.T) {
+	tests := []struct {
+		selector         string
+		wantRequirements int
+		wantSelector     bool
+		wantErr          bool
+	}{
+		{selector: "", wantRequirements: 0},
+		{selector: "cl=lighthouse", wantRequirements: 1, wantSelector: true},
+		{selector: "cl = lighthouse", wantRequirements: 1, wantSelector: true},
+		{selector: "cl=lighthouse|prysm,supernode=true,!builder", wantRequirements: 3, wantSelector: true},
+		{selector: "el!=geth", wantRequirements: 1, wantSelector: true},
+		{selector: "cl=", wantRequirements: 1, wantSelector: true},
+		{selector: "builder", wantRequirements: 1},
+		{selector: "!builder", wantRequirements: 1, wantSelector: true},
+		{selector: "!builder, supernode", wantRequirements: 2, wantSelector: true},
+		{selector: "lighthouse-geth-1", wantRequirements: 1},
+		{selector: "cl=lighthouse,,el=geth", wantRequirements: 2, wantSelector: true},
+		{selector: "lighthouse-.*", wantErr: true},
+		{selector: "cl==lighthouse", wantErr: true},
+		{selector: "(lighthouse|prysm)-geth", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseSelector(tt.selector)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v requirements", len(selector.requirements))
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(selector.requirements) != tt.wantRequirements {
+				t.Errorf("requirements = %v, want %v", len(selector.requirements), tt.wantRequirements)
+			}
+
+			if selector.isLabelSelector() != tt.wantSelector {
+				t.Errorf("isLabelSelector() = %v, want %v", selector.isLabelSelector(), tt.wantSelector)
+			}
+		})
+	}
+}
+
+func TestSelectorMatches(t *testing.T) {
+	labels := map[string]string{
+		"name":      "lighthouse-geth-1",
+		"cl":        "lighthouse",
+		"el":        "geth",
+		"supernode": "true",
+		"empty":     "",
+	}
+
+	tests := []struct {
+		selector string
+		want     bool
+	}{
+		{selector: "", want: true},
+		{selector: "cl=lighthouse", want: true},
+		{selector: "cl=prysm", want: false},
+		{selector: "cl=prysm|lighthouse", want: true},
+		{selector: "cl!=prysm", want: true},
+		{selector: "cl!=prysm|lighthouse", want: false},
+		{selector: "cl=lighthouse,el=geth,supernode=true", want: true},
+		{selector: "cl=lighthouse,el=nethermind", want: false},
+		{selector: "supernode", want: true},
+		{selector: "!supernode", want: false},
+		{selector: "builder", want: false},
+		{selector: "!builder", want: true},
+		{selector: "builder=true", want: false},
+		{selector: "builder!=true", want: true},
+		{selector: "empty", want: true},
+		{selector: "empty=", want: true},
+		{selector: "cl=", want: false},
+		{selector: "name=lighthouse-geth-1", want: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.selector, func(t *testing.T) {
+			selector, err := ParseSelector(tt.selector)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := selector.Matches(labels); got != tt.want {
+				t.Errorf("Matches() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestPoolClientMatchesPattern(t *testing.T) {
+	client := &PoolClient{
+		Config: &ClientConfig{
+			Name: "lighthouse-geth-1",
+			Labels: map[string]string{
+				"cl":    "lighthouse",
+				"el":    "geth",
+				"group": "bootnodes",
+			},
+		},
+	}
+
+	tests := []struct {
+		name    string
+		pattern string
+		want    bool
+	}{
+		{name: "selector match", pattern: "cl=lighthouse", want: true},
+		{name: "selector mismatch", pattern: "cl=prysm", want: false},
+		{name: "selector with existence check", pattern: "group=bootnodes,!builder", want: true},
+		{name: "implicit name label", pattern: "name=lighthouse-geth-1", want: true},
+		{name: "regex name match", pattern: "lighthouse-.*", want: true},
+		{name: "regex name mismatch", pattern: "^prysm-", want: false},
+		{name: "plain name is a regex", pattern: "lighthouse-geth-1", want: true},
+		{name: "label key alone is a name regex", pattern: "group", want: false},
+		{name: "negated existence check", pattern: "!builder", want: true},
+		{name: "negated existence check mismatch", pattern: "!group", want: false},
+		{name: "existence check list", pattern: "group,el", want: true},
+		{name: "existence check list mismatch", pattern: "group,builder", want: false},
+		{name: "regex alternation", pattern: "(prysm|lighthouse)-geth", want: true},
+		{name: "invalid regex", pattern: "lighthouse-(", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := client.MatchesPattern(tt.pattern); got != tt.want {
+				t.Errorf("MatchesPattern(%q) = %v, want %v", tt.pattern, got, tt.want)
+			}
+		})
+	}
+}
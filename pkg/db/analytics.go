@@ -0,0 +1,371 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// AnalyticsTestRun is the aggregated result of a finished test run.
+type AnalyticsTestRun struct {
+	RunID        uint64 `db:"run_id"`
+	TestID       string `db:"test_id"`
+	StartTime    int64  `db:"start_time"`
+	Duration     int64  `db:"duration"`
+	Status       string `db:"status"`
+	Flipped      int    `db:"flipped"`
+	FirstFailure string `db:"first_failure"`
+}
+
+// AnalyticsTaskRun is the aggregated result of a task with ID in a finished test run.
+type AnalyticsTaskRun struct {
+	RunID     uint64 `db:"run_id"`
+	TaskRef   string `db:"task_ref"`
+	TestID    string `db:"test_id"`
+	StartTime int64  `db:"start_time"`
+	Duration  int64  `db:"duration"`
+	Status    string `db:"status"`
+	Flipped   int    `db:"flipped"`
+}
+
+// AnalyticsStats are the aggregated run counts of a test or task within a time window.
+type AnalyticsStats struct {
+	Key          string  `db:"key"`
+	Runs         uint64  `db:"runs"`
+	Success      uint64  `db:"success"`
+	Failure      uint64  `db:"failure"`
+	Aborted      uint64  `db:"aborted"`
+	Skipped      uint64  `db:"skipped"`
+	Flips        uint64  `db:"flips"`
+	MeanDuration float64 `db:"mean_duration"`
+	LastRun      int64   `db:"last_run"`
+}
+
+// AnalyticsDuration is a single run duration of a test or task.
+type AnalyticsDuration struct {
+	Key      string `db:"key"`
+	Duration int64  `db:"duration"`
+}
+
+// AnalyticsFailureCount is the number of failed runs that first failed at the given task.
+type AnalyticsFailureCount struct {
+	TaskRef string `db:"task_ref"`
+	Count   uint64 `db:"count"`
+}
+
+// AnalyticsWindow restricts analytics queries to runs started within a time range (unix milliseconds, 0 for open).
+type AnalyticsWindow struct {
+	Since int64
+	Until int64
+}
+
+func (w *AnalyticsWindow) where(args []any) (string, []any) {
+	where := ""
+
+	if w == nil {
+		return where, args
+	}
+
+	if w.Since > 0 {
+		args = append(args, w.Since)
+		where += fmt.Sprintf(` AND start_time >= $%v`, len(args))
+	}
+
+	if w.Until > 0 {
+		args = append(args, w.Until)
+		where += fmt.Sprintf(` AND start_time < $%v`, len(args))
+	}
+
+	return where, args
+}
+
+// flips returns the aggregate that counts the status flips of the runs within the window.
+// A flip is only counted if the run it flipped against started within the window too, so runs before
+// the window do not add to the flakiness of the window.
+func (w *AnalyticsWindow) flips(table, match string, args []any) (string, []any) {
+	if w == nil || w.Since <= 0 {
+		return `SUM(flipped)`, args
+	}
+
+	args = append(args, w.Since)
+
+	return fmt.Sprintf(`SUM(CASE WHEN flipped = 1 AND COALESCE((
+			SELECT p.start_time FROM %[1]s p
+			WHERE %[2]s AND p.run_id < %[1]s.run_id AND p.status IN ('success', 'failure')
+			ORDER BY p.run_id DESC LIMIT 1
+		), 0) >= $%[3]v THEN 1 ELSE 0 END)`, table, match, len(args)), args
+}
+
+// InsertAnalyticsTestRun replaces the analytics records of a test run and refreshes the status flip
+// markers of the run and its successor, so runs may be added out of order.
+func (db *Database) InsertAnalyticsTestRun(tx *sqlx.Tx, run *AnalyticsTestRun, tasks []*AnalyticsTaskRun) error {
+	if err := db.DeleteAnalyticsTestRun(tx, run.RunID); err != nil {
+		return err
+	}
+
+	_, err := tx.Exec(`
+		INSERT INTO analytics_test_runs (
+			run_id, test_id, start_time, duration, status, flipped, first_failure
+		) VALUES ($1, $2, $3, $4, $5, 0, $6)`,
+		run.RunID, run.TestID, run.StartTime, run.Duration, run.Status, run.FirstFailure)
+	if err != nil {
+		return err
+	}
+
+	if err := db.updateAnalyticsTestFlips(tx, run.TestID, run.RunID); err != nil {
+		return err
+	}
+
+	for _, task := range tasks {
+		_, err = tx.Exec(`
+			INSERT INTO analytics_task_runs (
+				run_id, task_ref, test_id, start_time, duration, status, flipped
+			) VALUES ($1, $2, $3, $4, $5, $6, 0)`,
+			task.RunID, task.TaskRef, task.TestID, task.StartTime, task.Duration, task.Status)
+		if err != nil {
+			return err
+		}
+
+		if err := db.updateAnalyticsTaskFlips(tx, task.TestID, task.TaskRef, task.RunID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeleteAnalyticsTestRun deletes the analytics records of a test run and refreshes the status flip
+// markers of the runs that followed it, as they flip against the run before the deleted one now.
+func (db *Database) DeleteAnalyticsTestRun(tx *sqlx.Tx, runID uint64) error {
+	var testIDs []string
+
+	err := tx.Select(&testIDs, `SELECT test_id FROM analytics_test_runs WHERE run_id = $1`, runID)
+	if err != nil {
+		return err
+	}
+
+	var taskRefs []struct {
+		TestID  string `db:"test_id"`
+		TaskRef string `db:"task_ref"`
+	}
+
+	err = tx.Select(&taskRefs, `SELECT test_id, task_ref FROM analytics_task_runs WHERE run_id = $1`, runID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM analytics_test_runs WHERE run_id = $1`, runID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`DELETE FROM analytics_task_runs WHERE run_id = $1`, runID)
+	if err != nil {
+		return err
+	}
+
+	for _, testID := range testIDs {
+		if err := db.updateAnalyticsTestFlips(tx, testID, runID); err != nil {
+			return err
+		}
+	}
+
+	for _, taskRef := range taskRefs {
+		if err := db.updateAnalyticsTaskFlips(tx, taskRef.TestID, taskRef.TaskRef, runID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// updateAnalyticsTestFlips recomputes the status flip markers of the test runs from runID up to the next
+// successful or failed run of the test. A run flipped if its status differs from the previous successful or
+// failed run. runID does not need to exist anymore.
+func (db *Database) updateAnalyticsTestFlips(tx *sqlx.Tx, testID string, runID uint64) error {
+	_, err := tx.Exec(`
+		UPDATE analytics_test_runs SET flipped = CASE WHEN status IN ('success', 'failure') AND status <> COALESCE((
+			SELECT p.status FROM analytics_test_runs p
+			WHERE p.test_id = analytics_test_runs.test_id AND p.run_id < analytics_test_runs.run_id AND p.status IN ('success', 'failure')
+			ORDER BY p.run_id DESC LIMIT 1
+		), status) THEN 1 ELSE 0 END
+		WHERE test_id = $1 AND run_id >= $2 AND run_id <= COALESCE((
+			SELECT MIN(n.run_id) FROM analytics_test_runs n
+			WHERE n.test_id = $1 AND n.run_id > $2 AND n.status IN ('success', 'failure')
+		), $2)`,
+		testID, runID)
+
+	return err
+}
+
+// updateAnalyticsTaskFlips recomputes the status flip markers of a task like updateAnalyticsTestFlips.
+func (db *Database) updateAnalyticsTaskFlips(tx *sqlx.Tx, testID, taskRef string, runID uint64) error {
+	_, err := tx.Exec(`
+		UPDATE analytics_task_runs SET flipped = CASE WHEN status IN ('success', 'failure') AND status <> COALESCE((
+			SELECT p.status FROM analytics_task_runs p
+			WHERE p.test_id = analytics_task_runs.test_id AND p.task_ref = analytics_task_runs.task_ref
+				AND p.run_id < analytics_task_runs.run_id AND p.status IN ('success', 'failure')
+			ORDER BY p.run_id DESC LIMIT 1
+		), status) THEN 1 ELSE 0 END
+		WHERE test_id = $1 AND task_ref = $2 AND run_id >= $3 AND run_id <= COALESCE((
+			SELECT MIN(n.run_id) FROM analytics_task_runs n
+			WHERE n.test_id = $1 AND n.task_ref = $2 AND n.run_id > $3 AND n.status IN ('success', 'failure')
+		), $3)`,
+		testID, taskRef, runID)
+
+	return err
+}
+
+// GetUnindexedTestRunIDs returns the IDs of finished test runs that have no analytics records yet.
+func (db *Database) GetUnindexedTestRunIDs(limit uint64) ([]uint64, error) {
+	var runIDs []uint64
+
+	err := db.reader.Select(&runIDs, `
+		SELECT run_id FROM test_runs
+		WHERE status IN ('success', 'failure', 'aborted', 'skipped')
+			AND NOT EXISTS (SELECT 1 FROM analytics_test_runs a WHERE a.run_id = test_runs.run_id)
+		ORDER BY run_id ASC
+		LIMIT $1`,
+		limit)
+	if err != nil {
+		return nil, err
+	}
+
+	return runIDs, nil
+}
+
+// GetAnalyticsTestStats returns the aggregated run counts per test within the window.
+func (db *Database) GetAnalyticsTestStats(testID string, window *AnalyticsWindow) ([]*AnalyticsStats, error) {
+	args := []any{}
+	where := ""
+
+	if testID != "" {
+		args = append(args, testID)
+		where = ` AND test_id = $1`
+	}
+
+	windowWhere, args := window.where(args)
+	flips, args := window.flips("analytics_test_runs", "p.test_id = analytics_test_runs.test_id", args)
+
+	var stats []*AnalyticsStats
+
+	err := db.reader.Select(&stats, `
+		SELECT
+			test_id AS key,
+			COUNT(*) AS runs,
+			SUM(CASE WHEN status = 'success' THEN 1 ELSE 0 END) AS success,
+			SUM(CASE WHEN status = 'failure' THEN 1 ELSE 0 END) AS failure,
+			SUM(CASE WHEN status = 'aborted' THEN 1 ELSE 0 END) AS aborted,
+			SUM(CASE WHEN status = 'skipped' THEN 1 ELSE 0 END) AS skipped,
+			`+flips+` AS flips,
+			COALESCE(AVG(CASE WHEN status <> 'skipped' THEN duration END), 0) AS mean_duration,
+			MAX(start_time) AS last_run
+		FROM analytics_test_runs
+		WHERE 1 = 1`+where+windowWhere+`
+		GROUP BY test_id
+		ORDER BY test_id ASC`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetAnalyticsTaskStats returns the aggregated run counts per task ID of a test within the window.
+func (db *Database) GetAnalyticsTaskStats(testID string, window *AnalyticsWindow) ([]*AnalyticsStats, error) {
+	windowWhere, args := window.where([]any{testID})
+	flips, args := window.flips("analytics_task_runs", "p.test_id = analytics_task_runs.test_id AND p.task_ref = analytics_task_runs.task_ref", args)
+
+	var stats []*AnalyticsStats
+
+	err := db.reader.Select(&stats, `
+		SELECT
+			task_ref AS key,
+			COUNT(*) AS runs,
+			SUM(CASE WHEN status = 'success' THEN 1 ELSE 0 END) AS success,
+			SUM(CASE WHEN status = 'failure' THEN 1 ELSE 0 END) AS failure,
+			0 AS aborted,
+			SUM(CASE WHEN status = 'skipped' THEN 1 ELSE 0 END) AS skipped,
+			`+flips+` AS flips,
+			COALESCE(AVG(CASE WHEN status <> 'skipped' THEN duration END), 0) AS mean_duration,
+			MAX(start_time) AS last_run
+		FROM analytics_task_runs
+		WHERE test_id = $1`+windowWhere+`
+		GROUP BY task_ref
+		ORDER BY task_ref ASC`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return stats, nil
+}
+
+// GetAnalyticsTestDurations returns the durations of all non-skipped runs per test within the window,
+// ordered by test ID and duration.
+func (db *Database) GetAnalyticsTestDurations(testID string, window *AnalyticsWindow) ([]*AnalyticsDuration, error) {
+	args := []any{}
+	where := ""
+
+	if testID != "" {
+		args = append(args, testID)
+		where = ` AND test_id = $1`
+	}
+
+	windowWhere, args := window.where(args)
+
+	var durations []*AnalyticsDuration
+
+	err := db.reader.Select(&durations, `
+		SELECT test_id AS key, duration
+		FROM analytics_test_runs
+		WHERE status <> 'skipped'`+where+windowWhere+`
+		ORDER BY test_id ASC, duration ASC`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+// GetAnalyticsTaskDurations returns the durations of all non-skipped runs per task ID of a test within the window,
+// ordered by task ID and duration.
+func (db *Database) GetAnalyticsTaskDurations(testID string, window *AnalyticsWindow) ([]*AnalyticsDuration, error) {
+	windowWhere, args := window.where([]any{testID})
+
+	var durations []*AnalyticsDuration
+
+	err := db.reader.Select(&durations, `
+		SELECT task_ref AS key, duration
+		FROM analytics_task_runs
+		WHERE test_id = $1 AND status <> 'skipped'`+windowWhere+`
+		ORDER BY task_ref ASC, duration ASC`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return durations, nil
+}
+
+// GetAnalyticsFirstFailures returns the number of failed runs of a test per first failing task within the window.
+func (db *Database) GetAnalyticsFirstFailures(testID string, window *AnalyticsWindow) ([]*AnalyticsFailureCount, error) {
+	windowWhere, args := window.where([]any{testID})
+
+	var counts []*AnalyticsFailureCount
+
+	err := db.reader.Select(&counts, `
+		SELECT first_failure AS task_ref, COUNT(*) AS count
+		FROM analytics_test_runs
+		WHERE test_id = $1 AND status = 'failure'`+windowWhere+`
+		GROUP BY first_failure
+		ORDER BY count DESC, first_failure ASC`,
+		args...)
+	if err != nil {
+		return nil, err
+	}
+
+	return counts, nil
+}
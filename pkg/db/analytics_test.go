@@ -0,0 +1,217 @@
+package db
+
+import (
+	"io"
+	"path/filepath"
+	"testing"
+
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestDatabase(t *testing.T) *Database {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	database := NewDatabase(logger)
+
+	err := database.InitDB(&DatabaseConfig{
+		Engine: "sqlite",
+		Sqlite: &SqliteDatabaseConfig{
+			File: filepath.Join(t.TempDir(), "assertoor.db"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed initializing database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		//nolint:errcheck // ignore
+		database.CloseDB()
+	})
+
+	if err := database.ApplySchema(-2); err != nil {
+		t.Fatalf("failed applying database schema: %v", err)
+	}
+
+	return database
+}
+
+type testAnalyticsRun struct {
+	runID  uint64
+	status string
+}
+
+func insertTestAnalyticsRun(t *testing.T, database *Database, runID uint64, status string) {
+	t.Helper()
+
+	err := database.RunTransaction(func(tx *sqlx.Tx) error {
+		return database.InsertAnalyticsTestRun(tx, &AnalyticsTestRun{
+			RunID:     runID,
+			TestID:    "test1",
+			StartTime: int64(runID) * 1000,
+			Status:    status,
+		}, []*AnalyticsTaskRun{
+			{RunID: runID, TaskRef: "check", TestID: "test1", StartTime: int64(runID) * 1000, Status: status},
+		})
+	})
+	if err != nil {
+		t.Fatalf("failed inserting run %v: %v", runID, err)
+	}
+}
+
+func getTestAnalyticsFlips(t *testing.T, database *Database, table string) map[uint64]int {
+	t.Helper()
+
+	var rows []struct {
+		RunID   uint64 `db:"run_id"`
+		Flipped int    `db:"flipped"`
+	}
+
+	if err := database.reader.Select(&rows, `SELECT run_id, flipped FROM `+table+` ORDER BY run_id`); err != nil {
+		t.Fatalf("failed loading %v: %v", table, err)
+	}
+
+	flips := map[uint64]int{}
+	for _, row := range rows {
+		flips[row.RunID] = row.Flipped
+	}
+
+	return flips
+}
+
+func TestAnalyticsFlips(t *testing.T) {
+	tests := []struct {
+		name    string
+		runs    []testAnalyticsRun
+		deleted []uint64
+		want    map[uint64]int
+	}{
+		{
+			name: "runs in order",
+			runs: []testAnalyticsRun{{1, "success"}, {2, "failure"}, {3, "failure"}, {4, "success"}},
+			want: map[uint64]int{1: 0, 2: 1, 3: 0, 4: 1},
+		},
+		{
+			name: "runs out of order",
+			runs: []testAnalyticsRun{{1, "success"}, {3, "success"}, {2, "failure"}},
+			want: map[uint64]int{1: 0, 2: 1, 3: 1},
+		},
+		{
+			name: "aborted and skipped runs are ignored",
+			runs: []testAnalyticsRun{{1, "success"}, {2, "aborted"}, {3, "skipped"}, {4, "failure"}},
+			want: map[uint64]int{1: 0, 2: 0, 3: 0, 4: 1},
+		},
+		{
+			name: "reindexed run",
+			runs: []testAnalyticsRun{{1, "success"}, {2, "failure"}, {3, "success"}, {2, "success"}},
+			want: map[uint64]int{1: 0, 2: 0, 3: 0},
+		},
+		{
+			name:    "deleted run between flips",
+			runs:    []testAnalyticsRun{{1, "success"}, {2, "failure"}, {3, "success"}},
+			deleted: []uint64{2},
+			want:    map[uint64]int{1: 0, 3: 0},
+		},
+		{
+			name:    "deleted first run",
+			runs:    []testAnalyticsRun{{1, "failure"}, {2, "success"}, {3, "success"}},
+			deleted: []uint64{1},
+			want:    map[uint64]int{2: 0, 3: 0},
+		},
+		{
+			name:    "deleted run before aborted run",
+			runs:    []testAnalyticsRun{{1, "success"}, {2, "failure"}, {3, "aborted"}, {4, "success"}},
+			deleted: []uint64{2},
+			want:    map[uint64]int{1: 0, 3: 0, 4: 0},
+		},
+		{
+			name:    "deleted run creates flip",
+			runs:    []testAnalyticsRun{{1, "success"}, {2, "failure"}, {3, "failure"}},
+			deleted: []uint64{2},
+			want:    map[uint64]int{1: 0, 3: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			database := newTestDatabase(t)
+
+			for _, run := range tt.runs {
+				insertTestAnalyticsRun(t, database, run.runID, run.status)
+			}
+
+			for _, runID := range tt.deleted {
+				err := database.RunTransaction(func(tx *sqlx.Tx) error {
+					return database.DeleteAnalyticsTestRun(tx, runID)
+				})
+				if err != nil {
+					t.Fatalf("failed deleting run %v: %v", runID, err)
+				}
+			}
+
+			for _, table := range []string{"analytics_test_runs", "analytics_task_runs"} {
+				got := getTestAnalyticsFlips(t, database, table)
+
+				if len(got) != len(tt.want) {
+					t.Errorf("%v: got runs %v, want %v", table, got, tt.want)
+					continue
+				}
+
+				for runID, want := range tt.want {
+					if got[runID] != want {
+						t.Errorf("%v: run %v flipped = %v, want %v", table, runID, got[runID], want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestAnalyticsWindowFlips(t *testing.T) {
+	tests := []struct {
+		name   string
+		window *AnalyticsWindow
+		want   uint64
+	}{
+		{name: "no window", window: nil, want: 3},
+		{name: "open window", window: &AnalyticsWindow{}, want: 3},
+		{name: "flip against run before window", window: &AnalyticsWindow{Since: 2500}, want: 1},
+		{name: "window until", window: &AnalyticsWindow{Until: 3500}, want: 2},
+		{name: "closed window", window: &AnalyticsWindow{Since: 1500, Until: 3500}, want: 1},
+	}
+
+	database := newTestDatabase(t)
+
+	for runID, status := range []string{"success", "failure", "success", "failure"} {
+		insertTestAnalyticsRun(t, database, uint64(runID+1), status)
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			testStats, err := database.GetAnalyticsTestStats("test1", tt.window)
+			if err != nil {
+				t.Fatalf("failed loading test stats: %v", err)
+			}
+
+			taskStats, err := database.GetAnalyticsTaskStats("test1", tt.window)
+			if err != nil {
+				t.Fatalf("failed loading task stats: %v", err)
+			}
+
+			if len(testStats) != 1 || len(taskStats) != 1 {
+				t.Fatalf("expected stats of 1 test and task, got %v and %v", len(testStats), len(taskStats))
+			}
+
+			if testStats[0].Flips != tt.want {
+				t.Errorf("test flips = %v, want %v", testStats[0].Flips, tt.want)
+			}
+
+			if taskStats[0].Flips != tt.want {
+				t.Errorf("task flips = %v, want %v", taskStats[0].Flips, tt.want)
+			}
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package db
 
 import (
+	"context"
 	"embed"
 	"fmt"
 	"sync"
@@ -189,12 +190,17 @@ func (db *Database) CloseDB() error {
 }
 
 func (db *Database) RunTransaction(handler func(tx *sqlx.Tx) error) error {
+	return db.RunTransactionContext(context.Background(), handler)
+}
+
+// RunTransactionContext runs the handler within a transaction that is bound to the given context.
+func (db *Database) RunTransactionContext(ctx context.Context, handler func(tx *sqlx.Tx) error) error {
 	if db.engine == EngineSqlite {
 		db.writerMutex.Lock()
 		defer db.writerMutex.Unlock()
 	}
 
-	tx, err := db.writer.Beginx()
+	tx, err := db.writer.BeginTxx(ctx, nil)
 	if err != nil {
 		return fmt.Errorf("error starting db transactions: %v", err)
 	}
@@ -0,0 +1,160 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+type EventLogEntry struct {
+	EventID   uint64 `db:"event_id"`
+	EventType string `db:"event_type"`
+	EventTime int64  `db:"event_time"`
+	RunID     uint64 `db:"run_id"`
+	TaskIndex uint64 `db:"task_index"`
+	Data      string `db:"data"`
+}
+
+// EventLogFilter restricts the events returned by GetEventLog.
+type EventLogFilter struct {
+	RunID        uint64   // only events of this test run (0 for all)
+	Types        []string // only events with one of these types
+	ExcludeTypes []string // no events with one of these types
+	AfterID      uint64   // only events with a higher event ID
+	Since        int64    // only events at or after this time (unix milliseconds)
+}
+
+// InsertEventLogEntries inserts a batch of events into the event log.
+func (db *Database) InsertEventLogEntries(tx *sqlx.Tx, entries []*EventLogEntry) error {
+	for _, entry := range entries {
+		_, err := tx.Exec(db.EngineQuery(map[EngineType]string{
+			EnginePgsql: `
+				INSERT INTO event_log (
+					event_id, event_type, event_time, run_id, task_index, data
+				) VALUES ($1, $2, $3, $4, $5, $6)
+				ON CONFLICT (event_id) DO NOTHING`,
+			EngineSqlite: `
+				INSERT OR IGNORE INTO event_log (
+					event_id, event_type, event_time, run_id, task_index, data
+				) VALUES ($1, $2, $3, $4, $5, $6)`,
+		}),
+			entry.EventID, entry.EventType, entry.EventTime, entry.RunID, entry.TaskIndex, entry.Data)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// GetEventLog returns a range of logged events matching the filter, ordered by event ID,
+// and the total number of matching events.
+func (db *Database) GetEventLog(filter *EventLogFilter, offset, limit uint64) ([]*EventLogEntry, uint64, error) {
+	var where strings.Builder
+
+	args := []any{}
+	whereGlue := "WHERE"
+
+	if filter.RunID > 0 {
+		fmt.Fprintf(&where, ` %v run_id = $%v`, whereGlue, len(args)+1)
+		args = append(args, filter.RunID)
+		whereGlue = "AND"
+	}
+
+	if filter.AfterID > 0 {
+		fmt.Fprintf(&where, ` %v event_id > $%v`, whereGlue, len(args)+1)
+		args = append(args, filter.AfterID)
+		whereGlue = "AND"
+	}
+
+	if filter.Since > 0 {
+		fmt.Fprintf(&where, ` %v event_time >= $%v`, whereGlue, len(args)+1)
+		args = append(args, filter.Since)
+		whereGlue = "AND"
+	}
+
+	if len(filter.Types) > 0 {
+		fmt.Fprintf(&where, ` %v event_type IN (`, whereGlue)
+
+		for i, eventType := range filter.Types {
+			if i > 0 {
+				fmt.Fprint(&where, `, `)
+			}
+
+			fmt.Fprintf(&where, `$%v`, len(args)+1)
+			args = append(args, eventType)
+		}
+
+		fmt.Fprint(&where, `)`)
+
+		whereGlue = "AND"
+	}
+
+	if len(filter.ExcludeTypes) > 0 {
+		fmt.Fprintf(&where, ` %v event_type NOT IN (`, whereGlue)
+
+		for i, eventType := range filter.ExcludeTypes {
+			if i > 0 {
+				fmt.Fprint(&where, `, `)
+			}
+
+			fmt.Fprintf(&where, `$%v`, len(args)+1)
+			args = append(args, eventType)
+		}
+
+		fmt.Fprint(&where, `)`)
+	}
+
+	var total uint64
+
+	err := db.reader.Get(&total, `SELECT COUNT(*) FROM event_log`+where.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var sql strings.Builder
+
+	fmt.Fprintf(&sql, `SELECT * FROM event_log%v ORDER BY event_id ASC`, where.String())
+
+	if limit > 0 {
+		fmt.Fprintf(&sql, ` LIMIT $%v`, len(args)+1)
+		args = append(args, limit)
+	}
+
+	if offset > 0 {
+		fmt.Fprintf(&sql, ` OFFSET $%v`, len(args)+1)
+		args = append(args, offset)
+	}
+
+	var entries []*EventLogEntry
+
+	err = db.reader.Select(&entries, sql.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// GetLastEventLogID returns the highest event ID in the event log.
+func (db *Database) GetLastEventLogID() (uint64, error) {
+	var eventID uint64
+
+	err := db.reader.Get(&eventID, `SELECT COALESCE(MAX(event_id), 0) FROM event_log`)
+	if err != nil {
+		return 0, err
+	}
+
+	return eventID, nil
+}
+
+// DeleteEventLogBefore deletes all events that were logged before the given time (unix milliseconds).
+func (db *Database) DeleteEventLogBefore(tx *sqlx.Tx, eventTime int64) (int64, error) {
+	res, err := tx.Exec(`DELETE FROM event_log WHERE event_time < $1`, eventTime)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,218 @@
+package db
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/jmoiron/sqlx"
+)
+
+// KVStoreEntry is a value in the persistent key/value store. Values are JSON encoded.
+type KVStoreEntry struct {
+	Namespace  string `db:"namespace"`
+	Key        string `db:"key"`
+	Value      string `db:"value"`
+	Version    uint64 `db:"version"`
+	RunID      uint64 `db:"run_id"`
+	CreateTime int64  `db:"create_time"`
+	UpdateTime int64  `db:"update_time"`
+	ExpireTime int64  `db:"expire_time"`
+}
+
+// KVStoreNamespace is a namespace of the key/value store with its number of entries.
+type KVStoreNamespace struct {
+	Namespace string `db:"namespace"`
+	Entries   uint64 `db:"entries"`
+}
+
+// kvStoreLiveCondition matches entries that are not expired at the time given by the numbered argument.
+const kvStoreLiveCondition = `(expire_time = 0 OR expire_time > $%v)`
+
+// GetKVStoreEntry returns a non-expired entry of the key/value store or nil if it does not exist.
+// The entry is read within the transaction if tx is not nil.
+func (db *Database) GetKVStoreEntry(tx *sqlx.Tx, namespace, key string, now int64) (*KVStoreEntry, error) {
+	entries := []*KVStoreEntry{}
+	sql := `SELECT * FROM kv_store WHERE namespace = $1 AND key = $2 AND ` + fmt.Sprintf(kvStoreLiveCondition, 3)
+
+	var err error
+	if tx != nil {
+		err = tx.Select(&entries, sql, namespace, key, now)
+	} else {
+		err = db.reader.Select(&entries, sql, namespace, key, now)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, nil
+	}
+
+	return entries[0], nil
+}
+
+// GetKVStoreEntries returns a range of non-expired entries of a namespace ordered by key,
+// and the total number of matching entries. Only keys starting with prefix are returned if it's set.
+func (db *Database) GetKVStoreEntries(namespace, prefix string, now int64, offset, limit uint64) ([]*KVStoreEntry, uint64, error) {
+	var where strings.Builder
+
+	args := []any{namespace, now}
+
+	fmt.Fprintf(&where, ` WHERE namespace = $1 AND `+kvStoreLiveCondition, 2)
+
+	if prefix != "" {
+		fmt.Fprintf(&where, ` AND substr(key, 1, $%v) = $%v`, len(args)+1, len(args)+2)
+		args = append(args, len(prefix), prefix)
+	}
+
+	var total uint64
+
+	err := db.reader.Get(&total, `SELECT COUNT(*) FROM kv_store`+where.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	var sql strings.Builder
+
+	fmt.Fprintf(&sql, `SELECT * FROM kv_store%v ORDER BY key ASC`, where.String())
+
+	if limit > 0 {
+		fmt.Fprintf(&sql, ` LIMIT $%v`, len(args)+1)
+		args = append(args, limit)
+	}
+
+	if offset > 0 {
+		fmt.Fprintf(&sql, ` OFFSET $%v`, len(args)+1)
+		args = append(args, offset)
+	}
+
+	entries := []*KVStoreEntry{}
+
+	err = db.reader.Select(&entries, sql.String(), args...)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	return entries, total, nil
+}
+
+// GetKVStoreNamespaces returns all namespaces with non-expired entries.
+func (db *Database) GetKVStoreNamespaces(now int64) ([]*KVStoreNamespace, error) {
+	namespaces := []*KVStoreNamespace{}
+
+	err := db.reader.Select(&namespaces, `
+		SELECT namespace, COUNT(*) AS entries
+		FROM kv_store
+		WHERE `+fmt.Sprintf(kvStoreLiveCondition, 1)+`
+		GROUP BY namespace
+		ORDER BY namespace ASC`, now)
+	if err != nil {
+		return nil, err
+	}
+
+	return namespaces, nil
+}
+
+// SetKVStoreEntry inserts or replaces an entry and increments its version.
+// Replacing an expired entry starts over with version 1.
+func (db *Database) SetKVStoreEntry(tx *sqlx.Tx, entry *KVStoreEntry) error {
+	_, err := tx.Exec(`
+		INSERT INTO kv_store (
+			namespace, key, value, version, run_id, create_time, update_time, expire_time
+		) VALUES ($1, $2, $3, 1, $4, $5, $6, $7)
+		ON CONFLICT (namespace, key) DO UPDATE SET
+			value = excluded.value,
+			version = CASE
+				WHEN kv_store.expire_time > 0 AND kv_store.expire_time <= excluded.update_time THEN 1
+				ELSE kv_store.version + 1
+			END,
+			run_id = excluded.run_id,
+			create_time = CASE
+				WHEN kv_store.expire_time > 0 AND kv_store.expire_time <= excluded.update_time THEN excluded.create_time
+				ELSE kv_store.create_time
+			END,
+			update_time = excluded.update_time,
+			expire_time = excluded.expire_time`,
+		entry.Namespace, entry.Key, entry.Value, entry.RunID, entry.CreateTime, entry.UpdateTime, entry.ExpireTime)
+
+	return err
+}
+
+// InsertKVStoreEntry inserts an entry if no non-expired entry with the same key exists.
+// It returns false if the key is already taken.
+func (db *Database) InsertKVStoreEntry(tx *sqlx.Tx, entry *KVStoreEntry) (bool, error) {
+	_, err := tx.Exec(
+		`DELETE FROM kv_store WHERE namespace = $1 AND key = $2 AND expire_time > 0 AND expire_time <= $3`,
+		entry.Namespace, entry.Key, entry.UpdateTime,
+	)
+	if err != nil {
+		return false, err
+	}
+
+	res, err := tx.Exec(`
+		INSERT INTO kv_store (
+			namespace, key, value, version, run_id, create_time, update_time, expire_time
+		) VALUES ($1, $2, $3, 1, $4, $5, $6, $7)
+		ON CONFLICT (namespace, key) DO NOTHING`,
+		entry.Namespace, entry.Key, entry.Value, entry.RunID, entry.CreateTime, entry.UpdateTime, entry.ExpireTime)
+	if err != nil {
+		return false, err
+	}
+
+	inserted, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return inserted > 0, nil
+}
+
+// UpdateKVStoreEntry replaces the value of a non-expired entry if its version still matches.
+// It returns false if the entry does not exist or has been changed in the meantime.
+func (db *Database) UpdateKVStoreEntry(tx *sqlx.Tx, entry *KVStoreEntry, version uint64) (bool, error) {
+	res, err := tx.Exec(`
+		UPDATE kv_store SET
+			value = $1,
+			version = version + 1,
+			run_id = $2,
+			update_time = $3,
+			expire_time = $4
+		WHERE namespace = $5 AND key = $6 AND version = $7 AND `+fmt.Sprintf(kvStoreLiveCondition, 8),
+		entry.Value, entry.RunID, entry.UpdateTime, entry.ExpireTime, entry.Namespace, entry.Key, version, entry.UpdateTime)
+	if err != nil {
+		return false, err
+	}
+
+	updated, err := res.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+
+	return updated > 0, nil
+}
+
+// DeleteKVStoreEntry deletes an entry and returns false if no non-expired entry existed.
+func (db *Database) DeleteKVStoreEntry(tx *sqlx.Tx, namespace, key string, now int64) (bool, error) {
+	entry, err := db.GetKVStoreEntry(tx, namespace, key, now)
+	if err != nil {
+		return false, err
+	}
+
+	_, err = tx.Exec(`DELETE FROM kv_store WHERE namespace = $1 AND key = $2`, namespace, key)
+	if err != nil {
+		return false, err
+	}
+
+	return entry != nil, nil
+}
+
+// DeleteExpiredKVStoreEntries deletes all entries that expired before the given time (unix milliseconds).
+func (db *Database) DeleteExpiredKVStoreEntries(tx *sqlx.Tx, now int64) (int64, error) {
+	res, err := tx.Exec(`DELETE FROM kv_store WHERE expire_time > 0 AND expire_time <= $1`, now)
+	if err != nil {
+		return 0, err
+	}
+
+	return res.RowsAffected()
+}
@@ -0,0 +1,62 @@
+package db
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type NotificationDelivery struct {
+	DeliveryID   string `db:"delivery_id"`
+	Notifier     string `db:"notifier"`
+	EventType    string `db:"event_type"`
+	RunID        uint64 `db:"run_id"`
+	TestID       string `db:"test_id"`
+	Status       string `db:"status"`
+	Attempts     int    `db:"attempts"`
+	ResponseCode int    `db:"response_code"`
+	Error        string `db:"error"`
+	CreateTime   int64  `db:"create_time"`
+	UpdateTime   int64  `db:"update_time"`
+}
+
+// UpsertNotificationDelivery inserts or updates a notification delivery.
+func (db *Database) UpsertNotificationDelivery(tx *sqlx.Tx, delivery *NotificationDelivery) error {
+	_, err := tx.Exec(db.EngineQuery(map[EngineType]string{
+		EnginePgsql: `
+			INSERT INTO notification_deliveries (
+				delivery_id, notifier, event_type, run_id, test_id, status, attempts, response_code, error, create_time, update_time
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+			ON CONFLICT (delivery_id) DO UPDATE SET
+				status = excluded.status,
+				attempts = excluded.attempts,
+				response_code = excluded.response_code,
+				error = excluded.error,
+				update_time = excluded.update_time`,
+		EngineSqlite: `
+			INSERT OR REPLACE INTO notification_deliveries (
+				delivery_id, notifier, event_type, run_id, test_id, status, attempts, response_code, error, create_time, update_time
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)`,
+	}),
+		delivery.DeliveryID, delivery.Notifier, delivery.EventType, delivery.RunID, delivery.TestID, delivery.Status,
+		delivery.Attempts, delivery.ResponseCode, delivery.Error, delivery.CreateTime, delivery.UpdateTime)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetNotificationDeliveriesByRunID returns all notification deliveries of a test run.
+func (db *Database) GetNotificationDeliveriesByRunID(runID uint64) ([]*NotificationDelivery, error) {
+	var deliveries []*NotificationDelivery
+
+	err := db.reader.Select(&deliveries, `
+		SELECT * FROM notification_deliveries
+		WHERE run_id = $1
+		ORDER BY create_time ASC`,
+		runID)
+	if err != nil {
+		return nil, err
+	}
+
+	return deliveries, nil
+}
@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."test_sweeps"
+(
+    "sweep_id" INTEGER NOT NULL,
+    "test_id" VARCHAR(256) NOT NULL,
+    "create_time" BIGINT NOT NULL,
+    "matrix" TEXT NOT NULL,
+    CONSTRAINT "test_sweeps_pkey" PRIMARY KEY ("sweep_id")
+);
+
+CREATE TABLE IF NOT EXISTS public."test_sweep_runs"
+(
+    "sweep_id" INTEGER NOT NULL,
+    "run_id" INTEGER NOT NULL,
+    "combination" TEXT NOT NULL,
+    CONSTRAINT "test_sweep_runs_pkey" PRIMARY KEY ("sweep_id", "run_id")
+);
+
+CREATE INDEX IF NOT EXISTS "test_sweep_runs_run_id_idx" ON public."test_sweep_runs" ("run_id");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
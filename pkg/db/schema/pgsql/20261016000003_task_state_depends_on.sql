@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "task_states" ADD COLUMN "depends_on" TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
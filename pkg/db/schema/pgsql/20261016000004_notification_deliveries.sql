@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."notification_deliveries"
+(
+    "delivery_id" VARCHAR(64) NOT NULL,
+    "notifier" VARCHAR(256) NOT NULL,
+    "event_type" VARCHAR(64) NOT NULL,
+    "run_id" INTEGER NOT NULL,
+    "test_id" VARCHAR(256) NOT NULL,
+    "status" VARCHAR(16) NOT NULL,
+    "attempts" INTEGER NOT NULL,
+    "response_code" INTEGER NOT NULL,
+    "error" TEXT NOT NULL,
+    "create_time" BIGINT NOT NULL,
+    "update_time" BIGINT NOT NULL,
+    CONSTRAINT "notification_deliveries_pkey" PRIMARY KEY ("delivery_id")
+);
+
+CREATE INDEX IF NOT EXISTS "notification_deliveries_run_id_idx" ON public."notification_deliveries" ("run_id");
+CREATE INDEX IF NOT EXISTS "notification_deliveries_create_time_idx" ON public."notification_deliveries" ("create_time");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS public."kv_store"
+(
+    "namespace" VARCHAR(128) NOT NULL,
+    "key" VARCHAR(256) NOT NULL,
+    "value" TEXT NOT NULL,
+    "version" BIGINT NOT NULL DEFAULT 1,
+    "run_id" INTEGER NOT NULL DEFAULT 0,
+    "create_time" BIGINT NOT NULL,
+    "update_time" BIGINT NOT NULL,
+    "expire_time" BIGINT NOT NULL DEFAULT 0,
+    CONSTRAINT "kv_store_pkey" PRIMARY KEY ("namespace", "key")
+);
+
+CREATE INDEX IF NOT EXISTS "kv_store_expire_time_idx" ON public."kv_store" ("expire_time");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+
+ALTER TABLE "task_states" ADD COLUMN "task_vars" TEXT NOT NULL DEFAULT '';
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
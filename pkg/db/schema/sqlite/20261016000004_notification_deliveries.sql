@@ -0,0 +1,27 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "notification_deliveries"
+(
+    "delivery_id" TEXT NOT NULL,
+    "notifier" TEXT NOT NULL,
+    "event_type" TEXT NOT NULL,
+    "run_id" INTEGER NOT NULL,
+    "test_id" TEXT NOT NULL,
+    "status" TEXT NOT NULL,
+    "attempts" INTEGER NOT NULL,
+    "response_code" INTEGER NOT NULL,
+    "error" TEXT NOT NULL,
+    "create_time" INTEGER NOT NULL,
+    "update_time" INTEGER NOT NULL,
+    CONSTRAINT "notification_deliveries_pkey" PRIMARY KEY ("delivery_id")
+);
+
+CREATE INDEX IF NOT EXISTS "notification_deliveries_run_id_idx" ON "notification_deliveries" ("run_id");
+CREATE INDEX IF NOT EXISTS "notification_deliveries_create_time_idx" ON "notification_deliveries" ("create_time");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,22 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "event_log"
+(
+    "event_id" INTEGER NOT NULL,
+    "event_type" TEXT NOT NULL,
+    "event_time" INTEGER NOT NULL,
+    "run_id" INTEGER NOT NULL,
+    "task_index" INTEGER NOT NULL,
+    "data" TEXT NOT NULL,
+    CONSTRAINT "event_log_pkey" PRIMARY KEY ("event_id")
+);
+
+CREATE INDEX IF NOT EXISTS "event_log_run_id_idx" ON "event_log" ("run_id", "event_id");
+CREATE INDEX IF NOT EXISTS "event_log_event_time_idx" ON "event_log" ("event_time");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,37 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "analytics_test_runs"
+(
+    "run_id" INTEGER NOT NULL,
+    "test_id" TEXT NOT NULL,
+    "start_time" INTEGER NOT NULL,
+    "duration" INTEGER NOT NULL,
+    "status" TEXT NOT NULL,
+    "flipped" INTEGER NOT NULL DEFAULT 0,
+    "first_failure" TEXT NOT NULL DEFAULT '',
+    CONSTRAINT "analytics_test_runs_pkey" PRIMARY KEY ("run_id")
+);
+
+CREATE INDEX IF NOT EXISTS "analytics_test_runs_test_id_idx" ON "analytics_test_runs" ("test_id", "start_time");
+CREATE INDEX IF NOT EXISTS "analytics_test_runs_start_time_idx" ON "analytics_test_runs" ("start_time");
+
+CREATE TABLE IF NOT EXISTS "analytics_task_runs"
+(
+    "run_id" INTEGER NOT NULL,
+    "task_ref" TEXT NOT NULL,
+    "test_id" TEXT NOT NULL,
+    "start_time" INTEGER NOT NULL,
+    "duration" INTEGER NOT NULL,
+    "status" TEXT NOT NULL,
+    "flipped" INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT "analytics_task_runs_pkey" PRIMARY KEY ("run_id", "task_ref")
+);
+
+CREATE INDEX IF NOT EXISTS "analytics_task_runs_test_id_idx" ON "analytics_task_runs" ("test_id", "task_ref", "start_time");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+
+CREATE TABLE IF NOT EXISTS "kv_store"
+(
+    "namespace" TEXT NOT NULL,
+    "key" TEXT NOT NULL,
+    "value" TEXT NOT NULL,
+    "version" INTEGER NOT NULL DEFAULT 1,
+    "run_id" INTEGER NOT NULL DEFAULT 0,
+    "create_time" INTEGER NOT NULL,
+    "update_time" INTEGER NOT NULL,
+    "expire_time" INTEGER NOT NULL DEFAULT 0,
+    CONSTRAINT "kv_store_pkey" PRIMARY KEY ("namespace", "key")
+);
+
+CREATE INDEX IF NOT EXISTS "kv_store_expire_time_idx" ON "kv_store" ("expire_time");
+
+-- +goose StatementEnd
+-- +goose Down
+-- +goose StatementBegin
+SELECT 'NOT SUPPORTED';
+-- +goose StatementEnd
@@ -65,3 +65,19 @@ func (db *Database) GetLastLogIndex(runID, taskID uint64) (uint64, error) {
 
 	return logIdx, nil
 }
+
+// GetTaskLogsByRunID returns the logs of all tasks of a test run, ordered by task ID and log index.
+func (db *Database) GetTaskLogsByRunID(runID uint64) ([]*TaskLog, error) {
+	var logs []*TaskLog
+
+	err := db.reader.Select(&logs, `
+		SELECT * FROM task_logs
+		WHERE run_id = $1
+		ORDER BY task_id ASC, log_idx ASC`,
+		runID)
+	if err != nil {
+		return nil, err
+	}
+
+	return logs, nil
+}
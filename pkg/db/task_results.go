@@ -96,21 +96,27 @@ func (db *Database) GetAllTaskResultHeaders(runID uint64) ([]TaskResultHeader, e
 const (
 	testResultTaskID = 0
 	testResultType   = "test_result"
+	testYamlType     = "test_yaml"
 )
 
 // UpsertTestResult stores the run-level markdown blob (set by tasks that
 // write to $ASSERTOOR_TEST_RESULT). One blob per test run.
 func (db *Database) UpsertTestResult(runID uint64, data []byte) error {
 	return db.RunTransaction(func(tx *sqlx.Tx) error {
-		return db.UpsertTaskResult(tx, &TaskResult{
-			RunID:  runID,
-			TaskID: testResultTaskID,
-			Type:   testResultType,
-			Index:  0,
-			Name:   "result.md",
-			Size:   uint64(len(data)),
-			Data:   data,
-		})
+		return db.UpsertTestResultTx(tx, runID, data)
+	})
+}
+
+// UpsertTestResultTx stores the run-level markdown blob within a transaction.
+func (db *Database) UpsertTestResultTx(tx *sqlx.Tx, runID uint64, data []byte) error {
+	return db.UpsertTaskResult(tx, &TaskResult{
+		RunID:  runID,
+		TaskID: testResultTaskID,
+		Type:   testResultType,
+		Index:  0,
+		Name:   "result.md",
+		Size:   uint64(len(data)),
+		Data:   data,
 	})
 }
 
@@ -126,3 +132,28 @@ func (db *Database) GetTestResult(runID uint64) (*TaskResult, error) {
 
 	return result, nil
 }
+
+// UpsertTestYaml stores the test YAML of an imported test run, which might not be
+// registered on this instance.
+func (db *Database) UpsertTestYaml(tx *sqlx.Tx, runID uint64, data []byte) error {
+	return db.UpsertTaskResult(tx, &TaskResult{
+		RunID:  runID,
+		TaskID: testResultTaskID,
+		Type:   testYamlType,
+		Index:  0,
+		Name:   "test.yaml",
+		Size:   uint64(len(data)),
+		Data:   data,
+	})
+}
+
+// GetTestYaml returns the stored test YAML of an imported test run, or nil + nil error
+// when none is set.
+func (db *Database) GetTestYaml(runID uint64) (*TaskResult, error) {
+	result, err := db.GetTaskResultByIndex(runID, testResultTaskID, testYamlType, 0)
+	if err != nil {
+		return nil, nil //nolint:nilerr // sql.ErrNoRows-style miss
+	}
+
+	return result, nil
+}
@@ -16,6 +16,7 @@ type TaskState struct {
 	RefID      string `db:"ref_id"`
 	Timeout    int64  `db:"timeout"`
 	IfCond     string `db:"ifcond"`
+	DependsOn  string `db:"depends_on"`
 	RunFlags   uint32 `db:"run_flags"`
 	StartTime  int64  `db:"start_time"`
 	StopTime   int64  `db:"stop_time"`
@@ -24,6 +25,7 @@ type TaskState struct {
 	TaskStatus string `db:"task_status"`
 	TaskResult int    `db:"task_result"`
 	TaskError  string `db:"task_error"`
+	TaskVars   string `db:"task_vars"`
 }
 
 type TaskStateIndex struct {
@@ -45,9 +47,9 @@ func (db *Database) InsertTaskState(tx *sqlx.Tx, state *TaskState) error {
 	_, err := tx.Exec(db.EngineQuery(map[EngineType]string{
 		EnginePgsql: `
 			INSERT INTO task_states (
-				run_id, task_id, parent_task, name, title, ref_id, timeout, ifcond, run_flags, 
-				start_time, stop_time, scope_owner, task_config, task_status, task_result, task_error
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)
+				run_id, task_id, parent_task, name, title, ref_id, timeout, ifcond, depends_on, run_flags, 
+				start_time, stop_time, scope_owner, task_config, task_status, task_result, task_error, task_vars
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)
 			ON CONFLICT (run_id, task_id) DO UPDATE SET
 				parent_task = excluded.parent_task,
 				name = excluded.name,
@@ -55,6 +57,7 @@ func (db *Database) InsertTaskState(tx *sqlx.Tx, state *TaskState) error {
 				ref_id = excluded.ref_id,
 				timeout = excluded.timeout,
 				ifcond = excluded.ifcond,
+				depends_on = excluded.depends_on,
 				run_flags = excluded.run_flags,
 				start_time = excluded.start_time,
 				stop_time = excluded.stop_time,
@@ -62,16 +65,17 @@ func (db *Database) InsertTaskState(tx *sqlx.Tx, state *TaskState) error {
 				task_config = excluded.task_config,
 				task_status = excluded.task_status,
 				task_result = excluded.task_result,
-				task_error = excluded.task_error`,
+				task_error = excluded.task_error,
+				task_vars = excluded.task_vars`,
 		EngineSqlite: `
 			INSERT OR REPLACE INTO task_states (
-				run_id, task_id, parent_task, name, title, ref_id, timeout, ifcond, run_flags, 
-				start_time, stop_time, scope_owner, task_config, task_status, task_result, task_error
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16)`,
+				run_id, task_id, parent_task, name, title, ref_id, timeout, ifcond, depends_on, run_flags, 
+				start_time, stop_time, scope_owner, task_config, task_status, task_result, task_error, task_vars
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17, $18)`,
 	}),
 		state.RunID, state.TaskID, state.ParentTask, state.Name, state.Title, state.RefID, state.Timeout,
-		state.IfCond, state.RunFlags, state.StartTime, state.StopTime, state.ScopeOwner, state.TaskConfig,
-		state.TaskStatus, state.TaskResult, state.TaskError)
+		state.IfCond, state.DependsOn, state.RunFlags, state.StartTime, state.StopTime, state.ScopeOwner, state.TaskConfig,
+		state.TaskStatus, state.TaskResult, state.TaskError, state.TaskVars)
 	if err != nil {
 		return err
 	}
@@ -117,6 +121,9 @@ func (db *Database) UpdateTaskStateStatus(tx *sqlx.Tx, state *TaskState, updateF
 		case "task_error":
 			fmt.Fprintf(&sql, `task_error = $%v`, len(args)+1)
 			args = append(args, state.TaskError)
+		case "task_vars":
+			fmt.Fprintf(&sql, `task_vars = $%v`, len(args)+1)
+			args = append(args, state.TaskVars)
 		default:
 			return fmt.Errorf("unknown field %q", field)
 		}
@@ -164,3 +171,19 @@ func (db *Database) GetTaskStateByTaskID(runID, taskID uint64) (*TaskState, erro
 
 	return &state, nil
 }
+
+// GetTaskStatesByRunID returns all task states of a test run.
+func (db *Database) GetTaskStatesByRunID(runID uint64) ([]*TaskState, error) {
+	var states []*TaskState
+
+	err := db.reader.Select(&states, `
+		SELECT * FROM task_states
+		WHERE run_id = $1
+		ORDER BY task_id ASC`,
+		runID)
+	if err != nil {
+		return nil, err
+	}
+
+	return states, nil
+}
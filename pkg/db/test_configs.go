@@ -5,15 +5,16 @@ import (
 )
 
 type TestConfig struct {
-	TestID           string `db:"test_id"`
-	Source           string `db:"source"`
-	Name             string `db:"name"`
-	Timeout          int    `db:"timeout"`
-	Config           string `db:"config"`
-	ConfigVars       string `db:"config_vars"`
-	ScheduleStartup  bool   `db:"schedule_startup"`
-	ScheduleCronYaml string `db:"schedule_cron_yaml"`
-	YamlSource       string `db:"yaml_source"`
+	TestID            string `db:"test_id"`
+	Source            string `db:"source"`
+	Name              string `db:"name"`
+	Timeout           int    `db:"timeout"`
+	Config            string `db:"config"`
+	ConfigVars        string `db:"config_vars"`
+	ScheduleStartup   bool   `db:"schedule_startup"`
+	ScheduleCronYaml  string `db:"schedule_cron_yaml"`
+	ScheduleChainYaml string `db:"schedule_chain_yaml"`
+	YamlSource        string `db:"yaml_source"`
 }
 
 // InsertTestConfig inserts a test config into the database.
@@ -21,8 +22,8 @@ func (db *Database) InsertTestConfig(tx *sqlx.Tx, config *TestConfig) error {
 	_, err := tx.Exec(db.EngineQuery(map[EngineType]string{
 		EnginePgsql: `
 			INSERT INTO test_configs (
-				test_id, source, name, timeout, config, config_vars, schedule_startup, schedule_cron_yaml, yaml_source
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+				test_id, source, name, timeout, config, config_vars, schedule_startup, schedule_cron_yaml, schedule_chain_yaml, yaml_source
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 			ON CONFLICT (test_id) DO UPDATE SET
 				source = excluded.source,
 				name = excluded.name,
@@ -31,14 +32,15 @@ func (db *Database) InsertTestConfig(tx *sqlx.Tx, config *TestConfig) error {
 				config_vars = excluded.config_vars,
 				schedule_startup = excluded.schedule_startup,
 				schedule_cron_yaml = excluded.schedule_cron_yaml,
+				schedule_chain_yaml = excluded.schedule_chain_yaml,
 				yaml_source = excluded.yaml_source`,
 		EngineSqlite: `
 			INSERT OR REPLACE INTO test_configs (
-				test_id, source, name, timeout, config, config_vars, schedule_startup, schedule_cron_yaml, yaml_source
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+				test_id, source, name, timeout, config, config_vars, schedule_startup, schedule_cron_yaml, schedule_chain_yaml, yaml_source
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 	}),
 		config.TestID, config.Source, config.Name, config.Timeout, config.Config, config.ConfigVars,
-		config.ScheduleStartup, config.ScheduleCronYaml, config.YamlSource)
+		config.ScheduleStartup, config.ScheduleCronYaml, config.ScheduleChainYaml, config.YamlSource)
 	if err != nil {
 		return err
 	}
@@ -8,15 +8,16 @@ import (
 )
 
 type TestRun struct {
-	RunID     uint64 `db:"run_id"`
-	TestID    string `db:"test_id"`
-	Name      string `db:"name"`
-	Source    string `db:"source"`
-	Config    string `db:"config"`
-	StartTime int64  `db:"start_time"`
-	StopTime  int64  `db:"stop_time"`
-	Timeout   int32  `db:"timeout"`
-	Status    string `db:"status"`
+	RunID          uint64 `db:"run_id"`
+	TestID         string `db:"test_id"`
+	Name           string `db:"name"`
+	Source         string `db:"source"`
+	Config         string `db:"config"`
+	StartTime      int64  `db:"start_time"`
+	StopTime       int64  `db:"stop_time"`
+	Timeout        int32  `db:"timeout"`
+	Status         string `db:"status"`
+	ClientVersions string `db:"client_versions"`
 }
 
 // InsertTestRun inserts a test run into the database.
@@ -24,8 +25,8 @@ func (db *Database) InsertTestRun(tx *sqlx.Tx, run *TestRun) error {
 	_, err := tx.Exec(db.EngineQuery(map[EngineType]string{
 		EnginePgsql: `
 			INSERT INTO test_runs (
-				run_id, test_id, name, source, config, start_time, stop_time, timeout, status
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)
+				run_id, test_id, name, source, config, start_time, stop_time, timeout, status, client_versions
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)
 			ON CONFLICT (run_id) DO UPDATE SET
 				test_id = excluded.test_id,
 				name = excluded.name,
@@ -33,13 +34,14 @@ func (db *Database) InsertTestRun(tx *sqlx.Tx, run *TestRun) error {
 				start_time = excluded.start_time,
 				stop_time = excluded.stop_time,
 				timeout = excluded.timeout,
-				status = excluded.status`,
+				status = excluded.status,
+				client_versions = excluded.client_versions`,
 		EngineSqlite: `
 			INSERT OR REPLACE INTO test_runs (
-				run_id, test_id, name, source, config, start_time, stop_time, timeout, status
-			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)`,
+				run_id, test_id, name, source, config, start_time, stop_time, timeout, status, client_versions
+			) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10)`,
 	}),
-		run.RunID, run.TestID, run.Name, run.Source, run.Config, run.StartTime, run.StopTime, run.Timeout, run.Status)
+		run.RunID, run.TestID, run.Name, run.Source, run.Config, run.StartTime, run.StopTime, run.Timeout, run.Status, run.ClientVersions)
 	if err != nil {
 		return err
 	}
@@ -61,6 +63,34 @@ func (db *Database) UpdateTestRunStatus(tx *sqlx.Tx, run *TestRun) error {
 	return nil
 }
 
+// UpdateTestRunClientVersions updates the endpoint versions snapshot of a test run.
+func (db *Database) UpdateTestRunClientVersions(tx *sqlx.Tx, runID uint64, clientVersions string) error {
+	_, err := tx.Exec(`
+			UPDATE test_runs
+			SET client_versions = $1
+			WHERE run_id = $2`,
+		clientVersions, runID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// UpdateTestRunConfig updates the persisted variables of a test run.
+func (db *Database) UpdateTestRunConfig(tx *sqlx.Tx, runID uint64, config string) error {
+	_, err := tx.Exec(`
+			UPDATE test_runs
+			SET config = $1
+			WHERE run_id = $2`,
+		config, runID)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
 // GetTestRunByRunID returns a test run by run ID.
 func (db *Database) GetTestRunByRunID(runID uint64) (*TestRun, error) {
 	var run TestRun
@@ -140,7 +170,7 @@ func (db *Database) GetTestRunRange(testID string, firstRunID, offset, limit uin
 	return runs[1:], runs[0].RunID, nil
 }
 
-// DeleteTestRun deletes a test run and all associated task states and logs.
+// DeleteTestRun deletes a test run and all associated task states, logs and analytics records.
 func (db *Database) DeleteTestRun(tx *sqlx.Tx, runID uint64) error {
 	_, err := tx.Exec(`
 		DELETE FROM test_runs
@@ -166,17 +196,67 @@ func (db *Database) DeleteTestRun(tx *sqlx.Tx, runID uint64) error {
 		return err
 	}
 
-	return nil
+	_, err = tx.Exec(`
+		DELETE FROM test_sweep_runs
+		WHERE run_id = $1`,
+		runID)
+	if err != nil {
+		return err
+	}
+
+	_, err = tx.Exec(`
+		DELETE FROM event_log
+		WHERE run_id = $1`,
+		runID)
+	if err != nil {
+		return err
+	}
+
+	return db.DeleteAnalyticsTestRun(tx, runID)
+}
+
+// GetUncleanTestRuns returns all test runs that are still marked as running.
+func (db *Database) GetUncleanTestRuns() ([]*TestRun, error) {
+	var runs []*TestRun
+
+	err := db.reader.Select(&runs, `
+		SELECT * FROM test_runs
+		WHERE status = 'running'
+		ORDER BY run_id ASC`)
+	if err != nil {
+		return nil, err
+	}
+
+	return runs, nil
 }
 
-// CleanupUncleanTestRuns updates all running test runs and its tasks to aborted
-func (db *Database) CleanupUncleanTestRuns(tx *sqlx.Tx) error {
+// CleanupUncleanTestRuns updates all running test runs and its tasks to aborted.
+// Test runs listed in skipRunIDs (resumed runs) are left untouched.
+func (db *Database) CleanupUncleanTestRuns(tx *sqlx.Tx, skipRunIDs ...uint64) error {
+	var skipFilter strings.Builder
+
+	args := []any{}
+
+	if len(skipRunIDs) > 0 {
+		fmt.Fprint(&skipFilter, ` AND run_id NOT IN (`)
+
+		for i, runID := range skipRunIDs {
+			if i > 0 {
+				fmt.Fprint(&skipFilter, `, `)
+			}
+
+			fmt.Fprintf(&skipFilter, `$%v`, len(args)+1)
+			args = append(args, runID)
+		}
+
+		fmt.Fprint(&skipFilter, `)`)
+	}
+
 	// Update running test runs to aborted
 	_, err := tx.Exec(`
 		UPDATE test_runs
 		SET status = 'aborted'
-		WHERE status = 'running'
-	`)
+		WHERE status = 'running'`+skipFilter.String(), args...)
 	if err != nil {
 		return err
 	}
@@ -185,8 +265,7 @@ func (db *Database) CleanupUncleanTestRuns(tx *sqlx.Tx) error {
 	_, err = tx.Exec(fmt.Sprintf(`
 		UPDATE task_states
 		SET run_flags = run_flags & ~%v
-		WHERE run_flags & %v > 0
-	`, TaskRunFlagRunning, TaskRunFlagRunning))
+		WHERE run_flags & %v > 0`, TaskRunFlagRunning, TaskRunFlagRunning)+skipFilter.String(), args...)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,72 @@
+package db
+
+import (
+	"github.com/jmoiron/sqlx"
+)
+
+type TestSweep struct {
+	SweepID    uint64 `db:"sweep_id"`
+	TestID     string `db:"test_id"`
+	CreateTime int64  `db:"create_time"`
+	Matrix     string `db:"matrix"`
+}
+
+type TestSweepRun struct {
+	SweepID     uint64 `db:"sweep_id"`
+	RunID       uint64 `db:"run_id"`
+	Combination string `db:"combination"`
+}
+
+// InsertTestSweep inserts a test sweep into the database.
+func (db *Database) InsertTestSweep(tx *sqlx.Tx, sweep *TestSweep) error {
+	_, err := tx.Exec(`
+		INSERT INTO test_sweeps (sweep_id, test_id, create_time, matrix)
+		VALUES ($1, $2, $3, $4)`,
+		sweep.SweepID, sweep.TestID, sweep.CreateTime, sweep.Matrix)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// InsertTestSweepRun links a test run to a test sweep.
+func (db *Database) InsertTestSweepRun(tx *sqlx.Tx, sweepRun *TestSweepRun) error {
+	_, err := tx.Exec(`
+		INSERT INTO test_sweep_runs (sweep_id, run_id, combination)
+		VALUES ($1, $2, $3)`,
+		sweepRun.SweepID, sweepRun.RunID, sweepRun.Combination)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// GetTestSweep returns a test sweep by sweep ID.
+func (db *Database) GetTestSweep(sweepID uint64) (*TestSweep, error) {
+	var sweep TestSweep
+
+	err := db.reader.Get(&sweep, `SELECT * FROM test_sweeps WHERE sweep_id = $1`, sweepID)
+	if err != nil {
+		return nil, err
+	}
+
+	return &sweep, nil
+}
+
+// GetTestSweepRuns returns all test runs of a test sweep.
+func (db *Database) GetTestSweepRuns(sweepID uint64) ([]*TestSweepRun, error) {
+	var sweepRuns []*TestSweepRun
+
+	err := db.reader.Select(&sweepRuns, `
+		SELECT * FROM test_sweep_runs
+		WHERE sweep_id = $1
+		ORDER BY run_id ASC`,
+		sweepID)
+	if err != nil {
+		return nil, err
+	}
+
+	return sweepRuns, nil
+}
@@ -1,10 +1,15 @@
 package events
 
 import (
+	"bytes"
 	"context"
+	"encoding/json"
+	"fmt"
 	"sync"
 	"sync/atomic"
 
+	"github.com/ethpandaops/assertoor/pkg/metrics"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/sirupsen/logrus"
 )
 
@@ -17,6 +22,7 @@ type Subscriber struct {
 	filter  FilterFunc
 	channel chan *Event
 	closed  atomic.Bool
+	dropped atomic.Bool // set when an event got dropped because the channel was full
 }
 
 // Channel returns the channel for receiving events.
@@ -46,6 +52,7 @@ type EventBus struct {
 	nextEventID   atomic.Uint64
 	bufferSize    int
 	subscriberBuf int
+	eventLog      *EventLog
 }
 
 // NewEventBus creates a new event bus.
@@ -59,10 +66,39 @@ func NewEventBus(logger logrus.FieldLogger) *EventBus {
 	}
 }
 
+// SetEventLog enables the persistence of published events.
+// It needs to be called before the event bus is started.
+func (eb *EventBus) SetEventLog(eventLog *EventLog) {
+	eb.eventLog = eventLog
+}
+
+// EventLog returns the event log, or nil if events are not persisted.
+func (eb *EventBus) EventLog() *EventLog {
+	return eb.eventLog
+}
+
 // Start starts the event bus processing loop.
 func (eb *EventBus) Start(ctx context.Context) error {
 	eb.ctx, eb.cancel = context.WithCancel(ctx)
 
+	if eb.eventLog != nil {
+		// continue with the event IDs of the last run, so clients can resume with their last seen event ID
+		lastEventID, err := eb.eventLog.getLastEventID()
+		if err != nil {
+			return fmt.Errorf("failed loading last event id: %w", err)
+		}
+
+		eb.nextEventID.Store(lastEventID)
+
+		eb.wg.Add(1)
+
+		go func() {
+			defer eb.wg.Done()
+
+			eb.eventLog.runCleanup(eb.ctx)
+		}()
+	}
+
 	eb.wg.Add(1)
 
 	go eb.processEvents()
@@ -89,6 +125,7 @@ func (eb *EventBus) Stop() error {
 		}
 	}
 
+	metrics.AddEventBusSubscribers(-len(eb.subscribers))
 	eb.subscribers = make(map[uint64]*Subscriber, 16)
 	eb.subscribersMu.Unlock()
 
@@ -98,21 +135,49 @@ func (eb *EventBus) Stop() error {
 }
 
 // Publish publishes an event to all subscribers.
+// Registered secrets are masked in the event data before it is logged or dispatched.
 func (eb *EventBus) Publish(event *Event) {
 	if event == nil {
 		return
 	}
 
+	event.Data = redactEventData(event.Data)
+
 	// Assign event ID
 	event.ID = eb.nextEventID.Add(1)
 
 	select {
 	case eb.eventChan <- event:
+		metrics.ObserveEventPublished(string(event.Type))
 	default:
 		eb.logger.Warn("event channel full, dropping event")
+		metrics.ObserveEventDropped("bus_full")
 	}
 }
 
+// redactEventData masks registered secrets in the strings of the event data (errors, progress & log messages).
+func redactEventData(data json.RawMessage) json.RawMessage {
+	if len(data) == 0 || !secrets.HasSecrets() {
+		return data
+	}
+
+	// keep large numbers intact while re-encoding the data
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return json.RawMessage(secrets.Redact(string(data)))
+	}
+
+	redacted, err := json.Marshal(secrets.RedactValue(value))
+	if err != nil {
+		return json.RawMessage(secrets.Redact(string(data)))
+	}
+
+	return redacted
+}
+
 // Subscribe creates a new subscription with an optional filter.
 func (eb *EventBus) Subscribe(filter FilterFunc) *Subscriber {
 	sub := &Subscriber{
@@ -125,6 +190,8 @@ func (eb *EventBus) Subscribe(filter FilterFunc) *Subscriber {
 	eb.subscribers[sub.id] = sub
 	eb.subscribersMu.Unlock()
 
+	metrics.AddEventBusSubscribers(1)
+
 	eb.logger.WithField("subscriber_id", sub.id).Debug("new subscriber added")
 
 	return sub
@@ -140,6 +207,7 @@ func (eb *EventBus) Unsubscribe(sub *Subscriber) {
 
 	if _, exists := eb.subscribers[sub.id]; exists {
 		delete(eb.subscribers, sub.id)
+		metrics.AddEventBusSubscribers(-1)
 
 		if sub.closed.CompareAndSwap(false, true) {
 			close(sub.channel)
@@ -160,7 +228,32 @@ func (eb *EventBus) processEvents() {
 		case <-eb.ctx.Done():
 			return
 		case event := <-eb.eventChan:
-			eb.dispatchEvent(event)
+			if eb.eventLog == nil {
+				eb.dispatchEvent(event)
+				continue
+			}
+
+			// persist queued events in batches before dispatching them,
+			// so subscribers never see an event that can't be replayed yet
+			batch := []*Event{event}
+
+		batchLoop:
+			for len(batch) < eventLogBatchSize {
+				select {
+				case nextEvent := <-eb.eventChan:
+					batch = append(batch, nextEvent)
+				default:
+					break batchLoop
+				}
+			}
+
+			if err := eb.eventLog.storeEvents(batch); err != nil {
+				eb.logger.Warnf("failed storing %v events in event log: %v", len(batch), err)
+			}
+
+			for _, batchEvent := range batch {
+				eb.dispatchEvent(batchEvent)
+			}
 		}
 	}
 }
@@ -184,6 +277,8 @@ func (eb *EventBus) dispatchEvent(event *Event) {
 				"subscriber_id": sub.id,
 				"event_type":    event.Type,
 			}).Debug("subscriber channel full, dropping event")
+			metrics.ObserveEventDropped("subscriber_full")
+			sub.dropped.Store(true)
 		}
 	}
 }
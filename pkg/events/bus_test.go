@@ -0,0 +1,58 @@
+package events
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+)
+
+func TestPublishRedactsSecrets(t *testing.T) {
+	secret := "0x5f3c9d2a7e1b8f4c6a0d3e9b2c7f1a8e4d6b0c3f9a2e7d1b5c8f0a4e6d2b9c71"
+	secrets.Register(secret)
+
+	eventBus := newTestEventBus(t)
+	if err := eventBus.Start(t.Context()); err != nil {
+		t.Fatalf("failed starting event bus: %v", err)
+	}
+
+	//nolint:errcheck // ignore
+	defer eventBus.Stop()
+
+	subscriber := eventBus.Subscribe(nil)
+
+	eventBus.PublishTaskFailed(1, 2, "generate_transaction", "send tx", "tx", "failed sending tx with key "+secret)
+	eventBus.PublishTaskProgress(1, 2, "generate_transaction", "send tx", "tx", 50, "signing with "+strings.TrimPrefix(secret, "0x"))
+
+	received := []*Event{}
+	for len(received) < 2 {
+		select {
+		case event := <-subscriber.Channel():
+			received = append(received, event)
+		case <-time.After(5 * time.Second):
+			t.Fatalf("received %v events, want 2", len(received))
+		}
+	}
+
+	logged, _, err := eventBus.EventLog().LoadEvents(&db.EventLogFilter{}, 0, 10)
+	if err != nil {
+		t.Fatalf("failed loading logged events: %v", err)
+	}
+
+	if len(logged) != 2 {
+		t.Fatalf("logged %v events, want 2", len(logged))
+	}
+
+	for _, event := range append(received, logged...) {
+		data := string(event.Data)
+		if strings.Contains(data, strings.TrimPrefix(secret, "0x")) {
+			t.Errorf("secret in data of %v event: %v", event.Type, data)
+		}
+
+		if !strings.Contains(data, secrets.Mask) {
+			t.Errorf("no masked secret in data of %v event: %v", event.Type, data)
+		}
+	}
+}
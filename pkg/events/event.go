@@ -13,6 +13,8 @@ const (
 	EventTestStarted   EventType = "test.started"
 	EventTestCompleted EventType = "test.completed"
 	EventTestFailed    EventType = "test.failed"
+	EventTestPaused    EventType = "test.paused"
+	EventTestResumed   EventType = "test.resumed"
 )
 
 // Event types for task lifecycle.
@@ -61,6 +63,12 @@ type TestFailedData struct {
 	Error    string `json:"error,omitempty"`
 }
 
+// TestPausedData contains data for test.paused & test.resumed events.
+type TestPausedData struct {
+	TestID   string `json:"testId"`
+	TestName string `json:"testName"`
+}
+
 // TaskStartedData contains data for task.started events.
 type TaskStartedData struct {
 	TaskName  string `json:"taskName"`
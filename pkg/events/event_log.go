@@ -0,0 +1,162 @@
+package events
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/jmoiron/sqlx"
+	"github.com/sirupsen/logrus"
+)
+
+// LogConfig controls the persisted event log used to replay missed events.
+type LogConfig struct {
+	// Enabled toggles the persistence of published events.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// RetentionTime is how long logged events are kept. Defaults to 7 days.
+	RetentionTime helper.Duration `yaml:"retentionTime" json:"retentionTime"`
+
+	// ExcludeTypes is the list of event types that are not logged.
+	// Defaults to the high frequency client head & status updates and the task logs, which are stored in the task log table anyway.
+	ExcludeTypes []string `yaml:"excludeTypes" json:"excludeTypes"`
+}
+
+// DefaultLogConfig returns an enabled event log config with the default retention.
+func DefaultLogConfig() *LogConfig {
+	return &LogConfig{
+		Enabled:       true,
+		RetentionTime: helper.Duration{Duration: defaultLogRetention},
+		ExcludeTypes: []string{
+			string(EventClientHeadUpdate),
+			string(EventClientStatusUpdate),
+			string(EventTaskLog),
+		},
+	}
+}
+
+const (
+	defaultLogRetention     = 7 * 24 * time.Hour
+	eventLogCleanupInterval = 10 * time.Minute
+	eventLogBatchSize       = 100
+)
+
+// EventLog persists published events in the database.
+type EventLog struct {
+	config   *LogConfig
+	database *db.Database
+	logger   logrus.FieldLogger
+}
+
+// NewEventLog creates a new event log backed by the database.
+func NewEventLog(config *LogConfig, database *db.Database, logger logrus.FieldLogger) *EventLog {
+	if config == nil {
+		config = DefaultLogConfig()
+	}
+
+	return &EventLog{
+		config:   config,
+		database: database,
+		logger:   logger.WithField("component", "eventlog"),
+	}
+}
+
+// shouldLog returns true if the event type is not excluded from the event log.
+func (l *EventLog) shouldLog(event *Event) bool {
+	return !slices.Contains(l.config.ExcludeTypes, string(event.Type))
+}
+
+// getLastEventID returns the highest logged event ID, so event IDs keep increasing across restarts.
+func (l *EventLog) getLastEventID() (uint64, error) {
+	return l.database.GetLastEventLogID()
+}
+
+// storeEvents writes a batch of events to the database.
+func (l *EventLog) storeEvents(events []*Event) error {
+	entries := make([]*db.EventLogEntry, 0, len(events))
+
+	for _, event := range events {
+		if !l.shouldLog(event) {
+			continue
+		}
+
+		entries = append(entries, &db.EventLogEntry{
+			EventID:   event.ID,
+			EventType: string(event.Type),
+			EventTime: event.Timestamp.UnixMilli(),
+			RunID:     event.TestRunID,
+			TaskIndex: event.TaskIndex,
+			Data:      string(event.Data),
+		})
+	}
+
+	if len(entries) == 0 {
+		return nil
+	}
+
+	return l.database.RunTransaction(func(tx *sqlx.Tx) error {
+		return l.database.InsertEventLogEntries(tx, entries)
+	})
+}
+
+// LoadEvents returns a range of logged events matching the filter and the total number of matching events.
+func (l *EventLog) LoadEvents(filter *db.EventLogFilter, offset, limit uint64) ([]*Event, uint64, error) {
+	entries, total, err := l.database.GetEventLog(filter, offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed loading events: %w", err)
+	}
+
+	events := make([]*Event, 0, len(entries))
+
+	for _, entry := range entries {
+		event := &Event{
+			ID:        entry.EventID,
+			Type:      EventType(entry.EventType),
+			Timestamp: time.UnixMilli(entry.EventTime),
+			TestRunID: entry.RunID,
+			TaskIndex: entry.TaskIndex,
+		}
+
+		if entry.Data != "" {
+			event.Data = []byte(entry.Data)
+		}
+
+		events = append(events, event)
+	}
+
+	return events, total, nil
+}
+
+// runCleanup deletes events older than the retention time until the context is cancelled.
+func (l *EventLog) runCleanup(ctx context.Context) {
+	retentionTime := l.config.RetentionTime.Duration
+	if retentionTime <= 0 {
+		retentionTime = defaultLogRetention
+	}
+
+	for {
+		deleted := int64(0)
+
+		err := l.database.RunTransaction(func(tx *sqlx.Tx) error {
+			var err error
+
+			deleted, err = l.database.DeleteEventLogBefore(tx, time.Now().Add(-retentionTime).UnixMilli())
+
+			return err
+		})
+		if err != nil {
+			l.logger.Warnf("failed cleaning up event log: %v", err)
+		} else if deleted > 0 {
+			l.logger.Debugf("deleted %v events from event log", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(eventLogCleanupInterval):
+		}
+	}
+}
@@ -1,6 +1,7 @@
 package events
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -8,10 +9,14 @@ import (
 	"strings"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/helper"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/sirupsen/logrus"
 )
 
+const replayBatchSize = 1000
+
 // AuthTokenChecker is a function that validates an authorization token
 // for a request bound to host. host is the request Host header stripped
 // of any port — it's matched against the token's "scope" claim.
@@ -47,17 +52,18 @@ func NewSSEHandlerWithAuth(logger logrus.FieldLogger, eventBus *EventBus, authCh
 
 // HandleGlobalStream handles the global event stream endpoint.
 func (h *SSEHandler) HandleGlobalStream(w http.ResponseWriter, r *http.Request) {
-	h.handleSSE(w, r, nil)
+	h.handleSSE(w, r, 0, nil)
 }
 
 // HandleTestRunStream handles the per-test event stream endpoint.
 func (h *SSEHandler) HandleTestRunStream(w http.ResponseWriter, r *http.Request, testRunID uint64) {
 	filter := CreateTestRunFilter(testRunID)
-	h.handleSSE(w, r, filter)
+	h.handleSSE(w, r, testRunID, filter)
 }
 
 // handleSSE is the common SSE handling logic.
-func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, filter FilterFunc) {
+// testRunID restricts the replay of logged events to a single test run (0 for all test runs).
+func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, testRunID uint64, filter FilterFunc) {
 	// Check if the client supports SSE
 	flusher, ok := w.(http.Flusher)
 	if !ok {
@@ -92,7 +98,19 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, filter Fi
 		}
 	}
 
-	// Subscribe to events
+	// Parse optional since parameter to replay events from a point in time
+	var since time.Time
+
+	if sinceStr := r.URL.Query().Get("since"); sinceStr != "" {
+		var err error
+
+		since, err = helper.ParseTime(sinceStr)
+		if err != nil {
+			h.logger.WithError(err).Warn("invalid since parameter")
+		}
+	}
+
+	// Subscribe to events before replaying, so no event gets lost in between
 	sub := h.eventBus.Subscribe(filter)
 	defer h.eventBus.Unsubscribe(sub)
 
@@ -107,6 +125,32 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, filter Fi
 		Timestamp: time.Now(),
 	})
 
+	// Replay missed events from the event log.
+	// Live events are persisted before they're dispatched, so events that got dropped from the subscription
+	// while replaying are picked up by replaying again until no more events got dropped.
+	if lastEventID > 0 || !since.IsZero() {
+		for {
+			sub.dropped.Store(false)
+
+			var err error
+
+			lastEventID, err = h.replayEvents(ctx, w, flusher, testRunID, filter, lastEventID, since, isAuthenticated)
+
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err != nil {
+				h.logger.WithError(err).Warn("failed loading events for replay")
+				break
+			}
+
+			if !sub.dropped.Load() {
+				break
+			}
+		}
+	}
+
 	// Keep-alive ticker
 	ticker := time.NewTicker(30 * time.Second)
 	defer ticker.Stop()
@@ -127,8 +171,7 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, filter Fi
 				continue
 			}
 
-			// Filter out log events for unauthenticated clients
-			if h.requireAuthLog && !isAuthenticated && event.Type == EventTaskLog {
+			if !h.isVisible(event, isAuthenticated) {
 				continue
 			}
 
@@ -141,6 +184,87 @@ func (h *SSEHandler) handleSSE(w http.ResponseWriter, r *http.Request, filter Fi
 	}
 }
 
+// replayEvents sends the logged events after lastEventID (or since the given time) to the client.
+// The event log is read in batches until all logged events have been sent.
+// It returns the ID of the last replayed event.
+func (h *SSEHandler) replayEvents(
+	ctx context.Context,
+	w http.ResponseWriter,
+	flusher http.Flusher,
+	testRunID uint64,
+	filter FilterFunc,
+	lastEventID uint64,
+	since time.Time,
+	isAuthenticated bool,
+) (uint64, error) {
+	eventLog := h.eventBus.EventLog()
+	if eventLog == nil {
+		return lastEventID, nil
+	}
+
+	logFilter := &db.EventLogFilter{
+		RunID:   testRunID,
+		AfterID: lastEventID,
+	}
+
+	if !since.IsZero() {
+		logFilter.Since = since.UnixMilli()
+	}
+
+	replayed := 0
+
+	for ctx.Err() == nil {
+		events, _, err := eventLog.LoadEvents(logFilter, 0, replayBatchSize)
+		if err != nil {
+			return logFilter.AfterID, err
+		}
+
+		for _, event := range events {
+			logFilter.AfterID = event.ID
+
+			if filter != nil && !filter(event) {
+				continue
+			}
+
+			if !h.isVisible(event, isAuthenticated) {
+				continue
+			}
+
+			h.sendEvent(w, flusher, event)
+
+			replayed++
+		}
+
+		if len(events) < replayBatchSize {
+			break
+		}
+	}
+
+	h.logger.WithField("replayed", replayed).Debug("replayed events from event log")
+
+	return logFilter.AfterID, nil
+}
+
+// isVisible filters out log events for unauthenticated clients.
+func (h *SSEHandler) isVisible(event *Event, isAuthenticated bool) bool {
+	return IsVisibleEventType(event.Type, h.requireAuthLog, isAuthenticated)
+}
+
+// IsVisibleEventType returns true if events of the given type may be sent to a client.
+// Task log events are hidden from unauthenticated clients if requireAuthLog is set.
+func IsVisibleEventType(eventType EventType, requireAuthLog, isAuthenticated bool) bool {
+	return !requireAuthLog || isAuthenticated || eventType != EventTaskLog
+}
+
+// GetHiddenEventTypes returns the event types that must not be sent to a client, for use in event log queries.
+func GetHiddenEventTypes(requireAuthLog, isAuthenticated bool) []string {
+	if IsVisibleEventType(EventTaskLog, requireAuthLog, isAuthenticated) {
+		return nil
+	}
+
+	return []string{string(EventTaskLog)}
+}
+
 // checkAuth checks if the request has a valid authentication token.
 func (h *SSEHandler) checkAuth(r *http.Request) bool {
 	if h.authChecker == nil {
@@ -282,6 +406,32 @@ func (eb *EventBus) PublishTestFailed(testRunID uint64, testID, testName, errMsg
 	eb.Publish(event)
 }
 
+// PublishTestPaused publishes a test paused event.
+func (eb *EventBus) PublishTestPaused(testRunID uint64, testID, testName string) {
+	event, err := NewEvent(EventTestPaused, testRunID, 0, &TestPausedData{
+		TestID:   testID,
+		TestName: testName,
+	})
+	if err != nil {
+		return
+	}
+
+	eb.Publish(event)
+}
+
+// PublishTestResumed publishes a test resumed event.
+func (eb *EventBus) PublishTestResumed(testRunID uint64, testID, testName string) {
+	event, err := NewEvent(EventTestResumed, testRunID, 0, &TestPausedData{
+		TestID:   testID,
+		TestName: testName,
+	})
+	if err != nil {
+		return
+	}
+
+	eb.Publish(event)
+}
+
 // PublishTaskStarted publishes a task started event.
 func (eb *EventBus) PublishTaskStarted(
 	testRunID, taskIndex uint64,
@@ -450,5 +600,5 @@ func (eb *EventBus) PublishClientStatusUpdate(
 func (h *SSEHandler) HandleClientStream(w http.ResponseWriter, r *http.Request) {
 	// Filter for client events only
 	filter := CreateEventTypeFilter(EventClientHeadUpdate, EventClientStatusUpdate)
-	h.handleSSE(w, r, filter)
+	h.handleSSE(w, r, 0, filter)
 }
@@ -0,0 +1,297 @@
+package events
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/sirupsen/logrus"
+)
+
+// testSSEWriter records the IDs of the events written to an SSE stream.
+// onWrite is called synchronously for every write of the handler.
+type testSSEWriter struct {
+	mutex    sync.Mutex
+	header   http.Header
+	eventIDs []uint64
+	onWrite  func(line string)
+}
+
+func (w *testSSEWriter) Header() http.Header {
+	return w.header
+}
+
+func (w *testSSEWriter) WriteHeader(int) {}
+
+func (w *testSSEWriter) Flush() {}
+
+func (w *testSSEWriter) Write(data []byte) (int, error) {
+	line := string(data)
+
+	if w.onWrite != nil {
+		defer w.onWrite(line)
+	}
+
+	if !strings.HasPrefix(line, "id: ") {
+		return len(data), nil
+	}
+
+	eventID, err := strconv.ParseUint(strings.TrimSpace(strings.TrimPrefix(line, "id: ")), 10, 64)
+	if err != nil {
+		return 0, err
+	}
+
+	w.mutex.Lock()
+	w.eventIDs = append(w.eventIDs, eventID)
+	w.mutex.Unlock()
+
+	return len(data), nil
+}
+
+func (w *testSSEWriter) getEventIDs() []uint64 {
+	w.mutex.Lock()
+	defer w.mutex.Unlock()
+
+	return slices.Clone(w.eventIDs)
+}
+
+func newTestEventBus(t *testing.T) *EventBus {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	database := db.NewDatabase(logger)
+
+	err := database.InitDB(&db.DatabaseConfig{
+		Engine: "sqlite",
+		Sqlite: &db.SqliteDatabaseConfig{
+			File: filepath.Join(t.TempDir(), "assertoor.db"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed initializing database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		//nolint:errcheck // ignore
+		database.CloseDB()
+	})
+
+	if err := database.ApplySchema(-2); err != nil {
+		t.Fatalf("failed applying database schema: %v", err)
+	}
+
+	eventBus := NewEventBus(logger)
+	eventBus.SetEventLog(NewEventLog(&LogConfig{Enabled: true}, database, logger))
+
+	return eventBus
+}
+
+func newTestEvent(eventID uint64, eventType EventType) *Event {
+	return &Event{
+		ID:        eventID,
+		Type:      eventType,
+		Timestamp: time.Now(),
+		TestRunID: 1,
+	}
+}
+
+// runTestStream runs the SSE handler until the expected number of events got written.
+func runTestStream(t *testing.T, handler *SSEHandler, w *testSSEWriter, lastEventID uint64, wantCount int) []uint64 {
+	t.Helper()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/events/stream", http.NoBody).WithContext(ctx)
+	req.Header.Set("Last-Event-ID", strconv.FormatUint(lastEventID, 10))
+
+	done := make(chan struct{})
+
+	go func() {
+		defer close(done)
+
+		handler.HandleGlobalStream(w, req)
+	}()
+
+	deadline := time.Now().Add(5 * time.Second)
+
+	for len(w.getEventIDs()) < wantCount && time.Now().Before(deadline) {
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	// give the handler time to write unexpected events
+	time.Sleep(50 * time.Millisecond)
+	cancel()
+	<-done
+
+	return w.getEventIDs()
+}
+
+func TestSSEReplay(t *testing.T) {
+	tests := []struct {
+		name           string
+		requireAuthLog bool
+		logged         []*Event
+		lastEventID    uint64
+		want           []uint64
+	}{
+		{
+			name: "replay after last event id",
+			logged: []*Event{
+				newTestEvent(1, EventTestStarted),
+				newTestEvent(2, EventTaskStarted),
+				newTestEvent(3, EventTaskCompleted),
+			},
+			lastEventID: 1,
+			want:        []uint64{2, 3},
+		},
+		{
+			name: "task logs visible without auth requirement",
+			logged: []*Event{
+				newTestEvent(1, EventTestStarted),
+				newTestEvent(2, EventTaskLog),
+				newTestEvent(3, EventTaskCompleted),
+			},
+			lastEventID: 1,
+			want:        []uint64{2, 3},
+		},
+		{
+			name:           "task logs hidden from unauthenticated clients",
+			requireAuthLog: true,
+			logged: []*Event{
+				newTestEvent(1, EventTestStarted),
+				newTestEvent(2, EventTaskLog),
+				newTestEvent(3, EventTaskCompleted),
+			},
+			lastEventID: 1,
+			want:        []uint64{3},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			eventBus := newTestEventBus(t)
+
+			if err := eventBus.EventLog().storeEvents(tt.logged); err != nil {
+				t.Fatalf("failed storing events: %v", err)
+			}
+
+			handler := NewSSEHandlerWithAuth(logrus.New(), eventBus, func(string, string) *jwt.Token { return nil }, tt.requireAuthLog)
+			w := &testSSEWriter{header: http.Header{}}
+
+			got := runTestStream(t, handler, w, tt.lastEventID, len(tt.want))
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("sent events %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSSEReplayGap(t *testing.T) {
+	eventBus := newTestEventBus(t)
+
+	// unbuffered subscriptions drop every event that is dispatched while the handler is replaying
+	eventBus.subscriberBuf = 0
+
+	err := eventBus.EventLog().storeEvents([]*Event{
+		newTestEvent(1, EventTestStarted),
+		newTestEvent(2, EventTaskStarted),
+		newTestEvent(3, EventTaskCompleted),
+	})
+	if err != nil {
+		t.Fatalf("failed storing events: %v", err)
+	}
+
+	w := &testSSEWriter{header: http.Header{}}
+	w.onWrite = func(line string) {
+		if line != "id: 3\n" {
+			return
+		}
+
+		// event 4 gets logged and dispatched (& dropped) while event 3 is being replayed
+		event := newTestEvent(4, EventTaskStarted)
+
+		if err := eventBus.EventLog().storeEvents([]*Event{event}); err != nil {
+			t.Errorf("failed storing event: %v", err)
+		}
+
+		eventBus.dispatchEvent(event)
+	}
+
+	got := runTestStream(t, NewSSEHandler(logrus.New(), eventBus), w, 1, 3)
+	if want := []uint64{2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("sent events %v, want %v", got, want)
+	}
+}
+
+func TestSSEReplayDedupe(t *testing.T) {
+	eventBus := newTestEventBus(t)
+
+	logged := []*Event{
+		newTestEvent(2, EventTaskStarted),
+		newTestEvent(3, EventTaskCompleted),
+	}
+
+	if err := eventBus.EventLog().storeEvents(logged); err != nil {
+		t.Fatalf("failed storing events: %v", err)
+	}
+
+	w := &testSSEWriter{header: http.Header{}}
+	w.onWrite = func(line string) {
+		if line != "event: connected\n" {
+			return
+		}
+
+		// the logged events are also received live, as they got published after the client subscribed
+		for _, event := range logged {
+			eventBus.dispatchEvent(event)
+		}
+
+		eventBus.dispatchEvent(newTestEvent(4, EventTaskStarted))
+	}
+
+	got := runTestStream(t, NewSSEHandler(logrus.New(), eventBus), w, 1, 3)
+	if want := []uint64{2, 3, 4}; !slices.Equal(got, want) {
+		t.Errorf("sent events %v, want %v", got, want)
+	}
+}
+
+func TestIsVisibleEventType(t *testing.T) {
+	tests := []struct {
+		name            string
+		eventType       EventType
+		requireAuthLog  bool
+		isAuthenticated bool
+		want            bool
+		wantHidden      []string
+	}{
+		{name: "log without auth requirement", eventType: EventTaskLog, want: true},
+		{name: "log for authenticated client", eventType: EventTaskLog, requireAuthLog: true, isAuthenticated: true, want: true},
+		{name: "log for unauthenticated client", eventType: EventTaskLog, requireAuthLog: true, want: false, wantHidden: []string{"task.log"}},
+		{name: "lifecycle event for unauthenticated client", eventType: EventTaskFailed, requireAuthLog: true, want: true, wantHidden: []string{"task.log"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsVisibleEventType(tt.eventType, tt.requireAuthLog, tt.isAuthenticated); got != tt.want {
+				t.Errorf("IsVisibleEventType() = %v, want %v", got, tt.want)
+			}
+
+			if got := GetHiddenEventTypes(tt.requireAuthLog, tt.isAuthenticated); !slices.Equal(got, tt.wantHidden) {
+				t.Errorf("GetHiddenEventTypes() = %v, want %v", got, tt.wantHidden)
+			}
+		})
+	}
+}
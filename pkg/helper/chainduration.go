@@ -0,0 +1,136 @@
+package helper
+
+import (
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ChainClock provides the chain timing needed to resolve chain-relative durations.
+type ChainClock interface {
+	GenesisTime() time.Time
+	SlotDuration() time.Duration
+	SlotsPerEpoch() uint64
+}
+
+type ChainDurationUnit string
+
+const (
+	ChainDurationUnitSlot  ChainDurationUnit = "slot"
+	ChainDurationUnitEpoch ChainDurationUnit = "epoch"
+)
+
+type ChainDurationMode uint8
+
+const (
+	// ChainDurationCount is a number of slots/epochs (e.g. "3 epochs").
+	ChainDurationCount ChainDurationMode = iota
+	// ChainDurationOffset lasts until the start of the N-th slot/epoch after the current one (e.g. "slot+32").
+	ChainDurationOffset
+	// ChainDurationUntil lasts until the start of the given slot/epoch (e.g. "until epoch 12").
+	ChainDurationUntil
+)
+
+// ChainDuration is a duration given in chain units, which depends on the slot time & epoch length of the network.
+type ChainDuration struct {
+	Unit  ChainDurationUnit
+	Mode  ChainDurationMode
+	Value uint64
+}
+
+var (
+	chainDurationCountPattern  = regexp.MustCompile(`^(\d+)\s*(slot|slots|epoch|epochs)$`)
+	chainDurationOffsetPattern = regexp.MustCompile(`^(slot|epoch)\s*\+\s*(\d+)$`)
+	chainDurationUntilPattern  = regexp.MustCompile(`^until\s+(slot|epoch)\s+(\d+)$`)
+)
+
+// ParseChainDuration parses a chain-relative duration like "3 epochs", "32 slots", "slot+32", "epoch+2",
+// "until slot 100" or "until epoch 12".
+func ParseChainDuration(s string) (*ChainDuration, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+
+	var (
+		unit  string
+		value string
+		mode  ChainDurationMode
+	)
+
+	if match := chainDurationCountPattern.FindStringSubmatch(s); match != nil {
+		unit, value, mode = match[2], match[1], ChainDurationCount
+	} else if match := chainDurationOffsetPattern.FindStringSubmatch(s); match != nil {
+		unit, value, mode = match[1], match[2], ChainDurationOffset
+	} else if match := chainDurationUntilPattern.FindStringSubmatch(s); match != nil {
+		unit, value, mode = match[1], match[2], ChainDurationUntil
+	} else {
+		return nil, fmt.Errorf("invalid chain duration: %v", s)
+	}
+
+	parsedValue, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid chain duration value: %w", err)
+	}
+
+	return &ChainDuration{
+		Unit:  ChainDurationUnit(strings.TrimSuffix(unit, "s")),
+		Mode:  mode,
+		Value: parsedValue,
+	}, nil
+}
+
+func (c *ChainDuration) String() string {
+	switch c.Mode {
+	case ChainDurationOffset:
+		return fmt.Sprintf("%v+%v", c.Unit, c.Value)
+	case ChainDurationUntil:
+		return fmt.Sprintf("until %v %v", c.Unit, c.Value)
+	default:
+		if c.Value == 1 {
+			return fmt.Sprintf("1 %v", c.Unit)
+		}
+
+		return fmt.Sprintf("%v %vs", c.Value, c.Unit)
+	}
+}
+
+// ResolveAt returns the wall clock duration of the chain duration, starting at the given time.
+// Durations ending at a slot/epoch in the past resolve to 0.
+func (c *ChainDuration) ResolveAt(clock ChainClock, now time.Time) time.Duration {
+	slotDuration := clock.SlotDuration()
+	slotsPerEpoch := clock.SlotsPerEpoch()
+
+	slots := c.Value
+	if c.Unit == ChainDurationUnitEpoch {
+		slots *= slotsPerEpoch
+	}
+
+	if c.Mode == ChainDurationCount {
+		return time.Duration(slots) * slotDuration //nolint:gosec // no overflow for sane values
+	}
+
+	genesisTime := clock.GenesisTime()
+	currentSlot := uint64(0)
+
+	if now.After(genesisTime) && slotDuration > 0 {
+		currentSlot = uint64(now.Sub(genesisTime) / slotDuration)
+	}
+
+	var targetSlot uint64
+
+	switch {
+	case c.Mode == ChainDurationUntil:
+		targetSlot = slots
+	case c.Unit == ChainDurationUnitEpoch && slotsPerEpoch > 0:
+		targetSlot = (currentSlot/slotsPerEpoch)*slotsPerEpoch + slots
+	default:
+		targetSlot = currentSlot + slots
+	}
+
+	targetTime := genesisTime.Add(time.Duration(targetSlot) * slotDuration) //nolint:gosec // no overflow for sane values
+	if targetTime.Before(now) {
+		return 0
+	}
+
+	return targetTime.Sub(now)
+}
@@ -0,0 +1,174 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+type testChainClock struct {
+	genesisTime time.Time
+}
+
+func (c *testChainClock) GenesisTime() time.Time {
+	return c.genesisTime
+}
+
+func (c *testChainClock) SlotDuration() time.Duration {
+	return 12 * time.Second
+}
+
+func (c *testChainClock) SlotsPerEpoch() uint64 {
+	return 32
+}
+
+func TestParseChainDuration(t *testing.T) {
+	tests := []struct {
+		input      string
+		want       *ChainDuration
+		wantString string
+		wantErr    bool
+	}{
+		{input: "3 epochs", want: &ChainDuration{Unit: ChainDurationUnitEpoch, Mode: ChainDurationCount, Value: 3}, wantString: "3 epochs"},
+		{input: "1 epoch", want: &ChainDuration{Unit: ChainDurationUnitEpoch, Mode: ChainDurationCount, Value: 1}, wantString: "1 epoch"},
+		{input: "32slots", want: &ChainDuration{Unit: ChainDurationUnitSlot, Mode: ChainDurationCount, Value: 32}, wantString: "32 slots"},
+		{input: " 1 Slot ", want: &ChainDuration{Unit: ChainDurationUnitSlot, Mode: ChainDurationCount, Value: 1}, wantString: "1 slot"},
+		{input: "slot+32", want: &ChainDuration{Unit: ChainDurationUnitSlot, Mode: ChainDurationOffset, Value: 32}, wantString: "slot+32"},
+		{input: "epoch + 2", want: &ChainDuration{Unit: ChainDurationUnitEpoch, Mode: ChainDurationOffset, Value: 2}, wantString: "epoch+2"},
+		{input: "until slot 100", want: &ChainDuration{Unit: ChainDurationUnitSlot, Mode: ChainDurationUntil, Value: 100}, wantString: "until slot 100"},
+		{input: "until epoch 12", want: &ChainDuration{Unit: ChainDurationUnitEpoch, Mode: ChainDurationUntil, Value: 12}, wantString: "until epoch 12"},
+		{input: "", wantErr: true},
+		{input: "5m", wantErr: true},
+		{input: "3 blocks", wantErr: true},
+		{input: "slots+2", wantErr: true},
+		{input: "until epochs 12", wantErr: true},
+		{input: "-1 epoch", wantErr: true},
+		{input: "99999999999999999999 slots", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseChainDuration(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if *got != *tt.want {
+				t.Errorf("ParseChainDuration() = %+v, want %+v", got, tt.want)
+			}
+
+			if got.String() != tt.wantString {
+				t.Errorf("String() = %q, want %q", got.String(), tt.wantString)
+			}
+		})
+	}
+}
+
+func TestChainDurationResolveAt(t *testing.T) {
+	genesis := time.Unix(1700000000, 0)
+	clock := &testChainClock{genesisTime: genesis}
+	slotTime := func(slot int64) time.Time {
+		return genesis.Add(time.Duration(slot) * 12 * time.Second)
+	}
+
+	tests := []struct {
+		name     string
+		duration string
+		now      time.Time
+		want     time.Duration
+	}{
+		{name: "slot count", duration: "2 slots", now: slotTime(5), want: 24 * time.Second},
+		{name: "epoch count", duration: "3 epochs", now: slotTime(5), want: 3 * 32 * 12 * time.Second},
+		{name: "slot offset", duration: "slot+2", now: slotTime(5).Add(4 * time.Second), want: 20 * time.Second},
+		{name: "epoch offset", duration: "epoch+1", now: slotTime(40).Add(6 * time.Second), want: 282 * time.Second},
+		{name: "until future slot", duration: "until slot 100", now: slotTime(10), want: 90 * 12 * time.Second},
+		{name: "until past epoch", duration: "until epoch 1", now: slotTime(40), want: 0},
+		{name: "offset before genesis", duration: "slot+1", now: genesis.Add(-60 * time.Second), want: 72 * time.Second},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			chainDuration, err := ParseChainDuration(tt.duration)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if got := chainDuration.ResolveAt(clock, tt.now); got != tt.want {
+				t.Errorf("ResolveAt() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDurationUnmarshal(t *testing.T) {
+	tests := []struct {
+		input         string
+		wantDuration  time.Duration
+		chainRelative bool
+		wantErr       bool
+	}{
+		{input: "90s", wantDuration: 90 * time.Second},
+		{input: "2 epochs", chainRelative: true},
+		{input: "until slot 10", chainRelative: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			duration := ChainRelativeDuration{}
+
+			err := duration.Unmarshal(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error, got %v", duration)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if duration.Duration != tt.wantDuration {
+				t.Errorf("Duration = %v, want %v", duration.Duration, tt.wantDuration)
+			}
+
+			if duration.IsChainRelative() != tt.chainRelative {
+				t.Errorf("IsChainRelative() = %v, want %v", duration.IsChainRelative(), tt.chainRelative)
+			}
+
+			if !tt.chainRelative {
+				return
+			}
+
+			marshaled, err := duration.MarshalText()
+			if err != nil {
+				t.Fatalf("unexpected marshal error: %v", err)
+			}
+
+			if string(marshaled) != tt.input {
+				t.Errorf("MarshalText() = %q, want %q", marshaled, tt.input)
+			}
+		})
+	}
+}
+
+func TestDurationRejectsChainUnits(t *testing.T) {
+	for _, input := range []string{"2 epochs", "slot+32", "until epoch 12"} {
+		t.Run(input, func(t *testing.T) {
+			duration := Duration{}
+
+			if err := duration.Unmarshal(input); err == nil {
+				t.Errorf("expected error, got %v", duration)
+			}
+		})
+	}
+}
@@ -34,3 +34,87 @@ func (d Duration) MarshalText() ([]byte, error) {
 func (d Duration) MarshalJSON() ([]byte, error) {
 	return json.Marshal(d.String())
 }
+
+// ChainRelativeDuration is a duration that can also be given in chain units (e.g. "3 epochs", "until epoch 12" or "slot+32").
+// It is only used by fields that resolve chain units via the task scheduler (timeouts and delays), all other fields use Duration.
+type ChainRelativeDuration struct {
+	time.Duration
+
+	// chain is set for chain-relative durations.
+	// Duration holds the wall clock value of the last resolution via Resolve.
+	chain *ChainDuration
+}
+
+func (d *ChainRelativeDuration) UnmarshalText(text []byte) error {
+	return d.Unmarshal(string(text))
+}
+
+func (d *ChainRelativeDuration) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	return d.Unmarshal(s)
+}
+
+func (d *ChainRelativeDuration) Unmarshal(s string) (err error) {
+	d.chain = nil
+
+	d.Duration, err = time.ParseDuration(s)
+	if err == nil {
+		return nil
+	}
+
+	chainDuration, chainErr := ParseChainDuration(s)
+	if chainErr != nil {
+		return err
+	}
+
+	d.Duration = 0
+	d.chain = chainDuration
+
+	return nil
+}
+
+// IsChainRelative returns true if the duration is given in chain units and needs to be resolved via a chain clock.
+func (d *ChainRelativeDuration) IsChainRelative() bool {
+	return d.chain != nil
+}
+
+// ChainDuration returns the chain-relative duration, or nil for plain wall clock durations.
+func (d *ChainRelativeDuration) ChainDuration() *ChainDuration {
+	return d.chain
+}
+
+// Resolve updates the wall clock value of a chain-relative duration based on the current time.
+// Returns false if the duration is chain-relative and the chain clock is not available yet.
+func (d *ChainRelativeDuration) Resolve(clock ChainClock) bool {
+	if d.chain == nil {
+		return true
+	}
+
+	if clock == nil {
+		return false
+	}
+
+	d.Duration = d.chain.ResolveAt(clock, time.Now())
+
+	return true
+}
+
+func (d ChainRelativeDuration) MarshalText() ([]byte, error) {
+	if d.chain != nil {
+		return []byte(d.chain.String()), nil
+	}
+
+	return []byte(d.String()), nil
+}
+
+func (d ChainRelativeDuration) MarshalJSON() ([]byte, error) {
+	if d.chain != nil {
+		return json.Marshal(d.chain.String())
+	}
+
+	return json.Marshal(d.String())
+}
@@ -0,0 +1,30 @@
+package helper
+
+import (
+	"context"
+	"fmt"
+)
+
+type stickyKeyContextKey struct{}
+
+// WithStickyKey returns a copy of the context that carries the key used by sticky endpoint schedulers,
+// so all endpoint selections made with the context are pinned to the same endpoint.
+func WithStickyKey(ctx context.Context, key string) context.Context {
+	return context.WithValue(ctx, stickyKeyContextKey{}, key)
+}
+
+// GetStickyKey returns the sticky key of the context, or an empty string if the context has none.
+func GetStickyKey(ctx context.Context) string {
+	if ctx == nil {
+		return ""
+	}
+
+	key, _ := ctx.Value(stickyKeyContextKey{}).(string)
+
+	return key
+}
+
+// TestRunStickyKey returns the sticky key of a test run.
+func TestRunStickyKey(runID uint64) string {
+	return fmt.Sprintf("run-%v", runID)
+}
@@ -0,0 +1,25 @@
+package helper
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// ParseTime parses a point in time given as unix timestamp (seconds), RFC3339 timestamp
+// or duration relative to now (e.g. "15m").
+func ParseTime(value string) (time.Time, error) {
+	if unixTime, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(unixTime, 0), nil
+	}
+
+	if timestamp, err := time.Parse(time.RFC3339, value); err == nil {
+		return timestamp, nil
+	}
+
+	if duration, err := time.ParseDuration(value); err == nil {
+		return time.Now().Add(-duration), nil
+	}
+
+	return time.Time{}, fmt.Errorf("invalid time: %v (expected unix timestamp, RFC3339 timestamp or duration)", value)
+}
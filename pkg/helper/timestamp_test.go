@@ -0,0 +1,51 @@
+package helper
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseTime(t *testing.T) {
+	tests := []struct {
+		input   string
+		want    time.Time
+		wantAgo time.Duration
+		wantErr bool
+	}{
+		{input: "1700000000", want: time.Unix(1700000000, 0)},
+		{input: "2024-01-01T12:00:00Z", want: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)},
+		{input: "15m", wantAgo: 15 * time.Minute},
+		{input: "24h", wantAgo: 24 * time.Hour},
+		{input: "yesterday", wantErr: true},
+		{input: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			got, err := ParseTime(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error, got %v", got)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if tt.wantAgo > 0 {
+				if ago := time.Since(got); ago < tt.wantAgo || ago > tt.wantAgo+time.Minute {
+					t.Errorf("ParseTime(%q) = %v ago, want %v ago", tt.input, ago, tt.wantAgo)
+				}
+
+				return
+			}
+
+			if !got.Equal(tt.want) {
+				t.Errorf("ParseTime(%q) = %v, want %v", tt.input, got, tt.want)
+			}
+		})
+	}
+}
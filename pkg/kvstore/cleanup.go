@@ -0,0 +1,29 @@
+package kvstore
+
+import (
+	"context"
+	"time"
+
+	"github.com/sirupsen/logrus"
+)
+
+const cleanupInterval = 10 * time.Minute
+
+// RunCleanup periodically deletes expired entries until the context is cancelled.
+// Expired entries are hidden from reads anyway, the cleanup only reclaims their space.
+func (s *Store) RunCleanup(ctx context.Context, logger logrus.FieldLogger) {
+	for {
+		deleted, err := s.DeleteExpired()
+		if err != nil {
+			logger.Warnf("failed cleaning up expired store entries: %v", err)
+		} else if deleted > 0 {
+			logger.Debugf("deleted %v expired store entries", deleted)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(cleanupInterval):
+		}
+	}
+}
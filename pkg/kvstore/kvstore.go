@@ -0,0 +1,387 @@
+// Package kvstore implements a persistent, namespaced key/value store for state that outlives a
+// single test run. Values are JSON encoded, can expire after a TTL and are versioned, so concurrent
+// writers can use compare-and-set updates.
+package kvstore
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/jmoiron/sqlx"
+)
+
+const (
+	// DefaultNamespace is used by the store tasks if no namespace is configured.
+	DefaultNamespace = "default"
+
+	maxNamespaceLength = 128
+	maxKeyLength       = 256
+
+	// maxIncrementRetries limits the compare-and-set retries of concurrent increments.
+	maxIncrementRetries = 20
+)
+
+var (
+	// ErrConflict is returned if a conditional write didn't match the stored entry.
+	ErrConflict = errors.New("entry has been modified concurrently or does not match the expected version")
+
+	// ErrNotNumeric is returned when incrementing a value that is not an integer.
+	ErrNotNumeric = errors.New("stored value is not an integer")
+)
+
+// Entry is a value in the key/value store.
+type Entry struct {
+	Namespace  string `json:"namespace"`
+	Key        string `json:"key"`
+	Value      any    `json:"value"`
+	Version    uint64 `json:"version"`
+	RunID      uint64 `json:"run_id"`
+	CreateTime int64  `json:"create_time"`
+	UpdateTime int64  `json:"update_time"`
+	ExpireTime int64  `json:"expire_time,omitempty"`
+}
+
+// Namespace is a namespace of the key/value store with its number of entries.
+type Namespace struct {
+	Namespace string `json:"namespace"`
+	Entries   uint64 `json:"entries"`
+}
+
+// WriteOptions control how a value is written.
+type WriteOptions struct {
+	// TTL lets the entry expire after the given duration (0 for no expiry).
+	TTL time.Duration
+
+	// RunID is the test run that writes the entry (0 for API writes).
+	RunID uint64
+}
+
+// Store is the key/value store backed by the assertoor database.
+type Store struct {
+	database *db.Database
+}
+
+// NewStore creates a key/value store on top of the database.
+func NewStore(database *db.Database) *Store {
+	return &Store{
+		database: database,
+	}
+}
+
+// ValidateKey checks the namespace and key of an entry.
+func ValidateKey(namespace, key string) error {
+	switch {
+	case namespace == "":
+		return errors.New("namespace must not be empty")
+	case len(namespace) > maxNamespaceLength:
+		return fmt.Errorf("namespace must not be longer than %v characters", maxNamespaceLength)
+	case key == "":
+		return errors.New("key must not be empty")
+	case len(key) > maxKeyLength:
+		return fmt.Errorf("key must not be longer than %v characters", maxKeyLength)
+	}
+
+	return nil
+}
+
+// Get returns a non-expired entry or nil if it does not exist.
+func (s *Store) Get(namespace, key string) (*Entry, error) {
+	dbEntry, err := s.database.GetKVStoreEntry(nil, namespace, key, time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading store entry: %w", err)
+	}
+
+	if dbEntry == nil {
+		return nil, nil
+	}
+
+	return newEntry(dbEntry)
+}
+
+// List returns a range of the non-expired entries of a namespace ordered by key and the total number of entries.
+// Only keys starting with prefix are returned if it's set.
+func (s *Store) List(namespace, prefix string, offset, limit uint64) ([]*Entry, uint64, error) {
+	dbEntries, total, err := s.database.GetKVStoreEntries(namespace, prefix, time.Now().UnixMilli(), offset, limit)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed loading store entries: %w", err)
+	}
+
+	entries := make([]*Entry, 0, len(dbEntries))
+
+	for _, dbEntry := range dbEntries {
+		entry, err := newEntry(dbEntry)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		entries = append(entries, entry)
+	}
+
+	return entries, total, nil
+}
+
+// Namespaces returns all namespaces with non-expired entries.
+func (s *Store) Namespaces() ([]*Namespace, error) {
+	dbNamespaces, err := s.database.GetKVStoreNamespaces(time.Now().UnixMilli())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading store namespaces: %w", err)
+	}
+
+	namespaces := make([]*Namespace, 0, len(dbNamespaces))
+	for _, dbNamespace := range dbNamespaces {
+		namespaces = append(namespaces, &Namespace{
+			Namespace: dbNamespace.Namespace,
+			Entries:   dbNamespace.Entries,
+		})
+	}
+
+	return namespaces, nil
+}
+
+// Set stores a value, replacing any existing value.
+func (s *Store) Set(namespace, key string, value any, opts *WriteOptions) (*Entry, error) {
+	dbEntry, err := newDBEntry(namespace, key, value, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.write(namespace, key, dbEntry.UpdateTime, func(tx *sqlx.Tx) error {
+		return s.database.SetKVStoreEntry(tx, dbEntry)
+	})
+}
+
+// Create stores a value if the key does not exist yet. It returns ErrConflict otherwise.
+func (s *Store) Create(namespace, key string, value any, opts *WriteOptions) (*Entry, error) {
+	dbEntry, err := newDBEntry(namespace, key, value, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.write(namespace, key, dbEntry.UpdateTime, func(tx *sqlx.Tx) error {
+		inserted, err := s.database.InsertKVStoreEntry(tx, dbEntry)
+		if err != nil {
+			return err
+		}
+
+		if !inserted {
+			return ErrConflict
+		}
+
+		return nil
+	})
+}
+
+// Update replaces a value if the stored entry still has the given version. It returns ErrConflict otherwise.
+func (s *Store) Update(namespace, key string, value any, version uint64, opts *WriteOptions) (*Entry, error) {
+	dbEntry, err := newDBEntry(namespace, key, value, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.write(namespace, key, dbEntry.UpdateTime, func(tx *sqlx.Tx) error {
+		updated, err := s.database.UpdateKVStoreEntry(tx, dbEntry, version)
+		if err != nil {
+			return err
+		}
+
+		if !updated {
+			return ErrConflict
+		}
+
+		return nil
+	})
+}
+
+// Increment adds delta to an integer value and returns the updated entry.
+// Missing entries are created with delta as value. Concurrent increments are retried, so no update gets lost.
+func (s *Store) Increment(namespace, key string, delta int64, opts *WriteOptions) (*Entry, error) {
+	for range maxIncrementRetries {
+		current, err := s.Get(namespace, key)
+		if err != nil {
+			return nil, err
+		}
+
+		var entry *Entry
+
+		if current == nil {
+			entry, err = s.Create(namespace, key, delta, opts)
+		} else {
+			value, ok := toInteger(current.Value)
+			if !ok {
+				return nil, ErrNotNumeric
+			}
+
+			entry, err = s.Update(namespace, key, value+delta, current.Version, opts)
+		}
+
+		if !errors.Is(err, ErrConflict) {
+			return entry, err
+		}
+	}
+
+	return nil, ErrConflict
+}
+
+// Delete removes an entry and returns false if it did not exist.
+func (s *Store) Delete(namespace, key string) (bool, error) {
+	var deleted bool
+
+	err := s.database.RunTransaction(func(tx *sqlx.Tx) error {
+		var err error
+
+		deleted, err = s.database.DeleteKVStoreEntry(tx, namespace, key, time.Now().UnixMilli())
+
+		return err
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed deleting store entry: %w", err)
+	}
+
+	return deleted, nil
+}
+
+// DeleteExpired removes all expired entries and returns the number of deleted entries.
+func (s *Store) DeleteExpired() (int64, error) {
+	var deleted int64
+
+	err := s.database.RunTransaction(func(tx *sqlx.Tx) error {
+		var err error
+
+		deleted, err = s.database.DeleteExpiredKVStoreEntries(tx, time.Now().UnixMilli())
+
+		return err
+	})
+
+	return deleted, err
+}
+
+// write runs a write operation and reads back the resulting entry within the same transaction.
+func (s *Store) write(namespace, key string, now int64, writeFn func(tx *sqlx.Tx) error) (*Entry, error) {
+	var dbEntry *db.KVStoreEntry
+
+	err := s.database.RunTransaction(func(tx *sqlx.Tx) error {
+		if err := writeFn(tx); err != nil {
+			return err
+		}
+
+		var err error
+
+		dbEntry, err = s.database.GetKVStoreEntry(tx, namespace, key, now)
+
+		return err
+	})
+	if errors.Is(err, ErrConflict) {
+		return nil, err
+	} else if err != nil {
+		return nil, fmt.Errorf("failed writing store entry: %w", err)
+	}
+
+	if dbEntry == nil {
+		return nil, errors.New("store entry vanished after writing")
+	}
+
+	return newEntry(dbEntry)
+}
+
+func newDBEntry(namespace, key string, value any, opts *WriteOptions) (*db.KVStoreEntry, error) {
+	if err := ValidateKey(namespace, key); err != nil {
+		return nil, err
+	}
+
+	valueJSON, err := json.Marshal(value)
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding value: %w", err)
+	}
+
+	now := time.Now().UnixMilli()
+	dbEntry := &db.KVStoreEntry{
+		Namespace:  namespace,
+		Key:        key,
+		Value:      string(valueJSON),
+		CreateTime: now,
+		UpdateTime: now,
+	}
+
+	if opts != nil {
+		dbEntry.RunID = opts.RunID
+
+		if opts.TTL > 0 {
+			dbEntry.ExpireTime = now + opts.TTL.Milliseconds()
+		}
+	}
+
+	return dbEntry, nil
+}
+
+func newEntry(dbEntry *db.KVStoreEntry) (*Entry, error) {
+	var value any
+
+	decoder := json.NewDecoder(bytes.NewReader([]byte(dbEntry.Value)))
+	decoder.UseNumber()
+
+	if err := decoder.Decode(&value); err != nil {
+		return nil, fmt.Errorf("failed decoding value of %v/%v: %w", dbEntry.Namespace, dbEntry.Key, err)
+	}
+
+	return &Entry{
+		Namespace:  dbEntry.Namespace,
+		Key:        dbEntry.Key,
+		Value:      normalizeNumbers(value),
+		Version:    dbEntry.Version,
+		RunID:      dbEntry.RunID,
+		CreateTime: dbEntry.CreateTime,
+		UpdateTime: dbEntry.UpdateTime,
+		ExpireTime: dbEntry.ExpireTime,
+	}, nil
+}
+
+// normalizeNumbers converts decoded json numbers to int64 or float64, so values behave like
+// the ones of other task outputs in expressions.
+func normalizeNumbers(value any) any {
+	switch v := value.(type) {
+	case json.Number:
+		if intValue, err := v.Int64(); err == nil {
+			return intValue
+		}
+
+		if floatValue, err := v.Float64(); err == nil {
+			return floatValue
+		}
+
+		return v.String()
+	case map[string]any:
+		for key, item := range v {
+			v[key] = normalizeNumbers(item)
+		}
+
+		return v
+	case []any:
+		for idx, item := range v {
+			v[idx] = normalizeNumbers(item)
+		}
+
+		return v
+	default:
+		return value
+	}
+}
+
+func toInteger(value any) (int64, bool) {
+	switch v := value.(type) {
+	case int64:
+		return v, true
+	case float64:
+		if v != math.Trunc(v) || v < math.MinInt64 || v >= math.MaxInt64 {
+			return 0, false
+		}
+
+		return int64(v), true
+	default:
+		return 0, false
+	}
+}
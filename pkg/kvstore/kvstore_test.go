@@ -0,0 +1,224 @@
+package kvstore
+
+import (
+	"errors"
+	"io"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/sirupsen/logrus"
+)
+
+func newTestStore(t *testing.T) *Store {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	database := db.NewDatabase(logger)
+
+	err := database.InitDB(&db.DatabaseConfig{
+		Engine: "sqlite",
+		Sqlite: &db.SqliteDatabaseConfig{
+			File: filepath.Join(t.TempDir(), "assertoor.db"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed initializing database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		//nolint:errcheck // ignore
+		database.CloseDB()
+	})
+
+	if err := database.ApplySchema(-2); err != nil {
+		t.Fatalf("failed applying database schema: %v", err)
+	}
+
+	return NewStore(database)
+}
+
+func TestStoreCreate(t *testing.T) {
+	store := newTestStore(t)
+
+	entry, err := store.Create("ns", "key", "first", nil)
+	if err != nil {
+		t.Fatalf("failed creating entry: %v", err)
+	}
+
+	if entry.Value != "first" || entry.Version == 0 {
+		t.Errorf("unexpected created entry: %+v", entry)
+	}
+
+	if _, err := store.Create("ns", "key", "second", nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("Create() on existing key: got error %v, want ErrConflict", err)
+	}
+
+	entry, err = store.Get("ns", "key")
+	if err != nil {
+		t.Fatalf("failed loading entry: %v", err)
+	}
+
+	if entry == nil || entry.Value != "first" {
+		t.Errorf("entry got modified by conflicting create: %+v", entry)
+	}
+
+	// the same key can be created in another namespace
+	if _, err := store.Create("other", "key", "value", nil); err != nil {
+		t.Errorf("failed creating entry in other namespace: %v", err)
+	}
+}
+
+func TestStoreUpdate(t *testing.T) {
+	store := newTestStore(t)
+
+	created, err := store.Set("ns", "key", map[string]any{"count": 1}, nil)
+	if err != nil {
+		t.Fatalf("failed setting entry: %v", err)
+	}
+
+	updated, err := store.Update("ns", "key", map[string]any{"count": 2}, created.Version, nil)
+	if err != nil {
+		t.Fatalf("failed updating entry: %v", err)
+	}
+
+	if updated.Version == created.Version {
+		t.Errorf("version not changed by update: %v", updated.Version)
+	}
+
+	if count := updated.Value.(map[string]any)["count"]; count != int64(2) {
+		t.Errorf("updated count = %v, want 2", count)
+	}
+
+	// the stale version of the first write doesn't match anymore
+	if _, err := store.Update("ns", "key", map[string]any{"count": 3}, created.Version, nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update() with stale version: got error %v, want ErrConflict", err)
+	}
+
+	if _, err := store.Update("ns", "missing", 1, 1, nil); !errors.Is(err, ErrConflict) {
+		t.Errorf("Update() on missing key: got error %v, want ErrConflict", err)
+	}
+
+	entry, err := store.Get("ns", "key")
+	if err != nil {
+		t.Fatalf("failed loading entry: %v", err)
+	}
+
+	if entry.Version != updated.Version {
+		t.Errorf("entry got modified by conflicting update: %+v", entry)
+	}
+}
+
+func TestStoreIncrement(t *testing.T) {
+	store := newTestStore(t)
+
+	const (
+		workers    = 4
+		increments = 10
+	)
+
+	var wg sync.WaitGroup
+
+	errs := make(chan error, workers*increments)
+
+	for range workers {
+		wg.Add(1)
+
+		go func() {
+			defer wg.Done()
+
+			for range increments {
+				if _, err := store.Increment("ns", "counter", 1, nil); err != nil {
+					errs <- err
+				}
+			}
+		}()
+	}
+
+	wg.Wait()
+	close(errs)
+
+	for err := range errs {
+		t.Errorf("failed incrementing counter: %v", err)
+	}
+
+	entry, err := store.Get("ns", "counter")
+	if err != nil {
+		t.Fatalf("failed loading counter: %v", err)
+	}
+
+	if entry == nil || entry.Value != int64(workers*increments) {
+		t.Errorf("counter = %+v, want %v", entry, workers*increments)
+	}
+
+	if _, err := store.Set("ns", "text", "abc", nil); err != nil {
+		t.Fatalf("failed setting entry: %v", err)
+	}
+
+	if _, err := store.Increment("ns", "text", 1, nil); !errors.Is(err, ErrNotNumeric) {
+		t.Errorf("Increment() on text value: got error %v, want ErrNotNumeric", err)
+	}
+}
+
+func TestStoreExpiry(t *testing.T) {
+	store := newTestStore(t)
+
+	ttl := 50 * time.Millisecond
+
+	for _, key := range []string{"a-expiring", "b-expiring"} {
+		if _, err := store.Set("ns", key, 1, &WriteOptions{TTL: ttl}); err != nil {
+			t.Fatalf("failed setting entry %v: %v", key, err)
+		}
+	}
+
+	if _, err := store.Set("ns", "c-persistent", 1, nil); err != nil {
+		t.Fatalf("failed setting entry: %v", err)
+	}
+
+	entries, total, err := store.List("ns", "", 0, 10)
+	if err != nil {
+		t.Fatalf("failed listing entries: %v", err)
+	}
+
+	if total != 3 || len(entries) != 3 {
+		t.Errorf("listed %v of %v entries before expiry, want 3", len(entries), total)
+	}
+
+	time.Sleep(2 * ttl)
+
+	entry, err := store.Get("ns", "a-expiring")
+	if err != nil {
+		t.Fatalf("failed loading entry: %v", err)
+	}
+
+	if entry != nil {
+		t.Errorf("expired entry returned: %+v", entry)
+	}
+
+	entries, total, err = store.List("ns", "", 0, 10)
+	if err != nil {
+		t.Fatalf("failed listing entries: %v", err)
+	}
+
+	if total != 1 || len(entries) != 1 || entries[0].Key != "c-persistent" {
+		t.Errorf("listed %v entries (total %v) after expiry, want c-persistent only", len(entries), total)
+	}
+
+	// expired keys can be created again
+	if _, err := store.Create("ns", "b-expiring", 2, nil); err != nil {
+		t.Errorf("failed creating expired key: %v", err)
+	}
+
+	deleted, err := store.DeleteExpired()
+	if err != nil {
+		t.Fatalf("failed deleting expired entries: %v", err)
+	}
+
+	if deleted != 1 {
+		t.Errorf("deleted %v expired entries, want 1", deleted)
+	}
+}
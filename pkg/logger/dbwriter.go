@@ -32,6 +32,8 @@ func newLogDBWriter(logger *LogScope, bufferSize uint64, flushDelay time.Duratio
 		bufferSize: bufferSize,
 		flushDelay: flushDelay,
 		buf:        make([]*db.TaskLog, 0, bufferSize),
+		lastIdx:    logger.options.LogIndexOffset,
+		flushIdx:   logger.options.LogIndexOffset,
 	}
 }
 
@@ -27,6 +27,10 @@ type ScopeOptions struct {
 	TaskID     uint64
 	TaskName   string
 	TaskRefID  string
+
+	// LogIndexOffset continues the log numbering after entries that have
+	// already been persisted for this task (used for resumed tasks).
+	LogIndexOffset uint64
 }
 
 // EventBusPublisher defines the interface for publishing log events.
@@ -55,6 +59,9 @@ func NewLogger(options *ScopeOptions) *LogScope {
 	logger.logger.SetOutput(io.Discard)
 	logger.logger.SetLevel(logrus.DebugLevel)
 
+	// mask secrets before the entries are forwarded, stored or published
+	logger.logger.AddHook(&logRedactHook{})
+
 	if options.Parent != nil {
 		tmpEntry := options.Parent.WithFields(logrus.Fields{})
 		logger.parentLogger = tmpEntry.Logger
@@ -0,0 +1,37 @@
+package logger
+
+import (
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/sirupsen/logrus"
+)
+
+// logRedactHook masks secret values in log entries before they're passed to the other hooks.
+// It has to be the first hook of a logger.
+type logRedactHook struct{}
+
+func (lh *logRedactHook) Levels() []logrus.Level {
+	return logrus.AllLevels
+}
+
+func (lh *logRedactHook) Fire(entry *logrus.Entry) error {
+	if !secrets.HasSecrets() {
+		return nil
+	}
+
+	entry.Message = secrets.Redact(entry.Message)
+
+	for key, value := range entry.Data {
+		switch v := value.(type) {
+		case string:
+			entry.Data[key] = secrets.Redact(v)
+		case error:
+			if redacted := secrets.Redact(v.Error()); redacted != v.Error() {
+				entry.Data[key] = redacted
+			}
+		default:
+			entry.Data[key] = secrets.RedactValue(v)
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,59 @@
+package logger
+
+import (
+	"errors"
+	"io"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/sirupsen/logrus"
+	"github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestLogRedactHook(t *testing.T) {
+	secret := "hook-test-secret"
+	secrets.Register(secret)
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+	logger.AddHook(&logRedactHook{})
+
+	captureHook := test.NewLocal(logger)
+
+	logger.WithFields(logrus.Fields{
+		"token":  secret,
+		"err":    errors.New("invalid token " + secret),
+		"public": errors.New("public error"),
+		"config": map[string]any{"token": secret},
+		"count":  3,
+	}).Infof("using token %v", secret)
+
+	entry := captureHook.LastEntry()
+	if entry == nil {
+		t.Fatalf("no log entry captured")
+	}
+
+	if want := "using token " + secrets.Mask; entry.Message != want {
+		t.Errorf("message = %q, want %q", entry.Message, want)
+	}
+
+	if got := entry.Data["token"]; got != secrets.Mask {
+		t.Errorf("token = %v, want %v", got, secrets.Mask)
+	}
+
+	if got := entry.Data["err"]; got != "invalid token "+secrets.Mask {
+		t.Errorf("err = %v, want redacted error string", got)
+	}
+
+	if _, isError := entry.Data["public"].(error); !isError {
+		t.Errorf("public = %v, want unchanged error", entry.Data["public"])
+	}
+
+	if config, ok := entry.Data["config"].(map[string]any); !ok || config["token"] != secrets.Mask {
+		t.Errorf("config = %v, want redacted map", entry.Data["config"])
+	}
+
+	if got := entry.Data["count"]; got != 3 {
+		t.Errorf("count = %v, want 3", got)
+	}
+}
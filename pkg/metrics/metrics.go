@@ -0,0 +1,127 @@
+package metrics
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+const namespace = "assertoor"
+
+var (
+	testRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "test_runs_total",
+		Help:      "Number of completed test runs by test ID and final status.",
+	}, []string{"test_id", "status"})
+
+	testRunDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "test_run_duration_seconds",
+		Help:      "Duration of completed test runs by test ID and final status.",
+		Buckets:   prometheus.ExponentialBuckets(10, 2, 12), // 10s to ~5.7h
+	}, []string{"test_id", "status"})
+
+	taskRunsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "task_runs_total",
+		Help:      "Number of executed tasks by task name and result.",
+	}, []string{"task_name", "result"})
+
+	taskDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "task_duration_seconds",
+		Help:      "Duration of executed tasks by task name and result.",
+		Buckets:   prometheus.ExponentialBuckets(0.1, 3, 12), // 100ms to ~4.9h
+	}, []string{"task_name", "result"})
+
+	eventBusSubscribers = promauto.NewGauge(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "eventbus_subscribers",
+		Help:      "Number of active event bus subscribers.",
+	})
+
+	eventBusPublishedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "eventbus_events_published_total",
+		Help:      "Number of events published to the event bus by event type.",
+	}, []string{"event_type"})
+
+	eventBusDroppedTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "eventbus_events_dropped_total",
+		Help:      "Number of dropped events by reason (bus_full or subscriber_full).",
+	}, []string{"reason"})
+
+	rpcRequestDuration = promauto.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_duration_seconds",
+		Help:      "Latency of RPC requests to consensus and execution clients.",
+		Buckets:   prometheus.ExponentialBuckets(0.005, 2, 14), // 5ms to ~41s
+	}, []string{"client_type", "client", "method"})
+
+	rpcRequestErrorsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rpc_request_errors_total",
+		Help:      "Number of failed RPC requests to consensus and execution clients.",
+	}, []string{"client_type", "client", "method"})
+)
+
+// ObserveTestRun records a completed test run.
+func ObserveTestRun(testID, status string, duration time.Duration) {
+	testRunsTotal.WithLabelValues(testID, status).Inc()
+	testRunDuration.WithLabelValues(testID, status).Observe(duration.Seconds())
+}
+
+// ObserveTaskRun records an executed task.
+func ObserveTaskRun(taskName, result string, duration time.Duration) {
+	taskRunsTotal.WithLabelValues(taskName, result).Inc()
+	taskDuration.WithLabelValues(taskName, result).Observe(duration.Seconds())
+}
+
+// RegisterTestRunnerGauges registers the gauges reporting the state of the test runner.
+// The callbacks are invoked on every scrape. Only the first registration takes effect.
+func RegisterTestRunnerGauges(queueDepthFn, runningTestsFn func() int) {
+	//nolint:errcheck // ignore duplicate registrations
+	prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "test_queue_depth",
+		Help:      "Number of test runs waiting in the test queue.",
+	}, func() float64 {
+		return float64(queueDepthFn())
+	}))
+
+	//nolint:errcheck // ignore duplicate registrations
+	prometheus.Register(prometheus.NewGaugeFunc(prometheus.GaugeOpts{
+		Namespace: namespace,
+		Name:      "tests_running",
+		Help:      "Number of currently running test runs.",
+	}, func() float64 {
+		return float64(runningTestsFn())
+	}))
+}
+
+// AddEventBusSubscribers adjusts the number of active event bus subscribers.
+func AddEventBusSubscribers(delta int) {
+	eventBusSubscribers.Add(float64(delta))
+}
+
+// ObserveEventPublished records an event published to the event bus.
+func ObserveEventPublished(eventType string) {
+	eventBusPublishedTotal.WithLabelValues(eventType).Inc()
+}
+
+// ObserveEventDropped records an event dropped by the event bus.
+func ObserveEventDropped(reason string) {
+	eventBusDroppedTotal.WithLabelValues(reason).Inc()
+}
+
+// ObserveRPCRequest records the latency and outcome of a RPC request.
+func ObserveRPCRequest(clientType, clientName, method string, duration time.Duration, failed bool) {
+	rpcRequestDuration.WithLabelValues(clientType, clientName, method).Observe(duration.Seconds())
+
+	if failed {
+		rpcRequestErrorsTotal.WithLabelValues(clientType, clientName, method).Inc()
+	}
+}
@@ -0,0 +1,51 @@
+package metrics
+
+import (
+	"sync"
+	"time"
+)
+
+// rpcStatsWeight is the weight of a new sample in the rolling averages.
+const rpcStatsWeight = 0.1
+
+// RPCStats keeps rolling averages of the latency and error rate of the RPC requests to an endpoint.
+type RPCStats struct {
+	mutex     sync.RWMutex
+	latency   float64
+	errorRate float64
+	samples   uint64
+}
+
+// NewRPCStats creates a new, empty RPCStats instance.
+func NewRPCStats() *RPCStats {
+	return &RPCStats{}
+}
+
+// Observe adds a request to the rolling averages.
+func (s *RPCStats) Observe(duration time.Duration, failed bool) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	failure := float64(0)
+	if failed {
+		failure = 1
+	}
+
+	if s.samples == 0 {
+		s.latency = float64(duration)
+		s.errorRate = failure
+	} else {
+		s.latency += (float64(duration) - s.latency) * rpcStatsWeight
+		s.errorRate += (failure - s.errorRate) * rpcStatsWeight
+	}
+
+	s.samples++
+}
+
+// Get returns the rolling average latency, the rolling error rate (0-1) and the number of observed requests.
+func (s *RPCStats) Get() (latency time.Duration, errorRate float64, samples uint64) {
+	s.mutex.RLock()
+	defer s.mutex.RUnlock()
+
+	return time.Duration(s.latency), s.errorRate, s.samples
+}
@@ -0,0 +1,124 @@
+package metrics
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/tracing"
+)
+
+// RPCMethodFunc extracts the method label of a RPC request.
+type RPCMethodFunc func(req *http.Request) string
+
+// RPCTransport is a http.RoundTripper that records the latency and error rate of RPC requests.
+type RPCTransport struct {
+	base       http.RoundTripper
+	clientType string
+	clientName string
+	methodFn   RPCMethodFunc
+	stats      *RPCStats
+}
+
+// NewRPCTransport wraps the given round tripper (or http.DefaultTransport if nil) with RPC request metrics.
+func NewRPCTransport(base http.RoundTripper, clientType, clientName string, methodFn RPCMethodFunc) *RPCTransport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &RPCTransport{
+		base:       base,
+		clientType: clientType,
+		clientName: clientName,
+		methodFn:   methodFn,
+	}
+}
+
+// WithStats additionally records the requests in the given rolling endpoint stats.
+func (t *RPCTransport) WithStats(stats *RPCStats) *RPCTransport {
+	t.stats = stats
+	return t
+}
+
+// NewClientTransport builds the transport chain of a client endpoint: the request tracing is wrapped with
+// RPC request metrics, which are recorded into the given stats as well.
+func NewClientTransport(base http.RoundTripper, clientType, clientName string, methodFn RPCMethodFunc, stats *RPCStats) http.RoundTripper {
+	return NewRPCTransport(tracing.NewTransport(base, clientType, clientName, methodFn), clientType, clientName, methodFn).WithStats(stats)
+}
+
+func (t *RPCTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	method := t.methodFn(req)
+	startTime := time.Now()
+
+	resp, err := t.base.RoundTrip(req)
+
+	failed := err != nil || resp.StatusCode >= 500 || (resp.StatusCode >= 400 && resp.StatusCode != http.StatusNotFound)
+	duration := time.Since(startTime)
+	ObserveRPCRequest(t.clientType, t.clientName, method, duration, failed)
+
+	if t.stats != nil {
+		t.stats.Observe(duration, failed)
+	}
+
+	return resp, err
+}
+
+var beaconPathParamPattern = regexp.MustCompile(`^(0x[0-9a-fA-F]*|\d+)$`)
+
+// BeaconAPIMethod returns the request path of a beacon API request with slot, root and index parameters replaced by "{id}".
+func BeaconAPIMethod(req *http.Request) string {
+	segments := strings.Split(strings.Trim(req.URL.Path, "/"), "/")
+
+	for i, segment := range segments {
+		if beaconPathParamPattern.MatchString(segment) {
+			segments[i] = "{id}"
+		}
+	}
+
+	return req.Method + " /" + strings.Join(segments, "/")
+}
+
+// JSONRPCMethod returns the method name of a JSON-RPC request, or "batch" for batch requests.
+func JSONRPCMethod(req *http.Request) string {
+	if req.Body == nil || req.GetBody == nil {
+		return "unknown"
+	}
+
+	body, err := req.GetBody()
+	if err != nil {
+		return "unknown"
+	}
+
+	defer body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(body, 4096))
+	if err != nil {
+		return "unknown"
+	}
+
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '[' {
+		return "batch"
+	}
+
+	var rpcRequest struct {
+		Method string `json:"method"`
+	}
+
+	// the body may be truncated, so fall back to a simple scan if decoding fails
+	if err := json.Unmarshal(data, &rpcRequest); err == nil && rpcRequest.Method != "" {
+		return rpcRequest.Method
+	}
+
+	if match := jsonRPCMethodPattern.FindSubmatch(data); match != nil {
+		return string(match[1])
+	}
+
+	return "unknown"
+}
+
+var jsonRPCMethodPattern = regexp.MustCompile(`"method"\s*:\s*"([A-Za-z0-9_]+)"`)
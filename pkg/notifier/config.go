@@ -0,0 +1,204 @@
+package notifier
+
+import (
+	"fmt"
+	"net/url"
+	"path"
+	"slices"
+	"text/template"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+// Notification target types.
+const (
+	TypeWebhook = "webhook"
+	TypeSlack   = "slack"
+	TypeDiscord = "discord"
+)
+
+const (
+	defaultMaxRetries = 3
+	defaultRetryDelay = 5 * time.Second
+	maxRetryDelay     = 5 * time.Minute
+	defaultTimeout    = 10 * time.Second
+)
+
+// Config controls the outbound notifications for test lifecycle events.
+type Config struct {
+	// PublicURL is the externally reachable URL of the assertoor web UI.
+	// When set, notifications link to the test run page.
+	PublicURL string `yaml:"publicUrl" json:"publicUrl"`
+
+	// Targets is the list of webhook / chat endpoints that get notified.
+	Targets []*TargetConfig `yaml:"targets" json:"targets"`
+}
+
+// TargetConfig describes a single notification endpoint and the events it is interested in.
+type TargetConfig struct {
+	// Name identifies the target in logs and the delivery history.
+	Name string `yaml:"name" json:"name"`
+
+	// Type of the payload sent to the endpoint (webhook, slack or discord). Defaults to webhook.
+	Type string `yaml:"type" json:"type"`
+
+	// URL the notification is posted to.
+	URL string `yaml:"url" json:"url"`
+
+	// Secret used to sign the request body with HMAC-SHA256.
+	// The signature is sent as `X-Assertoor-Signature: sha256=<hex>` header.
+	Secret string `yaml:"secret" json:"-"`
+
+	// Headers are added to every request (e.g. for authentication).
+	Headers map[string]string `yaml:"headers" json:"headers,omitempty"`
+
+	// Events is the list of event types to notify about. Defaults to test.completed and test.failed.
+	Events []string `yaml:"events" json:"events,omitempty"`
+
+	// TestIDs restricts notifications to tests with a matching ID (glob patterns are supported).
+	TestIDs []string `yaml:"testIds" json:"testIds,omitempty"`
+
+	// Tags restricts notifications to tests that have at least one of the given tags.
+	Tags []string `yaml:"tags" json:"tags,omitempty"`
+
+	// Status restricts notifications to test runs with one of the given statuses (e.g. failure, aborted).
+	Status []string `yaml:"status" json:"status,omitempty"`
+
+	// Template is an optional Go template used to render the message text.
+	// For webhook targets it replaces the whole request body.
+	Template string `yaml:"template" json:"template,omitempty"`
+
+	// MaxRetries is the number of retries after a failed delivery. Defaults to 3.
+	MaxRetries *int `yaml:"maxRetries" json:"maxRetries,omitempty"`
+
+	// RetryDelay is the delay before the first retry, doubled for every further retry up to 5m. Defaults to 5s.
+	RetryDelay helper.Duration `yaml:"retryDelay" json:"retryDelay"`
+
+	// Timeout for a single delivery attempt. Defaults to 10s.
+	Timeout helper.Duration `yaml:"timeout" json:"timeout"`
+}
+
+// Validate checks the notification targets for missing or invalid settings.
+func (c *Config) Validate() error {
+	names := map[string]bool{}
+
+	for idx, targetConfig := range c.Targets {
+		if targetConfig == nil {
+			return fmt.Errorf("target[%d]: empty target", idx)
+		}
+
+		if err := targetConfig.Validate(); err != nil {
+			return fmt.Errorf("target[%d] '%s': %w", idx, targetConfig.Name, err)
+		}
+
+		if names[targetConfig.Name] {
+			return fmt.Errorf("target[%d]: duplicate name '%s'", idx, targetConfig.Name)
+		}
+
+		names[targetConfig.Name] = true
+	}
+
+	return nil
+}
+
+// Validate checks the target config for missing or invalid settings.
+func (c *TargetConfig) Validate() error {
+	if c.Name == "" {
+		return fmt.Errorf("name cannot be empty")
+	}
+
+	switch c.Type {
+	case "", TypeWebhook, TypeSlack, TypeDiscord:
+	default:
+		return fmt.Errorf("invalid type: %s", c.Type)
+	}
+
+	if c.URL == "" {
+		return fmt.Errorf("url cannot be empty")
+	}
+
+	if _, err := url.ParseRequestURI(c.URL); err != nil {
+		return fmt.Errorf("invalid url: %w", err)
+	}
+
+	for _, eventType := range c.Events {
+		if !slices.Contains(notifiableEvents, events.EventType(eventType)) {
+			return fmt.Errorf("unsupported event type: %s", eventType)
+		}
+	}
+
+	for _, pattern := range c.TestIDs {
+		if _, err := path.Match(pattern, ""); err != nil {
+			return fmt.Errorf("invalid test id pattern '%s': %w", pattern, err)
+		}
+	}
+
+	if c.MaxRetries != nil && *c.MaxRetries < 0 {
+		return fmt.Errorf("maxRetries cannot be negative")
+	}
+
+	if c.Template != "" {
+		if _, err := template.New(c.Name).Parse(c.Template); err != nil {
+			return fmt.Errorf("invalid template: %w", err)
+		}
+	}
+
+	return nil
+}
+
+func (c *TargetConfig) getType() string {
+	if c.Type == "" {
+		return TypeWebhook
+	}
+
+	return c.Type
+}
+
+func (c *TargetConfig) getEvents() []events.EventType {
+	if len(c.Events) == 0 {
+		return []events.EventType{events.EventTestCompleted, events.EventTestFailed}
+	}
+
+	eventTypes := make([]events.EventType, 0, len(c.Events))
+	for _, eventType := range c.Events {
+		eventTypes = append(eventTypes, events.EventType(eventType))
+	}
+
+	return eventTypes
+}
+
+func (c *TargetConfig) getMaxRetries() int {
+	if c.MaxRetries == nil {
+		return defaultMaxRetries
+	}
+
+	return *c.MaxRetries
+}
+
+func (c *TargetConfig) getRetryDelay() time.Duration {
+	if c.RetryDelay.Duration <= 0 {
+		return defaultRetryDelay
+	}
+
+	return c.RetryDelay.Duration
+}
+
+func (c *TargetConfig) getTimeout() time.Duration {
+	if c.Timeout.Duration <= 0 {
+		return defaultTimeout
+	}
+
+	return c.Timeout.Duration
+}
+
+// notifiableEvents are the test and task lifecycle events notifications can be sent for.
+var notifiableEvents = []events.EventType{
+	events.EventTestStarted,
+	events.EventTestCompleted,
+	events.EventTestFailed,
+	events.EventTestPaused,
+	events.EventTestResumed,
+	events.EventTaskFailed,
+}
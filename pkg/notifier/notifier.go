@@ -0,0 +1,221 @@
+// Package notifier sends outbound notifications for test lifecycle events.
+//
+// The service subscribes to the event bus and posts generic JSON webhooks
+// (optionally HMAC signed), Slack- or Discord-compatible messages to the
+// configured targets. Failed deliveries are retried with exponential backoff
+// and every delivery is recorded in the database.
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"path"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+// Notification is the information about an event that is passed to the targets.
+// It is the body of generic webhooks and the data passed to message templates.
+type Notification struct {
+	Event     string          `json:"event"`
+	EventID   uint64          `json:"eventId"`
+	Timestamp time.Time       `json:"timestamp"`
+	RunID     uint64          `json:"runId"`
+	TestID    string          `json:"testId"`
+	TestName  string          `json:"testName"`
+	Status    string          `json:"status"`
+	Tags      []string        `json:"tags,omitempty"`
+	TaskIndex uint64          `json:"taskIndex,omitempty"`
+	TaskName  string          `json:"taskName,omitempty"`
+	TaskTitle string          `json:"taskTitle,omitempty"`
+	Error     string          `json:"error,omitempty"`
+	URL       string          `json:"url,omitempty"`
+	Data      json.RawMessage `json:"data,omitempty"`
+}
+
+// eventData combines the fields of the test & task event data structs the notifications are built from.
+type eventData struct {
+	TestID    string `json:"testId"`
+	TestName  string `json:"testName"`
+	Status    string `json:"status"`
+	TaskName  string `json:"taskName"`
+	TaskTitle string `json:"taskTitle"`
+	Error     string `json:"error"`
+}
+
+// Service dispatches notifications for events on the event bus.
+type Service struct {
+	config      *Config
+	logger      logrus.FieldLogger
+	coordinator types.Coordinator
+	targets     []*target
+
+	cancel    context.CancelFunc
+	waitGroup sync.WaitGroup
+}
+
+// NewService creates a notification service for the configured targets.
+func NewService(config *Config, coordinator types.Coordinator, logger logrus.FieldLogger) (*Service, error) {
+	service := &Service{
+		config:      config,
+		logger:      logger.WithField("component", "notifier"),
+		coordinator: coordinator,
+		targets:     make([]*target, 0, len(config.Targets)),
+	}
+
+	for _, targetConfig := range config.Targets {
+		notifyTarget, err := newTarget(targetConfig)
+		if err != nil {
+			return nil, err
+		}
+
+		service.targets = append(service.targets, notifyTarget)
+	}
+
+	return service, nil
+}
+
+// Start subscribes to the event bus and processes events until the context is cancelled or Stop is called.
+func (s *Service) Start(ctx context.Context) {
+	if len(s.targets) == 0 {
+		return
+	}
+
+	ctx, s.cancel = context.WithCancel(ctx)
+
+	eventTypes := []events.EventType{}
+
+	for _, notifyTarget := range s.targets {
+		for _, eventType := range notifyTarget.config.getEvents() {
+			if !slices.Contains(eventTypes, eventType) {
+				eventTypes = append(eventTypes, eventType)
+			}
+		}
+	}
+
+	eventBus := s.coordinator.EventBus()
+	subscriber := eventBus.Subscribe(events.CreateEventTypeFilter(eventTypes...))
+
+	s.waitGroup.Add(1)
+
+	go func() {
+		defer s.waitGroup.Done()
+		defer eventBus.Unsubscribe(subscriber)
+
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case event, ok := <-subscriber.Channel():
+				if !ok {
+					return
+				}
+
+				s.processEvent(ctx, event)
+			}
+		}
+	}()
+}
+
+// Stop cancels pending retries and waits for running deliveries to finish.
+func (s *Service) Stop() {
+	if s.cancel != nil {
+		s.cancel()
+	}
+
+	s.waitGroup.Wait()
+}
+
+func (s *Service) processEvent(ctx context.Context, event *events.Event) {
+	notification := s.buildNotification(event)
+
+	for _, notifyTarget := range s.targets {
+		if !s.matchTarget(notifyTarget.config, notification) {
+			continue
+		}
+
+		s.waitGroup.Add(1)
+
+		go func() {
+			defer s.waitGroup.Done()
+
+			s.deliver(ctx, notifyTarget, notification)
+		}()
+	}
+}
+
+func (s *Service) buildNotification(event *events.Event) *Notification {
+	notification := &Notification{
+		Event:     string(event.Type),
+		EventID:   event.ID,
+		Timestamp: event.Timestamp,
+		RunID:     event.TestRunID,
+		TaskIndex: event.TaskIndex,
+		Data:      event.Data,
+	}
+
+	data := &eventData{}
+	if len(event.Data) > 0 {
+		if err := json.Unmarshal(event.Data, data); err != nil {
+			s.logger.Warnf("failed decoding data of event %v: %v", event.ID, err)
+		}
+	}
+
+	notification.TestID = data.TestID
+	notification.TestName = data.TestName
+	notification.Status = data.Status
+	notification.TaskName = data.TaskName
+	notification.TaskTitle = data.TaskTitle
+	notification.Error = data.Error
+
+	if testRef := s.coordinator.GetTestByRunID(event.TestRunID); testRef != nil {
+		notification.TestID = testRef.TestID()
+		notification.TestName = testRef.Name()
+		notification.Status = string(testRef.Status())
+	}
+
+	if registry := s.coordinator.TestRegistry(); registry != nil && notification.TestID != "" {
+		for _, descriptor := range registry.GetTestDescriptors() {
+			if descriptor.ID() == notification.TestID && descriptor.Config() != nil {
+				notification.Tags = descriptor.Config().Tags
+				break
+			}
+		}
+	}
+
+	if s.config.PublicURL != "" && notification.RunID > 0 {
+		notification.URL = getTestRunURL(s.config.PublicURL, notification.RunID)
+	}
+
+	return notification
+}
+
+func (s *Service) matchTarget(config *TargetConfig, notification *Notification) bool {
+	if !slices.Contains(config.getEvents(), events.EventType(notification.Event)) {
+		return false
+	}
+
+	if len(config.TestIDs) > 0 && !slices.ContainsFunc(config.TestIDs, func(pattern string) bool {
+		matched, _ := path.Match(pattern, notification.TestID)
+		return matched
+	}) {
+		return false
+	}
+
+	if len(config.Tags) > 0 && !slices.ContainsFunc(config.Tags, func(tag string) bool {
+		return slices.Contains(notification.Tags, tag)
+	}) {
+		return false
+	}
+
+	if len(config.Status) > 0 && !slices.Contains(config.Status, notification.Status) {
+		return false
+	}
+
+	return true
+}
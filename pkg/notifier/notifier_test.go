@@ -0,0 +1,152 @@
+package notifier
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/sirupsen/logrus"
+)
+
+func TestMatchTarget(t *testing.T) {
+	notification := &Notification{
+		Event:  "test.failed",
+		TestID: "devnet-sync-check",
+		Status: "failure",
+		Tags:   []string{"nightly", "sync"},
+	}
+
+	tests := []struct {
+		name   string
+		config *TargetConfig
+		want   bool
+	}{
+		{
+			name:   "default events",
+			config: &TargetConfig{},
+			want:   true,
+		},
+		{
+			name:   "event not subscribed",
+			config: &TargetConfig{Events: []string{"test.started", "task.failed"}},
+			want:   false,
+		},
+		{
+			name:   "exact test id",
+			config: &TargetConfig{TestIDs: []string{"other", "devnet-sync-check"}},
+			want:   true,
+		},
+		{
+			name:   "test id glob",
+			config: &TargetConfig{TestIDs: []string{"devnet-*"}},
+			want:   true,
+		},
+		{
+			name:   "test id mismatch",
+			config: &TargetConfig{TestIDs: []string{"mainnet-*"}},
+			want:   false,
+		},
+		{
+			name:   "matching tag",
+			config: &TargetConfig{Tags: []string{"weekly", "sync"}},
+			want:   true,
+		},
+		{
+			name:   "tag mismatch",
+			config: &TargetConfig{Tags: []string{"weekly"}},
+			want:   false,
+		},
+		{
+			name:   "matching status",
+			config: &TargetConfig{Status: []string{"failure", "aborted"}},
+			want:   true,
+		},
+		{
+			name:   "status mismatch",
+			config: &TargetConfig{Status: []string{"success"}},
+			want:   false,
+		},
+		{
+			name: "all filters must match",
+			config: &TargetConfig{
+				Events:  []string{"test.failed"},
+				TestIDs: []string{"devnet-*"},
+				Tags:    []string{"nightly"},
+				Status:  []string{"success"},
+			},
+			want: false,
+		},
+	}
+
+	service := &Service{}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := service.matchTarget(tt.config, notification); got != tt.want {
+				t.Errorf("matchTarget() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestNotificationRedactsSecrets(t *testing.T) {
+	secret := "0x9a4e2c7b1d8f3a6e0c5b9d2f7a1e4c8b3d6f0a9e2c5b8d1f4a7e0c3b6d9f2a58"
+	secrets.Register(secret)
+
+	bodies := make(chan string, 10)
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies <- string(body)
+
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	eventBus := events.NewEventBus(logger)
+	if err := eventBus.Start(t.Context()); err != nil {
+		t.Fatalf("failed starting event bus: %v", err)
+	}
+
+	//nolint:errcheck // ignore
+	defer eventBus.Stop()
+
+	service, err := NewService(&Config{
+		Targets: []*TargetConfig{
+			{Name: "hook", URL: server.URL, Events: []string{"task.failed"}},
+			{Name: "slack", Type: TypeSlack, URL: server.URL, Events: []string{"task.failed"}},
+			{Name: "discord", Type: TypeDiscord, URL: server.URL, Events: []string{"task.failed"}},
+		},
+	}, &testCoordinator{database: newTestDatabase(t), eventBus: eventBus}, logger)
+	if err != nil {
+		t.Fatalf("failed creating service: %v", err)
+	}
+
+	service.Start(t.Context())
+	defer service.Stop()
+
+	eventBus.PublishTaskFailed(7, 1, "generate_transaction", "send tx", "tx", "failed sending tx with key "+secret)
+
+	for range 3 {
+		select {
+		case body := <-bodies:
+			if strings.Contains(body, strings.TrimPrefix(secret, "0x")) {
+				t.Errorf("secret in notification payload: %v", body)
+			}
+
+			if !strings.Contains(body, secrets.Mask) {
+				t.Errorf("no masked secret in notification payload: %v", body)
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatalf("notification not delivered")
+		}
+	}
+}
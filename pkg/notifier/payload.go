@@ -0,0 +1,149 @@
+package notifier
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+type slackPayload struct {
+	Text        string             `json:"text"`
+	Attachments []*slackAttachment `json:"attachments,omitempty"`
+}
+
+type slackAttachment struct {
+	Color  string        `json:"color"`
+	Title  string        `json:"title,omitempty"`
+	Link   string        `json:"title_link,omitempty"`
+	Fields []*slackField `json:"fields,omitempty"`
+	Footer string        `json:"footer,omitempty"`
+	TS     int64         `json:"ts,omitempty"`
+}
+
+type slackField struct {
+	Title string `json:"title"`
+	Value string `json:"value"`
+	Short bool   `json:"short"`
+}
+
+type discordPayload struct {
+	Embeds []*discordEmbed `json:"embeds,omitempty"`
+}
+
+type discordEmbed struct {
+	Title       string          `json:"title"`
+	URL         string          `json:"url,omitempty"`
+	Description string          `json:"description,omitempty"`
+	Color       int             `json:"color"`
+	Fields      []*discordField `json:"fields,omitempty"`
+	Timestamp   string          `json:"timestamp,omitempty"`
+}
+
+type discordField struct {
+	Name   string `json:"name"`
+	Value  string `json:"value"`
+	Inline bool   `json:"inline"`
+}
+
+// buildSlackPayload creates a Slack incoming webhook message.
+// A rendered template replaces the default message text.
+func buildSlackPayload(notification *Notification, message string) *slackPayload {
+	if message == "" {
+		message = getDefaultMessage(notification)
+	}
+
+	attachment := &slackAttachment{
+		Color:  fmt.Sprintf("#%06x", getStatusColor(notification)),
+		Title:  fmt.Sprintf("%v (run %v)", notification.TestName, notification.RunID),
+		Link:   notification.URL,
+		Footer: "assertoor",
+		TS:     notification.Timestamp.Unix(),
+	}
+
+	for _, field := range getMessageFields(notification) {
+		attachment.Fields = append(attachment.Fields, &slackField{
+			Title: field[0],
+			Value: field[1],
+			Short: len(field[1]) < 40,
+		})
+	}
+
+	return &slackPayload{
+		Text:        message,
+		Attachments: []*slackAttachment{attachment},
+	}
+}
+
+// buildDiscordPayload creates a Discord webhook message with a single embed.
+// A rendered template replaces the default message content.
+func buildDiscordPayload(notification *Notification, message string) *discordPayload {
+	if message == "" {
+		message = getDefaultMessage(notification)
+	}
+
+	embed := &discordEmbed{
+		Title:       fmt.Sprintf("%v (run %v)", notification.TestName, notification.RunID),
+		URL:         notification.URL,
+		Description: message,
+		Color:       getStatusColor(notification),
+		Timestamp:   notification.Timestamp.UTC().Format(time.RFC3339),
+	}
+
+	for _, field := range getMessageFields(notification) {
+		embed.Fields = append(embed.Fields, &discordField{
+			Name:   field[0],
+			Value:  field[1],
+			Inline: len(field[1]) < 40,
+		})
+	}
+
+	return &discordPayload{
+		Embeds: []*discordEmbed{embed},
+	}
+}
+
+func getDefaultMessage(notification *Notification) string {
+	switch notification.Event {
+	case string(events.EventTaskFailed):
+		return fmt.Sprintf("Task %v (%v) of test %v failed in run %v", notification.TaskTitle, notification.TaskName, notification.TestName, notification.RunID)
+	case string(events.EventTestStarted):
+		return fmt.Sprintf("Test %v started (run %v)", notification.TestName, notification.RunID)
+	case string(events.EventTestPaused):
+		return fmt.Sprintf("Test %v paused (run %v)", notification.TestName, notification.RunID)
+	case string(events.EventTestResumed):
+		return fmt.Sprintf("Test %v resumed (run %v)", notification.TestName, notification.RunID)
+	default:
+		return fmt.Sprintf("Test %v finished with status %v (run %v)", notification.TestName, notification.Status, notification.RunID)
+	}
+}
+
+func getMessageFields(notification *Notification) [][2]string {
+	fields := [][2]string{
+		{"Test", notification.TestID},
+		{"Status", notification.Status},
+		{"Event", notification.Event},
+	}
+
+	if notification.Error != "" {
+		fields = append(fields, [2]string{"Error", notification.Error})
+	}
+
+	return fields
+}
+
+func getStatusColor(notification *Notification) int {
+	switch types.TestStatus(notification.Status) {
+	case types.TestStatusSuccess:
+		return 0x2eb67d
+	case types.TestStatusFailure, types.TestStatusAborted:
+		return 0xe01e5a
+	case types.TestStatusSkipped:
+		return 0x9e9e9e
+	case types.TestStatusPending, types.TestStatusRunning:
+		return 0x36c5f0
+	}
+
+	return 0x36c5f0
+}
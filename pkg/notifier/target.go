@@ -0,0 +1,248 @@
+package notifier
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"text/template"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/google/uuid"
+	"github.com/jmoiron/sqlx"
+)
+
+// Delivery states recorded in the database.
+const (
+	DeliveryStatusPending = "pending"
+	DeliveryStatusSuccess = "success"
+	DeliveryStatusFailed  = "failed"
+)
+
+// SignatureHeader carries the HMAC-SHA256 signature of the request body for targets with a secret.
+const SignatureHeader = "X-Assertoor-Signature"
+
+type target struct {
+	config     *TargetConfig
+	template   *template.Template
+	httpClient *http.Client
+}
+
+func newTarget(config *TargetConfig) (*target, error) {
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("notification target '%s': %w", config.Name, err)
+	}
+
+	t := &target{
+		config: config,
+		httpClient: &http.Client{
+			Timeout: config.getTimeout(),
+		},
+	}
+
+	if config.Template != "" {
+		tmpl, err := template.New(config.Name).Parse(config.Template)
+		if err != nil {
+			return nil, fmt.Errorf("notification target '%s': invalid template: %w", config.Name, err)
+		}
+
+		t.template = tmpl
+	}
+
+	return t, nil
+}
+
+// deliver sends the notification to the target, retrying failed attempts with exponential backoff.
+// The delivery state is persisted after every attempt.
+func (s *Service) deliver(ctx context.Context, t *target, notification *Notification) {
+	logger := s.logger.WithField("target", t.config.Name).WithField("event", notification.Event).WithField("run", notification.RunID)
+
+	delivery := &db.NotificationDelivery{
+		DeliveryID: uuid.New().String(),
+		Notifier:   t.config.Name,
+		EventType:  notification.Event,
+		RunID:      notification.RunID,
+		TestID:     notification.TestID,
+		Status:     DeliveryStatusPending,
+		CreateTime: time.Now().UnixMilli(),
+	}
+
+	body, contentType, err := t.buildPayload(notification)
+	if err != nil {
+		logger.Errorf("failed building notification payload: %v", err)
+
+		delivery.Status = DeliveryStatusFailed
+		delivery.Error = err.Error()
+		s.saveDelivery(delivery)
+
+		return
+	}
+
+	maxRetries := t.config.getMaxRetries()
+	retryDelay := t.config.getRetryDelay()
+
+	for attempt := 0; attempt <= maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(retryDelay):
+			}
+
+			retryDelay = nextRetryDelay(retryDelay)
+		}
+
+		statusCode, retryable, err := t.send(ctx, delivery.DeliveryID, notification.Event, body, contentType)
+
+		delivery.Attempts = attempt + 1
+		delivery.ResponseCode = statusCode
+		delivery.UpdateTime = time.Now().UnixMilli()
+
+		if err == nil {
+			delivery.Status = DeliveryStatusSuccess
+			delivery.Error = ""
+			s.saveDelivery(delivery)
+
+			logger.Debugf("notification delivered (attempt %v)", attempt+1)
+
+			return
+		}
+
+		delivery.Error = err.Error()
+
+		if !retryable || attempt == maxRetries {
+			delivery.Status = DeliveryStatusFailed
+			s.saveDelivery(delivery)
+
+			logger.Warnf("notification delivery failed after %v attempts: %v", attempt+1, err)
+
+			return
+		}
+
+		s.saveDelivery(delivery)
+
+		logger.Infof("notification delivery failed (attempt %v), retrying in %v: %v", attempt+1, retryDelay, err)
+	}
+}
+
+// nextRetryDelay doubles the retry delay, capped at maxRetryDelay.
+// Configured delays above the cap are kept as they are.
+func nextRetryDelay(retryDelay time.Duration) time.Duration {
+	if retryDelay >= maxRetryDelay {
+		return retryDelay
+	}
+
+	return min(retryDelay*2, maxRetryDelay)
+}
+
+func (s *Service) saveDelivery(delivery *db.NotificationDelivery) {
+	database := s.coordinator.Database()
+	if database == nil {
+		return
+	}
+
+	err := database.RunTransaction(func(tx *sqlx.Tx) error {
+		return database.UpsertNotificationDelivery(tx, delivery)
+	})
+	if err != nil {
+		s.logger.Warnf("failed saving notification delivery: %v", err)
+	}
+}
+
+// send posts the payload to the target URL.
+// It returns the response status code and whether a failed request should be retried.
+func (t *target) send(ctx context.Context, deliveryID, eventType string, body []byte, contentType string) (statusCode int, retryable bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, false, fmt.Errorf("failed creating request: %w", err)
+	}
+
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", "assertoor-notifier")
+	req.Header.Set("X-Assertoor-Event", eventType)
+	req.Header.Set("X-Assertoor-Delivery", deliveryID)
+
+	for key, value := range t.config.Headers {
+		req.Header.Set(key, value)
+	}
+
+	if t.config.Secret != "" {
+		req.Header.Set(SignatureHeader, "sha256="+Sign(t.config.Secret, body))
+	}
+
+	resp, err := t.httpClient.Do(req)
+	if err != nil {
+		return 0, true, fmt.Errorf("request failed: %w", err)
+	}
+
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		//nolint:errcheck // ignore
+		io.Copy(io.Discard, resp.Body)
+
+		return resp.StatusCode, false, nil
+	}
+
+	respBody, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	retryable = resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == http.StatusRequestTimeout
+
+	return resp.StatusCode, retryable, fmt.Errorf("unexpected response status %v: %v", resp.StatusCode, strings.TrimSpace(string(respBody)))
+}
+
+// Sign returns the hex encoded HMAC-SHA256 signature of the body.
+func Sign(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// buildPayload renders the request body for the target type.
+func (t *target) buildPayload(notification *Notification) (body []byte, contentType string, err error) {
+	message := ""
+
+	if t.template != nil {
+		buf := &bytes.Buffer{}
+		if execErr := t.template.Execute(buf, notification); execErr != nil {
+			return nil, "", fmt.Errorf("failed rendering template: %w", execErr)
+		}
+
+		message = buf.String()
+	}
+
+	switch t.config.getType() {
+	case TypeSlack:
+		body, err = json.Marshal(buildSlackPayload(notification, message))
+	case TypeDiscord:
+		body, err = json.Marshal(buildDiscordPayload(notification, message))
+	default:
+		if t.template != nil {
+			contentType = "text/plain"
+			if json.Valid([]byte(message)) {
+				contentType = "application/json"
+			}
+
+			return []byte(message), contentType, nil
+		}
+
+		body, err = json.Marshal(notification)
+	}
+
+	if err != nil {
+		return nil, "", fmt.Errorf("failed encoding payload: %w", err)
+	}
+
+	return body, "application/json", nil
+}
+
+func getTestRunURL(publicURL string, runID uint64) string {
+	return fmt.Sprintf("%v/run/%v", strings.TrimRight(publicURL, "/"), runID)
+}
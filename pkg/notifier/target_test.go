@@ -0,0 +1,455 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+type testCoordinator struct {
+	types.Coordinator
+	database *db.Database
+	eventBus *events.EventBus
+}
+
+func (c *testCoordinator) Database() *db.Database {
+	return c.database
+}
+
+func (c *testCoordinator) EventBus() *events.EventBus {
+	return c.eventBus
+}
+
+func (c *testCoordinator) GetTestByRunID(uint64) types.Test {
+	return nil
+}
+
+func (c *testCoordinator) TestRegistry() types.TestRegistry {
+	return nil
+}
+
+func newTestDatabase(t *testing.T) *db.Database {
+	t.Helper()
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	database := db.NewDatabase(logger)
+
+	err := database.InitDB(&db.DatabaseConfig{
+		Engine: "sqlite",
+		Sqlite: &db.SqliteDatabaseConfig{
+			File: filepath.Join(t.TempDir(), "assertoor.db"),
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed initializing database: %v", err)
+	}
+
+	t.Cleanup(func() {
+		//nolint:errcheck // ignore
+		database.CloseDB()
+	})
+
+	if err := database.ApplySchema(-2); err != nil {
+		t.Fatalf("failed applying database schema: %v", err)
+	}
+
+	return database
+}
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name   string
+		secret string
+		body   string
+		want   string
+	}{
+		{
+			name:   "known signature",
+			secret: "secret",
+			body:   `{"event":"test.failed"}`,
+			want:   "2a2c4270fac464aa72a8ba1f08caf5a3000143c2978231af0cd62cb95e644c3c",
+		},
+		{
+			name:   "different secret",
+			secret: "other",
+			body:   `{"event":"test.failed"}`,
+		},
+		{
+			name:   "different body",
+			secret: "secret",
+			body:   `{"event":"test.completed"}`,
+		},
+	}
+
+	reference := Sign("secret", []byte(`{"event":"test.failed"}`))
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Sign(tt.secret, []byte(tt.body))
+
+			if len(got) != 64 {
+				t.Errorf("signature %q is not a hex encoded sha256 hash", got)
+			}
+
+			if tt.want != "" && got != tt.want {
+				t.Errorf("Sign() = %v, want %v", got, tt.want)
+			}
+
+			if tt.want == "" && got == reference {
+				t.Errorf("signature does not depend on secret and body")
+			}
+		})
+	}
+}
+
+func TestNextRetryDelay(t *testing.T) {
+	tests := []struct {
+		name  string
+		delay time.Duration
+		want  time.Duration
+	}{
+		{name: "doubled", delay: 5 * time.Second, want: 10 * time.Second},
+		{name: "capped", delay: 4 * time.Minute, want: maxRetryDelay},
+		{name: "at cap", delay: maxRetryDelay, want: maxRetryDelay},
+		{name: "configured above cap", delay: 10 * time.Minute, want: 10 * time.Minute},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := nextRetryDelay(tt.delay); got != tt.want {
+				t.Errorf("nextRetryDelay(%v) = %v, want %v", tt.delay, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPayload(t *testing.T) {
+	notification := &Notification{
+		Event:     "test.failed",
+		EventID:   12,
+		Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		RunID:     7,
+		TestID:    "test1",
+		TestName:  "Test 1",
+		Status:    string(types.TestStatusFailure),
+		Tags:      []string{"nightly"},
+		Error:     "task failed",
+		URL:       "https://assertoor.example.com/run/7",
+	}
+
+	tests := []struct {
+		name            string
+		config          *TargetConfig
+		wantContentType string
+		wantBody        string
+		wantFields      map[string]any
+	}{
+		{
+			name:            "webhook",
+			config:          &TargetConfig{Name: "hook", URL: "http://localhost"},
+			wantContentType: "application/json",
+			wantFields: map[string]any{
+				"event":  "test.failed",
+				"runId":  float64(7),
+				"testId": "test1",
+				"status": "failure",
+				"error":  "task failed",
+				"url":    "https://assertoor.example.com/run/7",
+			},
+		},
+		{
+			name:            "webhook with json template",
+			config:          &TargetConfig{Name: "hook", URL: "http://localhost", Template: `{"run": {{ .RunID }}}`},
+			wantContentType: "application/json",
+			wantBody:        `{"run": 7}`,
+		},
+		{
+			name:            "webhook with text template",
+			config:          &TargetConfig{Name: "hook", URL: "http://localhost", Template: `{{ .TestName }} is {{ .Status }}`},
+			wantContentType: "text/plain",
+			wantBody:        "Test 1 is failure",
+		},
+		{
+			name:            "slack",
+			config:          &TargetConfig{Name: "slack", Type: TypeSlack, URL: "http://localhost"},
+			wantContentType: "application/json",
+			wantFields: map[string]any{
+				"text": "Test Test 1 finished with status failure (run 7)",
+			},
+		},
+		{
+			name:            "slack with template",
+			config:          &TargetConfig{Name: "slack", Type: TypeSlack, URL: "http://localhost", Template: `run {{ .RunID }} failed`},
+			wantContentType: "application/json",
+			wantFields: map[string]any{
+				"text": "run 7 failed",
+			},
+		},
+		{
+			name:            "discord",
+			config:          &TargetConfig{Name: "discord", Type: TypeDiscord, URL: "http://localhost"},
+			wantContentType: "application/json",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			notifyTarget, err := newTarget(tt.config)
+			if err != nil {
+				t.Fatalf("failed creating target: %v", err)
+			}
+
+			body, contentType, err := notifyTarget.buildPayload(notification)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if contentType != tt.wantContentType {
+				t.Errorf("content type = %v, want %v", contentType, tt.wantContentType)
+			}
+
+			if tt.wantBody != "" && string(body) != tt.wantBody {
+				t.Errorf("body = %v, want %v", string(body), tt.wantBody)
+			}
+
+			if tt.wantFields != nil {
+				fields := map[string]any{}
+				if err := json.Unmarshal(body, &fields); err != nil {
+					t.Fatalf("failed decoding body: %v", err)
+				}
+
+				for key, want := range tt.wantFields {
+					if fields[key] != want {
+						t.Errorf("field %v = %v, want %v", key, fields[key], want)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestBuildChatPayloads(t *testing.T) {
+	notification := &Notification{
+		Event:     "task.failed",
+		Timestamp: time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC),
+		RunID:     7,
+		TestID:    "test1",
+		TestName:  "Test 1",
+		Status:    string(types.TestStatusFailure),
+		TaskName:  "run_shell",
+		TaskTitle: "check",
+		Error:     "exit code 1",
+		URL:       "https://assertoor.example.com/run/7",
+	}
+
+	slack := buildSlackPayload(notification, "")
+	if slack.Text != "Task check (run_shell) of test Test 1 failed in run 7" {
+		t.Errorf("unexpected slack text: %v", slack.Text)
+	}
+
+	if len(slack.Attachments) != 1 {
+		t.Fatalf("expected 1 slack attachment, got %v", len(slack.Attachments))
+	}
+
+	attachment := slack.Attachments[0]
+	if attachment.Color != "#e01e5a" || attachment.Title != "Test 1 (run 7)" || attachment.Link != notification.URL {
+		t.Errorf("unexpected slack attachment: %+v", attachment)
+	}
+
+	if len(attachment.Fields) != 4 || attachment.Fields[3].Title != "Error" || attachment.Fields[3].Value != "exit code 1" {
+		t.Errorf("unexpected slack fields: %+v", attachment.Fields)
+	}
+
+	discord := buildDiscordPayload(notification, "custom message")
+	if len(discord.Embeds) != 1 {
+		t.Fatalf("expected 1 discord embed, got %v", len(discord.Embeds))
+	}
+
+	embed := discord.Embeds[0]
+	if embed.Description != "custom message" || embed.Color != 0xe01e5a || embed.Timestamp != "2024-01-01T12:00:00Z" {
+		t.Errorf("unexpected discord embed: %+v", embed)
+	}
+
+	if len(embed.Fields) != 4 || embed.Fields[0].Name != "Test" || embed.Fields[0].Value != "test1" {
+		t.Errorf("unexpected discord fields: %+v", embed.Fields)
+	}
+}
+
+func TestDeliver(t *testing.T) {
+	tests := []struct {
+		name         string
+		responses    []int
+		maxRetries   int
+		wantRequests int
+		wantStatus   string
+		wantCode     int
+	}{
+		{
+			name:         "delivered",
+			responses:    []int{http.StatusOK},
+			maxRetries:   3,
+			wantRequests: 1,
+			wantStatus:   DeliveryStatusSuccess,
+			wantCode:     http.StatusOK,
+		},
+		{
+			name:         "delivered after retries",
+			responses:    []int{http.StatusInternalServerError, http.StatusTooManyRequests, http.StatusNoContent},
+			maxRetries:   3,
+			wantRequests: 3,
+			wantStatus:   DeliveryStatusSuccess,
+			wantCode:     http.StatusNoContent,
+		},
+		{
+			name:         "retries exhausted",
+			responses:    []int{http.StatusBadGateway, http.StatusBadGateway, http.StatusBadGateway},
+			maxRetries:   2,
+			wantRequests: 3,
+			wantStatus:   DeliveryStatusFailed,
+			wantCode:     http.StatusBadGateway,
+		},
+		{
+			name:         "client error is not retried",
+			responses:    []int{http.StatusBadRequest},
+			maxRetries:   3,
+			wantRequests: 1,
+			wantStatus:   DeliveryStatusFailed,
+			wantCode:     http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var (
+				mutex    sync.Mutex
+				requests int
+			)
+
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, _ := io.ReadAll(r.Body)
+
+				if r.Header.Get(SignatureHeader) != "sha256="+Sign("secret", body) {
+					t.Errorf("invalid signature header: %v", r.Header.Get(SignatureHeader))
+				}
+
+				if r.Header.Get("X-Assertoor-Event") != "test.failed" {
+					t.Errorf("invalid event header: %v", r.Header.Get("X-Assertoor-Event"))
+				}
+
+				mutex.Lock()
+				status := tt.responses[min(requests, len(tt.responses)-1)]
+				requests++
+				mutex.Unlock()
+
+				w.WriteHeader(status)
+			}))
+			defer server.Close()
+
+			maxRetries := tt.maxRetries
+			notifyTarget, err := newTarget(&TargetConfig{
+				Name:       "hook",
+				URL:        server.URL,
+				Secret:     "secret",
+				MaxRetries: &maxRetries,
+				RetryDelay: helper.Duration{Duration: time.Millisecond},
+			})
+			if err != nil {
+				t.Fatalf("failed creating target: %v", err)
+			}
+
+			logger := logrus.New()
+			logger.SetOutput(io.Discard)
+
+			database := newTestDatabase(t)
+			service := &Service{
+				config:      &Config{},
+				logger:      logger,
+				coordinator: &testCoordinator{database: database},
+			}
+
+			service.deliver(context.Background(), notifyTarget, &Notification{
+				Event:  "test.failed",
+				RunID:  7,
+				TestID: "test1",
+			})
+
+			if requests != tt.wantRequests {
+				t.Errorf("requests = %v, want %v", requests, tt.wantRequests)
+			}
+
+			deliveries, err := database.GetNotificationDeliveriesByRunID(7)
+			if err != nil {
+				t.Fatalf("failed loading deliveries: %v", err)
+			}
+
+			if len(deliveries) != 1 {
+				t.Fatalf("expected 1 delivery, got %v", len(deliveries))
+			}
+
+			delivery := deliveries[0]
+			if delivery.Status != tt.wantStatus || delivery.Attempts != tt.wantRequests || delivery.ResponseCode != tt.wantCode {
+				t.Errorf("unexpected delivery: status %v, attempts %v, code %v", delivery.Status, delivery.Attempts, delivery.ResponseCode)
+			}
+
+			if tt.wantStatus == DeliveryStatusFailed && !strings.Contains(delivery.Error, "unexpected response status") {
+				t.Errorf("unexpected delivery error: %v", delivery.Error)
+			}
+		})
+	}
+}
+
+func TestDeliverCancelled(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	notifyTarget, err := newTarget(&TargetConfig{
+		Name:       "hook",
+		URL:        server.URL,
+		RetryDelay: helper.Duration{Duration: time.Hour},
+	})
+	if err != nil {
+		t.Fatalf("failed creating target: %v", err)
+	}
+
+	logger := logrus.New()
+	logger.SetOutput(io.Discard)
+
+	service := &Service{
+		config:      &Config{},
+		logger:      logger,
+		coordinator: &testCoordinator{},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+
+	go func() {
+		service.deliver(ctx, notifyTarget, &Notification{Event: "test.failed", RunID: 7})
+		close(done)
+	}()
+
+	cancel()
+
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatalf("delivery did not stop after context cancellation")
+	}
+}
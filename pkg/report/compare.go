@@ -0,0 +1,431 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+	"reflect"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+// Comparison is the result of comparing two test runs.
+// Tasks of both runs are aligned by task ID, or by their title path for tasks without ID.
+type Comparison struct {
+	A       *ComparedRun      `json:"a"`
+	B       *ComparedRun      `json:"b"`
+	Summary ComparisonSummary `json:"summary"`
+	Config  []*ValueDiff      `json:"config"`
+	Clients []*ClientDiff     `json:"clients"`
+	Tasks   []*TaskDiff       `json:"tasks"`
+}
+
+// ComparedRun holds the metadata of one of the compared test runs.
+type ComparedRun struct {
+	RunID     uint64                 `json:"run_id"`
+	TestID    string                 `json:"test_id"`
+	Name      string                 `json:"name"`
+	Status    types.TestStatus       `json:"status"`
+	StartTime int64                  `json:"start_time"`
+	StopTime  int64                  `json:"stop_time"`
+	Duration  int64                  `json:"duration"`
+	Clients   []*types.ClientVersion `json:"clients"`
+}
+
+// ComparisonSummary counts the aligned tasks by their kind of difference.
+type ComparisonSummary struct {
+	StatusChanged bool `json:"status_changed"`
+	Tasks         int  `json:"tasks"`
+	OnlyInA       int  `json:"only_in_a"`
+	OnlyInB       int  `json:"only_in_b"`
+	ResultChanged int  `json:"result_changed"`
+	ConfigChanged int  `json:"config_changed"`
+	OutputChanged int  `json:"output_changed"`
+	ClientChanged int  `json:"client_changed"`
+}
+
+// ValueDiff is a value that differs between the two runs. Key is the dotted path of the value.
+type ValueDiff struct {
+	Key string `json:"key"`
+	A   any    `json:"a"`
+	B   any    `json:"b"`
+}
+
+// ClientDiff compares the endpoint versions seen at the start of both runs.
+type ClientDiff struct {
+	Name       string `json:"name"`
+	AConsensus string `json:"a_consensus,omitempty"`
+	BConsensus string `json:"b_consensus,omitempty"`
+	AExecution string `json:"a_execution,omitempty"`
+	BExecution string `json:"b_execution,omitempty"`
+	Changed    bool   `json:"changed"`
+}
+
+// TaskDiff is a pair of aligned tasks. A or B is nil if the task only ran in one of the runs.
+type TaskDiff struct {
+	Key           string        `json:"key"`
+	Depth         int           `json:"depth"`
+	Name          string        `json:"name"`
+	Title         string        `json:"title"`
+	Cleanup       bool          `json:"cleanup,omitempty"`
+	A             *ComparedTask `json:"a"`
+	B             *ComparedTask `json:"b"`
+	ResultChanged bool          `json:"result_changed"`
+	DurationDelta int64         `json:"duration_delta"`
+	Config        []*ValueDiff  `json:"config,omitempty"`
+	Outputs       []*ValueDiff  `json:"outputs,omitempty"`
+}
+
+// ComparedTask holds the result of a task in one of the compared runs.
+type ComparedTask struct {
+	Index    uint64 `json:"index"`
+	Status   string `json:"status"`
+	Duration int64  `json:"duration"`
+	Error    string `json:"error,omitempty"`
+}
+
+type comparedTaskData struct {
+	report  *TaskReport
+	key     string
+	cleanup bool
+	config  map[string]any
+	outputs map[string]any
+}
+
+// CompareRuns compares two test runs task by task.
+// Run level config and endpoint versions are loaded from the test run records in the database.
+func CompareRuns(database *db.Database, testA, testB types.Test) (*Comparison, error) {
+	dbRunA, err := database.GetTestRunByRunID(testA.RunID())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test run %v: %w", testA.RunID(), err)
+	}
+
+	dbRunB, err := database.GetTestRunByRunID(testB.RunID())
+	if err != nil {
+		return nil, fmt.Errorf("failed loading test run %v: %w", testB.RunID(), err)
+	}
+
+	reportA := NewTestReport(testA, false)
+	reportB := NewTestReport(testB, false)
+
+	comparison := &Comparison{
+		A: newComparedRun(reportA, dbRunA),
+		B: newComparedRun(reportB, dbRunB),
+	}
+
+	comparison.Summary.StatusChanged = comparison.A.Status != comparison.B.Status
+	comparison.Config = diffValues(parseYamlMap(dbRunA.Config), parseYamlMap(dbRunB.Config))
+	comparison.Clients = diffClients(comparison.A.Clients, comparison.B.Clients)
+	comparison.Tasks = alignTasks(collectComparedTasks(testA, reportA), collectComparedTasks(testB, reportB))
+
+	for _, clientDiff := range comparison.Clients {
+		if clientDiff.Changed {
+			comparison.Summary.ClientChanged++
+		}
+	}
+
+	for _, taskDiff := range comparison.Tasks {
+		comparison.Summary.Tasks++
+
+		switch {
+		case taskDiff.A == nil:
+			comparison.Summary.OnlyInB++
+		case taskDiff.B == nil:
+			comparison.Summary.OnlyInA++
+		case taskDiff.ResultChanged:
+			comparison.Summary.ResultChanged++
+		}
+
+		if len(taskDiff.Config) > 0 {
+			comparison.Summary.ConfigChanged++
+		}
+
+		if len(taskDiff.Outputs) > 0 {
+			comparison.Summary.OutputChanged++
+		}
+	}
+
+	return comparison, nil
+}
+
+func newComparedRun(report *TestReport, dbRun *db.TestRun) *ComparedRun {
+	run := &ComparedRun{
+		RunID:    report.RunID,
+		TestID:   report.TestID,
+		Name:     report.Name,
+		Status:   report.Status,
+		Duration: report.Duration().Milliseconds(),
+		Clients:  []*types.ClientVersion{},
+	}
+
+	if !report.StartTime.IsZero() {
+		run.StartTime = report.StartTime.Unix()
+	}
+
+	if !report.StopTime.IsZero() {
+		run.StopTime = report.StopTime.Unix()
+	}
+
+	if dbRun.ClientVersions != "" {
+		//nolint:errcheck // ignore broken snapshots
+		yaml.Unmarshal([]byte(dbRun.ClientVersions), &run.Clients)
+	}
+
+	return run
+}
+
+func collectComparedTasks(test types.Test, report *TestReport) []*comparedTaskData {
+	tasks := make([]*comparedTaskData, 0, len(report.Tasks)+len(report.Cleanup))
+	keys := map[uint64]string{}
+	keyCounts := map[string]int{}
+	taskScheduler := test.GetTaskScheduler()
+
+	addTasks := func(taskReports []*TaskReport, cleanup bool) {
+		for _, taskReport := range taskReports {
+			key := ""
+
+			switch {
+			case taskReport.ID != "":
+				key = "id:" + taskReport.ID
+			case keys[taskReport.ParentIndex] != "" && taskReport.Depth > 0:
+				key = keys[taskReport.ParentIndex] + "/" + taskReport.Title
+			case cleanup:
+				key = "cleanup/" + taskReport.Title
+			default:
+				key = "/" + taskReport.Title
+			}
+
+			// tasks with the same key (e.g. created in a loop) are matched in order of appearance
+			keyCounts[key]++
+			if keyCounts[key] > 1 {
+				key = fmt.Sprintf("%v#%v", key, keyCounts[key])
+			}
+
+			keys[taskReport.Index] = key
+
+			task := &comparedTaskData{
+				report:  taskReport,
+				key:     key,
+				cleanup: cleanup,
+			}
+
+			if taskScheduler != nil {
+				if taskState := taskScheduler.GetTaskState(types.TaskIndex(taskReport.Index)); taskState != nil {
+					task.config = toValueMap(secrets.RedactConfig(taskState.Config()))
+
+					if statusVars := taskState.GetTaskStatusVars(); statusVars != nil {
+						task.outputs = toValueMap(statusVars.GetSubScope("outputs").GetVarsMap(nil, false))
+					}
+				}
+			}
+
+			tasks = append(tasks, task)
+		}
+	}
+
+	addTasks(report.Tasks, false)
+	addTasks(report.Cleanup, true)
+
+	return tasks
+}
+
+// alignTasks merges the task lists of both runs by task key.
+// The order of run A is kept, tasks that only exist in run B are inserted behind their preceding task of run B.
+func alignTasks(tasksA, tasksB []*comparedTaskData) []*TaskDiff {
+	type alignedTask struct {
+		diff  *TaskDiff
+		taskA *comparedTaskData
+	}
+
+	aligned := make([]*alignedTask, 0, len(tasksA))
+	alignedByKey := map[string]*alignedTask{}
+
+	for _, task := range tasksA {
+		entry := &alignedTask{
+			diff:  newTaskDiff(task),
+			taskA: task,
+		}
+		entry.diff.A = newComparedTask(task.report)
+
+		aligned = append(aligned, entry)
+		alignedByKey[task.key] = entry
+	}
+
+	var lastEntry *alignedTask
+
+	for _, task := range tasksB {
+		if entry, ok := alignedByKey[task.key]; ok {
+			entry.diff.B = newComparedTask(task.report)
+			finishTaskDiff(entry.diff, entry.taskA, task)
+
+			lastEntry = entry
+
+			continue
+		}
+
+		entry := &alignedTask{
+			diff: newTaskDiff(task),
+		}
+		entry.diff.B = newComparedTask(task.report)
+
+		insertAt := 0
+		if lastEntry != nil {
+			insertAt = slices.Index(aligned, lastEntry) + 1
+		}
+
+		aligned = slices.Insert(aligned, insertAt, entry)
+		lastEntry = entry
+	}
+
+	diffs := make([]*TaskDiff, len(aligned))
+	for idx, entry := range aligned {
+		diffs[idx] = entry.diff
+	}
+
+	return diffs
+}
+
+func newTaskDiff(task *comparedTaskData) *TaskDiff {
+	return &TaskDiff{
+		Key:     task.key,
+		Depth:   task.report.Depth,
+		Name:    task.report.Name,
+		Title:   task.report.Title,
+		Cleanup: task.cleanup,
+	}
+}
+
+func newComparedTask(taskReport *TaskReport) *ComparedTask {
+	return &ComparedTask{
+		Index:    taskReport.Index,
+		Status:   taskReport.Status,
+		Duration: taskReport.Duration.Milliseconds(),
+		Error:    taskReport.Error,
+	}
+}
+
+func finishTaskDiff(diff *TaskDiff, taskA, taskB *comparedTaskData) {
+	diff.ResultChanged = diff.A.Status != diff.B.Status
+	diff.DurationDelta = (taskB.report.Duration - taskA.report.Duration).Round(time.Millisecond).Milliseconds()
+	diff.Config = diffValues(taskA.config, taskB.config)
+	diff.Outputs = diffValues(taskA.outputs, taskB.outputs)
+}
+
+func diffClients(clientsA, clientsB []*types.ClientVersion) []*ClientDiff {
+	diffs := []*ClientDiff{}
+	diffMap := map[string]*ClientDiff{}
+
+	getDiff := func(name string) *ClientDiff {
+		if diff, ok := diffMap[name]; ok {
+			return diff
+		}
+
+		diff := &ClientDiff{Name: name}
+		diffMap[name] = diff
+		diffs = append(diffs, diff)
+
+		return diff
+	}
+
+	for _, client := range clientsA {
+		diff := getDiff(client.Name)
+		diff.AConsensus = client.ConsensusVersion
+		diff.AExecution = client.ExecutionVersion
+	}
+
+	for _, client := range clientsB {
+		diff := getDiff(client.Name)
+		diff.BConsensus = client.ConsensusVersion
+		diff.BExecution = client.ExecutionVersion
+	}
+
+	for _, diff := range diffs {
+		diff.Changed = diff.AConsensus != diff.BConsensus || diff.AExecution != diff.BExecution
+	}
+
+	return diffs
+}
+
+// diffValues returns the differences between two flattened value maps, sorted by key.
+func diffValues(valuesA, valuesB map[string]any) []*ValueDiff {
+	diffs := []*ValueDiff{}
+
+	for key, valueA := range valuesA {
+		valueB, ok := valuesB[key]
+		if !ok || !reflect.DeepEqual(valueA, valueB) {
+			diffs = append(diffs, &ValueDiff{Key: key, A: valueA, B: valueB})
+		}
+	}
+
+	for key, valueB := range valuesB {
+		if _, ok := valuesA[key]; !ok {
+			diffs = append(diffs, &ValueDiff{Key: key, A: nil, B: valueB})
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Key < diffs[j].Key
+	})
+
+	return diffs
+}
+
+// toValueMap converts a config struct or variables map to a flat map with dotted keys.
+// Values are normalized via a json round trip, so structs and maps compare equally.
+func toValueMap(value any) map[string]any {
+	if value == nil {
+		return nil
+	}
+
+	jsonData, err := json.Marshal(value)
+	if err != nil {
+		return nil
+	}
+
+	var decoded any
+	if err := json.Unmarshal(jsonData, &decoded); err != nil {
+		return nil
+	}
+
+	values := map[string]any{}
+	flattenValue("", decoded, values)
+
+	return values
+}
+
+func parseYamlMap(data string) map[string]any {
+	if data == "" {
+		return nil
+	}
+
+	parsed := map[string]any{}
+	if err := yaml.Unmarshal([]byte(data), &parsed); err != nil {
+		return nil
+	}
+
+	return toValueMap(parsed)
+}
+
+func flattenValue(prefix string, value any, values map[string]any) {
+	mapValue, ok := value.(map[string]any)
+	if !ok || len(mapValue) == 0 {
+		if prefix != "" {
+			values[prefix] = value
+		}
+
+		return
+	}
+
+	for key, item := range mapValue {
+		if prefix != "" {
+			key = prefix + "." + key
+		}
+
+		flattenValue(key, item, values)
+	}
+}
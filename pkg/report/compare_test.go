@@ -0,0 +1,282 @@
+package report
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+// testCompareRun is a test run without task scheduler, so only the task reports are compared.
+type testCompareRun struct {
+	types.Test
+}
+
+func (testCompareRun) GetTaskScheduler() types.TaskScheduler {
+	return nil
+}
+
+func TestAlignTasks(t *testing.T) {
+	baseTasks := []*TaskReport{
+		{Index: 1, Depth: 0, Title: "setup", Status: TaskStatusSuccess},
+		{Index: 2, Depth: 0, Title: "run", Status: TaskStatusSuccess},
+		{Index: 3, ParentIndex: 2, Depth: 1, Title: "check", Status: TaskStatusSuccess},
+		{Index: 4, Depth: 0, Title: "teardown", Status: TaskStatusSuccess},
+	}
+
+	tests := []struct {
+		name     string
+		tasksA   []*TaskReport
+		tasksB   []*TaskReport
+		cleanupA []*TaskReport
+		cleanupB []*TaskReport
+		want     []string
+	}{
+		{
+			name:   "identical runs",
+			tasksA: baseTasks,
+			tasksB: baseTasks,
+			want:   []string{"/setup AB", "/run AB", "/run/check AB", "/teardown AB"},
+		},
+		{
+			name:   "task added in b",
+			tasksA: baseTasks,
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "setup"},
+				{Index: 2, Depth: 0, Title: "run"},
+				{Index: 3, ParentIndex: 2, Depth: 1, Title: "check"},
+				{Index: 4, ParentIndex: 2, Depth: 1, Title: "extra"},
+				{Index: 5, Depth: 0, Title: "teardown"},
+			},
+			want: []string{"/setup AB", "/run AB", "/run/check AB", "/run/extra B", "/teardown AB"},
+		},
+		{
+			name:   "task added before all tasks in b",
+			tasksA: baseTasks,
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "init"},
+				{Index: 2, Depth: 0, Title: "setup"},
+				{Index: 3, Depth: 0, Title: "run"},
+				{Index: 4, ParentIndex: 3, Depth: 1, Title: "check"},
+				{Index: 5, Depth: 0, Title: "teardown"},
+			},
+			want: []string{"/init B", "/setup AB", "/run AB", "/run/check AB", "/teardown AB"},
+		},
+		{
+			name:   "task removed in b",
+			tasksA: baseTasks,
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "setup"},
+				{Index: 2, Depth: 0, Title: "run"},
+				{Index: 3, Depth: 0, Title: "teardown"},
+			},
+			want: []string{"/setup AB", "/run AB", "/run/check A", "/teardown AB"},
+		},
+		{
+			name:   "reordered tasks keep the order of a",
+			tasksA: baseTasks,
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "teardown"},
+				{Index: 2, Depth: 0, Title: "run"},
+				{Index: 3, ParentIndex: 2, Depth: 1, Title: "check"},
+				{Index: 4, Depth: 0, Title: "setup"},
+			},
+			want: []string{"/setup AB", "/run AB", "/run/check AB", "/teardown AB"},
+		},
+		{
+			name:   "moved child task",
+			tasksA: baseTasks,
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "setup"},
+				{Index: 2, ParentIndex: 1, Depth: 1, Title: "check"},
+				{Index: 3, Depth: 0, Title: "run"},
+				{Index: 4, Depth: 0, Title: "teardown"},
+			},
+			want: []string{"/setup AB", "/setup/check B", "/run AB", "/run/check A", "/teardown AB"},
+		},
+		{
+			name: "repeated titles are matched in order",
+			tasksA: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "step"},
+				{Index: 2, Depth: 0, Title: "step"},
+			},
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, Title: "step"},
+				{Index: 2, Depth: 0, Title: "step"},
+				{Index: 3, Depth: 0, Title: "step"},
+			},
+			want: []string{"/step AB", "/step#2 AB", "/step#3 B"},
+		},
+		{
+			name: "task ids take precedence over titles",
+			tasksA: []*TaskReport{
+				{Index: 1, Depth: 0, ID: "wait", Title: "wait 2 epochs"},
+			},
+			tasksB: []*TaskReport{
+				{Index: 1, Depth: 0, ID: "wait", Title: "wait 3 epochs"},
+			},
+			want: []string{"id:wait AB"},
+		},
+		{
+			name:     "cleanup tasks are aligned separately",
+			tasksA:   []*TaskReport{{Index: 1, Depth: 0, Title: "run"}},
+			cleanupA: []*TaskReport{{Index: 2, Depth: 0, Title: "teardown"}},
+			tasksB:   []*TaskReport{{Index: 1, Depth: 0, Title: "run"}, {Index: 2, Depth: 0, Title: "teardown"}},
+			want:     []string{"/run AB", "/teardown B", "cleanup/teardown A"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tasksA := collectComparedTasks(testCompareRun{}, &TestReport{Tasks: tt.tasksA, Cleanup: tt.cleanupA})
+			tasksB := collectComparedTasks(testCompareRun{}, &TestReport{Tasks: tt.tasksB, Cleanup: tt.cleanupB})
+
+			got := []string{}
+
+			for _, diff := range alignTasks(tasksA, tasksB) {
+				presence := ""
+				if diff.A != nil {
+					presence += "A"
+				}
+
+				if diff.B != nil {
+					presence += "B"
+				}
+
+				got = append(got, diff.Key+" "+presence)
+			}
+
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("alignTasks() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestAlignTasksResultChange(t *testing.T) {
+	tasksA := collectComparedTasks(testCompareRun{}, &TestReport{Tasks: []*TaskReport{
+		{Index: 1, Title: "check", Status: TaskStatusSuccess, Duration: 1500 * time.Millisecond},
+	}})
+	tasksB := collectComparedTasks(testCompareRun{}, &TestReport{Tasks: []*TaskReport{
+		{Index: 1, Title: "check", Status: TaskStatusFailure, Duration: 4 * time.Second, Error: "timeout"},
+	}})
+
+	diffs := alignTasks(tasksA, tasksB)
+	if len(diffs) != 1 {
+		t.Fatalf("expected 1 aligned task, got %v", len(diffs))
+	}
+
+	diff := diffs[0]
+	if !diff.ResultChanged {
+		t.Errorf("expected result change")
+	}
+
+	if diff.DurationDelta != 2500 {
+		t.Errorf("duration delta = %v, want 2500", diff.DurationDelta)
+	}
+
+	if diff.A.Status != TaskStatusSuccess || diff.B.Status != TaskStatusFailure || diff.B.Error != "timeout" {
+		t.Errorf("unexpected compared tasks: %+v / %+v", diff.A, diff.B)
+	}
+}
+
+func TestDiffValues(t *testing.T) {
+	tests := []struct {
+		name    string
+		valuesA any
+		valuesB any
+		want    []*ValueDiff
+	}{
+		{
+			name:    "equal values",
+			valuesA: map[string]any{"a": 1, "b": map[string]any{"c": "x"}},
+			valuesB: map[string]any{"a": 1, "b": map[string]any{"c": "x"}},
+			want:    []*ValueDiff{},
+		},
+		{
+			name:    "changed nested value",
+			valuesA: map[string]any{"config": map[string]any{"limits": map[string]any{"gas": 100, "count": 2}}},
+			valuesB: map[string]any{"config": map[string]any{"limits": map[string]any{"gas": 200, "count": 2}}},
+			want: []*ValueDiff{
+				{Key: "config.limits.gas", A: float64(100), B: float64(200)},
+			},
+		},
+		{
+			name:    "added and removed keys",
+			valuesA: map[string]any{"a": "x", "nested": map[string]any{"old": true}},
+			valuesB: map[string]any{"b": "y", "nested": map[string]any{"new": true}},
+			want: []*ValueDiff{
+				{Key: "a", A: "x", B: nil},
+				{Key: "b", A: nil, B: "y"},
+				{Key: "nested.new", A: nil, B: true},
+				{Key: "nested.old", A: true, B: nil},
+			},
+		},
+		{
+			name:    "lists are compared as a whole",
+			valuesA: map[string]any{"clients": []string{"lighthouse", "teku"}},
+			valuesB: map[string]any{"clients": []string{"teku", "lighthouse"}},
+			want: []*ValueDiff{
+				{Key: "clients", A: []any{"lighthouse", "teku"}, B: []any{"teku", "lighthouse"}},
+			},
+		},
+		{
+			name:    "value replaced by map",
+			valuesA: map[string]any{"endpoint": "beacon-1"},
+			valuesB: map[string]any{"endpoint": map[string]any{"name": "beacon-1"}},
+			want: []*ValueDiff{
+				{Key: "endpoint", A: "beacon-1", B: nil},
+				{Key: "endpoint.name", A: nil, B: "beacon-1"},
+			},
+		},
+		{
+			name: "structs and maps compare equally",
+			valuesA: struct {
+				Timeout string `json:"timeout"`
+				Retries int    `json:"retries"`
+			}{Timeout: "5m", Retries: 3},
+			valuesB: map[string]any{"timeout": "5m", "retries": 3},
+			want:    []*ValueDiff{},
+		},
+		{
+			name:    "missing values",
+			valuesA: nil,
+			valuesB: map[string]any{"a": map[string]any{}},
+			want: []*ValueDiff{
+				{Key: "a", A: nil, B: map[string]any{}},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := diffValues(toValueMap(tt.valuesA), toValueMap(tt.valuesB))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("diffValues() = %v, want %v", formatValueDiffs(got), formatValueDiffs(tt.want))
+			}
+		})
+	}
+}
+
+func TestParseYamlMap(t *testing.T) {
+	got := parseYamlMap("walletPrivkey: \"0x00\"\nlimits:\n  gas: 100\n")
+	want := map[string]any{"walletPrivkey": "0x00", "limits.gas": float64(100)}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("parseYamlMap() = %v, want %v", got, want)
+	}
+
+	if got := parseYamlMap("[invalid"); got != nil {
+		t.Errorf("expected nil for invalid yaml, got %v", got)
+	}
+}
+
+func formatValueDiffs(diffs []*ValueDiff) []ValueDiff {
+	values := make([]ValueDiff, len(diffs))
+	for idx, diff := range diffs {
+		values[idx] = *diff
+	}
+
+	return values
+}
@@ -0,0 +1,148 @@
+package report
+
+import (
+	"encoding/xml"
+	"fmt"
+	"strings"
+	"time"
+)
+
+type junitTestSuites struct {
+	XMLName  xml.Name          `xml:"testsuites"`
+	Name     string            `xml:"name,attr"`
+	Tests    int               `xml:"tests,attr"`
+	Failures int               `xml:"failures,attr"`
+	Errors   int               `xml:"errors,attr"`
+	Skipped  int               `xml:"skipped,attr"`
+	Time     string            `xml:"time,attr"`
+	Suites   []*junitTestSuite `xml:"testsuite"`
+}
+
+type junitTestSuite struct {
+	Name       string           `xml:"name,attr"`
+	ID         int              `xml:"id,attr"`
+	Tests      int              `xml:"tests,attr"`
+	Failures   int              `xml:"failures,attr"`
+	Errors     int              `xml:"errors,attr"`
+	Skipped    int              `xml:"skipped,attr"`
+	Time       string           `xml:"time,attr"`
+	Timestamp  string           `xml:"timestamp,attr,omitempty"`
+	Properties []*junitProperty `xml:"properties>property,omitempty"`
+	Cases      []*junitTestCase `xml:"testcase"`
+}
+
+type junitProperty struct {
+	Name  string `xml:"name,attr"`
+	Value string `xml:"value,attr"`
+}
+
+type junitTestCase struct {
+	Name      string        `xml:"name,attr"`
+	ClassName string        `xml:"classname,attr"`
+	Time      string        `xml:"time,attr"`
+	Failure   *junitMessage `xml:"failure,omitempty"`
+	Skipped   *junitMessage `xml:"skipped,omitempty"`
+	SystemOut string        `xml:"system-out,omitempty"`
+}
+
+type junitMessage struct {
+	Message string `xml:"message,attr,omitempty"`
+	Type    string `xml:"type,attr,omitempty"`
+	Body    string `xml:",chardata"`
+}
+
+// JUnit renders the report as JUnit XML.
+// Each root task becomes a test suite with a test case for every task in its subtree, cleanup tasks are grouped in a separate suite.
+func (r *TestReport) JUnit() ([]byte, error) {
+	suites := &junitTestSuites{
+		Name:   r.Name,
+		Time:   formatJUnitDuration(r.Duration()),
+		Suites: []*junitTestSuite{},
+	}
+
+	addSuites := func(taskReports []*TaskReport, suffix string) {
+		var suite *junitTestSuite
+
+		for _, taskReport := range taskReports {
+			if taskReport.Depth == 0 || suite == nil {
+				suite = &junitTestSuite{
+					Name: fmt.Sprintf("%v%v: %v", r.TestID, suffix, taskReport.Title),
+					ID:   len(suites.Suites),
+					Time: formatJUnitDuration(taskReport.Duration),
+					Properties: []*junitProperty{
+						{Name: "run_id", Value: fmt.Sprintf("%v", r.RunID)},
+						{Name: "test_id", Value: r.TestID},
+						{Name: "test_status", Value: string(r.Status)},
+					},
+				}
+
+				if !taskReport.StartTime.IsZero() {
+					suite.Timestamp = taskReport.StartTime.UTC().Format(time.RFC3339)
+				}
+
+				suites.Suites = append(suites.Suites, suite)
+			}
+
+			suite.Cases = append(suite.Cases, r.junitTestCase(taskReport, suite.Name))
+			suite.Tests++
+
+			switch {
+			case suite.Cases[len(suite.Cases)-1].Failure != nil:
+				suite.Failures++
+			case suite.Cases[len(suite.Cases)-1].Skipped != nil:
+				suite.Skipped++
+			}
+		}
+	}
+
+	addSuites(r.Tasks, "")
+	addSuites(r.Cleanup, " (cleanup)")
+
+	for _, suite := range suites.Suites {
+		suites.Tests += suite.Tests
+		suites.Failures += suite.Failures
+		suites.Skipped += suite.Skipped
+	}
+
+	data, err := xml.MarshalIndent(suites, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding junit report: %w", err)
+	}
+
+	return append([]byte(xml.Header), data...), nil
+}
+
+func (r *TestReport) junitTestCase(taskReport *TaskReport, className string) *junitTestCase {
+	testCase := &junitTestCase{
+		Name:      fmt.Sprintf("#%v %v", taskReport.Index, taskReport.Title),
+		ClassName: className,
+		Time:      formatJUnitDuration(taskReport.Duration),
+		SystemOut: strings.Join(taskReport.Log, "\n"),
+	}
+
+	switch taskReport.Status {
+	case TaskStatusFailure:
+		message := taskReport.Error
+		if message == "" {
+			message = "task failed"
+		}
+
+		testCase.Failure = &junitMessage{
+			Message: message,
+			Type:    taskReport.Name,
+			Body:    message,
+		}
+	case TaskStatusSkipped:
+		testCase.Skipped = &junitMessage{Message: "task skipped"}
+	case TaskStatusPending:
+		testCase.Skipped = &junitMessage{Message: "task not started"}
+	case TaskStatusRunning:
+		testCase.Skipped = &junitMessage{Message: "task still running"}
+	}
+
+	return testCase
+}
+
+func formatJUnitDuration(duration time.Duration) string {
+	return fmt.Sprintf("%.3f", duration.Seconds())
+}
@@ -0,0 +1,220 @@
+package report
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+const (
+	FormatJUnit       = "junit"
+	FormatTAP         = "tap"
+	FormatJSONSummary = "json-summary"
+)
+
+// Formats lists all supported report formats.
+var Formats = []string{FormatJUnit, FormatTAP, FormatJSONSummary}
+
+const (
+	TaskStatusSuccess = "success"
+	TaskStatusFailure = "failure"
+	TaskStatusSkipped = "skipped"
+	TaskStatusPending = "pending"
+	TaskStatusRunning = "running"
+	TaskStatusNone    = "none"
+)
+
+// maxLogLines is the number of log lines included as excerpt for each task.
+const maxLogLines = 50
+
+// TestReport is the format independent report of a test run.
+type TestReport struct {
+	RunID     uint64
+	TestID    string
+	Name      string
+	Status    types.TestStatus
+	StartTime time.Time
+	StopTime  time.Time
+	Tasks     []*TaskReport
+	Cleanup   []*TaskReport
+}
+
+// TaskReport is the report of a single task in the task tree.
+type TaskReport struct {
+	Index       uint64
+	ParentIndex uint64
+	Depth       int
+	ID          string
+	Name        string
+	Title       string
+	Status      string
+	Error       string
+	StartTime   time.Time
+	StopTime    time.Time
+	Duration    time.Duration
+	Log         []string
+}
+
+// Generate renders the report of a test run in the given format.
+// Returns the report and its content type.
+func Generate(test types.Test, format string, includeLogs bool) (data []byte, contentType string, err error) {
+	report := NewTestReport(test, includeLogs)
+
+	switch format {
+	case FormatJUnit:
+		data, err = report.JUnit()
+		contentType = "application/xml; charset=utf-8"
+	case FormatTAP:
+		data = report.TAP()
+		contentType = "text/plain; charset=utf-8"
+	case FormatJSONSummary:
+		data, err = report.JSONSummary()
+		contentType = "application/json"
+	default:
+		err = fmt.Errorf("unsupported report format '%v' (supported: %v)", format, strings.Join(Formats, ", "))
+	}
+
+	return data, contentType, err
+}
+
+// FileExtension returns the file extension for reports of the given format.
+func FileExtension(format string) string {
+	switch format {
+	case FormatJUnit:
+		return "xml"
+	case FormatTAP:
+		return "tap"
+	default:
+		return "json"
+	}
+}
+
+// NewTestReport collects the task tree and results of a test run.
+// Log excerpts of the tasks are only collected if includeLogs is set.
+func NewTestReport(test types.Test, includeLogs bool) *TestReport {
+	report := &TestReport{
+		RunID:     test.RunID(),
+		TestID:    test.TestID(),
+		Name:      test.Name(),
+		Status:    test.Status(),
+		StartTime: test.StartTime(),
+		StopTime:  test.StopTime(),
+	}
+
+	taskScheduler := test.GetTaskScheduler()
+	if taskScheduler == nil {
+		return report
+	}
+
+	report.Tasks = collectTaskReports(taskScheduler, taskScheduler.GetAllTasks(), includeLogs)
+	report.Cleanup = collectTaskReports(taskScheduler, taskScheduler.GetAllCleanupTasks(), includeLogs)
+
+	return report
+}
+
+func collectTaskReports(taskScheduler types.TaskScheduler, taskIndexes []types.TaskIndex, includeLogs bool) []*TaskReport {
+	taskReports := make([]*TaskReport, 0, len(taskIndexes))
+	depths := map[uint64]int{}
+
+	for _, taskIndex := range taskIndexes {
+		taskState := taskScheduler.GetTaskState(taskIndex)
+		if taskState == nil {
+			continue
+		}
+
+		taskReport := newTaskReport(taskState, includeLogs)
+
+		if parentDepth, ok := depths[taskReport.ParentIndex]; ok {
+			taskReport.Depth = parentDepth + 1
+		}
+
+		depths[taskReport.Index] = taskReport.Depth
+		taskReports = append(taskReports, taskReport)
+	}
+
+	return taskReports
+}
+
+func newTaskReport(taskState types.TaskState, includeLogs bool) *TaskReport {
+	taskStatus := taskState.GetTaskStatus()
+
+	taskReport := &TaskReport{
+		Index:       uint64(taskState.Index()),
+		ParentIndex: uint64(taskState.ParentIndex()),
+		ID:          taskState.ID(),
+		Name:        taskState.Name(),
+		Title:       taskState.Title(),
+		StartTime:   taskStatus.StartTime,
+		StopTime:    taskStatus.StopTime,
+	}
+
+	switch {
+	case !taskStatus.IsStarted:
+		taskReport.Status = TaskStatusPending
+	case taskStatus.IsSkipped:
+		taskReport.Status = TaskStatusSkipped
+	case taskStatus.IsRunning:
+		taskReport.Status = TaskStatusRunning
+		taskReport.Duration = time.Since(taskStatus.StartTime)
+	case taskStatus.Result == types.TaskResultSuccess:
+		taskReport.Status = TaskStatusSuccess
+	case taskStatus.Result == types.TaskResultFailure:
+		taskReport.Status = TaskStatusFailure
+	default:
+		taskReport.Status = TaskStatusNone
+	}
+
+	if taskStatus.IsStarted && !taskStatus.IsRunning {
+		taskReport.Duration = taskStatus.StopTime.Sub(taskStatus.StartTime)
+	}
+
+	if taskStatus.Error != nil {
+		taskReport.Error = taskStatus.Error.Error()
+	}
+
+	if includeLogs && taskStatus.Logger != nil {
+		logCount := taskStatus.Logger.GetLogEntryCount()
+		logStart := uint64(0)
+
+		if logCount > maxLogLines {
+			logStart = logCount - maxLogLines
+		}
+
+		for _, logEntry := range taskStatus.Logger.GetLogEntries(logStart, maxLogLines) {
+			taskReport.Log = append(taskReport.Log, fmt.Sprintf(
+				"%v [%v] %v",
+				time.UnixMilli(logEntry.LogTime).UTC().Format(time.RFC3339Nano),
+				logrus.Level(logEntry.LogLevel).String(),
+				logEntry.LogMessage,
+			))
+		}
+	}
+
+	return taskReport
+}
+
+// Counts returns the number of tasks per status.
+func (r *TestReport) Counts() map[string]int {
+	counts := map[string]int{}
+
+	for _, taskReport := range append(append([]*TaskReport{}, r.Tasks...), r.Cleanup...) {
+		counts[taskReport.Status]++
+	}
+
+	return counts
+}
+
+// Duration returns the run time of the test run.
+func (r *TestReport) Duration() time.Duration {
+	switch {
+	case r.StartTime.IsZero():
+		return 0
+	case r.StopTime.IsZero() || r.StopTime.Before(r.StartTime):
+		return time.Since(r.StartTime)
+	default:
+		return r.StopTime.Sub(r.StartTime)
+	}
+}
@@ -0,0 +1,304 @@
+package report
+
+import (
+	"encoding/xml"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+const testTaskDuration = 1500 * time.Millisecond
+
+var testReportStartTime = time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+
+// newTestReport wraps the tasks of a test case into the report shared by all renderer tests.
+func newTestReport(tasks, cleanup []*TaskReport) *TestReport {
+	return &TestReport{
+		RunID:     7,
+		TestID:    "test1",
+		Name:      "Test 1",
+		Status:    types.TestStatusFailure,
+		StartTime: testReportStartTime,
+		StopTime:  testReportStartTime.Add(90 * time.Second),
+		Tasks:     tasks,
+		Cleanup:   cleanup,
+	}
+}
+
+func TestJUnit(t *testing.T) {
+	tests := []struct {
+		name         string
+		tasks        []*TaskReport
+		cleanup      []*TaskReport
+		wantSuites   []string
+		wantCases    []int
+		wantTests    int
+		wantFailures int
+		wantSkipped  int
+		wantFailure  string
+		wantOutput   string
+	}{
+		{
+			name:       "empty report",
+			wantSuites: []string{},
+			wantCases:  []int{},
+		},
+		{
+			name: "root tasks become suites",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "setup", Status: TaskStatusSuccess, Duration: testTaskDuration},
+				{Index: 2, Depth: 0, Name: "run_shell", Title: "run", Status: TaskStatusSuccess, Duration: testTaskDuration},
+				{Index: 3, Depth: 1, Name: "run_shell", Title: "step", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			wantSuites: []string{"test1: setup", "test1: run"},
+			wantCases:  []int{1, 2},
+			wantTests:  3,
+		},
+		{
+			name: "failed and skipped tasks",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "run", Status: TaskStatusFailure, Duration: testTaskDuration},
+				{Index: 2, Depth: 1, Name: "run_shell", Title: "prepare", Status: TaskStatusSuccess, Duration: testTaskDuration},
+				{Index: 3, Depth: 1, Name: "run_shell", Title: "check", Status: TaskStatusFailure, Duration: testTaskDuration, Error: "exit code 1", Log: []string{"line 1", "line 2"}},
+				{Index: 4, Depth: 1, Name: "run_shell", Title: "skipped", Status: TaskStatusSkipped, Duration: testTaskDuration},
+				{Index: 5, Depth: 1, Name: "run_shell", Title: "pending", Status: TaskStatusPending, Duration: testTaskDuration},
+				{Index: 6, Depth: 1, Name: "run_shell", Title: "running", Status: TaskStatusRunning, Duration: testTaskDuration},
+			},
+			wantSuites:   []string{"test1: run"},
+			wantCases:    []int{6},
+			wantTests:    6,
+			wantFailures: 2,
+			wantSkipped:  3,
+			wantFailure:  "exit code 1",
+			wantOutput:   "line 1\nline 2",
+		},
+		{
+			name: "cleanup tasks get a separate suite",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "run", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			cleanup: []*TaskReport{
+				{Index: 2, Depth: 0, Name: "run_shell", Title: "teardown", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			wantSuites: []string{"test1: run", "test1 (cleanup): teardown"},
+			wantCases:  []int{1, 1},
+			wantTests:  2,
+		},
+		{
+			name: "nested first task opens a suite",
+			tasks: []*TaskReport{
+				{Index: 2, Depth: 1, Name: "run_shell", Title: "orphan", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			wantSuites: []string{"test1: orphan"},
+			wantCases:  []int{1},
+			wantTests:  1,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			data, err := newTestReport(tt.tasks, tt.cleanup).JUnit()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !strings.HasPrefix(string(data), xml.Header) {
+				t.Errorf("missing xml header")
+			}
+
+			suites := &junitTestSuites{}
+			if err := xml.Unmarshal(data, suites); err != nil {
+				t.Fatalf("failed parsing junit report: %v", err)
+			}
+
+			if suites.Name != "Test 1" || suites.Time != "90.000" {
+				t.Errorf("testsuites name = %q, time = %q, want %q, %q", suites.Name, suites.Time, "Test 1", "90.000")
+			}
+
+			if suites.Tests != tt.wantTests || suites.Failures != tt.wantFailures || suites.Skipped != tt.wantSkipped {
+				t.Errorf("tests/failures/skipped = %v/%v/%v, want %v/%v/%v", suites.Tests, suites.Failures, suites.Skipped, tt.wantTests, tt.wantFailures, tt.wantSkipped)
+			}
+
+			if len(suites.Suites) != len(tt.wantSuites) {
+				t.Fatalf("suites = %v, want %v", len(suites.Suites), len(tt.wantSuites))
+			}
+
+			for idx, suite := range suites.Suites {
+				if suite.Name != tt.wantSuites[idx] {
+					t.Errorf("suite %v name = %q, want %q", idx, suite.Name, tt.wantSuites[idx])
+				}
+
+				if len(suite.Cases) != tt.wantCases[idx] || suite.Tests != tt.wantCases[idx] {
+					t.Errorf("suite %v cases = %v (tests %v), want %v", idx, len(suite.Cases), suite.Tests, tt.wantCases[idx])
+				}
+
+				for _, testCase := range suite.Cases {
+					if testCase.ClassName != suite.Name {
+						t.Errorf("case %q classname = %q, want %q", testCase.Name, testCase.ClassName, suite.Name)
+					}
+
+					if testCase.Time != "1.500" {
+						t.Errorf("case %q time = %q, want %q", testCase.Name, testCase.Time, "1.500")
+					}
+
+					if testCase.Name != "#3 check" {
+						continue
+					}
+
+					if testCase.Failure == nil || testCase.Failure.Message != tt.wantFailure || testCase.Failure.Type != "run_shell" {
+						t.Errorf("case %q failure = %+v, want message %q", testCase.Name, testCase.Failure, tt.wantFailure)
+					}
+
+					if testCase.SystemOut != tt.wantOutput {
+						t.Errorf("case %q system-out = %q, want %q", testCase.Name, testCase.SystemOut, tt.wantOutput)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestJUnitTestCaseStatus(t *testing.T) {
+	tests := []struct {
+		status      string
+		wantFailure string
+		wantSkipped string
+	}{
+		{status: TaskStatusSuccess},
+		{status: TaskStatusNone},
+		{status: TaskStatusFailure, wantFailure: "task failed"},
+		{status: TaskStatusSkipped, wantSkipped: "task skipped"},
+		{status: TaskStatusPending, wantSkipped: "task not started"},
+		{status: TaskStatusRunning, wantSkipped: "task still running"},
+	}
+
+	report := newTestReport(nil, nil)
+
+	for _, tt := range tests {
+		t.Run(tt.status, func(t *testing.T) {
+			testCase := report.junitTestCase(&TaskReport{Index: 1, Name: "run_shell", Title: "task", Status: tt.status, Duration: testTaskDuration}, "suite")
+
+			failure, skipped := "", ""
+			if testCase.Failure != nil {
+				failure = testCase.Failure.Message
+			}
+
+			if testCase.Skipped != nil {
+				skipped = testCase.Skipped.Message
+			}
+
+			if failure != tt.wantFailure {
+				t.Errorf("failure = %q, want %q", failure, tt.wantFailure)
+			}
+
+			if skipped != tt.wantSkipped {
+				t.Errorf("skipped = %q, want %q", skipped, tt.wantSkipped)
+			}
+		})
+	}
+}
+
+func TestTAP(t *testing.T) {
+	tests := []struct {
+		name      string
+		tasks     []*TaskReport
+		cleanup   []*TaskReport
+		wantLines []string
+	}{
+		{
+			name: "empty report",
+			wantLines: []string{
+				"TAP version 13",
+				"1..0",
+				"# test Test 1 (test1), run 7: failure",
+			},
+		},
+		{
+			name: "test points per status",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "run", Status: TaskStatusSuccess, Duration: testTaskDuration},
+				{Index: 2, Depth: 1, Name: "run_shell", Title: "skipped", Status: TaskStatusSkipped, Duration: testTaskDuration},
+				{Index: 3, Depth: 1, Name: "run_shell", Title: "pending", Status: TaskStatusPending, Duration: testTaskDuration},
+				{Index: 4, Depth: 1, Name: "run_shell", Title: "running", Status: TaskStatusRunning, Duration: testTaskDuration},
+			},
+			wantLines: []string{
+				"TAP version 13",
+				"1..4",
+				"# test Test 1 (test1), run 7: failure",
+				"ok 1 - #1 run",
+				"ok 2 -   #2 skipped # SKIP task skipped",
+				"ok 3 -   #3 pending # SKIP task not started",
+				"not ok 4 -   #4 running # TODO task still running",
+			},
+		},
+		{
+			name: "failure diagnostic",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "run", Status: TaskStatusFailure, Duration: testTaskDuration},
+				{Index: 2, Depth: 1, Name: "run_shell", Title: "check", Status: TaskStatusFailure, Duration: testTaskDuration, Error: "exit code 1", Log: []string{"some output"}},
+			},
+			wantLines: []string{
+				"TAP version 13",
+				"1..2",
+				"# test Test 1 (test1), run 7: failure",
+				"not ok 1 - #1 run",
+				"  ---",
+				"  message: task failed",
+				"  severity: fail",
+				"  task: run_shell",
+				"  duration_ms: 1500",
+				"  ...",
+				"not ok 2 -   #2 check",
+				"  ---",
+				"  message: exit code 1",
+				"  severity: fail",
+				"  task: run_shell",
+				"  duration_ms: 1500",
+				"  log:",
+				"      - some output",
+				"  ...",
+			},
+		},
+		{
+			name: "cleanup tasks",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "run", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			cleanup: []*TaskReport{
+				{Index: 2, Depth: 0, Name: "run_shell", Title: "teardown", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			wantLines: []string{
+				"TAP version 13",
+				"1..2",
+				"# test Test 1 (test1), run 7: failure",
+				"ok 1 - #1 run",
+				"# cleanup tasks",
+				"ok 2 - #2 teardown",
+			},
+		},
+		{
+			name: "escaped titles",
+			tasks: []*TaskReport{
+				{Index: 1, Depth: 0, Name: "run_shell", Title: "check #1\nof C:\\data", Status: TaskStatusSuccess, Duration: testTaskDuration},
+			},
+			wantLines: []string{
+				"TAP version 13",
+				"1..1",
+				"# test Test 1 (test1), run 7: failure",
+				"ok 1 - #1 check \\#1 of C:\\\\data",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := strings.Split(strings.TrimSuffix(string(newTestReport(tt.tasks, tt.cleanup).TAP()), "\n"), "\n")
+
+			if strings.Join(got, "\n") != strings.Join(tt.wantLines, "\n") {
+				t.Errorf("TAP() mismatch\n got:\n%v\nwant:\n%v", strings.Join(got, "\n"), strings.Join(tt.wantLines, "\n"))
+			}
+		})
+	}
+}
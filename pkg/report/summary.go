@@ -0,0 +1,90 @@
+package report
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+type jsonSummary struct {
+	RunID        uint64             `json:"run_id"`
+	TestID       string             `json:"test_id"`
+	Name         string             `json:"name"`
+	Status       types.TestStatus   `json:"status"`
+	StartTime    int64              `json:"start_time"`
+	StopTime     int64              `json:"stop_time"`
+	Duration     float64            `json:"duration"`
+	Counts       map[string]int     `json:"counts"`
+	Tasks        []*jsonSummaryTask `json:"tasks"`
+	CleanupTasks []*jsonSummaryTask `json:"cleanup_tasks"`
+}
+
+type jsonSummaryTask struct {
+	Index       uint64   `json:"index"`
+	ParentIndex uint64   `json:"parent_index"`
+	Depth       int      `json:"depth"`
+	ID          string   `json:"id,omitempty"`
+	Name        string   `json:"name"`
+	Title       string   `json:"title"`
+	Status      string   `json:"status"`
+	Duration    float64  `json:"duration"`
+	Error       string   `json:"error,omitempty"`
+	Log         []string `json:"log,omitempty"`
+}
+
+// JSONSummary renders the report as compact JSON summary with task counts per status.
+// Log excerpts are only included for failed tasks.
+func (r *TestReport) JSONSummary() ([]byte, error) {
+	summary := &jsonSummary{
+		RunID:        r.RunID,
+		TestID:       r.TestID,
+		Name:         r.Name,
+		Status:       r.Status,
+		Duration:     r.Duration().Seconds(),
+		Counts:       r.Counts(),
+		Tasks:        getJSONSummaryTasks(r.Tasks),
+		CleanupTasks: getJSONSummaryTasks(r.Cleanup),
+	}
+
+	if !r.StartTime.IsZero() {
+		summary.StartTime = r.StartTime.Unix()
+	}
+
+	if !r.StopTime.IsZero() {
+		summary.StopTime = r.StopTime.Unix()
+	}
+
+	data, err := json.MarshalIndent(summary, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed encoding json summary: %w", err)
+	}
+
+	return data, nil
+}
+
+func getJSONSummaryTasks(taskReports []*TaskReport) []*jsonSummaryTask {
+	tasks := make([]*jsonSummaryTask, 0, len(taskReports))
+
+	for _, taskReport := range taskReports {
+		task := &jsonSummaryTask{
+			Index:       taskReport.Index,
+			ParentIndex: taskReport.ParentIndex,
+			Depth:       taskReport.Depth,
+			ID:          taskReport.ID,
+			Name:        taskReport.Name,
+			Title:       taskReport.Title,
+			Status:      taskReport.Status,
+			Duration:    taskReport.Duration.Seconds(),
+			Error:       taskReport.Error,
+		}
+
+		if taskReport.Status == TaskStatusFailure {
+			task.Log = taskReport.Log
+		}
+
+		tasks = append(tasks, task)
+	}
+
+	return tasks
+}
@@ -0,0 +1,83 @@
+package report
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+type tapDiagnostic struct {
+	Message    string   `yaml:"message,omitempty"`
+	Severity   string   `yaml:"severity"`
+	Task       string   `yaml:"task"`
+	DurationMs int64    `yaml:"duration_ms"`
+	Log        []string `yaml:"log,omitempty"`
+}
+
+// TAP renders the report in the Test Anything Protocol (version 13) format.
+// Every task is reported as a test point in task tree order, failures carry a YAML diagnostic block.
+func (r *TestReport) TAP() []byte {
+	buf := &bytes.Buffer{}
+	taskReports := append(append([]*TaskReport{}, r.Tasks...), r.Cleanup...)
+
+	fmt.Fprintf(buf, "TAP version 13\n")
+	fmt.Fprintf(buf, "1..%v\n", len(taskReports))
+	fmt.Fprintf(buf, "# test %v (%v), run %v: %v\n", r.Name, r.TestID, r.RunID, r.Status)
+
+	cleanupStart := len(r.Tasks)
+
+	for idx, taskReport := range taskReports {
+		if idx == cleanupStart && len(r.Cleanup) > 0 {
+			fmt.Fprintf(buf, "# cleanup tasks\n")
+		}
+
+		description := fmt.Sprintf("%v#%v %v", strings.Repeat("  ", taskReport.Depth), taskReport.Index, tapEscape(taskReport.Title))
+
+		switch taskReport.Status {
+		case TaskStatusFailure:
+			fmt.Fprintf(buf, "not ok %v - %v\n", idx+1, description)
+
+			message := taskReport.Error
+			if message == "" {
+				message = "task failed"
+			}
+
+			diagnostic, err := yaml.Marshal(&tapDiagnostic{
+				Message:    message,
+				Severity:   "fail",
+				Task:       taskReport.Name,
+				DurationMs: taskReport.Duration.Milliseconds(),
+				Log:        taskReport.Log,
+			})
+			if err == nil {
+				fmt.Fprintf(buf, "  ---\n")
+
+				for _, line := range strings.Split(strings.TrimRight(string(diagnostic), "\n"), "\n") {
+					fmt.Fprintf(buf, "  %v\n", line)
+				}
+
+				fmt.Fprintf(buf, "  ...\n")
+			}
+		case TaskStatusSkipped:
+			fmt.Fprintf(buf, "ok %v - %v # SKIP task skipped\n", idx+1, description)
+		case TaskStatusPending:
+			fmt.Fprintf(buf, "ok %v - %v # SKIP task not started\n", idx+1, description)
+		case TaskStatusRunning:
+			fmt.Fprintf(buf, "not ok %v - %v # TODO task still running\n", idx+1, description)
+		default:
+			fmt.Fprintf(buf, "ok %v - %v\n", idx+1, description)
+		}
+	}
+
+	return buf.Bytes()
+}
+
+// tapEscape escapes characters with a special meaning in TAP test point descriptions.
+func tapEscape(description string) string {
+	description = strings.ReplaceAll(description, "\\", "\\\\")
+	description = strings.ReplaceAll(description, "#", "\\#")
+
+	return strings.ReplaceAll(description, "\n", " ")
+}
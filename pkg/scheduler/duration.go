@@ -0,0 +1,57 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+// ResolveDuration returns the wall clock value of a duration.
+// Chain-relative durations are resolved via the consensus chain clock. If genesis or specs are not known yet,
+// it waits until they become available, so the duration gets re-evaluated with the actual network timing.
+func (ts *TaskScheduler) ResolveDuration(ctx context.Context, duration *helper.ChainRelativeDuration) (time.Duration, error) {
+	if !duration.IsChainRelative() {
+		return duration.Duration, nil
+	}
+
+	loggedWait := false
+
+	for {
+		if duration.Resolve(ts.getChainClock()) {
+			return duration.Duration, nil
+		}
+
+		if !loggedWait {
+			ts.logger.Infof("waiting for chain genesis & specs to resolve duration '%v'", duration.ChainDuration())
+			loggedWait = true
+		}
+
+		select {
+		case <-ctx.Done():
+			return 0, fmt.Errorf("failed resolving duration '%v': %w", duration.ChainDuration(), ctx.Err())
+		case <-time.After(1 * time.Second):
+		}
+	}
+}
+
+func (ts *TaskScheduler) getChainClock() helper.ChainClock {
+	clientPool := ts.services.ClientPool()
+	if clientPool == nil {
+		return nil
+	}
+
+	return clientPool.GetConsensusPool().GetBlockCache().GetChainClock()
+}
+
+// checkResolvedTimeout returns the wall clock value of a resolved timeout.
+// Chain-relative timeouts ending at a slot or epoch that has already passed resolve to 0,
+// which is reported as error instead of being treated as immediate timeout.
+func checkResolvedTimeout(timeout *helper.ChainRelativeDuration) (time.Duration, error) {
+	if timeout.IsChainRelative() && timeout.Duration <= 0 {
+		return 0, fmt.Errorf("task timeout '%v' has already passed", timeout.ChainDuration())
+	}
+
+	return timeout.Duration, nil
+}
@@ -0,0 +1,81 @@
+package scheduler
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+type testChainClock struct {
+	genesisTime time.Time
+}
+
+func (c *testChainClock) GenesisTime() time.Time {
+	return c.genesisTime
+}
+
+func (c *testChainClock) SlotDuration() time.Duration {
+	return 12 * time.Second
+}
+
+func (c *testChainClock) SlotsPerEpoch() uint64 {
+	return 32
+}
+
+func TestCheckResolvedTimeout(t *testing.T) {
+	// genesis 10 epochs ago
+	clock := &testChainClock{genesisTime: time.Now().Add(-10 * 32 * 12 * time.Second)}
+
+	tests := []struct {
+		timeout string
+		wantMin time.Duration
+		wantErr string
+	}{
+		{timeout: "5m", wantMin: 5 * time.Minute},
+		{timeout: "2 epochs", wantMin: 2 * 32 * 12 * time.Second},
+		{timeout: "until epoch 12", wantMin: 32 * 12 * time.Second},
+		{timeout: "until epoch 5", wantErr: "task timeout 'until epoch 5' has already passed"},
+		{timeout: "until slot 1", wantErr: "task timeout 'until slot 1' has already passed"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.timeout, func(t *testing.T) {
+			options := struct {
+				Timeout helper.ChainRelativeDuration
+			}{}
+
+			if err := options.Timeout.Unmarshal(tt.timeout); err != nil {
+				t.Fatalf("failed parsing timeout: %v", err)
+			}
+
+			// resolve into a copy, the options must stay untouched
+			resolvedTimeout := options.Timeout
+			if !resolvedTimeout.Resolve(clock) {
+				t.Fatalf("failed resolving timeout")
+			}
+
+			timeout, err := checkResolvedTimeout(&resolvedTimeout)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v, want %q", err, tt.wantErr)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if timeout < tt.wantMin {
+				t.Errorf("timeout = %v, want at least %v", timeout, tt.wantMin)
+			}
+
+			if options.Timeout.IsChainRelative() && options.Timeout.Duration != 0 {
+				t.Errorf("shared timeout has been modified: %v", options.Timeout.Duration)
+			}
+		})
+	}
+}
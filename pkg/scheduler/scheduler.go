@@ -9,6 +9,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/helper"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/sirupsen/logrus"
 )
@@ -28,6 +29,9 @@ type TaskScheduler struct {
 	taskStateMap     map[types.TaskIndex]*taskState
 	cancelTaskCtx    context.CancelFunc
 	cancelCleanupCtx context.CancelFunc
+	resumeState      *resumeState
+	pauseMutex       sync.Mutex
+	pauseChan        chan struct{}
 
 	testResultMutex sync.Mutex
 	testResultDir   string
@@ -167,6 +171,9 @@ func (ts *TaskScheduler) AddCleanupTask(options *types.TaskOptions) (types.TaskI
 func (ts *TaskScheduler) RunTasks(testRunCtx context.Context, timeout time.Duration) error {
 	var cleanupCtx, tasksCtx context.Context
 
+	// pin the endpoint selections of all tasks to one endpoint when using the sticky scheduler
+	testRunCtx = helper.WithStickyKey(testRunCtx, helper.TestRunStickyKey(ts.testRunID))
+
 	cleanupCtx, ts.cancelCleanupCtx = context.WithCancel(testRunCtx)
 
 	defer ts.cleanupTestResult()
@@ -6,7 +6,9 @@ import (
 	"runtime/debug"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/metrics"
 	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/ethpandaops/assertoor/pkg/vars"
 )
 
 // ExecuteTask executes a task
@@ -17,6 +19,11 @@ func (ts *TaskScheduler) ExecuteTask(ctx context.Context, taskIndex types.TaskIn
 		return fmt.Errorf("task not found")
 	}
 
+	// replay tasks that have been completed before the test run got interrupted
+	if taskState.isRestored {
+		return ts.replayTask(ctx, taskState, taskWatchFn)
+	}
+
 	taskLogger := taskState.logger.GetLogger()
 
 	// check if task has already been started/executed
@@ -24,6 +31,15 @@ func (ts *TaskScheduler) ExecuteTask(ctx context.Context, taskIndex types.TaskIn
 		return fmt.Errorf("task has already been executed")
 	}
 
+	// don't start new tasks while the test run is paused (cleanup tasks are not held back)
+	if !taskState.isCleanup && ts.IsPaused() {
+		taskLogger.Infof("test run paused, waiting for resume before starting task")
+
+		if err := ts.WaitIfPaused(ctx); err != nil {
+			return fmt.Errorf("task cancelled while test run was paused: %w", err)
+		}
+	}
+
 	taskState.isStarted = true
 	taskState.startTime = time.Now()
 	taskState.isRunning = true
@@ -37,15 +53,20 @@ func (ts *TaskScheduler) ExecuteTask(ctx context.Context, taskIndex types.TaskIn
 	// emit task started event
 	ts.emitTaskStarted(taskState)
 
+	// trace the task as child span of its parent task (or the test run for root tasks)
+	ctx = ts.startTaskSpan(ctx, taskState)
+
 	defer func() {
 		taskState.isRunning = false
 		taskState.stopTime = time.Now()
 		taskState.taskStatusVars.SetVar("running", false)
+		taskState.endTaskSpan()
 
 		if err := taskState.updateTaskState(); err != nil {
 			taskLogger.Errorf("task state update on db failed: %v", err)
 		}
 
+		observeTaskMetrics(taskState)
 		taskState.logger.Flush()
 	}()
 
@@ -74,11 +95,14 @@ func (ts *TaskScheduler) ExecuteTask(ctx context.Context, taskIndex types.TaskIn
 		}
 	}
 
+	// track variables set by the task, so they can be restored when resuming the test run
+	taskState.varsRecorder = vars.NewScopeRecorder(taskState.taskVars)
+
 	// create task control context
 	taskCtx := &types.TaskContext{
 		Scheduler: ts,
 		Index:     taskState.index,
-		Vars:      taskState.taskVars,
+		Vars:      taskState.varsRecorder,
 		Outputs:   taskState.taskOutputs,
 		Logger:    taskState.logger,
 		NewTask: func(options *types.TaskOptions, variables types.Variables) (types.TaskIndex, error) {
@@ -131,9 +155,39 @@ func (ts *TaskScheduler) ExecuteTask(ctx context.Context, taskIndex types.TaskIn
 	// create cancelable task context
 	taskContext, taskCancelFn := context.WithCancel(ctx)
 	taskTimeout := task.Timeout()
+	chainTimeout := taskState.options.Timeout.IsChainRelative()
+	timeoutErrChan := make(chan error, 1)
 
-	if taskTimeout > 0 {
+	if taskTimeout > 0 || chainTimeout {
 		go func() {
+			if chainTimeout {
+				// chain-relative timeouts are resolved once genesis & specs are known.
+				// the timeout is resolved into a copy, as the task options are read concurrently.
+				resolvedTimeout := taskState.options.Timeout
+				if _, err := ts.ResolveDuration(taskContext, &resolvedTimeout); err != nil {
+					return
+				}
+
+				var timeoutErr error
+
+				taskTimeout, timeoutErr = checkResolvedTimeout(&resolvedTimeout)
+				if timeoutErr != nil {
+					taskState.isTimeout = true
+					taskState.taskStatusVars.SetVar("timeout", true)
+
+					taskLogger.Warnf("%v", timeoutErr)
+
+					timeoutErrChan <- timeoutErr
+
+					taskCancelFn()
+
+					return
+				}
+
+				taskState.resolvedTimeout.Store(int64(taskTimeout))
+				taskLogger.Infof("resolved task timeout '%v' to %v", resolvedTimeout.ChainDuration(), taskTimeout)
+			}
+
 			select {
 			case <-time.After(taskTimeout):
 				taskState.isTimeout = true
@@ -169,6 +223,13 @@ func (ts *TaskScheduler) ExecuteTask(ctx context.Context, taskIndex types.TaskIn
 	taskLogger.Infof("starting task")
 
 	err = task.Execute(taskContext)
+
+	select {
+	case timeoutErr := <-timeoutErrChan:
+		err = timeoutErr
+	default:
+	}
+
 	if err != nil {
 		taskLogger.Errorf("task execution returned error: %v", err)
 
@@ -224,6 +285,23 @@ func (ts *TaskScheduler) emitTaskStarted(taskState *taskState) {
 	)
 }
 
+func observeTaskMetrics(taskState *taskState) {
+	var resultStr string
+
+	switch {
+	case taskState.isSkipped:
+		resultStr = "skipped"
+	case taskState.taskResult == types.TaskResultSuccess:
+		resultStr = "success"
+	case taskState.taskResult == types.TaskResultFailure:
+		resultStr = "failure"
+	default:
+		resultStr = "none"
+	}
+
+	metrics.ObserveTaskRun(taskState.options.Name, resultStr, taskState.stopTime.Sub(taskState.startTime))
+}
+
 func (ts *TaskScheduler) emitTaskCompleted(taskState *taskState) {
 	eventBus := ts.services.EventBus()
 	if eventBus == nil {
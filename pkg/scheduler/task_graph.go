@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+var errTaskGraphDependencyFailed = errors.New("dependency failed")
+
+type taskGraphResult struct {
+	index types.TaskIndex
+	err   error
+}
+
+// ExecuteTaskGraph executes a list of sibling tasks as a directed acyclic graph.
+// Each task is started as soon as all tasks it depends on are completed, with at most MaxParallel tasks running at the same time.
+// Returns the error of the first failed task, or the context error if the execution got cancelled.
+func (ts *TaskScheduler) ExecuteTaskGraph(ctx context.Context, taskIndexes []types.TaskIndex, options *types.TaskGraphOptions) error {
+	if options == nil {
+		options = &types.TaskGraphOptions{}
+	}
+
+	dependencies := make(map[types.TaskIndex][]types.TaskIndex, len(taskIndexes))
+
+	for _, taskIndex := range taskIndexes {
+		taskState := ts.getTaskState(taskIndex)
+		if taskState == nil {
+			return fmt.Errorf("task %v not found", taskIndex)
+		}
+
+		dependencies[taskIndex] = taskState.DependsOn()
+	}
+
+	resultChan := make(chan taskGraphResult, len(taskIndexes))
+	results := map[types.TaskIndex]error{}
+	started := map[types.TaskIndex]bool{}
+	runningCount := uint64(0)
+	stopScheduling := false
+
+	var graphErr error
+
+	for {
+		// start all tasks with completed dependencies, skipped tasks may unblock further tasks
+		for progress := !stopScheduling; progress; {
+			progress = false
+
+			for _, taskIndex := range taskIndexes {
+				if started[taskIndex] {
+					continue
+				}
+
+				isReady, skipReason := ts.checkTaskGraphDependencies(dependencies[taskIndex], results, options)
+				if !isReady {
+					continue
+				}
+
+				if skipReason != "" {
+					started[taskIndex] = true
+					results[taskIndex] = errTaskGraphDependencyFailed
+					progress = true
+
+					ts.skipTask(ts.getTaskState(taskIndex), skipReason)
+
+					if options.OnTaskComplete != nil {
+						options.OnTaskComplete(taskIndex, errTaskGraphDependencyFailed)
+					}
+
+					continue
+				}
+
+				if options.MaxParallel > 0 && runningCount >= options.MaxParallel {
+					break
+				}
+
+				started[taskIndex] = true
+				runningCount++
+
+				go func(taskIndex types.TaskIndex) {
+					err := ts.ExecuteTask(ctx, taskIndex, nil)
+					resultChan <- taskGraphResult{
+						index: taskIndex,
+						err:   err,
+					}
+				}(taskIndex)
+			}
+		}
+
+		if runningCount == 0 {
+			break
+		}
+
+		result := <-resultChan
+		runningCount--
+		results[result.index] = result.err
+
+		if result.err != nil {
+			if graphErr == nil {
+				graphErr = fmt.Errorf("task %v failed: %w", result.index, result.err)
+			}
+
+			if !options.ContinueOnFailure {
+				stopScheduling = true
+			}
+		}
+
+		if ctx.Err() != nil {
+			stopScheduling = true
+		}
+
+		if options.OnTaskComplete != nil {
+			options.OnTaskComplete(result.index, result.err)
+		}
+	}
+
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	return graphErr
+}
+
+// checkTaskGraphDependencies checks if all dependencies of a task are completed.
+// Returns a skip reason if the task should be skipped due to a failed dependency.
+func (ts *TaskScheduler) checkTaskGraphDependencies(dependencies []types.TaskIndex, results map[types.TaskIndex]error, options *types.TaskGraphOptions) (isReady bool, skipReason string) {
+	for _, dependency := range dependencies {
+		depErr, isCompleted := results[dependency]
+		if !isCompleted {
+			return false, ""
+		}
+
+		if depErr != nil && options.DependencyFailure != types.TaskGraphDependencyFailureContinue && skipReason == "" {
+			depState := ts.getTaskState(dependency)
+			skipReason = fmt.Sprintf("dependency %v (%v) did not succeed", depState.options.ID, dependency)
+		}
+	}
+
+	return true, skipReason
+}
+
+// skipTask marks a task that has not been started as skipped without executing it.
+func (ts *TaskScheduler) skipTask(taskState *taskState, reason string) {
+	if taskState == nil || taskState.isStarted {
+		return
+	}
+
+	taskState.logger.GetLogger().Infof("skipping task: %v", reason)
+
+	taskState.isStarted = true
+	taskState.isSkipped = true
+	taskState.startTime = time.Now()
+	taskState.stopTime = taskState.startTime
+	taskState.taskStatusVars.SetVar("started", true)
+	taskState.setTaskResult(types.TaskResultNone, false)
+
+	if err := taskState.updateTaskState(); err != nil {
+		taskState.logger.GetLogger().Errorf("task state update on db failed: %v", err)
+	}
+
+	ts.emitTaskStarted(taskState)
+	observeTaskMetrics(taskState)
+	taskState.logger.Flush()
+}
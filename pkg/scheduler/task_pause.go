@@ -0,0 +1,64 @@
+package scheduler
+
+import (
+	"context"
+)
+
+// Pause stops the scheduler from starting new tasks until Resume is called.
+// Running tasks continue, but may wait for the resume at safe points via WaitIfPaused.
+// Returns false if the scheduler is already paused.
+func (ts *TaskScheduler) Pause() bool {
+	ts.pauseMutex.Lock()
+	defer ts.pauseMutex.Unlock()
+
+	if ts.pauseChan != nil {
+		return false
+	}
+
+	ts.pauseChan = make(chan struct{})
+
+	return true
+}
+
+// Resume continues a paused scheduler.
+// Returns false if the scheduler is not paused.
+func (ts *TaskScheduler) Resume() bool {
+	ts.pauseMutex.Lock()
+	defer ts.pauseMutex.Unlock()
+
+	if ts.pauseChan == nil {
+		return false
+	}
+
+	close(ts.pauseChan)
+	ts.pauseChan = nil
+
+	return true
+}
+
+// IsPaused returns true if the scheduler is paused.
+func (ts *TaskScheduler) IsPaused() bool {
+	ts.pauseMutex.Lock()
+	defer ts.pauseMutex.Unlock()
+
+	return ts.pauseChan != nil
+}
+
+// WaitIfPaused blocks while the scheduler is paused.
+// It returns an error if the context gets cancelled while waiting.
+func (ts *TaskScheduler) WaitIfPaused(ctx context.Context) error {
+	ts.pauseMutex.Lock()
+	pauseChan := ts.pauseChan
+	ts.pauseMutex.Unlock()
+
+	if pauseChan == nil {
+		return nil
+	}
+
+	select {
+	case <-pauseChan:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,141 @@
+package scheduler
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+// PlanTasks expands the task tree without executing any task.
+// The config of every task is loaded & validated, which creates the static child tasks of flow tasks.
+// Child tasks that are created at runtime (e.g. by run_task_options or run_external_tasks) are not part of the plan.
+// The scheduler must not be used to run the tasks afterwards.
+func (ts *TaskScheduler) PlanTasks() (taskPlans, cleanupTaskPlans []*types.TaskPlan) {
+	plannedTasks := map[types.TaskIndex]*types.TaskPlan{}
+
+	for {
+		ts.taskStateMutex.RLock()
+		pendingTasks := []*taskState{}
+
+		for taskIdx, taskState := range ts.taskStateMap {
+			if plannedTasks[taskIdx] == nil {
+				pendingTasks = append(pendingTasks, taskState)
+			}
+		}
+		ts.taskStateMutex.RUnlock()
+
+		if len(pendingTasks) == 0 {
+			break
+		}
+
+		// plan parents before their children, so child scopes are initialized properly
+		sort.Slice(pendingTasks, func(a, b int) bool {
+			return pendingTasks[a].index < pendingTasks[b].index
+		})
+
+		for _, taskState := range pendingTasks {
+			plannedTasks[taskState.index] = ts.planTask(taskState)
+		}
+	}
+
+	for _, taskIdx := range ts.GetAllTasks() {
+		taskPlans = append(taskPlans, plannedTasks[taskIdx])
+	}
+
+	for _, taskIdx := range ts.GetAllCleanupTasks() {
+		cleanupTaskPlans = append(cleanupTaskPlans, plannedTasks[taskIdx])
+	}
+
+	return taskPlans, cleanupTaskPlans
+}
+
+func (ts *TaskScheduler) planTask(taskState *taskState) *types.TaskPlan {
+	taskPlan := &types.TaskPlan{
+		Index:       taskState.index,
+		ParentIndex: taskState.ParentIndex(),
+		Depth:       taskState.taskDepth,
+		ID:          taskState.options.ID,
+		Name:        taskState.options.Name,
+		Title:       taskState.Title(),
+		ConfigVars:  []*types.TaskPlanQuery{},
+		DependsOn:   taskState.DependsOn(),
+	}
+
+	if taskState.options.Timeout.Duration > 0 {
+		taskPlan.Timeout = taskState.options.Timeout.Duration.String()
+	}
+
+	// check task condition
+	if taskState.options.If != "" {
+		taskPlan.If = planQuery(taskState.taskVars, "", taskState.options.If)
+	}
+
+	// check dynamic config variables
+	for cfgName, cfgQuery := range taskState.options.ConfigVars {
+		taskPlan.ConfigVars = append(taskPlan.ConfigVars, planQuery(taskState.taskVars, cfgName, cfgQuery))
+	}
+
+	sort.Slice(taskPlan.ConfigVars, func(a, b int) bool {
+		return taskPlan.ConfigVars[a].Name < taskPlan.ConfigVars[b].Name
+	})
+
+	// load & validate task config, this initializes the static child tasks of flow tasks
+	taskCtx := &types.TaskContext{
+		Scheduler: ts,
+		Index:     taskState.index,
+		Vars:      taskState.taskVars,
+		Outputs:   taskState.taskOutputs,
+		Logger:    taskState.logger,
+		NewTask: func(options *types.TaskOptions, variables types.Variables) (types.TaskIndex, error) {
+			task, err := ts.newTaskState(options, taskState, variables, taskState.isCleanup)
+			if err != nil {
+				return 0, err
+			}
+
+			return task.index, nil
+		},
+		SetResult:      func(_ types.TaskResult) {},
+		ReportProgress: func(_ float64, _ string) {},
+		EmitEvent:      func(_ string, _ any) {},
+	}
+
+	task, err := taskState.descriptor.NewTask(taskCtx, taskState.options)
+	if err != nil {
+		taskPlan.ConfigError = fmt.Sprintf("failed task initialization: %v", err)
+		return taskPlan
+	}
+
+	if err := task.LoadConfig(); err != nil {
+		taskPlan.ConfigError = err.Error()
+		return taskPlan
+	}
+
+	taskState.taskConfig = task.Config()
+	taskPlan.Config = secrets.RedactConfig(taskState.taskConfig)
+
+	return taskPlan
+}
+
+func planQuery(variables types.Variables, name, query string) *types.TaskPlanQuery {
+	queryPlan := &types.TaskPlanQuery{
+		Name:  name,
+		Query: query,
+	}
+
+	value, found, err := variables.ResolveQuery(query)
+
+	switch {
+	case err != nil:
+		queryPlan.Status = types.PlanQueryInvalid
+		queryPlan.Error = err.Error()
+	case !found || value == nil:
+		queryPlan.Status = types.PlanQueryUnresolved
+	default:
+		queryPlan.Status = types.PlanQueryResolved
+		queryPlan.Value = value
+	}
+
+	return queryPlan
+}
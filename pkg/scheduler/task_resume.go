@@ -0,0 +1,281 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/ethpandaops/assertoor/pkg/tasks"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+type resumeState struct {
+	childStates  map[resumeStateKey][]*db.TaskState
+	childOffsets map[resumeStateKey]int
+}
+
+type resumeStateKey struct {
+	parentTask uint64
+	isCleanup  bool
+}
+
+// LoadResumeState loads the persisted task states of an interrupted test run.
+// Tasks created afterwards are matched against the persisted states, so completed
+// tasks get restored instead of being executed again.
+func (ts *TaskScheduler) LoadResumeState() error {
+	database := ts.services.Database()
+	if database == nil {
+		return fmt.Errorf("cannot resume test run without database")
+	}
+
+	dbTaskStates, err := database.GetTaskStatesByRunID(ts.testRunID)
+	if err != nil {
+		return fmt.Errorf("failed loading task states: %w", err)
+	}
+
+	resumeState := &resumeState{
+		childStates:  map[resumeStateKey][]*db.TaskState{},
+		childOffsets: map[resumeStateKey]int{},
+	}
+
+	for _, dbTaskState := range dbTaskStates {
+		if isInterruptedTaskState(dbTaskState) {
+			taskDescriptor := tasks.GetTaskDescriptor(dbTaskState.Name)
+			if taskDescriptor == nil || !taskDescriptor.Resumable {
+				return fmt.Errorf("task %v (%v) got interrupted and cannot be resumed", dbTaskState.TaskID, dbTaskState.Name)
+			}
+		}
+
+		stateKey := resumeStateKey{
+			parentTask: dbTaskState.ParentTask,
+			isCleanup:  dbTaskState.RunFlags&db.TaskRunFlagCleanup != 0,
+		}
+		resumeState.childStates[stateKey] = append(resumeState.childStates[stateKey], dbTaskState)
+
+		if types.TaskIndex(dbTaskState.TaskID) > ts.taskCount {
+			ts.taskCount = types.TaskIndex(dbTaskState.TaskID)
+		}
+	}
+
+	ts.resumeState = resumeState
+
+	return nil
+}
+
+func isInterruptedTaskState(dbTaskState *db.TaskState) bool {
+	if dbTaskState.RunFlags&db.TaskRunFlagStarted == 0 {
+		return false
+	}
+
+	return dbTaskState.RunFlags&db.TaskRunFlagRunning != 0 || dbTaskState.StopTime == 0
+}
+
+func isCompletedTaskState(dbTaskState *db.TaskState) bool {
+	return dbTaskState.RunFlags&db.TaskRunFlagStarted != 0 && !isInterruptedTaskState(dbTaskState)
+}
+
+// matchTaskState returns the persisted state for the next child task of the given parent.
+// Children are matched in creation order, the first mismatch stops matching for that parent.
+func (rs *resumeState) matchTaskState(parentState *taskState, options *types.TaskOptions, isCleanupTask bool) *db.TaskState {
+	stateKey := resumeStateKey{
+		isCleanup: isCleanupTask,
+	}
+
+	if parentState != nil {
+		stateKey.parentTask = uint64(parentState.index)
+	}
+
+	childStates := rs.childStates[stateKey]
+	childOffset := rs.childOffsets[stateKey]
+
+	if childOffset >= len(childStates) {
+		return nil
+	}
+
+	dbTaskState := childStates[childOffset]
+	if dbTaskState.Name != options.Name || dbTaskState.RefID != options.ID {
+		// task tree diverged from the persisted one
+		rs.childOffsets[stateKey] = len(childStates)
+		return nil
+	}
+
+	rs.childOffsets[stateKey] = childOffset + 1
+
+	return dbTaskState
+}
+
+// restoreTaskState restores status, results, outputs & variables of a task that has
+// been completed before the test run got interrupted.
+func (ts *taskState) restoreTaskState(dbTaskState *db.TaskState) error {
+	ts.isRestored = true
+	ts.isStarted = true
+	ts.isSkipped = dbTaskState.RunFlags&db.TaskRunFlagSkipped != 0
+	ts.isTimeout = dbTaskState.RunFlags&db.TaskRunFlagTimeout != 0
+	ts.startTime = time.UnixMilli(dbTaskState.StartTime)
+	ts.stopTime = time.UnixMilli(dbTaskState.StopTime)
+	ts.taskResult = types.TaskResult(dbTaskState.TaskResult)
+	ts.dbTaskState = dbTaskState
+
+	if dbTaskState.TaskError != "" {
+		ts.taskError = errors.New(dbTaskState.TaskError)
+	}
+
+	if dbTaskState.TaskConfig != "" {
+		if err := yaml.Unmarshal([]byte(dbTaskState.TaskConfig), &ts.taskConfig); err != nil {
+			return fmt.Errorf("failed parsing task config: %w", err)
+		}
+	}
+
+	statusVars := map[string]any{}
+	if err := yaml.Unmarshal([]byte(dbTaskState.TaskStatus), &statusVars); err != nil {
+		return fmt.Errorf("failed parsing task status: %w", err)
+	}
+
+	for varName, varValue := range statusVars {
+		if varName != "outputs" {
+			ts.taskStatusVars.SetVar(varName, varValue)
+			continue
+		}
+
+		if outputsMap, ok := varValue.(map[string]any); ok {
+			for outputName, outputValue := range outputsMap {
+				ts.taskOutputs.SetVar(outputName, outputValue)
+			}
+		}
+	}
+
+	if dbTaskState.TaskVars != "" {
+		if err := yaml.Unmarshal([]byte(dbTaskState.TaskVars), &ts.restoredVars); err != nil {
+			return fmt.Errorf("failed parsing task variables: %w", err)
+		}
+	}
+
+	// secrets are masked in the persisted variables, they're restored from the variables they were inherited from
+	for varName, varValue := range ts.restoredVars {
+		if !secrets.ContainsMask(varValue) {
+			continue
+		}
+
+		inheritedValue, _ := ts.taskVars.LookupVar(varName)
+
+		restoredValue, ok := secrets.RestoreValue(varValue, inheritedValue)
+		if !ok {
+			return fmt.Errorf("cannot restore secrets in task variable %v", varName)
+		}
+
+		ts.restoredVars[varName] = restoredValue
+	}
+
+	return nil
+}
+
+// restoreChildTasks restores the persisted child tasks of a restored task.
+// The children are not executed again, they're only needed to show the complete task tree.
+// Variables set by children within the scope of the replayed task are replayed along with the task.
+// Must be called with taskStateMutex held.
+func (ts *TaskScheduler) restoreChildTasks(parentState, replayState *taskState, scopeOwner uint64) error {
+	stateKey := resumeStateKey{
+		parentTask: uint64(parentState.index),
+		isCleanup:  parentState.isCleanup,
+	}
+
+	for _, dbTaskState := range ts.resumeState.childStates[stateKey] {
+		if !isCompletedTaskState(dbTaskState) {
+			continue
+		}
+
+		options := &types.TaskOptions{
+			Name:    dbTaskState.Name,
+			Title:   dbTaskState.Title,
+			ID:      dbTaskState.RefID,
+			If:      dbTaskState.IfCond,
+			Timeout: helper.ChainRelativeDuration{Duration: time.Duration(dbTaskState.Timeout) * time.Second},
+		}
+
+		if dbTaskState.DependsOn != "" {
+			options.DependsOn = strings.Split(dbTaskState.DependsOn, ",")
+		}
+
+		taskDescriptor := tasks.GetTaskDescriptor(options.Name)
+		if taskDescriptor == nil {
+			return fmt.Errorf("unknown task name: %v", options.Name)
+		}
+
+		// restored children get a detached scope, so their ids don't leak into the parents scope
+		taskState := ts.createTaskState(types.TaskIndex(dbTaskState.TaskID), options, taskDescriptor, parentState, parentState.taskVars.NewScope(), parentState.isCleanup)
+
+		if err := taskState.restoreTaskState(dbTaskState); err != nil {
+			return fmt.Errorf("failed restoring task %v: %w", dbTaskState.TaskID, err)
+		}
+
+		taskState.isReplayed = true
+
+		if dbTaskState.ScopeOwner == scopeOwner {
+			for varName, varValue := range taskState.restoredVars {
+				replayState.inheritedVars[varName] = varValue
+			}
+		}
+
+		ts.emitTaskCreated(taskState)
+
+		if err := ts.restoreChildTasks(taskState, replayState, scopeOwner); err != nil {
+			return err
+		}
+	}
+
+	ts.resumeState.childOffsets[stateKey] = len(ts.resumeState.childStates[stateKey])
+
+	return nil
+}
+
+// replayTask completes a restored task without executing it again.
+// Variables set by the original execution are applied to the task scope again.
+func (ts *TaskScheduler) replayTask(ctx context.Context, taskState *taskState, taskWatchFn func(ctx context.Context, cancelFn context.CancelFunc, taskIndex types.TaskIndex)) error {
+	if taskState.isReplayed {
+		return fmt.Errorf("task has already been executed")
+	}
+
+	taskState.isReplayed = true
+
+	for varName, varValue := range taskState.inheritedVars {
+		taskState.taskVars.SetVar(varName, varValue)
+	}
+
+	for varName, varValue := range taskState.restoredVars {
+		taskState.taskVars.SetVar(varName, varValue)
+	}
+
+	taskState.logger.GetLogger().Infof("task has been completed before the test run got interrupted, skipping task")
+
+	if taskWatchFn != nil {
+		watchCtx, watchCancelFn := context.WithCancel(ctx)
+
+		go func() {
+			defer watchCancelFn()
+
+			taskWatchFn(watchCtx, watchCancelFn, taskState.index)
+		}()
+	}
+
+	if taskState.taskResult == types.TaskResultFailure {
+		ts.emitTaskFailed(taskState)
+
+		return fmt.Errorf("task failed: %w", taskState.taskError)
+	}
+
+	ts.emitTaskCompleted(taskState)
+
+	return nil
+}
+
+// isRunInterrupted returns true if the test run context has been cancelled (coordinator shutdown).
+// The task states aren't updated in that case, so the test run can be resumed from the last persisted state.
+func (ts *TaskScheduler) isRunInterrupted() bool {
+	return ts.testRunCtx != nil && ts.testRunCtx.Err() != nil
+}
@@ -0,0 +1,337 @@
+package scheduler
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/ethpandaops/assertoor/pkg/vars"
+)
+
+func TestResumeStateMatchTaskState(t *testing.T) {
+	completedFlags := db.TaskRunFlagStarted
+
+	tests := []struct {
+		name      string
+		persisted []*db.TaskState
+		tasks     []types.TaskOptions
+		cleanup   bool
+		want      []uint64
+	}{
+		{
+			name: "all tasks matched",
+			persisted: []*db.TaskState{
+				{TaskID: 1, Name: "sleep", RefID: "wait", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 2, Name: "check_clients_are_healthy", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 3, Name: "sleep", RunFlags: completedFlags | db.TaskRunFlagRunning},
+			},
+			tasks: []types.TaskOptions{
+				{Name: "sleep", ID: "wait"},
+				{Name: "check_clients_are_healthy"},
+				{Name: "sleep"},
+			},
+			want: []uint64{1, 2, 3},
+		},
+		{
+			name: "changed task name stops matching",
+			persisted: []*db.TaskState{
+				{TaskID: 1, Name: "sleep", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 2, Name: "check_clients_are_healthy", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 3, Name: "sleep", RunFlags: completedFlags, StopTime: 1},
+			},
+			tasks: []types.TaskOptions{
+				{Name: "sleep"},
+				{Name: "run_shell"},
+				{Name: "sleep"},
+			},
+			want: []uint64{1, 0, 0},
+		},
+		{
+			name: "changed task id stops matching",
+			persisted: []*db.TaskState{
+				{TaskID: 1, Name: "sleep", RefID: "first", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 2, Name: "sleep", RefID: "second", RunFlags: completedFlags, StopTime: 1},
+			},
+			tasks: []types.TaskOptions{
+				{Name: "sleep", ID: "first"},
+				{Name: "sleep", ID: "renamed"},
+			},
+			want: []uint64{1, 0},
+		},
+		{
+			name: "removed task stops matching",
+			persisted: []*db.TaskState{
+				{TaskID: 1, Name: "sleep", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 2, Name: "check_clients_are_healthy", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 3, Name: "run_shell", RunFlags: completedFlags, StopTime: 1},
+			},
+			tasks: []types.TaskOptions{
+				{Name: "sleep"},
+				{Name: "run_shell"},
+			},
+			want: []uint64{1, 0},
+		},
+		{
+			name: "added task is created fresh",
+			persisted: []*db.TaskState{
+				{TaskID: 1, Name: "sleep", RunFlags: completedFlags, StopTime: 1},
+			},
+			tasks: []types.TaskOptions{
+				{Name: "sleep"},
+				{Name: "run_shell"},
+			},
+			want: []uint64{1, 0},
+		},
+		{
+			name: "cleanup tasks are matched separately",
+			persisted: []*db.TaskState{
+				{TaskID: 1, Name: "sleep", RunFlags: completedFlags, StopTime: 1},
+				{TaskID: 2, Name: "run_shell", RunFlags: completedFlags | db.TaskRunFlagCleanup, StopTime: 1},
+			},
+			tasks: []types.TaskOptions{
+				{Name: "run_shell"},
+			},
+			cleanup: true,
+			want:    []uint64{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rs := &resumeState{
+				childStates:  map[resumeStateKey][]*db.TaskState{},
+				childOffsets: map[resumeStateKey]int{},
+			}
+
+			for _, dbTaskState := range tt.persisted {
+				stateKey := resumeStateKey{
+					parentTask: dbTaskState.ParentTask,
+					isCleanup:  dbTaskState.RunFlags&db.TaskRunFlagCleanup != 0,
+				}
+				rs.childStates[stateKey] = append(rs.childStates[stateKey], dbTaskState)
+			}
+
+			for idx := range tt.tasks {
+				matched := rs.matchTaskState(nil, &tt.tasks[idx], tt.cleanup)
+
+				matchedID := uint64(0)
+				if matched != nil {
+					matchedID = matched.TaskID
+				}
+
+				if matchedID != tt.want[idx] {
+					t.Errorf("task %v (%v): matched task %v, want %v", idx, tt.tasks[idx].Name, matchedID, tt.want[idx])
+				}
+			}
+		})
+	}
+}
+
+func TestResumeStateTaskStateCompletion(t *testing.T) {
+	tests := []struct {
+		name            string
+		state           *db.TaskState
+		wantCompleted   bool
+		wantInterrupted bool
+	}{
+		{
+			name:  "not started",
+			state: &db.TaskState{},
+		},
+		{
+			name:          "completed",
+			state:         &db.TaskState{RunFlags: db.TaskRunFlagStarted, StopTime: 1000},
+			wantCompleted: true,
+		},
+		{
+			name:            "still running",
+			state:           &db.TaskState{RunFlags: db.TaskRunFlagStarted | db.TaskRunFlagRunning, StopTime: 1000},
+			wantInterrupted: true,
+		},
+		{
+			name:            "started without stop time",
+			state:           &db.TaskState{RunFlags: db.TaskRunFlagStarted},
+			wantInterrupted: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isCompletedTaskState(tt.state); got != tt.wantCompleted {
+				t.Errorf("isCompletedTaskState() = %v, want %v", got, tt.wantCompleted)
+			}
+
+			if got := isInterruptedTaskState(tt.state); got != tt.wantInterrupted {
+				t.Errorf("isInterruptedTaskState() = %v, want %v", got, tt.wantInterrupted)
+			}
+		})
+	}
+}
+
+func TestRestoreTaskState(t *testing.T) {
+	tests := []struct {
+		name        string
+		state       *db.TaskState
+		wantResult  types.TaskResult
+		wantError   string
+		wantOutputs map[string]any
+		wantVars    map[string]any
+		wantErr     bool
+	}{
+		{
+			name: "successful task",
+			state: &db.TaskState{
+				RunFlags:   db.TaskRunFlagStarted,
+				StartTime:  1000,
+				StopTime:   2000,
+				TaskResult: int(types.TaskResultSuccess),
+				TaskStatus: "outputs:\n  blockNumber: 42\nprogress: 100\n",
+				TaskVars:   "deployedAddress: \"0x01\"\n",
+			},
+			wantResult:  types.TaskResultSuccess,
+			wantOutputs: map[string]any{"blockNumber": 42},
+			wantVars:    map[string]any{"deployedAddress": "0x01"},
+		},
+		{
+			name: "failed task",
+			state: &db.TaskState{
+				RunFlags:   db.TaskRunFlagStarted | db.TaskRunFlagTimeout,
+				StopTime:   2000,
+				TaskResult: int(types.TaskResultFailure),
+				TaskError:  "task timed out",
+				TaskStatus: "{}\n",
+			},
+			wantResult: types.TaskResultFailure,
+			wantError:  "task timed out",
+		},
+		{
+			name: "invalid task status",
+			state: &db.TaskState{
+				RunFlags:   db.TaskRunFlagStarted,
+				StopTime:   2000,
+				TaskStatus: "[invalid",
+			},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			taskState := &taskState{
+				taskOutputs:    vars.NewVariables(nil),
+				taskStatusVars: vars.NewVariables(nil),
+			}
+
+			err := taskState.restoreTaskState(tt.state)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected error")
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !taskState.isRestored || !taskState.isStarted {
+				t.Errorf("task state not marked as restored & started")
+			}
+
+			if taskState.taskResult != tt.wantResult {
+				t.Errorf("taskResult = %v, want %v", taskState.taskResult, tt.wantResult)
+			}
+
+			gotError := ""
+			if taskState.taskError != nil {
+				gotError = taskState.taskError.Error()
+			}
+
+			if gotError != tt.wantError {
+				t.Errorf("taskError = %q, want %q", gotError, tt.wantError)
+			}
+
+			for name, want := range tt.wantOutputs {
+				if got := taskState.taskOutputs.GetVar(name); got != want {
+					t.Errorf("output %v = %v, want %v", name, got, want)
+				}
+			}
+
+			if len(taskState.restoredVars) != len(tt.wantVars) {
+				t.Errorf("restoredVars = %v, want %v", taskState.restoredVars, tt.wantVars)
+			}
+
+			for name, want := range tt.wantVars {
+				if got := taskState.restoredVars[name]; got != want {
+					t.Errorf("restored var %v = %v, want %v", name, got, want)
+				}
+			}
+		})
+	}
+}
+
+func TestTaskStateSecretVars(t *testing.T) {
+	secret := "0x7a28b5ba57c53603b0b07b56bba752f7784bf506fa95edc395f5cf6c7514fe9d"
+	secrets.Register(secret)
+
+	parentVars := vars.NewVariables(nil)
+	parentVars.SetVar("walletPrivkey", secret)
+
+	state := &taskState{
+		ts:             &TaskScheduler{services: NewServicesProvider(nil, nil, nil, nil, nil)},
+		options:        &types.TaskOptions{},
+		taskVars:       parentVars.NewScope(),
+		taskStatusVars: vars.NewVariables(nil),
+		dbTaskState:    &db.TaskState{},
+		taskError:      fmt.Errorf("invalid key %v", secret),
+	}
+	state.varsRecorder = vars.NewScopeRecorder(state.taskVars)
+	state.varsRecorder.SetVar("walletPrivkey", secret)
+	state.varsRecorder.SetVar("wallet", map[string]any{"privkey": secret, "address": "0x01"})
+
+	if err := state.updateTaskState(); err != nil {
+		t.Fatalf("failed updating task state: %v", err)
+	}
+
+	for field, value := range map[string]string{
+		"task_vars":  state.dbTaskState.TaskVars,
+		"task_error": state.dbTaskState.TaskError,
+	} {
+		if strings.Contains(value, strings.TrimPrefix(secret, "0x")) || !strings.Contains(value, secrets.Mask) {
+			t.Errorf("%v not redacted: %q", field, value)
+		}
+	}
+
+	// variables copied from a secret are restored from the inherited variable
+	restoredState := &taskState{
+		taskVars:       parentVars.NewScope(),
+		taskOutputs:    vars.NewVariables(nil),
+		taskStatusVars: vars.NewVariables(nil),
+	}
+
+	persistedState := *state.dbTaskState
+	persistedState.TaskVars = "walletPrivkey: \"" + secrets.Mask + "\"\n"
+
+	if err := restoredState.restoreTaskState(&persistedState); err != nil {
+		t.Fatalf("failed restoring task state: %v", err)
+	}
+
+	if got := restoredState.restoredVars["walletPrivkey"]; got != secret {
+		t.Errorf("restored var walletPrivkey = %v, want the secret", got)
+	}
+
+	// secrets in variables without inherited value can't be restored
+	restoredState = &taskState{
+		taskVars:       parentVars.NewScope(),
+		taskOutputs:    vars.NewVariables(nil),
+		taskStatusVars: vars.NewVariables(nil),
+	}
+
+	if err := restoredState.restoreTaskState(state.dbTaskState); err == nil {
+		t.Errorf("expected error for unrestorable secret in variable wallet")
+	}
+}
@@ -2,15 +2,20 @@ package scheduler
 
 import (
 	"fmt"
+	"slices"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/db"
 	"github.com/ethpandaops/assertoor/pkg/logger"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/tasks"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/ethpandaops/assertoor/pkg/vars"
 	"github.com/jmoiron/sqlx"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 )
 
@@ -33,6 +38,9 @@ type taskState struct {
 	startTime time.Time
 	stopTime  time.Time
 
+	// wall clock value of a chain-relative timeout, set once the timeout has been resolved
+	resolvedTimeout atomic.Int64
+
 	taskConfig     any
 	taskOutputs    types.Variables
 	taskStatusVars types.Variables
@@ -46,7 +54,14 @@ type taskState struct {
 	progress        float64
 	progressMessage string
 
+	varsRecorder  *vars.ScopeRecorder
+	isRestored    bool
+	isReplayed    bool
+	restoredVars  map[string]any
+	inheritedVars map[string]any
+
 	dbTaskState *db.TaskState
+	span        trace.Span
 }
 
 func (ts *TaskScheduler) newTaskState(options *types.TaskOptions, parentState *taskState, variables types.Variables, isCleanupTask bool) (*taskState, error) {
@@ -63,12 +78,96 @@ func (ts *TaskScheduler) newTaskState(options *types.TaskOptions, parentState *t
 		return nil, fmt.Errorf("unknown task name: %v", options.Name)
 	}
 
-	// create task state
 	ts.taskStateMutex.Lock()
 	defer ts.taskStateMutex.Unlock()
 
-	ts.taskCount++
-	taskIdx := ts.taskCount
+	// match against persisted task states when resuming an interrupted test run
+	var resumedState *db.TaskState
+
+	if ts.resumeState != nil {
+		resumedState = ts.resumeState.matchTaskState(parentState, options, isCleanupTask)
+	}
+
+	var taskIdx types.TaskIndex
+
+	if resumedState != nil {
+		taskIdx = types.TaskIndex(resumedState.TaskID)
+	} else {
+		ts.taskCount++
+		taskIdx = ts.taskCount
+	}
+
+	// create task state
+	taskState := ts.createTaskState(taskIdx, options, taskDescriptor, parentState, variables, isCleanupTask)
+
+	if resumedState != nil && isCompletedTaskState(resumedState) {
+		if err := taskState.restoreTaskState(resumedState); err != nil {
+			return nil, fmt.Errorf("failed restoring task %v: %w", taskIdx, err)
+		}
+
+		taskState.inheritedVars = map[string]any{}
+
+		ts.emitTaskCreated(taskState)
+
+		if err := ts.restoreChildTasks(taskState, taskState, uint64(taskState.GetScopeOwner())); err != nil {
+			return nil, err
+		}
+
+		return taskState, nil
+	}
+
+	// add to database
+	if database := ts.services.Database(); database != nil {
+		taskState.dbTaskState = &db.TaskState{
+			RunID:      ts.testRunID,
+			TaskID:     uint64(taskIdx),
+			Name:       taskState.options.Name,
+			Title:      taskState.Title(),
+			RefID:      taskState.options.ID,
+			Timeout:    int64(taskState.options.Timeout.Seconds()),
+			IfCond:     taskState.options.If,
+			DependsOn:  strings.Join(taskState.options.DependsOn, ","),
+			ScopeOwner: uint64(taskState.GetScopeOwner()),
+		}
+
+		if taskState.isCleanup {
+			taskState.dbTaskState.RunFlags |= db.TaskRunFlagCleanup
+		}
+
+		if parentState != nil {
+			taskState.dbTaskState.ParentTask = uint64(parentState.index)
+		}
+
+		err := database.RunTransaction(func(tx *sqlx.Tx) error {
+			return database.InsertTaskState(tx, taskState.dbTaskState)
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	ts.emitTaskCreated(taskState)
+
+	return taskState, nil
+}
+
+// createTaskState creates the in-memory state of a task and registers it in the scheduler.
+// Must be called with taskStateMutex held.
+func (ts *TaskScheduler) createTaskState(taskIdx types.TaskIndex, options *types.TaskOptions, taskDescriptor *types.TaskDescriptor, parentState *taskState, variables types.Variables, isCleanupTask bool) *taskState {
+	logIndexOffset := uint64(0)
+
+	if database := ts.services.Database(); database != nil && ts.resumeState != nil {
+		// continue the log of tasks that have been started before the test run got interrupted
+		//nolint:errcheck // ignore missing logs
+		logIndexOffset, _ = database.GetLastLogIndex(ts.testRunID, uint64(taskIdx))
+	}
+
+	// avoid passing a typed nil event bus to the logger
+	var eventBus logger.EventBusPublisher
+	if ts.services.EventBus() != nil {
+		eventBus = ts.services.EventBus()
+	}
+
 	taskState := &taskState{
 		ts:          ts,
 		index:       taskIdx,
@@ -78,14 +177,15 @@ func (ts *TaskScheduler) newTaskState(options *types.TaskOptions, parentState *t
 		taskVars:    variables,
 		isCleanup:   isCleanupTask,
 		logger: logger.NewLogger(&logger.ScopeOptions{
-			Parent:     ts.logger.WithField("task", options.Name).WithField("taskidx", taskIdx),
-			BufferSize: 1000,
-			Database:   ts.services.Database(),
-			EventBus:   ts.services.EventBus(),
-			TestRunID:  ts.testRunID,
-			TaskID:     uint64(taskIdx),
-			TaskName:   options.Name,
-			TaskRefID:  options.ID,
+			Parent:         ts.logger.WithField("task", options.Name).WithField("taskidx", taskIdx),
+			BufferSize:     1000,
+			Database:       ts.services.Database(),
+			EventBus:       eventBus,
+			TestRunID:      ts.testRunID,
+			TaskID:         uint64(taskIdx),
+			TaskName:       options.Name,
+			TaskRefID:      options.ID,
+			LogIndexOffset: logIndexOffset,
 		}),
 		taskOutputs:    vars.NewVariables(nil),
 		taskStatusVars: vars.NewVariables(nil),
@@ -111,69 +211,50 @@ func (ts *TaskScheduler) newTaskState(options *types.TaskOptions, parentState *t
 		ts.allTasks = append(ts.allTasks, taskIdx)
 	}
 
-	// add to database
-	if database := ts.services.Database(); database != nil {
-		taskState.dbTaskState = &db.TaskState{
-			RunID:   ts.testRunID,
-			TaskID:  uint64(taskIdx),
-			Name:    taskState.options.Name,
-			Title:   taskState.Title(),
-			RefID:   taskState.options.ID,
-			Timeout: int64(taskState.options.Timeout.Seconds()),
-			IfCond:  taskState.options.If,
-		}
-
-		if taskState.isCleanup {
-			taskState.dbTaskState.RunFlags |= db.TaskRunFlagCleanup
-		}
-
-		if parentState != nil {
-			taskState.dbTaskState.ParentTask = uint64(parentState.index)
-		}
+	return taskState
+}
 
-		err := database.RunTransaction(func(tx *sqlx.Tx) error {
-			return database.InsertTaskState(tx, taskState.dbTaskState)
-		})
-		if err != nil {
-			return nil, err
-		}
+func (ts *TaskScheduler) emitTaskCreated(taskState *taskState) {
+	eventBus := ts.services.EventBus()
+	if eventBus == nil {
+		return
 	}
 
-	// Emit task.created event
-	if eventBus := ts.services.EventBus(); eventBus != nil {
-		parentIdx := uint64(0)
-		if parentState != nil {
-			parentIdx = uint64(parentState.index)
-		}
+	parentIdx := uint64(0)
+	if taskState.parentState != nil {
+		parentIdx = uint64(taskState.parentState.index)
+	}
 
-		// Extract runConcurrent from raw config (config isn't loaded/typed yet)
-		var runConcurrent bool
+	// Extract runConcurrent from raw config (config isn't loaded/typed yet)
+	var runConcurrent bool
 
-		if taskState.options.Config != nil {
-			var partial struct {
-				RunConcurrent bool `yaml:"runConcurrent"`
-			}
-			if err := taskState.options.Config.Unmarshal(&partial); err == nil {
-				runConcurrent = partial.RunConcurrent
-			}
+	if taskState.options.Config != nil {
+		var partial struct {
+			RunConcurrent bool `yaml:"runConcurrent"`
+		}
+		if err := taskState.options.Config.Unmarshal(&partial); err == nil {
+			runConcurrent = partial.RunConcurrent
 		}
-
-		eventBus.PublishTaskCreated(
-			ts.testRunID,
-			uint64(taskIdx),
-			taskState.options.Name,
-			taskState.Title(),
-			taskState.options.ID,
-			parentIdx,
-			runConcurrent,
-		)
 	}
 
-	return taskState, nil
+	eventBus.PublishTaskCreated(
+		ts.testRunID,
+		uint64(taskState.index),
+		taskState.options.Name,
+		taskState.Title(),
+		taskState.options.ID,
+		parentIdx,
+		runConcurrent,
+	)
 }
 
 func (ts *taskState) updateTaskState() error {
-	if ts.dbTaskState == nil {
+	if ts.dbTaskState == nil || ts.isRestored {
+		return nil
+	}
+
+	if ts.ts.isRunInterrupted() {
+		// keep the last persisted state, the test run gets resumed from there
 		return nil
 	}
 
@@ -227,7 +308,7 @@ func (ts *taskState) updateTaskState() error {
 
 	taskStatusVars := ts.taskStatusVars.GetVarsMap(nil, false)
 
-	configVarsYaml, err := yaml.Marshal(ts.Config())
+	configVarsYaml, err := yaml.Marshal(secrets.RedactConfig(ts.Config()))
 	if err != nil {
 		return err
 	}
@@ -255,10 +336,26 @@ func (ts *taskState) updateTaskState() error {
 		changedFields = append(changedFields, "task_result")
 	}
 
-	if ts.taskError != nil && ts.taskError.Error() != ts.dbTaskState.TaskError {
-		ts.dbTaskState.TaskError = ts.taskError.Error()
+	if ts.taskError != nil {
+		if taskError := secrets.Redact(ts.taskError.Error()); taskError != ts.dbTaskState.TaskError {
+			ts.dbTaskState.TaskError = taskError
 
-		changedFields = append(changedFields, "task_error")
+			changedFields = append(changedFields, "task_error")
+		}
+	}
+
+	if ts.varsRecorder != nil {
+		// variables may hold copies of secrets, they're restored from the inherited variables when resuming
+		recordedVarsYaml, err := yaml.Marshal(secrets.RedactConfig(ts.varsRecorder.GetRecordedVars()))
+		if err != nil {
+			return err
+		}
+
+		if string(recordedVarsYaml) != ts.dbTaskState.TaskVars {
+			ts.dbTaskState.TaskVars = string(recordedVarsYaml)
+
+			changedFields = append(changedFields, "task_vars")
+		}
 	}
 
 	if len(changedFields) == 0 {
@@ -349,6 +446,32 @@ func (ts *taskState) GetScopeOwner() types.TaskIndex {
 	return 0
 }
 
+// DependsOn returns the indexes of the sibling tasks referenced by the dependsOn task option.
+func (ts *taskState) DependsOn() []types.TaskIndex {
+	if len(ts.options.DependsOn) == 0 {
+		return nil
+	}
+
+	ts.ts.taskStateMutex.RLock()
+	defer ts.ts.taskStateMutex.RUnlock()
+
+	dependencies := []types.TaskIndex{}
+
+	for _, siblingState := range ts.ts.taskStateMap {
+		if siblingState == ts || siblingState.parentState != ts.parentState || siblingState.isCleanup != ts.isCleanup || siblingState.options.ID == "" {
+			continue
+		}
+
+		if slices.Contains(ts.options.DependsOn, siblingState.options.ID) {
+			dependencies = append(dependencies, siblingState.index)
+		}
+	}
+
+	slices.Sort(dependencies)
+
+	return dependencies
+}
+
 func (ts *taskState) GetTaskResultUpdateChan(oldResult types.TaskResult) <-chan bool {
 	ts.resultMutex.RLock()
 	defer ts.resultMutex.RUnlock()
@@ -401,6 +524,10 @@ func (ts *taskState) Config() any {
 }
 
 func (ts *taskState) Timeout() time.Duration {
+	if resolvedTimeout := ts.resolvedTimeout.Load(); resolvedTimeout > 0 {
+		return time.Duration(resolvedTimeout)
+	}
+
 	if ts.task != nil {
 		return ts.task.Timeout()
 	}
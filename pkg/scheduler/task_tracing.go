@@ -0,0 +1,74 @@
+package scheduler
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ethpandaops/assertoor/pkg/tracing"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// startTaskSpan starts the trace span of a task and returns the context to execute the task with.
+// The span is parented to the span of the parent task, so the trace mirrors the task tree even if
+// the parent task executes its children with a different context.
+func (ts *TaskScheduler) startTaskSpan(ctx context.Context, taskState *taskState) context.Context {
+	parentCtx := ctx
+
+	if taskState.parentState != nil && taskState.parentState.span != nil {
+		parentCtx = trace.ContextWithSpan(ctx, taskState.parentState.span)
+	}
+
+	attributes := []attribute.KeyValue{
+		attribute.Int64("assertoor.run_id", int64(ts.testRunID)),        //nolint:gosec // ignore
+		attribute.Int64("assertoor.task_index", int64(taskState.index)), //nolint:gosec // ignore
+		attribute.String("assertoor.task_name", taskState.options.Name),
+		attribute.String("assertoor.task_title", taskState.Title()),
+		attribute.Bool("assertoor.task_cleanup", taskState.isCleanup),
+	}
+
+	if taskState.parentState != nil {
+		attributes = append(attributes, attribute.Int64("assertoor.task_parent_index", int64(taskState.parentState.index))) //nolint:gosec // ignore
+	}
+
+	if taskState.options.ID != "" {
+		attributes = append(attributes, attribute.String("assertoor.task_id", taskState.options.ID))
+	}
+
+	spanCtx, span := tracing.StartSpan(parentCtx, taskState.options.Name, trace.WithAttributes(attributes...))
+	taskState.span = span
+
+	return spanCtx
+}
+
+// endTaskSpan records the task result on the trace span of the task and ends it.
+func (ts *taskState) endTaskSpan() {
+	if ts.span == nil {
+		return
+	}
+
+	var spanErr error
+
+	switch {
+	case ts.isSkipped:
+		ts.span.SetAttributes(attribute.String("assertoor.task_result", "skipped"))
+	case ts.taskResult == types.TaskResultSuccess:
+		ts.span.SetAttributes(attribute.String("assertoor.task_result", "success"))
+	case ts.taskResult == types.TaskResultFailure:
+		ts.span.SetAttributes(attribute.String("assertoor.task_result", "failure"))
+
+		spanErr = ts.taskError
+		if spanErr == nil {
+			spanErr = fmt.Errorf("task failed")
+		}
+	default:
+		ts.span.SetAttributes(attribute.String("assertoor.task_result", "none"))
+	}
+
+	if ts.isTimeout {
+		ts.span.SetAttributes(attribute.Bool("assertoor.task_timeout", true))
+	}
+
+	tracing.EndSpan(ts.span, spanErr)
+}
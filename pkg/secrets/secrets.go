@@ -0,0 +1,259 @@
+// Package secrets keeps track of secret values (private keys, mnemonics, tokens) and redacts
+// them from everything assertoor shows or persists: variable maps, task configs, logs and
+// command output.
+package secrets
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Mask replaces secret values in redacted output.
+const Mask = "********"
+
+// minSecretLength is the minimum length of registered secrets. Shorter values would
+// mask unrelated output and can't be kept secret by masking anyway.
+const minSecretLength = 6
+
+var registry = struct {
+	mutex    sync.RWMutex
+	values   map[string]bool
+	replacer *strings.Replacer
+}{
+	values: map[string]bool{},
+}
+
+// Register adds a value to the set of secrets that get redacted.
+// Hex values are registered with and without 0x prefix.
+func Register(value string) {
+	value = strings.TrimSpace(value)
+	if len(value) < minSecretLength {
+		return
+	}
+
+	registry.mutex.Lock()
+	defer registry.mutex.Unlock()
+
+	registerValue(value)
+
+	if trimmed, found := strings.CutPrefix(value, "0x"); found {
+		registerValue(trimmed)
+	} else if isHex(value) {
+		registerValue("0x" + value)
+	}
+
+	// replace longer secrets first, so secrets containing other secrets are masked completely
+	values := make([]string, 0, len(registry.values))
+	for secret := range registry.values {
+		values = append(values, secret)
+	}
+
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+
+	replacements := make([]string, 0, len(values)*2)
+	for _, secret := range values {
+		replacements = append(replacements, secret, Mask)
+	}
+
+	registry.replacer = strings.NewReplacer(replacements...)
+}
+
+func registerValue(value string) {
+	if len(value) >= minSecretLength {
+		registry.values[value] = true
+	}
+}
+
+func isHex(value string) bool {
+	for _, c := range value {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') && (c < 'A' || c > 'F') {
+			return false
+		}
+	}
+
+	return true
+}
+
+func getReplacer() *strings.Replacer {
+	registry.mutex.RLock()
+	defer registry.mutex.RUnlock()
+
+	return registry.replacer
+}
+
+// HasSecrets returns true if any secret has been registered.
+func HasSecrets() bool {
+	return getReplacer() != nil
+}
+
+// Redact masks all registered secrets in a string.
+func Redact(str string) string {
+	replacer := getReplacer()
+	if replacer == nil || str == "" {
+		return str
+	}
+
+	return replacer.Replace(str)
+}
+
+// RedactValue returns a copy of a generalized value (as returned by yaml or json unmarshalling)
+// with all registered secrets masked in its strings.
+func RedactValue(value any) any {
+	replacer := getReplacer()
+	if replacer == nil {
+		return value
+	}
+
+	return redactValue(replacer, value)
+}
+
+func redactValue(replacer *strings.Replacer, value any) any {
+	switch v := value.(type) {
+	case string:
+		return replacer.Replace(v)
+	case map[string]any:
+		redacted := make(map[string]any, len(v))
+		for key, item := range v {
+			redacted[key] = redactValue(replacer, item)
+		}
+
+		return redacted
+	case map[any]any:
+		redacted := make(map[any]any, len(v))
+		for key, item := range v {
+			redacted[key] = redactValue(replacer, item)
+		}
+
+		return redacted
+	case []any:
+		redacted := make([]any, len(v))
+		for idx, item := range v {
+			redacted[idx] = redactValue(replacer, item)
+		}
+
+		return redacted
+	case []string:
+		redacted := make([]string, len(v))
+		for idx, item := range v {
+			redacted[idx] = replacer.Replace(item)
+		}
+
+		return redacted
+	default:
+		return value
+	}
+}
+
+// RedactConfig returns a generalized copy of a task config with all registered secrets masked.
+// The config is returned unchanged if no secrets are registered.
+func RedactConfig(config any) any {
+	if config == nil || !HasSecrets() {
+		return config
+	}
+
+	configYaml, err := yaml.Marshal(config)
+	if err != nil {
+		return nil
+	}
+
+	var generalized any
+
+	if err := yaml.Unmarshal(configYaml, &generalized); err != nil {
+		return nil
+	}
+
+	return RedactValue(generalized)
+}
+
+// ContainsMask returns true if a generalized value contains a masked secret.
+func ContainsMask(value any) bool {
+	switch v := value.(type) {
+	case string:
+		return strings.Contains(v, Mask)
+	case map[string]any:
+		for _, item := range v {
+			if ContainsMask(item) {
+				return true
+			}
+		}
+	case []any:
+		for _, item := range v {
+			if ContainsMask(item) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// RestoreValue restores the masked secrets in a persisted value from the value it was derived from.
+// Parts of the persisted value that match the redacted inherited value are replaced by the inherited value,
+// everything else is kept. Returns false if a masked secret has no matching inherited value.
+func RestoreValue(persisted, inherited any) (any, bool) {
+	if !ContainsMask(persisted) {
+		return persisted, true
+	}
+
+	if inherited == nil {
+		return nil, false
+	}
+
+	// bring the inherited value into the generalized form of the persisted yaml
+	inheritedYaml, err := yaml.Marshal(inherited)
+	if err != nil {
+		return nil, false
+	}
+
+	var generalized any
+	if err := yaml.Unmarshal(inheritedYaml, &generalized); err != nil {
+		return nil, false
+	}
+
+	if reflect.DeepEqual(RedactValue(generalized), persisted) {
+		return generalized, true
+	}
+
+	switch v := persisted.(type) {
+	case map[string]any:
+		inheritedMap, _ := generalized.(map[string]any)
+		restored := make(map[string]any, len(v))
+
+		for key, item := range v {
+			restoredItem, ok := RestoreValue(item, inheritedMap[key])
+			if !ok {
+				return nil, false
+			}
+
+			restored[key] = restoredItem
+		}
+
+		return restored, true
+	case []any:
+		inheritedList, _ := generalized.([]any)
+		if len(inheritedList) != len(v) {
+			return nil, false
+		}
+
+		restored := make([]any, len(v))
+
+		for idx, item := range v {
+			restoredItem, ok := RestoreValue(item, inheritedList[idx])
+			if !ok {
+				return nil, false
+			}
+
+			restored[idx] = restoredItem
+		}
+
+		return restored, true
+	}
+
+	return nil, false
+}
@@ -0,0 +1,58 @@
+package secrets
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// Source describes where the value of a secret variable is loaded from.
+// Exactly one of Env and File must be set.
+type Source struct {
+	// Env is the name of the environment variable holding the secret.
+	Env string `yaml:"env" json:"env"`
+
+	// File is the path of a file holding the secret. Trailing whitespace is removed.
+	File string `yaml:"file" json:"file"`
+}
+
+// Validate checks that exactly one source is configured.
+func (s *Source) Validate() error {
+	switch {
+	case s.Env != "" && s.File != "":
+		return errors.New("only one of env and file can be set")
+	case s.Env == "" && s.File == "":
+		return errors.New("either env or file must be set")
+	}
+
+	return nil
+}
+
+// Load reads the secret value and registers it for redaction.
+func (s *Source) Load() (string, error) {
+	var value string
+
+	switch {
+	case s.Env != "":
+		envValue, found := os.LookupEnv(s.Env)
+		if !found {
+			return "", fmt.Errorf("environment variable %v not set", s.Env)
+		}
+
+		value = envValue
+	case s.File != "":
+		fileData, err := os.ReadFile(s.File)
+		if err != nil {
+			return "", fmt.Errorf("failed reading secret file: %w", err)
+		}
+
+		value = strings.TrimRight(string(fileData), " \t\r\n")
+	default:
+		return "", errors.New("no secret source configured")
+	}
+
+	Register(value)
+
+	return value, nil
+}
@@ -7,7 +7,7 @@ import (
 )
 
 type Config struct {
-	ClientPattern         string          `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for health checking."`
+	ClientPattern         string          `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for health checking."`
 	PollInterval          helper.Duration `yaml:"pollInterval" json:"pollInterval" desc:"Interval between health check polls (e.g., '5s', '1m')."`
 	SkipConsensusCheck    bool            `yaml:"skipConsensusCheck" json:"skipConsensusCheck" desc:"If true, skip consensus client health checks."`
 	SkipExecutionCheck    bool            `yaml:"skipExecutionCheck" json:"skipExecutionCheck" desc:"If true, skip execution client health checks."`
@@ -47,7 +47,8 @@ var (
 				Description: "Number of clients that passed health check.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -18,8 +18,8 @@ type Config struct {
 	ReferenceURL string `yaml:"referenceUrl" json:"referenceUrl" desc:"Reference URL (e.g. spec PR) for this check."`
 
 	// Client selection --------------------------------------------------------
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific CL endpoints (matches client.Name). Empty = all."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude specific CL endpoints (matches client.Name)."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific CL endpoints (matches client.Name or labels). Empty = all."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude specific CL endpoints (matches client.Name or labels)."`
 
 	// HTTP request (omit for SSE) --------------------------------------------
 	Method      string            `yaml:"method" json:"method" desc:"HTTP method. Default GET. Set to empty when 'sse' is configured."`
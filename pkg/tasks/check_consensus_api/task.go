@@ -105,7 +105,8 @@ var (
 				Description: "The reference URL from the config (echoed for aggregator use).",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -68,7 +68,8 @@ var (
 				Description: "Percentage of total attestation participation.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
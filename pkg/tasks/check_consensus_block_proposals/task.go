@@ -45,7 +45,8 @@ var (
 				Description: "Array of block bodies that match the criteria.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -9,7 +9,7 @@ type Config struct {
 	ExpectActive      bool    `yaml:"expectActive" json:"expectActive" desc:"If true, expect the builder to have FAR_FUTURE withdrawable epoch (i.e. active)."`
 	FailOnCheckMiss   bool    `yaml:"failOnCheckMiss" json:"failOnCheckMiss" desc:"If true, fail the task when builder status check condition is not met."`
 	ContinueOnPass    bool    `yaml:"continueOnPass" json:"continueOnPass" desc:"If true, continue monitoring after the check passes instead of completing immediately."`
-	ClientPattern     string  `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for state queries."`
+	ClientPattern     string  `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for state queries."`
 }
 
 func DefaultConfig() Config {
@@ -36,7 +36,8 @@ var (
 				Description: "The builder's public key.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -33,7 +33,8 @@ var (
 				Description: "Number of epochs since the last finalized checkpoint.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
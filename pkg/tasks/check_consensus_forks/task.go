@@ -24,7 +24,8 @@ var (
 				Description: "Array of fork info objects with head slot, root, and clients.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
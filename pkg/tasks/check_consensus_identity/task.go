@@ -52,7 +52,8 @@ var (
 				Description: "Number of clients that failed checks.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -21,6 +21,7 @@ var (
 		Config:      DefaultConfig(),
 		Outputs:     []types.TaskOutputDefinition{},
 		NewTask:     NewTask,
+		Resumable:   true,
 	}
 )
 
@@ -33,7 +33,8 @@ var (
 				Description: "The current wallclock epoch number.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
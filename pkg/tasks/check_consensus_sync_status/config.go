@@ -8,7 +8,7 @@ import (
 )
 
 type Config struct {
-	ClientPattern           string          `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for sync status checking."`
+	ClientPattern           string          `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for sync status checking."`
 	PollInterval            helper.Duration `yaml:"pollInterval" json:"pollInterval" desc:"Interval between sync status polls (e.g., '5s', '1m')."`
 	ExpectSyncing           bool            `yaml:"expectSyncing" json:"expectSyncing" desc:"If true, expect clients to be syncing."`
 	ExpectOptimistic        bool            `yaml:"expectOptimistic" json:"expectOptimistic" desc:"If true, expect clients to be in optimistic mode."`
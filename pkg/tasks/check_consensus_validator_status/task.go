@@ -34,7 +34,8 @@ var (
 				Description: "The validator's public key.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
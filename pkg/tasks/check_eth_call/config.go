@@ -8,8 +8,8 @@ type Config struct {
 	BlockNumber    uint64   `yaml:"blockNumber" json:"blockNumber" desc:"Block number to execute eth_call at (0 for latest)."`
 	FailOnMismatch bool     `yaml:"failOnMismatch" json:"failOnMismatch" desc:"If true, fail the task when eth_call result does not match expected."`
 
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for eth_call."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for eth_call."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 	ContinueOnPass       bool   `yaml:"continueOnPass" json:"continueOnPass" desc:"If true, continue monitoring after the check passes instead of completing immediately."`
 }
 
@@ -29,7 +29,8 @@ var (
 				Description: "The result of the eth_call as a hex string.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
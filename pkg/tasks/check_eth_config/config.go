@@ -1,8 +1,8 @@
 package checkethconfig
 
 type Config struct {
-	ClientPattern         string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for checking configuration."`
-	ExcludeClientPattern  string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern         string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for checking configuration."`
+	ExcludeClientPattern  string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 	FailOnMismatch        bool   `yaml:"failOnMismatch" json:"failOnMismatch" desc:"If true, fail the task when client configurations do not match."`
 	ExcludeSyncingClients bool   `yaml:"excludeSyncingClients" json:"excludeSyncingClients" desc:"If true, exclude clients that are still syncing from the check."`
 }
@@ -26,7 +26,8 @@ var (
 				Description: "The eth_config JSON returned by clients.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
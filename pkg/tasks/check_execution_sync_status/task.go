@@ -31,7 +31,8 @@ var (
 				Description: "Array of clients that do not meet sync criteria.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
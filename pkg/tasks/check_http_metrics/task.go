@@ -67,7 +67,8 @@ var (
 				Description: "Number of assertion evaluation errors.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
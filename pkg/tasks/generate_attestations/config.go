@@ -18,8 +18,8 @@ type Config struct {
 	LimitEpochs int `yaml:"limitEpochs" json:"limitEpochs" require:"A.2" desc:"Number of epochs to generate attestations for."`
 
 	// Client selection
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting attestations."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting attestations."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 
 	// Advanced settings
 	LastEpochAttestations bool   `yaml:"lastEpochAttestations" json:"lastEpochAttestations" desc:"If true, generate attestations referencing the last epoch instead of current."`
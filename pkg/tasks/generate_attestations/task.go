@@ -150,6 +150,11 @@ func (t *Task) Execute(ctx context.Context) error {
 			return ctx.Err()
 
 		case slot := <-slotSubscription.Channel():
+			// Skip slots while the test run is paused
+			if t.ctx.Scheduler.IsPaused() {
+				continue
+			}
+
 			// Skip slot processing in sendAllLastEpoch mode
 			if t.config.SendAllLastEpoch {
 				continue
@@ -32,8 +32,8 @@ type Config struct {
 	Amount        *big.Int `yaml:"amount" json:"amount" desc:"Amount (in wei) to send in each blob transaction."`
 	LegacyBlobTx  bool     `yaml:"legacyBlobTx" json:"legacyBlobTx" desc:"If true, use legacy blob transaction format."`
 
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting transactions."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting transactions."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 }
 
 func DefaultConfig() Config {
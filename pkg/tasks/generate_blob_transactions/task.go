@@ -219,6 +219,11 @@ func (t *Task) Execute(ctx context.Context) error {
 		} else if err := ctx.Err(); err != nil {
 			return err
 		}
+
+		// wait while the test run is paused
+		if err := t.ctx.Scheduler.WaitIfPaused(ctx); err != nil {
+			return err
+		}
 	}
 
 	return nil
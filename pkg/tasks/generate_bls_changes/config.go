@@ -11,8 +11,8 @@ type Config struct {
 	StartIndex           int    `yaml:"startIndex" json:"startIndex" desc:"Index within the mnemonic from which to start generating validator keys."`
 	IndexCount           int    `yaml:"indexCount" json:"indexCount" require:"A.3" desc:"Number of validator keys to generate from the mnemonic."`
 	TargetAddress        string `yaml:"targetAddress" json:"targetAddress" require:"C" desc:"Execution layer address to set as withdrawal credentials."`
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting operations."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting operations."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 	AwaitInclusion       bool   `yaml:"awaitInclusion" json:"awaitInclusion" desc:"Wait for BLS changes to be included in beacon blocks before completing."`
 }
 
@@ -221,6 +221,11 @@ func (t *Task) Execute(ctx context.Context) error {
 		} else if err := ctx.Err(); err != nil {
 			return err
 		}
+
+		// wait while the test run is paused
+		if err := t.ctx.Scheduler.WaitIfPaused(ctx); err != nil {
+			return err
+		}
 	}
 
 	t.ctx.Outputs.SetVar("blsChanges", blsChangesList)
@@ -377,7 +382,7 @@ func (t *Task) generateBlsChange(ctx context.Context, accountIdx uint64) (any, p
 	var client *consensus.Client
 
 	if t.config.ClientPattern == "" && t.config.ExcludeClientPattern == "" {
-		client = clientPool.GetConsensusPool().AwaitReadyEndpoint(ctx, consensus.AnyClient)
+		client = clientPool.GetConsensusPool().AwaitReadyEndpoint(ctx, consensus.AnyClient, consensus.WithTestRunStickyKey(t.ctx.Scheduler.GetTestRunID()))
 		if client == nil {
 			return nil, 0, ctx.Err()
 		}
@@ -23,8 +23,8 @@ type Config struct {
 	TxTipCap               *big.Int `yaml:"txTipCap" json:"txTipCap" desc:"Maximum priority tip (in wei) for builder deposit transactions."`
 	TxGasLimit             uint64   `yaml:"txGasLimit" json:"txGasLimit" desc:"Gas limit for builder deposit transactions."`
 	TxFeeBuffer            *big.Int `yaml:"txFeeBuffer" json:"txFeeBuffer" desc:"Extra value (in wei) sent on top of the deposit amount to cover the request fee."`
-	ClientPattern          string   `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting transactions."`
-	ExcludeClientPattern   string   `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern          string   `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting transactions."`
+	ExcludeClientPattern   string   `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 	AwaitReceipt           bool     `yaml:"awaitReceipt" json:"awaitReceipt" desc:"Wait for transaction receipts on the execution layer before completing."`
 	FailOnReject           bool     `yaml:"failOnReject" json:"failOnReject" desc:"Fail the task if any builder deposit transaction is rejected."`
 	AwaitInclusion         bool     `yaml:"awaitInclusion" json:"awaitInclusion" desc:"Wait for builder deposits to be included in beacon blocks before completing."`
@@ -19,8 +19,8 @@ type Config struct {
 	TxFeeCap             *big.Int `yaml:"txFeeCap" json:"txFeeCap" desc:"Maximum fee cap (in wei) for builder exit transactions."`
 	TxTipCap             *big.Int `yaml:"txTipCap" json:"txTipCap" desc:"Maximum priority tip (in wei) for builder exit transactions."`
 	TxGasLimit           uint64   `yaml:"txGasLimit" json:"txGasLimit" desc:"Gas limit for builder exit transactions."`
-	ClientPattern        string   `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting transactions."`
-	ExcludeClientPattern string   `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string   `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting transactions."`
+	ExcludeClientPattern string   `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 	AwaitReceipt         bool     `yaml:"awaitReceipt" json:"awaitReceipt" desc:"Wait for transaction receipts before completing."`
 	FailOnReject         bool     `yaml:"failOnReject" json:"failOnReject" desc:"Fail the task if any transaction is rejected."`
 }
@@ -265,6 +265,11 @@ func (t *Task) Execute(ctx context.Context) error {
 		} else if ctx.Err() != nil {
 			return nil
 		}
+
+		// wait while the test run is paused
+		if err := t.ctx.Scheduler.WaitIfPaused(ctx); err != nil {
+			return err
+		}
 	}
 
 	if t.config.AwaitReceipt {
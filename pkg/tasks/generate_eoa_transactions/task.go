@@ -241,6 +241,11 @@ func (t *Task) Execute(ctx context.Context) error {
 		} else if err := ctx.Err(); err != nil {
 			return err
 		}
+
+		// wait while the test run is paused
+		if err := t.ctx.Scheduler.WaitIfPaused(ctx); err != nil {
+			return err
+		}
 	}
 
 	if t.config.AwaitReceipt {
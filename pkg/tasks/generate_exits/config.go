@@ -12,8 +12,8 @@ type Config struct {
 	IndexCount           int    `yaml:"indexCount" json:"indexCount" require:"A.3" desc:"Number of validator keys to generate from the mnemonic."`
 	SendToAllClients     bool   `yaml:"sendToAllClients" json:"sendToAllClients" desc:"If true, submit voluntary exits to all ready consensus clients in parallel instead of just one."`
 	ExitEpoch            int64  `yaml:"exitEpoch" json:"exitEpoch" desc:"Exit epoch to set in the voluntary exit message (-1 for current epoch)."`
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting operations."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting operations."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 	AwaitInclusion       bool   `yaml:"awaitInclusion" json:"awaitInclusion" desc:"Wait for voluntary exits to be included in beacon blocks before completing."`
 }
 
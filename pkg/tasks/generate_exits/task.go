@@ -186,6 +186,11 @@ func (t *Task) Execute(ctx context.Context) error {
 		} else if err := ctx.Err(); err != nil {
 			return err
 		}
+
+		// wait while the test run is paused
+		if err := t.ctx.Scheduler.WaitIfPaused(ctx); err != nil {
+			return err
+		}
 	}
 
 	if totalCount == 0 {
@@ -322,7 +327,7 @@ func (t *Task) generateVoluntaryExit(ctx context.Context, accountIdx uint64, for
 				}
 			}
 		} else {
-			client := clientPool.GetConsensusPool().GetReadyEndpoint(consensus.AnyClient)
+			client := clientPool.GetConsensusPool().GetReadyEndpoint(consensus.AnyClient, consensus.WithTestRunStickyKey(t.ctx.Scheduler.GetTestRunID()))
 			if client != nil {
 				clients = []*consensus.Client{client}
 			}
@@ -186,6 +186,11 @@ func (t *Task) Execute(ctx context.Context) error {
 		} else if err := ctx.Err(); err != nil {
 			return err
 		}
+
+		// wait while the test run is paused
+		if err := t.ctx.Scheduler.WaitIfPaused(ctx); err != nil {
+			return err
+		}
 	}
 
 	// Await inclusion in blocks if configured
@@ -370,7 +375,7 @@ func (t *Task) generateSlashing(ctx context.Context, accountIdx uint64, forkStat
 	var client *consensus.Client
 
 	if t.config.ClientPattern == "" && t.config.ExcludeClientPattern == "" {
-		client = clientPool.GetConsensusPool().GetReadyEndpoint(consensus.AnyClient)
+		client = clientPool.GetConsensusPool().GetReadyEndpoint(consensus.AnyClient, consensus.WithTestRunStickyKey(t.ctx.Scheduler.GetTestRunID()))
 	} else {
 		clients := clientPool.GetClientsByNamePatterns(t.config.ClientPattern, t.config.ExcludeClientPattern)
 		if len(clients) == 0 {
@@ -33,8 +33,8 @@ type Config struct {
 		SignerPrivkey string  `yaml:"signerPrivkey" json:"signerPrivkey" desc:"Private key of the signer for the authorization."`
 	} `yaml:"authorizations" json:"authorizations" desc:"List of authorizations for EIP-7702 set code transactions."`
 
-	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern to select specific client endpoints for submitting the transaction."`
-	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern to exclude certain client endpoints."`
+	ClientPattern        string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector to select specific client endpoints for submitting the transaction."`
+	ExcludeClientPattern string `yaml:"excludeClientPattern" json:"excludeClientPattern" desc:"Regex pattern or label selector to exclude certain client endpoints."`
 
 	AwaitReceipt  bool `yaml:"awaitReceipt" json:"awaitReceipt" desc:"Wait for the transaction receipt before completing."`
 	FailOnReject  bool `yaml:"failOnReject" json:"failOnReject" desc:"Fail the task if the transaction is rejected."`
@@ -338,7 +338,7 @@ func (t *Task) Execute(ctx context.Context) error {
 
 	for _, authorizationWallet := range t.authorizationWallets {
 		// resync nonces of authorization wallets (might be increased by more than one, so we need to resync)
-		client := t.ctx.Scheduler.GetServices().WalletManager().GetReadyClient()
+		client := t.ctx.Scheduler.GetServices().WalletManager().GetReadyClient(ctx)
 
 		err = authorizationWallet.UpdateWallet(ctx, client, true)
 		if err != nil {
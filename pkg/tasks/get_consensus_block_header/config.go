@@ -7,7 +7,7 @@ import "github.com/ethpandaops/assertoor/pkg/helper"
 type Config struct {
 	// ClientPattern selects a single CL client to query. Empty = the
 	// first online client from the pool.
-	ClientPattern string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern selecting the source CL client. Empty = first online."`
+	ClientPattern string `yaml:"clientPattern" json:"clientPattern" desc:"Regex pattern or label selector selecting the source CL client. Empty = first online."`
 
 	// Slot, if non-zero, fetches the canonical block at that slot.
 	// Mutually exclusive with BlockRoot; takes precedence over the
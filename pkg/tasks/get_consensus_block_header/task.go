@@ -42,7 +42,8 @@ var (
 			{Name: "parentRoot", Type: "string", Description: "Parent block root."},
 			{Name: "stateRoot", Type: "string", Description: "State root committed in this block."},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
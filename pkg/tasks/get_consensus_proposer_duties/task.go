@@ -40,7 +40,8 @@ var (
 			{Name: "firstFutureValidatorIndex", Type: "int", Description: "Validator index of firstFutureSlot's proposer."},
 			{Name: "validatorIndices", Type: "array", Description: "Up to maxDuties unique validator indices drawn from this epoch's schedule."},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
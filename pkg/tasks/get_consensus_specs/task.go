@@ -23,7 +23,8 @@ var (
 				Description: "The consensus chain specs object.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -41,7 +41,8 @@ var (
 				Description: "Number of matching validators found.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
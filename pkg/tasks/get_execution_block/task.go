@@ -26,7 +26,8 @@ var (
 				Description: "The execution block header.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
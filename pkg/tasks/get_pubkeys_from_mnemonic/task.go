@@ -27,7 +27,8 @@ var (
 				Description: "Array of generated public keys.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -25,7 +25,8 @@ var (
 				Description: "The randomly generated mnemonic.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -41,7 +41,8 @@ var (
 				Description: "Summary object with wallet details.",
 			},
 		},
-		NewTask: NewTask,
+		NewTask:   NewTask,
+		Resumable: true,
 	}
 )
 
@@ -0,0 +1,46 @@
+package manageendpoint
+
+import (
+	"errors"
+	"fmt"
+)
+
+const (
+	ActionAdd     = "add"
+	ActionRemove  = "remove"
+	ActionDisable = "disable"
+	ActionEnable  = "enable"
+)
+
+type Config struct {
+	Action           string            `yaml:"action" json:"action" require:"A" desc:"Action to perform (add, remove, disable or enable)."`
+	Name             string            `yaml:"name" json:"name" require:"A" desc:"Name of the endpoint."`
+	ConsensusURL     string            `yaml:"consensusUrl" json:"consensusUrl" desc:"Consensus client URL of the endpoint to add."`
+	ConsensusHeaders map[string]string `yaml:"consensusHeaders" json:"consensusHeaders" desc:"Extra headers for the consensus client requests."`
+	ExecutionURL     string            `yaml:"executionUrl" json:"executionUrl" desc:"Execution client URL of the endpoint to add."`
+	ExecutionHeaders map[string]string `yaml:"executionHeaders" json:"executionHeaders" desc:"Extra headers for the execution client requests."`
+	Labels           map[string]string `yaml:"labels" json:"labels" desc:"Labels of the endpoint to add, used by client selectors."`
+	WaitForReady     bool              `yaml:"waitForReady" json:"waitForReady" desc:"Wait until the added or re-enabled endpoint is ready."`
+}
+
+func DefaultConfig() Config {
+	return Config{}
+}
+
+func (c *Config) Validate() error {
+	if c.Name == "" {
+		return errors.New("name must be set")
+	}
+
+	switch c.Action {
+	case ActionAdd:
+		if c.ConsensusURL == "" && c.ExecutionURL == "" {
+			return errors.New("consensusUrl or executionUrl must be set to add an endpoint")
+		}
+	case ActionRemove, ActionDisable, ActionEnable:
+	default:
+		return fmt.Errorf("invalid action: %v", c.Action)
+	}
+
+	return nil
+}
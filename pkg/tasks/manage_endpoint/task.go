@@ -0,0 +1,146 @@
+package manageendpoint
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	TaskName       = "manage_endpoint"
+	TaskDescriptor = &types.TaskDescriptor{
+		Name:        TaskName,
+		Description: "Adds, removes, disables or re-enables a client endpoint at runtime.",
+		Category:    "utility",
+		Config:      DefaultConfig(),
+		Outputs: []types.TaskOutputDefinition{
+			{
+				Name:        "clientIndex",
+				Type:        "int",
+				Description: "Index of the managed endpoint (-1 for removed endpoints).",
+			},
+		},
+		NewTask: NewTask,
+	}
+)
+
+type Task struct {
+	ctx     *types.TaskContext
+	options *types.TaskOptions
+	config  Config
+	logger  logrus.FieldLogger
+}
+
+func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
+	return &Task{
+		ctx:     ctx,
+		options: options,
+		logger:  ctx.Logger.GetLogger(),
+	}, nil
+}
+
+func (t *Task) Config() interface{} {
+	return t.config
+}
+
+func (t *Task) Timeout() time.Duration {
+	return t.options.Timeout.Duration
+}
+
+func (t *Task) LoadConfig() error {
+	config := DefaultConfig()
+
+	// parse static config
+	if t.options.Config != nil {
+		if err := t.options.Config.Unmarshal(&config); err != nil {
+			return fmt.Errorf("error parsing task config for %v: %w", TaskName, err)
+		}
+	}
+
+	// load dynamic vars
+	err := t.ctx.Vars.ConsumeVars(&config, t.options.ConfigVars)
+	if err != nil {
+		return err
+	}
+
+	// validate config
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	t.config = config
+
+	return nil
+}
+
+func (t *Task) Execute(ctx context.Context) error {
+	clientPool := t.ctx.Scheduler.GetServices().ClientPool()
+
+	var err error
+
+	switch t.config.Action {
+	case ActionAdd:
+		err = clientPool.AddClient(&clients.ClientConfig{
+			Name:             t.config.Name,
+			ConsensusURL:     t.config.ConsensusURL,
+			ConsensusHeaders: t.config.ConsensusHeaders,
+			ExecutionURL:     t.config.ExecutionURL,
+			ExecutionHeaders: t.config.ExecutionHeaders,
+			Labels:           t.config.Labels,
+		})
+	case ActionRemove:
+		err = clientPool.RemoveClient(t.config.Name)
+	case ActionDisable:
+		err = clientPool.SetClientEnabled(t.config.Name, false)
+	case ActionEnable:
+		err = clientPool.SetClientEnabled(t.config.Name, true)
+	}
+
+	if err != nil {
+		return fmt.Errorf("could not %v endpoint %v: %w", t.config.Action, t.config.Name, err)
+	}
+
+	t.logger.Infof("endpoint %v: %v", t.config.Name, t.config.Action)
+
+	poolClient := clientPool.GetClientByName(t.config.Name)
+	if poolClient == nil {
+		t.ctx.Outputs.SetVar("clientIndex", -1)
+		t.ctx.ReportProgress(100, "Endpoint removed")
+
+		return nil
+	}
+
+	t.ctx.Outputs.SetVar("clientIndex", int(poolClient.ConsensusClient.GetIndex()))
+
+	if t.config.WaitForReady && (t.config.Action == ActionAdd || t.config.Action == ActionEnable) {
+		t.ctx.ReportProgress(0, "Waiting for endpoint to become ready...")
+
+		for !t.isClientReady(clientPool, poolClient) {
+			select {
+			case <-ctx.Done():
+				return ctx.Err()
+			case <-time.After(1 * time.Second):
+			}
+		}
+	}
+
+	t.ctx.ReportProgress(100, fmt.Sprintf("Endpoint %v: %v", t.config.Name, t.config.Action))
+
+	return nil
+}
+
+func (t *Task) isClientReady(clientPool *clients.ClientPool, poolClient *clients.PoolClient) bool {
+	if poolClient.Config.ConsensusURL != "" && !clientPool.GetConsensusPool().IsClientReady(poolClient.ConsensusClient) {
+		return false
+	}
+
+	if poolClient.Config.ExecutionURL != "" && !clientPool.GetExecutionPool().IsClientReady(poolClient.ExecutionClient) {
+		return false
+	}
+
+	return true
+}
@@ -26,6 +26,7 @@ var (
 		Config:      DefaultConfig(),
 		Outputs:     []types.TaskOutputDefinition{},
 		NewTask:     NewTask,
+		Resumable:   true,
 	}
 )
 
@@ -14,9 +14,12 @@ import (
 	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/tracing"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -172,6 +175,17 @@ func (t *Task) Execute(ctx context.Context) error {
 	nodeArgs := append([]string{}, t.config.NodeArgs...)
 	nodeArgs = append(nodeArgs, scriptPath)
 
+	// trace the node process as child span of the task
+	ctx, span := tracing.StartSpan(ctx, "run_javascript subprocess", trace.WithAttributes(
+		attribute.String("process.executable.name", t.config.NodePath),
+	))
+
+	var execErr error
+
+	defer func() {
+		tracing.EndSpan(span, execErr)
+	}()
+
 	command := exec.CommandContext(ctx, t.config.NodePath, nodeArgs...) //nolint:gosec // user-provided script is the whole point
 
 	stdin, err := command.StdinPipe()
@@ -203,6 +217,9 @@ func (t *Task) Execute(ctx context.Context) error {
 		return envErr
 	}
 
+	// propagate the trace context (TRACEPARENT) to the node process
+	command.Env = append(command.Env, tracing.SubprocessEnv(ctx)...)
+
 	defer func() {
 		t.storeTaskResults(summaryFile, resultDir)
 	}()
@@ -211,6 +228,8 @@ func (t *Task) Execute(ctx context.Context) error {
 	if err = command.Start(); err != nil {
 		scriptLogger.Errorf("failed starting node: %v", err)
 
+		execErr = err
+
 		return err
 	}
 
@@ -221,8 +240,6 @@ func (t *Task) Execute(ctx context.Context) error {
 	}
 
 	// wait for process & output streams
-	var execErr error
-
 	waitChan := make(chan bool)
 
 	go func() {
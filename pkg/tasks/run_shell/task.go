@@ -14,9 +14,12 @@ import (
 	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/tracing"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 )
 
 var (
@@ -98,6 +101,17 @@ func (t *Task) Execute(ctx context.Context) error {
 		}
 	}()
 
+	// trace the shell process as child span of the task
+	ctx, span := tracing.StartSpan(ctx, "run_shell subprocess", trace.WithAttributes(
+		attribute.String("process.executable.name", t.config.Shell),
+	))
+
+	var execErr error
+
+	defer func() {
+		tracing.EndSpan(span, execErr)
+	}()
+
 	//nolint:gosec // ignore
 	command := exec.CommandContext(ctx, t.config.Shell, t.config.ShellArgs...)
 
@@ -106,6 +120,9 @@ func (t *Task) Execute(ctx context.Context) error {
 	// user-supplied envVars below still take precedence via append order.
 	command.Env = append(command.Env, os.Environ()...)
 
+	// propagate the trace context (TRACEPARENT) to the shell
+	command.Env = append(command.Env, tracing.SubprocessEnv(ctx)...)
+
 	stdin, err := command.StdinPipe()
 	if err != nil {
 		cmdLogger.Errorf("failed getting stdin pipe")
@@ -183,6 +200,9 @@ func (t *Task) Execute(ctx context.Context) error {
 	err = command.Start()
 	if err != nil {
 		cmdLogger.Errorf("failed starting shell")
+
+		execErr = err
+
 		return err
 	}
 
@@ -198,8 +218,6 @@ func (t *Task) Execute(ctx context.Context) error {
 	}
 
 	// wait for process & output streams
-	var execErr error
-
 	waitChan := make(chan bool)
 
 	go func() {
@@ -7,9 +7,10 @@ import (
 )
 
 type Config struct {
-	ForegroundTask   *helper.RawMessageMasked `yaml:"foregroundTask" json:"foregroundTask" require:"A" desc:"The primary task to execute in the foreground."`
-	BackgroundTask   *helper.RawMessageMasked `yaml:"backgroundTask" json:"backgroundTask" desc:"The task to execute in the background while foreground runs."`
-	NewVariableScope bool                     `yaml:"newVariableScope" json:"newVariableScope" desc:"If true, create a new variable scope for child tasks."`
+	ForegroundTask   *helper.RawMessageMasked     `yaml:"foregroundTask" json:"foregroundTask" require:"A" desc:"The primary task to execute in the foreground."`
+	BackgroundTask   *helper.RawMessageMasked     `yaml:"backgroundTask" json:"backgroundTask" desc:"The task to execute in the background while foreground runs."`
+	NewVariableScope bool                         `yaml:"newVariableScope" json:"newVariableScope" desc:"If true, create a new variable scope for child tasks."`
+	ForegroundDelay  helper.ChainRelativeDuration `yaml:"foregroundDelay" json:"foregroundDelay" desc:"Time window between starting the background task and starting the foreground task (wall clock or chain-relative, e.g. '2 epochs')."`
 
 	// When to complete (based on foreground task result)
 	// These allow early exit even if foreground task hasn't returned yet
@@ -20,6 +20,7 @@ var (
 		Config:      DefaultConfig(),
 		Outputs:     []types.TaskOutputDefinition{},
 		NewTask:     NewTask,
+		Resumable:   true,
 	}
 )
 
@@ -129,6 +130,36 @@ func (t *Task) Execute(ctx context.Context) error {
 		go t.execBackgroundTask(childCtx)
 	}
 
+	if t.config.ForegroundDelay.Duration > 0 || t.config.ForegroundDelay.IsChainRelative() {
+		// resolve a copy, so the config keeps the chain-relative value
+		delayDuration := t.config.ForegroundDelay
+
+		foregroundDelay, err := t.ctx.Scheduler.ResolveDuration(childCtx, &delayDuration)
+		if err != nil {
+			cancel()
+			return err
+		}
+
+		t.ctx.ReportProgress(0, fmt.Sprintf("Waiting %v before starting foreground task...", foregroundDelay.Round(time.Second)))
+
+		select {
+		case <-time.After(foregroundDelay):
+		case result := <-t.resultChan:
+			// background task completed the task during the delay
+			t.ctx.SetResult(result.result)
+
+			t.resultChanMtx.Lock()
+			t.resultChan = nil
+			t.resultChanMtx.Unlock()
+			cancel()
+
+			return result.err
+		case <-ctx.Done():
+			cancel()
+			return ctx.Err()
+		}
+	}
+
 	t.ctx.ReportProgress(0, "Running foreground task...")
 
 	go t.execForegroundTask(childCtx)
@@ -0,0 +1,53 @@
+package runtaskloop
+
+import (
+	"errors"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+type Config struct {
+	Tasks []helper.RawMessageMasked `yaml:"tasks" json:"tasks" require:"A" desc:"List of tasks to execute sequentially in each iteration."`
+
+	// Loop conditions
+	While         string `yaml:"while" json:"while" desc:"JQ condition checked before each iteration. The loop stops once it evaluates to false."`
+	Until         string `yaml:"until" json:"until" desc:"JQ condition checked after each iteration. The loop stops once it evaluates to true."`
+	MaxIterations uint64 `yaml:"maxIterations" json:"maxIterations" desc:"Maximum number of iterations (0 = unlimited)."`
+
+	// Iteration behavior
+	IterationVar string                       `yaml:"iterationVar" json:"iterationVar" desc:"Variable name to bind the current iteration number (starting at 0) to."`
+	Interval     helper.ChainRelativeDuration `yaml:"interval" json:"interval" desc:"Time to wait between iterations (wall clock or chain-relative, e.g. '1 epoch')."`
+	SlotAligned  bool                         `yaml:"slotAligned" json:"slotAligned" desc:"If true, start each iteration at the beginning of a new slot."`
+
+	// Failure handling (default: stop on first failed iteration)
+	ContinueOnFailure   bool `yaml:"continueOnFailure" json:"continueOnFailure" desc:"If true, continue with the next iteration even if an iteration fails."`
+	FailOnMaxIterations bool `yaml:"failOnMaxIterations" json:"failOnMaxIterations" desc:"If true, fail when maxIterations is reached before the while/until condition ended the loop."`
+
+	// Result transformation
+	InvertResult bool `yaml:"invertResult" json:"invertResult" desc:"If true, swap success and failure results."`
+	IgnoreResult bool `yaml:"ignoreResult" json:"ignoreResult" desc:"If true, always report success regardless of child task results."`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Tasks:        []helper.RawMessageMasked{},
+		IterationVar: "iteration",
+	}
+}
+
+func (c *Config) Validate() error {
+	if len(c.Tasks) == 0 {
+		return errors.New("at least one task must be specified")
+	}
+
+	if c.While == "" && c.Until == "" && c.MaxIterations == 0 {
+		return errors.New("at least one of while, until or maxIterations must be specified")
+	}
+
+	// a condition-only loop needs a bound or a pace, otherwise it spins as fast as its child tasks complete
+	if c.MaxIterations == 0 && c.Interval.Duration <= 0 && !c.Interval.IsChainRelative() && !c.SlotAligned {
+		return errors.New("maxIterations, a non-zero interval or slotAligned must be specified when looping on while/until only")
+	}
+
+	return nil
+}
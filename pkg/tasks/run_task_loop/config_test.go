@@ -0,0 +1,83 @@
+package runtaskloop
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+func TestConfig_Validate(t *testing.T) {
+	chainInterval := helper.ChainRelativeDuration{}
+	if err := chainInterval.Unmarshal("1 epoch"); err != nil {
+		t.Fatalf("failed parsing chain-relative interval: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		mutate  func(c *Config)
+		wantErr string
+	}{
+		{
+			name:    "no tasks",
+			mutate:  func(c *Config) { c.Tasks = nil },
+			wantErr: "at least one task must be specified",
+		},
+		{
+			name: "no loop condition",
+			mutate: func(c *Config) {
+				c.Until = ""
+			},
+			wantErr: "at least one of while, until or maxIterations must be specified",
+		},
+		{
+			name:    "condition only",
+			mutate:  func(c *Config) {},
+			wantErr: "maxIterations, a non-zero interval or slotAligned must be specified",
+		},
+		{
+			name:   "condition with maxIterations",
+			mutate: func(c *Config) { c.MaxIterations = 10 },
+		},
+		{
+			name:   "condition with interval",
+			mutate: func(c *Config) { c.Interval = helper.ChainRelativeDuration{Duration: 12 * time.Second} },
+		},
+		{
+			name:   "condition with chain-relative interval",
+			mutate: func(c *Config) { c.Interval = chainInterval },
+		},
+		{
+			name:   "condition with slot alignment",
+			mutate: func(c *Config) { c.SlotAligned = true },
+		},
+		{
+			name: "maxIterations only",
+			mutate: func(c *Config) {
+				c.Until = ""
+				c.MaxIterations = 3
+			},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			cfg := DefaultConfig()
+			cfg.Tasks = []helper.RawMessageMasked{{}}
+			cfg.Until = "true"
+			tc.mutate(&cfg)
+
+			err := cfg.Validate()
+
+			switch {
+			case tc.wantErr == "" && err != nil:
+				t.Fatalf("unexpected error: %v", err)
+			case tc.wantErr != "" && err == nil:
+				t.Fatalf("expected error containing %q, got nil", tc.wantErr)
+			case tc.wantErr != "" && !strings.Contains(err.Error(), tc.wantErr):
+				t.Fatalf("error %q does not contain %q", err.Error(), tc.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,274 @@
+package runtaskloop
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/ethpandaops/assertoor/pkg/vars"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	TaskName       = "run_task_loop"
+	TaskDescriptor = &types.TaskDescriptor{
+		Name:        TaskName,
+		Description: "Run tasks repeatedly while or until a condition holds.",
+		Category:    "flow-control",
+		Config:      DefaultConfig(),
+		Outputs: []types.TaskOutputDefinition{
+			{
+				Name:        "iterations",
+				Type:        "int",
+				Description: "Number of completed iterations.",
+			},
+			{
+				Name:        "childScopes",
+				Type:        "object",
+				Description: "Variable scopes of the iterations, keyed by iteration number.",
+			},
+		},
+		NewTask:   NewTask,
+		Resumable: true,
+	}
+)
+
+type Task struct {
+	ctx     *types.TaskContext
+	options *types.TaskOptions
+	config  Config
+	logger  logrus.FieldLogger
+}
+
+func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
+	return &Task{
+		ctx:     ctx,
+		options: options,
+		logger:  ctx.Logger.GetLogger(),
+	}, nil
+}
+
+func (t *Task) Config() interface{} {
+	return t.config
+}
+
+func (t *Task) Timeout() time.Duration {
+	return t.options.Timeout.Duration
+}
+
+func (t *Task) LoadConfig() error {
+	config := DefaultConfig()
+
+	// parse static config
+	if t.options.Config != nil {
+		if err := t.options.Config.Unmarshal(&config); err != nil {
+			return fmt.Errorf("error parsing task config for %v: %w", TaskName, err)
+		}
+	}
+
+	// load dynamic vars
+	err := t.ctx.Vars.ConsumeVars(&config, t.options.ConfigVars)
+	if err != nil {
+		return err
+	}
+
+	// validate config
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	t.config = config
+
+	return nil
+}
+
+func (t *Task) Execute(ctx context.Context) error {
+	var slotChan <-chan bool
+
+	if t.config.SlotAligned {
+		slotSubscription := t.ctx.Scheduler.GetServices().ClientPool().GetConsensusPool().GetBlockCache().SubscribeWallclockSlotEvent(1)
+		defer slotSubscription.Unsubscribe()
+
+		slotNotifyChan := make(chan bool)
+		slotChan = slotNotifyChan
+
+		go func() {
+			for {
+				select {
+				case <-ctx.Done():
+					return
+				case <-slotSubscription.Channel():
+				}
+
+				// drop slot events while an iteration is running
+				select {
+				case slotNotifyChan <- true:
+				default:
+				}
+			}
+		}()
+	}
+
+	childScopes := vars.NewVariables(nil)
+	t.ctx.Outputs.SetSubScope("childScopes", childScopes)
+	t.ctx.Outputs.SetVar("iterations", 0)
+
+	var loopErr error
+
+	conditionMet := false
+	iteration := uint64(0)
+
+	for t.config.MaxIterations == 0 || iteration < t.config.MaxIterations {
+		if iteration > 0 && (t.config.Interval.Duration > 0 || t.config.Interval.IsChainRelative()) {
+			// resolve a copy, so the config keeps the chain-relative value
+			intervalDuration := t.config.Interval
+
+			interval, err := t.ctx.Scheduler.ResolveDuration(ctx, &intervalDuration)
+			if err != nil {
+				return err
+			}
+
+			select {
+			case <-time.After(interval):
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		if slotChan != nil {
+			select {
+			case <-slotChan:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+
+		iterationVars := t.ctx.Vars.NewScope()
+		iterationVars.SetVar("scopeOwner", uint64(t.ctx.Index))
+
+		if t.config.IterationVar != "" {
+			iterationVars.SetVar(t.config.IterationVar, iteration)
+		}
+
+		if t.config.While != "" {
+			isValid, err := t.evaluateCondition(iterationVars, t.config.While)
+			if err != nil {
+				return fmt.Errorf("failed evaluating while condition: %w", err)
+			}
+
+			if !isValid {
+				t.logger.Infof("while condition not met before iteration %v, stopping loop", iteration)
+
+				conditionMet = true
+
+				break
+			}
+		}
+
+		childScopes.SetSubScope(fmt.Sprintf("%v", iteration), vars.NewScopeFilter(iterationVars))
+
+		iterationErr := t.runIteration(ctx, iteration, iterationVars)
+
+		iteration++
+
+		t.ctx.Outputs.SetVar("iterations", iteration)
+
+		if t.config.MaxIterations > 0 {
+			progress := float64(iteration) / float64(t.config.MaxIterations) * 100
+			t.ctx.ReportProgress(progress, fmt.Sprintf("Iteration %d/%d completed", iteration, t.config.MaxIterations))
+		} else {
+			t.ctx.ReportProgress(0, fmt.Sprintf("Iteration %d completed", iteration))
+		}
+
+		if iterationErr != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+
+			if !t.config.ContinueOnFailure {
+				loopErr = fmt.Errorf("iteration %v failed: %w", iteration-1, iterationErr)
+				break
+			}
+
+			t.logger.Warnf("iteration %v failed: %v", iteration-1, iterationErr)
+		}
+
+		if t.config.Until != "" {
+			isValid, err := t.evaluateCondition(iterationVars, t.config.Until)
+			if err != nil {
+				return fmt.Errorf("failed evaluating until condition: %w", err)
+			}
+
+			if isValid {
+				t.logger.Infof("until condition met after iteration %v, stopping loop", iteration-1)
+
+				conditionMet = true
+
+				break
+			}
+		}
+	}
+
+	hasCondition := t.config.While != "" || t.config.Until != ""
+	if loopErr == nil && hasCondition && !conditionMet && t.config.FailOnMaxIterations {
+		loopErr = fmt.Errorf("loop condition not met after %v iterations", iteration)
+	}
+
+	// Apply result transformation
+	if t.config.IgnoreResult {
+		return nil
+	}
+
+	if t.config.InvertResult {
+		if loopErr != nil {
+			return nil
+		}
+
+		return fmt.Errorf("all iterations succeeded, but failure was expected")
+	}
+
+	return loopErr
+}
+
+func (t *Task) runIteration(ctx context.Context, iteration uint64, iterationVars types.Variables) error {
+	childTasks := make([]types.TaskIndex, 0, len(t.config.Tasks))
+
+	for i := range t.config.Tasks {
+		taskOpts, err := t.ctx.Scheduler.ParseTaskOptions(&t.config.Tasks[i])
+		if err != nil {
+			return fmt.Errorf("failed parsing child task config #%v : %w", i+1, err)
+		}
+
+		task, err := t.ctx.NewTask(taskOpts, iterationVars)
+		if err != nil {
+			return fmt.Errorf("failed initializing child task #%v : %w", i+1, err)
+		}
+
+		childTasks = append(childTasks, task)
+	}
+
+	t.logger.Debugf("starting iteration %v", iteration)
+
+	for i, task := range childTasks {
+		if err := t.ctx.Scheduler.ExecuteTask(ctx, task, nil); err != nil {
+			return fmt.Errorf("child task #%v failed: %w", i+1, err)
+		}
+	}
+
+	return nil
+}
+
+func (t *Task) evaluateCondition(taskVars types.Variables, query string) (bool, error) {
+	conditionResult, _, err := taskVars.ResolveQuery(query)
+	if err != nil {
+		return false, err
+	}
+
+	isValid, isOk := conditionResult.(bool)
+	if !isOk {
+		return false, fmt.Errorf("condition did not return a boolean: %v", conditionResult)
+	}
+
+	return isValid, nil
+}
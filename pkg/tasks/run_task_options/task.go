@@ -19,6 +19,7 @@ var (
 		Config:      DefaultConfig(),
 		Outputs:     []types.TaskOutputDefinition{},
 		NewTask:     NewTask,
+		Resumable:   true,
 	}
 )
 
@@ -0,0 +1,41 @@
+package runtaskswitch
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+type Config struct {
+	Cases            []CaseConfig              `yaml:"cases" json:"cases" require:"A" desc:"List of cases. The tasks of the first case with a matching condition are executed."`
+	Default          []helper.RawMessageMasked `yaml:"default" json:"default" desc:"List of tasks to execute if no case matches."`
+	NewVariableScope bool                      `yaml:"newVariableScope" json:"newVariableScope" desc:"If true, create a new variable scope for child tasks."`
+	FailOnNoMatch    bool                      `yaml:"failOnNoMatch" json:"failOnNoMatch" desc:"If true, fail when no case matches and no default tasks are defined."`
+}
+
+type CaseConfig struct {
+	Name  string                    `yaml:"name" json:"name" desc:"Optional name of the case, exposed via the matchedCase output."`
+	If    string                    `yaml:"if" json:"if" require:"A" desc:"JQ condition that selects this case."`
+	Tasks []helper.RawMessageMasked `yaml:"tasks" json:"tasks" desc:"List of tasks to execute sequentially if the case matches."`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Cases: []CaseConfig{},
+	}
+}
+
+func (c *Config) Validate() error {
+	if len(c.Cases) == 0 {
+		return errors.New("at least one case must be specified")
+	}
+
+	for i, caseCfg := range c.Cases {
+		if caseCfg.If == "" {
+			return fmt.Errorf("case #%v has no condition", i+1)
+		}
+	}
+
+	return nil
+}
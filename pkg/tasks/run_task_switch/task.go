@@ -0,0 +1,173 @@
+package runtaskswitch
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/ethpandaops/assertoor/pkg/vars"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	TaskName       = "run_task_switch"
+	TaskDescriptor = &types.TaskDescriptor{
+		Name:        TaskName,
+		Description: "Run the tasks of the first case with a matching condition.",
+		Category:    "flow-control",
+		Config:      DefaultConfig(),
+		Outputs: []types.TaskOutputDefinition{
+			{
+				Name:        "matchedCase",
+				Type:        "string",
+				Description: "Name (or number) of the matched case, 'default' if the default tasks were executed or empty if nothing matched.",
+			},
+			{
+				Name:        "matchedIndex",
+				Type:        "int",
+				Description: "Index of the matched case (-1 if no case matched).",
+			},
+		},
+		NewTask:   NewTask,
+		Resumable: true,
+	}
+)
+
+type Task struct {
+	ctx     *types.TaskContext
+	options *types.TaskOptions
+	config  Config
+	logger  logrus.FieldLogger
+}
+
+func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
+	return &Task{
+		ctx:     ctx,
+		options: options,
+		logger:  ctx.Logger.GetLogger(),
+	}, nil
+}
+
+func (t *Task) Config() interface{} {
+	return t.config
+}
+
+func (t *Task) Timeout() time.Duration {
+	return t.options.Timeout.Duration
+}
+
+func (t *Task) LoadConfig() error {
+	config := DefaultConfig()
+
+	// parse static config
+	if t.options.Config != nil {
+		if err := t.options.Config.Unmarshal(&config); err != nil {
+			return fmt.Errorf("error parsing task config for %v: %w", TaskName, err)
+		}
+	}
+
+	// load dynamic vars
+	err := t.ctx.Vars.ConsumeVars(&config, t.options.ConfigVars)
+	if err != nil {
+		return err
+	}
+
+	// validate config
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	t.config = config
+
+	return nil
+}
+
+func (t *Task) Execute(ctx context.Context) error {
+	matchedIndex := -1
+	matchedCase := ""
+
+	var branchTasks []helper.RawMessageMasked
+
+	for i, caseCfg := range t.config.Cases {
+		conditionResult, _, err := t.ctx.Vars.ResolveQuery(caseCfg.If)
+		if err != nil {
+			return fmt.Errorf("failed evaluating condition of case #%v: %w", i+1, err)
+		}
+
+		isValid, isOk := conditionResult.(bool)
+		if !isOk {
+			return fmt.Errorf("condition of case #%v did not return a boolean: %v", i+1, conditionResult)
+		}
+
+		if isValid {
+			matchedIndex = i
+			matchedCase = caseCfg.Name
+
+			if matchedCase == "" {
+				matchedCase = fmt.Sprintf("%v", i+1)
+			}
+
+			branchTasks = caseCfg.Tasks
+
+			break
+		}
+	}
+
+	if matchedIndex == -1 && len(t.config.Default) > 0 {
+		matchedCase = "default"
+		branchTasks = t.config.Default
+	}
+
+	t.ctx.Outputs.SetVar("matchedCase", matchedCase)
+	t.ctx.Outputs.SetVar("matchedIndex", matchedIndex)
+
+	if matchedCase == "" {
+		if t.config.FailOnNoMatch {
+			return fmt.Errorf("no case matched")
+		}
+
+		t.logger.Infof("no case matched, skipping child tasks")
+
+		return nil
+	}
+
+	t.logger.Infof("case %v matched", matchedCase)
+
+	// init child tasks
+	taskVars := t.ctx.Vars
+	if t.config.NewVariableScope {
+		taskVars = taskVars.NewScope()
+		taskVars.SetVar("scopeOwner", uint64(t.ctx.Index))
+		t.ctx.Outputs.SetSubScope("childScope", vars.NewScopeFilter(taskVars))
+	}
+
+	childTasks := make([]types.TaskIndex, 0, len(branchTasks))
+
+	for i := range branchTasks {
+		taskOpts, err := t.ctx.Scheduler.ParseTaskOptions(&branchTasks[i])
+		if err != nil {
+			return fmt.Errorf("failed parsing child task config #%v : %w", i+1, err)
+		}
+
+		task, err := t.ctx.NewTask(taskOpts, taskVars)
+		if err != nil {
+			return fmt.Errorf("failed initializing child task #%v : %w", i+1, err)
+		}
+
+		childTasks = append(childTasks, task)
+	}
+
+	for i, task := range childTasks {
+		if err := t.ctx.Scheduler.ExecuteTask(ctx, task, nil); err != nil {
+			return fmt.Errorf("child task #%v failed: %w", i+1, err)
+		}
+
+		completedTasks := i + 1
+		progress := float64(completedTasks) / float64(len(childTasks)) * 100
+		t.ctx.ReportProgress(progress, fmt.Sprintf("Task %d/%d completed", completedTasks, len(childTasks)))
+	}
+
+	return nil
+}
@@ -2,12 +2,14 @@ package runtasks
 
 import (
 	"errors"
+	"fmt"
 
 	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/types"
 )
 
 type Config struct {
-	Tasks            []helper.RawMessageMasked `yaml:"tasks" json:"tasks" require:"A" desc:"List of tasks to execute sequentially."`
+	Tasks            []helper.RawMessageMasked `yaml:"tasks" json:"tasks" require:"A" desc:"List of tasks to execute sequentially, or as a dependency graph if any task defines dependsOn."`
 	NewVariableScope bool                      `yaml:"newVariableScope" json:"newVariableScope" desc:"If true, create a new variable scope for child tasks."`
 
 	// Failure handling (default: stop on first failure)
@@ -16,11 +18,16 @@ type Config struct {
 	// Result transformation
 	InvertResult bool `yaml:"invertResult" json:"invertResult" desc:"If true, swap success and failure results."`
 	IgnoreResult bool `yaml:"ignoreResult" json:"ignoreResult" desc:"If true, always report success regardless of child task results."`
+
+	// Dependency graph execution (only used if any child task defines dependsOn)
+	MaxParallel       uint64 `yaml:"maxParallel" json:"maxParallel" desc:"Maximum number of child tasks running at the same time when executing a dependency graph (0 = unlimited)."`
+	DependencyFailure string `yaml:"dependencyFailure" json:"dependencyFailure" desc:"How to handle dependents of a failed child task: 'skip' to skip them or 'continue' to run them anyway."`
 }
 
 func DefaultConfig() Config {
 	return Config{
-		Tasks: []helper.RawMessageMasked{},
+		Tasks:             []helper.RawMessageMasked{},
+		DependencyFailure: types.TaskGraphDependencyFailureSkip,
 	}
 }
 
@@ -29,5 +36,11 @@ func (c *Config) Validate() error {
 		return errors.New("at least one task must be specified")
 	}
 
+	switch c.DependencyFailure {
+	case types.TaskGraphDependencyFailureSkip, types.TaskGraphDependencyFailureContinue:
+	default:
+		return fmt.Errorf("invalid dependencyFailure '%v', must be 'skip' or 'continue'", c.DependencyFailure)
+	}
+
 	return nil
 }
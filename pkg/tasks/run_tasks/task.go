@@ -13,11 +13,12 @@ var (
 	TaskName       = "run_tasks"
 	TaskDescriptor = &types.TaskDescriptor{
 		Name:        TaskName,
-		Description: "Run tasks sequentially.",
+		Description: "Run tasks sequentially, or as a dependency graph if child tasks define dependsOn.",
 		Category:    "flow-control",
 		Config:      DefaultConfig(),
 		Outputs:     []types.TaskOutputDefinition{},
 		NewTask:     NewTask,
+		Resumable:   true,
 	}
 )
 
@@ -27,6 +28,9 @@ type Task struct {
 	config  Config
 	logger  logrus.FieldLogger
 	tasks   []types.TaskIndex
+
+	// execute child tasks as dependency graph
+	graphMode bool
 }
 
 func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
@@ -68,6 +72,8 @@ func (t *Task) LoadConfig() error {
 
 	// init child tasks
 	childTasks := []types.TaskIndex{}
+	childOptions := []*types.TaskOptions{}
+	graphMode := false
 
 	var taskVars types.Variables
 
@@ -83,6 +89,18 @@ func (t *Task) LoadConfig() error {
 			return fmt.Errorf("failed parsing child task config #%v : %w", i+1, err)
 		}
 
+		childOptions = append(childOptions, taskOpts)
+	}
+
+	if err := types.ValidateTaskGraph(childOptions); err != nil {
+		return fmt.Errorf("invalid child task dependencies: %w", err)
+	}
+
+	for i, taskOpts := range childOptions {
+		if len(taskOpts.DependsOn) > 0 {
+			graphMode = true
+		}
+
 		task, err := t.ctx.NewTask(taskOpts, taskVars)
 		if err != nil {
 			return fmt.Errorf("failed initializing child task #%v : %w", i+1, err)
@@ -93,11 +111,37 @@ func (t *Task) LoadConfig() error {
 
 	t.config = config
 	t.tasks = childTasks
+	t.graphMode = graphMode
 
 	return nil
 }
 
 func (t *Task) Execute(ctx context.Context) error {
+	var taskErr error
+
+	if t.graphMode {
+		taskErr = t.executeGraph(ctx)
+	} else {
+		taskErr = t.executeSequential(ctx)
+	}
+
+	// Apply result transformation
+	if t.config.IgnoreResult {
+		return nil
+	}
+
+	if t.config.InvertResult {
+		if taskErr != nil {
+			return nil
+		}
+
+		return fmt.Errorf("all tasks succeeded, but failure was expected")
+	}
+
+	return taskErr
+}
+
+func (t *Task) executeSequential(ctx context.Context) error {
 	totalTasks := len(t.tasks)
 
 	var taskErr error
@@ -119,18 +163,40 @@ func (t *Task) Execute(ctx context.Context) error {
 		t.ctx.ReportProgress(progress, fmt.Sprintf("Task %d/%d completed", completedTasks, totalTasks))
 	}
 
-	// Apply result transformation
-	if t.config.IgnoreResult {
-		return nil
+	return taskErr
+}
+
+func (t *Task) executeGraph(ctx context.Context) error {
+	totalTasks := len(t.tasks)
+	completedTasks := 0
+
+	childNumbers := make(map[types.TaskIndex]int, totalTasks)
+	for i, task := range t.tasks {
+		childNumbers[task] = i + 1
 	}
 
-	if t.config.InvertResult {
-		if taskErr != nil {
-			return nil
-		}
+	err := t.ctx.Scheduler.ExecuteTaskGraph(ctx, t.tasks, &types.TaskGraphOptions{
+		MaxParallel:       t.config.MaxParallel,
+		ContinueOnFailure: t.config.ContinueOnFailure,
+		DependencyFailure: t.config.DependencyFailure,
+		OnTaskComplete: func(taskIndex types.TaskIndex, err error) {
+			if err != nil && t.config.ContinueOnFailure {
+				t.logger.Warnf("child task #%v failed: %v", childNumbers[taskIndex], err)
+			}
 
-		return fmt.Errorf("all tasks succeeded, but failure was expected")
+			// Report progress after each task completes or got skipped
+			completedTasks++
+			progress := float64(completedTasks) / float64(totalTasks) * 100
+			t.ctx.ReportProgress(progress, fmt.Sprintf("Task %d/%d completed", completedTasks, totalTasks))
+		},
+	})
+
+	switch {
+	case ctx.Err() != nil:
+		return ctx.Err()
+	case err != nil && !t.config.ContinueOnFailure:
+		return fmt.Errorf("child task graph failed: %w", err)
 	}
 
-	return taskErr
+	return nil
 }
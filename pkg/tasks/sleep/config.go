@@ -7,7 +7,7 @@ import (
 )
 
 type Config struct {
-	Duration helper.Duration `yaml:"duration" json:"duration" require:"A" desc:"Duration to sleep (e.g., '10s', '5m', '1h')."`
+	Duration helper.ChainRelativeDuration `yaml:"duration" json:"duration" require:"A" desc:"Duration to sleep (e.g., '10s', '5m', '1h', '3 epochs', 'slot+32' or 'until epoch 12')."`
 }
 
 func DefaultConfig() Config {
@@ -15,7 +15,7 @@ func DefaultConfig() Config {
 }
 
 func (c *Config) Validate() error {
-	if c.Duration.Duration <= 0 {
+	if !c.Duration.IsChainRelative() && c.Duration.Duration <= 0 {
 		return errors.New("duration must be greater than 0")
 	}
 
@@ -18,6 +18,7 @@ var (
 		Config:      DefaultConfig(),
 		Outputs:     []types.TaskOutputDefinition{},
 		NewTask:     NewTask,
+		Resumable:   true,
 	}
 )
 
@@ -71,7 +72,14 @@ func (t *Task) LoadConfig() error {
 }
 
 func (t *Task) Execute(ctx context.Context) error {
-	duration := t.config.Duration.Duration
+	// resolve a copy, so the config keeps the chain-relative value
+	sleepDuration := t.config.Duration
+
+	duration, err := t.ctx.Scheduler.ResolveDuration(ctx, &sleepDuration)
+	if err != nil {
+		return err
+	}
+
 	if duration <= 0 {
 		return nil
 	}
@@ -81,7 +89,11 @@ func (t *Task) Execute(ctx context.Context) error {
 
 	defer ticker.Stop()
 
-	t.ctx.ReportProgress(0, fmt.Sprintf("Sleeping for %v", duration))
+	if chainDuration := t.config.Duration.ChainDuration(); chainDuration != nil {
+		t.ctx.ReportProgress(0, fmt.Sprintf("Sleeping for %v (%v)", duration.Round(time.Second), chainDuration))
+	} else {
+		t.ctx.ReportProgress(0, fmt.Sprintf("Sleeping for %v", duration))
+	}
 
 	for {
 		select {
@@ -0,0 +1,100 @@
+package storedelete
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	TaskName       = "store_delete"
+	TaskDescriptor = &types.TaskDescriptor{
+		Name:        TaskName,
+		Description: "Deletes a value from the persistent key/value store.",
+		Category:    "utility",
+		Config:      DefaultConfig(),
+		Outputs: []types.TaskOutputDefinition{
+			{
+				Name:        "deleted",
+				Type:        "bool",
+				Description: "True if the key existed and has been deleted.",
+			},
+		},
+		NewTask:   NewTask,
+		Resumable: true,
+	}
+)
+
+type Task struct {
+	ctx     *types.TaskContext
+	options *types.TaskOptions
+	config  Config
+	logger  logrus.FieldLogger
+}
+
+func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
+	return &Task{
+		ctx:     ctx,
+		options: options,
+		logger:  ctx.Logger.GetLogger(),
+	}, nil
+}
+
+func (t *Task) Config() interface{} {
+	return t.config
+}
+
+func (t *Task) Timeout() time.Duration {
+	return t.options.Timeout.Duration
+}
+
+func (t *Task) LoadConfig() error {
+	config := DefaultConfig()
+
+	// parse static config
+	if t.options.Config != nil {
+		if err := t.options.Config.Unmarshal(&config); err != nil {
+			return fmt.Errorf("error parsing task config for %v: %w", TaskName, err)
+		}
+	}
+
+	// load dynamic vars
+	err := t.ctx.Vars.ConsumeVars(&config, t.options.ConfigVars)
+	if err != nil {
+		return err
+	}
+
+	// validate config
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	t.config = config
+
+	return nil
+}
+
+func (t *Task) Execute(_ context.Context) error {
+	store := kvstore.NewStore(t.ctx.Scheduler.GetServices().Database())
+
+	deleted, err := store.Delete(t.config.Namespace, t.config.Key)
+	if err != nil {
+		return err
+	}
+
+	if !deleted && t.config.FailIfMissing {
+		return fmt.Errorf("key %v/%v not found", t.config.Namespace, t.config.Key)
+	}
+
+	t.logger.Infof("deleted %v/%v: %v", t.config.Namespace, t.config.Key, deleted)
+
+	t.ctx.Outputs.SetVar("deleted", deleted)
+
+	t.ctx.ReportProgress(100, "Key deleted")
+
+	return nil
+}
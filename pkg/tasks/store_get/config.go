@@ -0,0 +1,22 @@
+package storeget
+
+import (
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
+)
+
+type Config struct {
+	Namespace     string `yaml:"namespace" json:"namespace" desc:"Namespace of the key."`
+	Key           string `yaml:"key" json:"key" require:"A" desc:"Key to read."`
+	DefaultValue  any    `yaml:"defaultValue" json:"defaultValue,omitempty" desc:"Value returned if the key does not exist."`
+	FailIfMissing bool   `yaml:"failIfMissing" json:"failIfMissing" desc:"Fail the task if the key does not exist."`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Namespace: kvstore.DefaultNamespace,
+	}
+}
+
+func (c *Config) Validate() error {
+	return kvstore.ValidateKey(c.Namespace, c.Key)
+}
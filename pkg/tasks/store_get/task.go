@@ -0,0 +1,124 @@
+package storeget
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	TaskName       = "store_get"
+	TaskDescriptor = &types.TaskDescriptor{
+		Name:        TaskName,
+		Description: "Reads a value from the persistent key/value store.",
+		Category:    "utility",
+		Config:      DefaultConfig(),
+		Outputs: []types.TaskOutputDefinition{
+			{
+				Name:        "found",
+				Type:        "bool",
+				Description: "True if the key exists.",
+			},
+			{
+				Name:        "value",
+				Type:        "any",
+				Description: "The stored value or defaultValue if the key does not exist.",
+			},
+			{
+				Name:        "version",
+				Type:        "uint64",
+				Description: "Version of the stored entry (0 if the key does not exist).",
+			},
+		},
+		NewTask:   NewTask,
+		Resumable: true,
+	}
+)
+
+type Task struct {
+	ctx     *types.TaskContext
+	options *types.TaskOptions
+	config  Config
+	logger  logrus.FieldLogger
+}
+
+func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
+	return &Task{
+		ctx:     ctx,
+		options: options,
+		logger:  ctx.Logger.GetLogger(),
+	}, nil
+}
+
+func (t *Task) Config() interface{} {
+	return t.config
+}
+
+func (t *Task) Timeout() time.Duration {
+	return t.options.Timeout.Duration
+}
+
+func (t *Task) LoadConfig() error {
+	config := DefaultConfig()
+
+	// parse static config
+	if t.options.Config != nil {
+		if err := t.options.Config.Unmarshal(&config); err != nil {
+			return fmt.Errorf("error parsing task config for %v: %w", TaskName, err)
+		}
+	}
+
+	// load dynamic vars
+	err := t.ctx.Vars.ConsumeVars(&config, t.options.ConfigVars)
+	if err != nil {
+		return err
+	}
+
+	// validate config
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	t.config = config
+
+	return nil
+}
+
+func (t *Task) Execute(_ context.Context) error {
+	store := kvstore.NewStore(t.ctx.Scheduler.GetServices().Database())
+
+	entry, err := store.Get(t.config.Namespace, t.config.Key)
+	if err != nil {
+		return err
+	}
+
+	if entry == nil {
+		if t.config.FailIfMissing {
+			return fmt.Errorf("key %v/%v not found", t.config.Namespace, t.config.Key)
+		}
+
+		t.logger.Infof("key %v/%v not found, using default value", t.config.Namespace, t.config.Key)
+
+		t.ctx.Outputs.SetVar("found", false)
+		t.ctx.Outputs.SetVar("value", t.config.DefaultValue)
+		t.ctx.Outputs.SetVar("version", uint64(0))
+
+		t.ctx.ReportProgress(100, "Key not found")
+
+		return nil
+	}
+
+	t.logger.Infof("loaded %v/%v (version %v)", entry.Namespace, entry.Key, entry.Version)
+
+	t.ctx.Outputs.SetVar("found", true)
+	t.ctx.Outputs.SetVar("value", entry.Value)
+	t.ctx.Outputs.SetVar("version", entry.Version)
+
+	t.ctx.ReportProgress(100, "Value loaded")
+
+	return nil
+}
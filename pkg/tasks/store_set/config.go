@@ -0,0 +1,46 @@
+package storeset
+
+import (
+	"errors"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
+)
+
+type Config struct {
+	Namespace      string          `yaml:"namespace" json:"namespace" desc:"Namespace of the key."`
+	Key            string          `yaml:"key" json:"key" require:"A" desc:"Key to write."`
+	Value          any             `yaml:"value" json:"value,omitempty" desc:"Value to store (any YAML/JSON value)."`
+	TTL            helper.Duration `yaml:"ttl" json:"ttl" desc:"Let the entry expire after this duration (0 for no expiry)."`
+	OnlyIfMissing  bool            `yaml:"onlyIfMissing" json:"onlyIfMissing" desc:"Only store the value if the key does not exist yet."`
+	IfVersion      uint64          `yaml:"ifVersion" json:"ifVersion" desc:"Only store the value if the stored entry still has this version (compare-and-set)."`
+	Increment      int64           `yaml:"increment" json:"increment" desc:"Atomically add this amount to the stored integer instead of writing value (missing keys start at 0)."`
+	FailOnConflict bool            `yaml:"failOnConflict" json:"failOnConflict" desc:"Fail the task if onlyIfMissing or ifVersion prevented the write."`
+}
+
+func DefaultConfig() Config {
+	return Config{
+		Namespace:      kvstore.DefaultNamespace,
+		FailOnConflict: true,
+	}
+}
+
+func (c *Config) Validate() error {
+	if err := kvstore.ValidateKey(c.Namespace, c.Key); err != nil {
+		return err
+	}
+
+	if c.TTL.Duration < 0 {
+		return errors.New("ttl must be a positive fixed duration")
+	}
+
+	if c.OnlyIfMissing && c.IfVersion > 0 {
+		return errors.New("onlyIfMissing and ifVersion can not be used together")
+	}
+
+	if c.Increment != 0 && (c.OnlyIfMissing || c.IfVersion > 0 || c.Value != nil) {
+		return errors.New("increment can not be used together with value, onlyIfMissing or ifVersion")
+	}
+
+	return nil
+}
@@ -0,0 +1,156 @@
+package storeset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	TaskName       = "store_set"
+	TaskDescriptor = &types.TaskDescriptor{
+		Name:        TaskName,
+		Description: "Writes a value to the persistent key/value store.",
+		Category:    "utility",
+		Config:      DefaultConfig(),
+		Outputs: []types.TaskOutputDefinition{
+			{
+				Name:        "stored",
+				Type:        "bool",
+				Description: "True if the value has been written, false if the write was skipped due to a conflict.",
+			},
+			{
+				Name:        "value",
+				Type:        "any",
+				Description: "The stored value (the current value if the write was skipped).",
+			},
+			{
+				Name:        "version",
+				Type:        "uint64",
+				Description: "Version of the stored entry (the current version if the write was skipped).",
+			},
+		},
+		NewTask: NewTask,
+	}
+)
+
+type Task struct {
+	ctx     *types.TaskContext
+	options *types.TaskOptions
+	config  Config
+	logger  logrus.FieldLogger
+}
+
+func NewTask(ctx *types.TaskContext, options *types.TaskOptions) (types.Task, error) {
+	return &Task{
+		ctx:     ctx,
+		options: options,
+		logger:  ctx.Logger.GetLogger(),
+	}, nil
+}
+
+func (t *Task) Config() interface{} {
+	return t.config
+}
+
+func (t *Task) Timeout() time.Duration {
+	return t.options.Timeout.Duration
+}
+
+func (t *Task) LoadConfig() error {
+	config := DefaultConfig()
+
+	// parse static config
+	if t.options.Config != nil {
+		if err := t.options.Config.Unmarshal(&config); err != nil {
+			return fmt.Errorf("error parsing task config for %v: %w", TaskName, err)
+		}
+	}
+
+	// load dynamic vars
+	err := t.ctx.Vars.ConsumeVars(&config, t.options.ConfigVars)
+	if err != nil {
+		return err
+	}
+
+	// validate config
+	if err := config.Validate(); err != nil {
+		return err
+	}
+
+	t.config = config
+
+	return nil
+}
+
+func (t *Task) Execute(_ context.Context) error {
+	store := kvstore.NewStore(t.ctx.Scheduler.GetServices().Database())
+	writeOpts := &kvstore.WriteOptions{
+		TTL:   t.config.TTL.Duration,
+		RunID: t.ctx.Scheduler.GetTestRunID(),
+	}
+
+	var (
+		entry *kvstore.Entry
+		err   error
+	)
+
+	switch {
+	case t.config.Increment != 0:
+		entry, err = store.Increment(t.config.Namespace, t.config.Key, t.config.Increment, writeOpts)
+	case t.config.OnlyIfMissing:
+		entry, err = store.Create(t.config.Namespace, t.config.Key, t.config.Value, writeOpts)
+	case t.config.IfVersion > 0:
+		entry, err = store.Update(t.config.Namespace, t.config.Key, t.config.Value, t.config.IfVersion, writeOpts)
+	default:
+		entry, err = store.Set(t.config.Namespace, t.config.Key, t.config.Value, writeOpts)
+	}
+
+	if errors.Is(err, kvstore.ErrConflict) && t.config.Increment == 0 {
+		return t.handleConflict(store)
+	} else if err != nil {
+		return err
+	}
+
+	t.logger.Infof("stored %v/%v (version %v)", entry.Namespace, entry.Key, entry.Version)
+
+	t.ctx.Outputs.SetVar("stored", true)
+	t.ctx.Outputs.SetVar("value", entry.Value)
+	t.ctx.Outputs.SetVar("version", entry.Version)
+
+	t.ctx.ReportProgress(100, "Value stored")
+
+	return nil
+}
+
+func (t *Task) handleConflict(store *kvstore.Store) error {
+	if t.config.FailOnConflict {
+		return fmt.Errorf("could not store %v/%v: %w", t.config.Namespace, t.config.Key, kvstore.ErrConflict)
+	}
+
+	t.logger.Infof("skipped storing %v/%v due to conflict", t.config.Namespace, t.config.Key)
+
+	current, err := store.Get(t.config.Namespace, t.config.Key)
+	if err != nil {
+		return err
+	}
+
+	t.ctx.Outputs.SetVar("stored", false)
+
+	if current != nil {
+		t.ctx.Outputs.SetVar("value", current.Value)
+		t.ctx.Outputs.SetVar("version", current.Version)
+	} else {
+		t.ctx.Outputs.SetVar("value", nil)
+		t.ctx.Outputs.SetVar("version", uint64(0))
+	}
+
+	t.ctx.ReportProgress(100, "Value not stored due to conflict")
+
+	return nil
+}
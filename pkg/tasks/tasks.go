@@ -43,17 +43,23 @@ import (
 	getpubkeysfrommnemonic "github.com/ethpandaops/assertoor/pkg/tasks/get_pubkeys_from_mnemonic"
 	getrandommnemonic "github.com/ethpandaops/assertoor/pkg/tasks/get_random_mnemonic"
 	getwalletdetails "github.com/ethpandaops/assertoor/pkg/tasks/get_wallet_details"
+	manageendpoint "github.com/ethpandaops/assertoor/pkg/tasks/manage_endpoint"
 	runcommand "github.com/ethpandaops/assertoor/pkg/tasks/run_command"
 	runexternaltasks "github.com/ethpandaops/assertoor/pkg/tasks/run_external_tasks"
 	runjavascript "github.com/ethpandaops/assertoor/pkg/tasks/run_javascript"
 	runshell "github.com/ethpandaops/assertoor/pkg/tasks/run_shell"
 	runspamoorscenario "github.com/ethpandaops/assertoor/pkg/tasks/run_spamoor_scenario"
 	runtaskbackground "github.com/ethpandaops/assertoor/pkg/tasks/run_task_background"
+	runtaskloop "github.com/ethpandaops/assertoor/pkg/tasks/run_task_loop"
 	runtaskmatrix "github.com/ethpandaops/assertoor/pkg/tasks/run_task_matrix"
 	runtaskoptions "github.com/ethpandaops/assertoor/pkg/tasks/run_task_options"
+	runtaskswitch "github.com/ethpandaops/assertoor/pkg/tasks/run_task_switch"
 	runtasks "github.com/ethpandaops/assertoor/pkg/tasks/run_tasks"
 	runtasksconcurrent "github.com/ethpandaops/assertoor/pkg/tasks/run_tasks_concurrent"
 	sleep "github.com/ethpandaops/assertoor/pkg/tasks/sleep"
+	storedelete "github.com/ethpandaops/assertoor/pkg/tasks/store_delete"
+	storeget "github.com/ethpandaops/assertoor/pkg/tasks/store_get"
+	storeset "github.com/ethpandaops/assertoor/pkg/tasks/store_set"
 	tysmhookactivation "github.com/ethpandaops/assertoor/pkg/tasks/tysm_hook_activation"
 	tysmhookdeactivation "github.com/ethpandaops/assertoor/pkg/tasks/tysm_hook_deactivation"
 )
@@ -99,17 +105,23 @@ var AvailableTaskDescriptors = []*types.TaskDescriptor{
 	getpubkeysfrommnemonic.TaskDescriptor,
 	getrandommnemonic.TaskDescriptor,
 	getwalletdetails.TaskDescriptor,
+	manageendpoint.TaskDescriptor,
 	runcommand.TaskDescriptor,
 	runexternaltasks.TaskDescriptor,
 	runjavascript.TaskDescriptor,
 	runshell.TaskDescriptor,
 	runspamoorscenario.TaskDescriptor,
 	runtaskbackground.TaskDescriptor,
+	runtaskloop.TaskDescriptor,
 	runtaskmatrix.TaskDescriptor,
 	runtaskoptions.TaskDescriptor,
+	runtaskswitch.TaskDescriptor,
 	runtasks.TaskDescriptor,
 	runtasksconcurrent.TaskDescriptor,
 	sleep.TaskDescriptor,
+	storedelete.TaskDescriptor,
+	storeget.TaskDescriptor,
+	storeset.TaskDescriptor,
 	tysmhookactivation.TaskDescriptor,
 	tysmhookdeactivation.TaskDescriptor,
 }
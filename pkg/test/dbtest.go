@@ -2,12 +2,15 @@ package test
 
 import (
 	"fmt"
+	"slices"
 	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/db"
 	"github.com/ethpandaops/assertoor/pkg/logger"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/tasks"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/ethpandaops/assertoor/pkg/vars"
@@ -80,6 +83,18 @@ func (dbt *dbTest) GetTaskScheduler() types.TaskScheduler {
 
 func (dbt *dbTest) AbortTest(_ bool) {}
 
+func (dbt *dbTest) Pause() error {
+	return fmt.Errorf("test is not running")
+}
+
+func (dbt *dbTest) Resume() error {
+	return fmt.Errorf("test is not running")
+}
+
+func (dbt *dbTest) IsPaused() bool {
+	return false
+}
+
 func (dbt *dbTest) GetTaskCount() uint64 {
 	dbt.loadTaskIndex()
 	return uint64(len(dbt.taskIndex))
@@ -285,7 +300,7 @@ func (dtt *dbTestTask) Config() interface{} {
 		return nil
 	}
 
-	return config
+	return secrets.RedactValue(config)
 }
 
 func (dtt *dbTestTask) Timeout() time.Duration {
@@ -333,6 +348,37 @@ func (dtt *dbTestTask) GetScopeOwner() types.TaskIndex {
 	return types.TaskIndex(dtt.taskState.ScopeOwner)
 }
 
+func (dtt *dbTestTask) DependsOn() []types.TaskIndex {
+	if dtt.taskState.DependsOn == "" {
+		return nil
+	}
+
+	taskStates, err := dtt.database.GetTaskStatesByRunID(dtt.taskState.RunID)
+	if err != nil {
+		return nil
+	}
+
+	dependsOn := strings.Split(dtt.taskState.DependsOn, ",")
+	isCleanup := dtt.taskState.RunFlags&db.TaskRunFlagCleanup != 0
+	dependencies := []types.TaskIndex{}
+
+	for _, siblingState := range taskStates {
+		if siblingState.TaskID == dtt.taskState.TaskID || siblingState.ParentTask != dtt.taskState.ParentTask || siblingState.RefID == "" {
+			continue
+		}
+
+		if (siblingState.RunFlags&db.TaskRunFlagCleanup != 0) != isCleanup {
+			continue
+		}
+
+		if slices.Contains(dependsOn, siblingState.RefID) {
+			dependencies = append(dependencies, types.TaskIndex(siblingState.TaskID))
+		}
+	}
+
+	return dependencies
+}
+
 func (dtt *dbTestTask) GetTaskResultUpdateChan(_ types.TaskResult) <-chan bool {
 	return nil
 }
@@ -251,6 +251,11 @@ func (d *Descriptor) Err() error {
 	return d.err
 }
 
+// SetErr marks the descriptor as failed, e.g. if the test is part of a trigger cycle.
+func (d *Descriptor) SetErr(err error) {
+	d.err = err
+}
+
 // GetSchedule returns the current schedule under a read lock so it
 // can be safely consulted from the cron scheduler and HTTP handlers
 // while SetSchedule may be writing concurrently.
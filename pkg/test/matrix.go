@@ -0,0 +1,65 @@
+package test
+
+import (
+	"maps"
+	"sort"
+)
+
+// CountTestMatrixCombinations returns the number of combinations of the matrix values without expanding them.
+// Counting stops as soon as the count exceeds limit, so the returned value is at most limit+1.
+func CountTestMatrixCombinations(matrix map[string][]any, limit int) int {
+	if len(matrix) == 0 {
+		return 0
+	}
+
+	for _, values := range matrix {
+		if len(values) == 0 {
+			return 0
+		}
+	}
+
+	count := 1
+
+	for _, values := range matrix {
+		count *= len(values)
+
+		if count > limit {
+			return limit + 1
+		}
+	}
+
+	return count
+}
+
+// ExpandTestMatrix returns all combinations of the matrix values, one config override map per combination.
+// Combinations are ordered by the sorted matrix keys, with the last key changing fastest.
+func ExpandTestMatrix(matrix map[string][]any) []map[string]any {
+	if len(matrix) == 0 {
+		return nil
+	}
+
+	keys := make([]string, 0, len(matrix))
+	for key := range matrix {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	combinations := []map[string]any{{}}
+
+	for _, key := range keys {
+		expanded := make([]map[string]any, 0, len(combinations)*len(matrix[key]))
+
+		for _, combination := range combinations {
+			for _, value := range matrix[key] {
+				next := maps.Clone(combination)
+				next[key] = value
+				expanded = append(expanded, next)
+			}
+		}
+
+		combinations = expanded
+	}
+
+	return combinations
+}
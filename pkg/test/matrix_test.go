@@ -0,0 +1,143 @@
+package test
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestExpandTestMatrix(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix map[string][]any
+		want   []map[string]any
+	}{
+		{
+			name:   "nil matrix",
+			matrix: nil,
+			want:   nil,
+		},
+		{
+			name:   "empty matrix",
+			matrix: map[string][]any{},
+			want:   nil,
+		},
+		{
+			name: "single key",
+			matrix: map[string][]any{
+				"client": {"lighthouse", "teku"},
+			},
+			want: []map[string]any{
+				{"client": "lighthouse"},
+				{"client": "teku"},
+			},
+		},
+		{
+			name: "sorted keys with last key changing fastest",
+			matrix: map[string][]any{
+				"txCount": {10, 100},
+				"client":  {"lighthouse", "teku"},
+			},
+			want: []map[string]any{
+				{"client": "lighthouse", "txCount": 10},
+				{"client": "lighthouse", "txCount": 100},
+				{"client": "teku", "txCount": 10},
+				{"client": "teku", "txCount": 100},
+			},
+		},
+		{
+			name: "three keys",
+			matrix: map[string][]any{
+				"a": {1, 2},
+				"b": {true},
+				"c": {"x", "y", "z"},
+			},
+			want: []map[string]any{
+				{"a": 1, "b": true, "c": "x"},
+				{"a": 1, "b": true, "c": "y"},
+				{"a": 1, "b": true, "c": "z"},
+				{"a": 2, "b": true, "c": "x"},
+				{"a": 2, "b": true, "c": "y"},
+				{"a": 2, "b": true, "c": "z"},
+			},
+		},
+		{
+			name: "key without values",
+			matrix: map[string][]any{
+				"client": {"lighthouse", "teku"},
+				"empty":  {},
+			},
+			want: []map[string]any{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := ExpandTestMatrix(tt.matrix)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ExpandTestMatrix() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCountTestMatrixCombinations(t *testing.T) {
+	tests := []struct {
+		name   string
+		matrix map[string][]any
+		limit  int
+		want   int
+	}{
+		{
+			name:   "nil matrix",
+			matrix: nil,
+			limit:  10,
+			want:   0,
+		},
+		{
+			name: "within limit",
+			matrix: map[string][]any{
+				"client": {"lighthouse", "teku", "prysm"},
+				"count":  {1, 2},
+			},
+			limit: 10,
+			want:  6,
+		},
+		{
+			name: "exactly at limit",
+			matrix: map[string][]any{
+				"client": {"lighthouse", "teku"},
+				"count":  {1, 2},
+			},
+			limit: 4,
+			want:  4,
+		},
+		{
+			name: "above limit",
+			matrix: map[string][]any{
+				"a": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				"b": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				"c": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+			},
+			limit: 100,
+			want:  101,
+		},
+		{
+			name: "key without values",
+			matrix: map[string][]any{
+				"a": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				"b": {1, 2, 3, 4, 5, 6, 7, 8, 9, 10},
+				"c": {},
+			},
+			limit: 10,
+			want:  0,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := CountTestMatrixCombinations(tt.matrix, tt.limit); got != tt.want {
+				t.Errorf("CountTestMatrixCombinations() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,92 @@
+package test
+
+import (
+	"fmt"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/scheduler"
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/sirupsen/logrus"
+)
+
+// planServices hides the database & event bus from the task scheduler,
+// so planning a test does not persist task states or emit task events.
+type planServices struct {
+	types.TaskServices
+}
+
+func (s *planServices) Database() *db.Database {
+	return nil
+}
+
+func (s *planServices) EventBus() *events.EventBus {
+	return nil
+}
+
+// PlanTest expands the task tree of a test without executing it.
+// Config variables & conditions are resolved against the global & test variables and
+// the config of each task is loaded & validated, so config errors show up before the test is run.
+func PlanTest(descriptor types.TestDescriptor, log logrus.FieldLogger, services types.TaskServices, configOverrides map[string]any) *types.TestPlan {
+	testPlan := &types.TestPlan{
+		TestID:       descriptor.ID(),
+		Errors:       []string{},
+		Tasks:        []*types.TaskPlan{},
+		CleanupTasks: []*types.TaskPlan{},
+	}
+
+	if err := descriptor.Err(); err != nil {
+		testPlan.Errors = append(testPlan.Errors, fmt.Sprintf("failed loading test: %v", err))
+		return testPlan
+	}
+
+	test := newTest(0, descriptor, log, &planServices{services}, configOverrides)
+	test.taskScheduler = scheduler.NewTaskScheduler(test.logger, test.services, test.variables, 0)
+	testPlan.Name = test.config.Name
+
+	for i := range test.config.Tasks {
+		taskOptions, err := test.taskScheduler.ParseTaskOptions(&test.config.Tasks[i])
+		if err == nil {
+			_, err = test.taskScheduler.AddRootTask(taskOptions)
+		}
+
+		if err != nil {
+			testPlan.Errors = append(testPlan.Errors, fmt.Sprintf("task #%v: %v", i+1, err))
+		}
+	}
+
+	for i := range test.config.CleanupTasks {
+		taskOptions, err := test.taskScheduler.ParseTaskOptions(&test.config.CleanupTasks[i])
+		if err == nil {
+			_, err = test.taskScheduler.AddCleanupTask(taskOptions)
+		}
+
+		if err != nil {
+			testPlan.Errors = append(testPlan.Errors, fmt.Sprintf("cleanup task #%v: %v", i+1, err))
+		}
+	}
+
+	taskPlans, cleanupTaskPlans := test.taskScheduler.PlanTasks()
+	testPlan.Tasks = append(testPlan.Tasks, taskPlans...)
+	testPlan.CleanupTasks = append(testPlan.CleanupTasks, cleanupTaskPlans...)
+
+	testPlan.Valid = len(testPlan.Errors) == 0
+
+	for _, taskPlan := range append(taskPlans, cleanupTaskPlans...) {
+		if taskPlan.ConfigError != "" {
+			testPlan.Valid = false
+		}
+
+		for _, queryPlan := range taskPlan.ConfigVars {
+			if queryPlan.Status == types.PlanQueryInvalid {
+				testPlan.Valid = false
+			}
+		}
+
+		if taskPlan.If != nil && taskPlan.If.Status == types.PlanQueryInvalid {
+			testPlan.Valid = false
+		}
+	}
+
+	return testPlan
+}
@@ -7,15 +7,23 @@ import (
 
 	"github.com/ethpandaops/assertoor/pkg/db"
 	"github.com/ethpandaops/assertoor/pkg/logger"
+	"github.com/ethpandaops/assertoor/pkg/metrics"
 	"github.com/ethpandaops/assertoor/pkg/scheduler"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/tasks"
+	"github.com/ethpandaops/assertoor/pkg/tracing"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/ethpandaops/assertoor/pkg/vars"
 	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
 	"gopkg.in/yaml.v3"
 )
 
+// testStatusPersistTimeout limits how long the final status update of a test run may take on shutdown.
+const testStatusPersistTimeout = 10 * time.Second
+
 type Test struct {
 	runID         uint64
 	services      types.TaskServices
@@ -34,27 +42,7 @@ type Test struct {
 }
 
 func CreateTest(runID uint64, descriptor types.TestDescriptor, log logrus.FieldLogger, services types.TaskServices, configOverrides map[string]any) (types.TestRunner, error) {
-	test := &Test{
-		runID:      runID,
-		services:   services,
-		logger:     log.WithField("RunID", runID).WithField("TestID", descriptor.ID()),
-		descriptor: descriptor,
-		config:     descriptor.Config(),
-		status:     types.TestStatusPending,
-	}
-	if test.config.Timeout.Duration > 0 {
-		test.timeout = test.config.Timeout.Duration
-	}
-
-	// set test variables
-	test.variables = vars.NewVariables(descriptor.Vars())
-	for cfgKey, cfgValue := range configOverrides {
-		test.variables.SetVar(cfgKey, cfgValue)
-	}
-
-	// set base path
-	test.variables.SetVar("testBasePath", descriptor.BasePath())
-	test.variables.SetVar("taskBasePath", descriptor.BasePath())
+	test := newTest(runID, descriptor, log, services, configOverrides)
 
 	// add test run to database
 	configYaml, err := yaml.Marshal(test.variables.GetVarsMap(nil, false))
@@ -85,36 +73,124 @@ func CreateTest(runID uint64, descriptor types.TestDescriptor, log logrus.FieldL
 
 	// parse tasks
 	test.taskScheduler = scheduler.NewTaskScheduler(test.logger, services, test.variables, runID)
-	for i := range test.config.Tasks {
-		taskOptions, err := test.taskScheduler.ParseTaskOptions(&test.config.Tasks[i])
+	if err := test.addTasks(); err != nil {
+		return nil, err
+	}
+
+	return test, nil
+}
+
+// ResumeTest recreates a test run that got interrupted by a restart.
+// The task tree is rebuilt from the test config and matched against the persisted task states,
+// so completed tasks are skipped and only the interrupted task and what follows it runs again.
+func ResumeTest(dbTestRun *db.TestRun, descriptor types.TestDescriptor, log logrus.FieldLogger, services types.TaskServices) (types.TestRunner, error) {
+	// the persisted run config contains all variables of the original run, including config overrides
+	configOverrides := map[string]any{}
+	if err := yaml.Unmarshal([]byte(dbTestRun.Config), &configOverrides); err != nil {
+		return nil, fmt.Errorf("failed parsing test run config: %w", err)
+	}
+
+	// secrets are masked in the persisted config, they're restored from the variables they were inherited from
+	descriptorVars := descriptor.Vars()
+
+	for cfgKey, cfgValue := range configOverrides {
+		if !secrets.ContainsMask(cfgValue) {
+			continue
+		}
+
+		inheritedValue, _ := descriptorVars.LookupVar(cfgKey)
+
+		restoredValue, ok := secrets.RestoreValue(cfgValue, inheritedValue)
+		if !ok {
+			return nil, fmt.Errorf("cannot restore secrets in test run variable %v", cfgKey)
+		}
+
+		configOverrides[cfgKey] = restoredValue
+	}
+
+	test := newTest(dbTestRun.RunID, descriptor, log, services, configOverrides)
+	test.dbTestRun = dbTestRun
+
+	if dbTestRun.StartTime > 0 {
+		test.startTime = time.UnixMilli(dbTestRun.StartTime)
+	}
+
+	test.taskScheduler = scheduler.NewTaskScheduler(test.logger, services, test.variables, dbTestRun.RunID)
+	if err := test.taskScheduler.LoadResumeState(); err != nil {
+		return nil, err
+	}
+
+	if err := test.addTasks(); err != nil {
+		return nil, err
+	}
+
+	test.logger.Info("resuming interrupted test run")
+
+	return test, nil
+}
+
+func newTest(runID uint64, descriptor types.TestDescriptor, log logrus.FieldLogger, services types.TaskServices, configOverrides map[string]any) *Test {
+	test := &Test{
+		runID:      runID,
+		services:   services,
+		logger:     log.WithField("RunID", runID).WithField("TestID", descriptor.ID()),
+		descriptor: descriptor,
+		config:     descriptor.Config(),
+		status:     types.TestStatusPending,
+	}
+	if test.config.Timeout.Duration > 0 {
+		test.timeout = test.config.Timeout.Duration
+	}
+
+	// set test variables
+	test.variables = vars.NewVariables(descriptor.Vars())
+	for cfgKey, cfgValue := range configOverrides {
+		test.variables.SetVar(cfgKey, cfgValue)
+	}
+
+	// set base path
+	test.variables.SetVar("testBasePath", descriptor.BasePath())
+	test.variables.SetVar("taskBasePath", descriptor.BasePath())
+
+	return test
+}
+
+func (t *Test) addTasks() error {
+	for i := range t.config.Tasks {
+		taskOptions, err := t.taskScheduler.ParseTaskOptions(&t.config.Tasks[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		_, err = test.taskScheduler.AddRootTask(taskOptions)
+		_, err = t.taskScheduler.AddRootTask(taskOptions)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	for i := range test.config.CleanupTasks {
-		taskOptions, err := test.taskScheduler.ParseTaskOptions(&test.config.CleanupTasks[i])
+	for i := range t.config.CleanupTasks {
+		taskOptions, err := t.taskScheduler.ParseTaskOptions(&t.config.CleanupTasks[i])
 		if err != nil {
-			return nil, err
+			return err
 		}
 
-		_, err = test.taskScheduler.AddCleanupTask(taskOptions)
+		_, err = t.taskScheduler.AddCleanupTask(taskOptions)
 		if err != nil {
-			return nil, err
+			return err
 		}
 	}
 
-	return test, nil
+	return nil
 }
 
-func (t *Test) updateTestStatus() error {
+func (t *Test) updateTestStatus(ctx context.Context) error {
+	return t.persistTestStatus(ctx, t.status)
+}
+
+// persistTestStatus writes the given status along with the start & stop time of the test run to the database.
+func (t *Test) persistTestStatus(ctx context.Context, status types.TestStatus) error {
 	// update test run in database
-	t.dbTestRun.Status = string(t.status)
+	t.dbTestRun.Status = string(status)
 
 	if t.startTime.IsZero() {
 		t.dbTestRun.StartTime = 0
@@ -122,13 +198,13 @@ func (t *Test) updateTestStatus() error {
 		t.dbTestRun.StartTime = t.startTime.UnixMilli()
 	}
 
-	if t.stopTime.IsZero() {
+	if t.stopTime.IsZero() || status == types.TestStatusRunning {
 		t.dbTestRun.StopTime = 0
 	} else {
 		t.dbTestRun.StopTime = t.stopTime.UnixMilli()
 	}
 
-	if err := t.services.Database().RunTransaction(func(tx *sqlx.Tx) error {
+	if err := t.services.Database().RunTransactionContext(ctx, func(tx *sqlx.Tx) error {
 		return t.services.Database().UpdateTestRunStatus(tx, t.dbTestRun)
 	}); err != nil {
 		return err
@@ -137,6 +213,43 @@ func (t *Test) updateTestStatus() error {
 	return nil
 }
 
+// snapshotClientVersions captures the versions of all endpoints in the client pool, so runs can be compared later.
+func (t *Test) snapshotClientVersions() error {
+	clientPool := t.services.ClientPool()
+	if clientPool == nil {
+		return nil
+	}
+
+	clientVersions := []*types.ClientVersion{}
+
+	for _, client := range clientPool.GetAllClients() {
+		clientVersion := &types.ClientVersion{
+			Name: client.Config.Name,
+		}
+
+		if client.ConsensusClient != nil {
+			clientVersion.ConsensusVersion = client.ConsensusClient.GetVersion()
+		}
+
+		if client.ExecutionClient != nil {
+			clientVersion.ExecutionVersion = client.ExecutionClient.GetVersion()
+		}
+
+		clientVersions = append(clientVersions, clientVersion)
+	}
+
+	clientVersionsYaml, err := yaml.Marshal(clientVersions)
+	if err != nil {
+		return err
+	}
+
+	t.dbTestRun.ClientVersions = string(clientVersionsYaml)
+
+	return t.services.Database().RunTransaction(func(tx *sqlx.Tx) error {
+		return t.services.Database().UpdateTestRunClientVersions(tx, t.runID, t.dbTestRun.ClientVersions)
+	})
+}
+
 func (t *Test) RunID() uint64 {
 	return t.runID
 }
@@ -195,26 +308,78 @@ func (t *Test) Run(ctx context.Context) error {
 		return nil
 	}
 
-	// track start/stop time
-	t.startTime = time.Now()
+	// track start/stop time (resumed runs keep their original start time)
+	if t.startTime.IsZero() {
+		t.startTime = time.Now()
+	}
+
 	t.status = types.TestStatusRunning
 
-	if err := t.updateTestStatus(); err != nil {
+	if err := t.updateTestStatus(ctx); err != nil {
 		t.logger.WithError(err).Error("failed updating test status")
 	}
 
+	// resumed runs keep the endpoint versions seen at their original start
+	if t.dbTestRun.ClientVersions == "" {
+		if err := t.snapshotClientVersions(); err != nil {
+			t.logger.WithError(err).Warn("failed saving endpoint versions")
+		}
+	}
+
 	defer func() {
 		t.stopTime = time.Now()
 
-		if err := t.updateTestStatus(); err != nil {
+		// the coordinator context is cancelled on shutdown, so the final state is persisted with a detached context
+		persistCtx, cancelPersistCtx := context.WithTimeout(context.WithoutCancel(ctx), testStatusPersistTimeout)
+		defer cancelPersistCtx()
+
+		if ctx.Err() != nil {
+			// keep the run marked as running in the db, it's either resumed or aborted on next startup
+			if err := t.persistTestStatus(persistCtx, types.TestStatusRunning); err != nil {
+				t.logger.WithError(err).Error("failed persisting interrupted test status")
+			}
+
+			return
+		}
+
+		if err := t.updateTestStatus(persistCtx); err != nil {
 			t.logger.WithError(err).Error("failed updating test status")
 		}
+
+		metrics.ObserveTestRun(t.TestID(), string(t.status), t.stopTime.Sub(t.startTime))
+	}()
+
+	// trace the test run, task spans are nested below the test run span
+	traceCtx, span := tracing.StartSpan(ctx, fmt.Sprintf("test %v", t.TestID()), trace.WithAttributes(
+		attribute.Int64("assertoor.run_id", int64(t.runID)), //nolint:gosec // ignore
+		attribute.String("assertoor.test_id", t.TestID()),
+		attribute.String("assertoor.test_name", t.Name()),
+	))
+
+	defer func() {
+		span.SetAttributes(attribute.String("assertoor.test_status", string(t.status)))
+
+		var spanErr error
+		if t.status != types.TestStatusSuccess {
+			spanErr = fmt.Errorf("test %v", t.status)
+		}
+
+		tracing.EndSpan(span, spanErr)
 	}()
 
 	// run test tasks
-	testRunCtx, cancelTestRunCtx := context.WithCancel(ctx)
+	testRunCtx, cancelTestRunCtx := context.WithCancel(traceCtx)
 	defer cancelTestRunCtx()
 
+	// chain-relative test timeouts (e.g. "10 epochs") are resolved when the test starts
+	if t.config.Timeout.IsChainRelative() {
+		testTimeout := t.config.Timeout
+		if resolvedTimeout, err := t.taskScheduler.ResolveDuration(testRunCtx, &testTimeout); err == nil {
+			// a timeout that already passed must not disable the timeout
+			t.timeout = max(resolvedTimeout, time.Nanosecond)
+		}
+	}
+
 	t.logger.WithField("timeout", t.timeout.String()).Info("starting test")
 
 	err := t.taskScheduler.RunTasks(testRunCtx, t.timeout)
@@ -251,6 +416,79 @@ func (t *Test) AbortTest(skipCleanup bool) {
 
 	if t.taskScheduler != nil {
 		t.taskScheduler.CancelTasks(skipCleanup)
+
+		if t.taskScheduler.Resume() {
+			t.publishPauseEvent(false)
+		}
+	}
+}
+
+// Skip marks a pending test run as skipped without running any task.
+func (t *Test) Skip(reason string) {
+	if t.status != types.TestStatusPending {
+		return
+	}
+
+	t.logger.Infof("skipping test: %v", reason)
+	t.status = types.TestStatusSkipped
+	t.startTime = time.Now()
+	t.stopTime = t.startTime
+
+	if err := t.updateTestStatus(context.Background()); err != nil {
+		t.logger.WithError(err).Error("failed updating test status")
+	}
+
+	metrics.ObserveTestRun(t.TestID(), string(t.status), 0)
+}
+
+// Pause pauses the test run. No new tasks are started until the test run is resumed,
+// running tasks may hold off further actions at safe points.
+// The test and task timeouts are not stopped while the test run is paused.
+func (t *Test) Pause() error {
+	if t.taskScheduler == nil || t.status != types.TestStatusRunning {
+		return fmt.Errorf("test is not running")
+	}
+
+	if !t.taskScheduler.Pause() {
+		return fmt.Errorf("test is already paused")
+	}
+
+	t.logger.Info("pausing test")
+	t.publishPauseEvent(true)
+
+	return nil
+}
+
+// Resume continues a paused test run.
+func (t *Test) Resume() error {
+	if t.taskScheduler == nil || t.status != types.TestStatusRunning {
+		return fmt.Errorf("test is not running")
+	}
+
+	if !t.taskScheduler.Resume() {
+		return fmt.Errorf("test is not paused")
+	}
+
+	t.logger.Info("resuming test")
+	t.publishPauseEvent(false)
+
+	return nil
+}
+
+func (t *Test) IsPaused() bool {
+	return t.taskScheduler != nil && t.status == types.TestStatusRunning && t.taskScheduler.IsPaused()
+}
+
+func (t *Test) publishPauseEvent(paused bool) {
+	eventBus := t.services.EventBus()
+	if eventBus == nil {
+		return
+	}
+
+	if paused {
+		eventBus.PublishTestPaused(t.runID, t.descriptor.ID(), t.config.Name)
+	} else {
+		eventBus.PublishTestResumed(t.runID, t.descriptor.ID(), t.config.Name)
 	}
 }
 
@@ -262,6 +500,25 @@ func (t *Test) GetTestVariables() types.Variables {
 	return t.variables
 }
 
+// SetTestVariables sets variables of a pending test run and updates the persisted run config,
+// so the values are restored when the test run gets resumed after a restart.
+func (t *Test) SetTestVariables(values map[string]any) error {
+	for varName, varValue := range values {
+		t.variables.SetVar(varName, varValue)
+	}
+
+	configYaml, err := yaml.Marshal(t.variables.GetVarsMap(nil, false))
+	if err != nil {
+		return err
+	}
+
+	t.dbTestRun.Config = string(configYaml)
+
+	return t.services.Database().RunTransaction(func(tx *sqlx.Tx) error {
+		return t.services.Database().UpdateTestRunConfig(tx, t.runID, t.dbTestRun.Config)
+	})
+}
+
 // ValidateTestConfig validates a test configuration including its task configurations
 func ValidateTestConfig(config *types.TestConfig) error {
 	if len(config.Tasks) == 0 {
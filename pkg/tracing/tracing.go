@@ -0,0 +1,127 @@
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/trace"
+)
+
+const tracerName = "github.com/ethpandaops/assertoor"
+
+// Config controls the export of test run, task and RPC call traces.
+type Config struct {
+	// Enabled toggles the trace export. When false all spans are no-ops.
+	Enabled bool `yaml:"enabled" json:"enabled"`
+
+	// Endpoint is the OTLP/HTTP collector URL (e.g. `http://localhost:4318`).
+	// If empty, the standard OTEL_EXPORTER_OTLP_* environment variables are used.
+	Endpoint string `yaml:"endpoint" json:"endpoint"`
+
+	// Insecure disables TLS for the collector connection.
+	Insecure bool `yaml:"insecure" json:"insecure"`
+
+	// Headers are sent with every export request (e.g. for authentication).
+	Headers map[string]string `yaml:"headers" json:"headers"`
+
+	// ServiceName is reported as `service.name` resource attribute.
+	ServiceName string `yaml:"serviceName" json:"serviceName"`
+
+	// SampleRatio is the fraction of test runs that are traced (0 < ratio <= 1). Defaults to 1.
+	SampleRatio float64 `yaml:"sampleRatio" json:"sampleRatio"`
+}
+
+// Init sets up the global tracer provider and trace context propagation.
+// The returned function flushes and stops the exporter.
+func Init(ctx context.Context, config *Config) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	if config == nil || !config.Enabled {
+		return func(context.Context) error { return nil }, nil
+	}
+
+	exporterOpts := []otlptracehttp.Option{}
+
+	if config.Endpoint != "" {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithEndpointURL(config.Endpoint))
+	}
+
+	if config.Insecure {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithInsecure())
+	}
+
+	if len(config.Headers) > 0 {
+		exporterOpts = append(exporterOpts, otlptracehttp.WithHeaders(config.Headers))
+	}
+
+	exporter, err := otlptracehttp.New(ctx, exporterOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating OTLP trace exporter: %w", err)
+	}
+
+	serviceName := config.ServiceName
+	if serviceName == "" {
+		serviceName = "assertoor"
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(attribute.String("service.name", serviceName)),
+		resource.WithFromEnv(),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed creating trace resource: %w", err)
+	}
+
+	sampleRatio := config.SampleRatio
+	if sampleRatio <= 0 || sampleRatio > 1 {
+		sampleRatio = 1
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		sdktrace.WithBatcher(exporter),
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRatio))),
+	)
+
+	otel.SetTracerProvider(provider)
+
+	return provider.Shutdown, nil
+}
+
+// StartSpan starts a new span as child of the span in ctx.
+func StartSpan(ctx context.Context, name string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
+	return otel.Tracer(tracerName).Start(ctx, name, opts...)
+}
+
+// EndSpan marks the span as failed if err is set and ends it.
+func EndSpan(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+
+	span.End()
+}
+
+// SubprocessEnv returns the environment variables (TRACEPARENT, TRACESTATE, BAGGAGE)
+// that propagate the trace context in ctx to a subprocess.
+func SubprocessEnv(ctx context.Context) []string {
+	carrier := propagation.MapCarrier{}
+	otel.GetTextMapPropagator().Inject(ctx, carrier)
+
+	env := make([]string, 0, len(carrier))
+	for key, value := range carrier {
+		env = append(env, fmt.Sprintf("%v=%v", strings.ToUpper(key), value))
+	}
+
+	return env
+}
@@ -0,0 +1,76 @@
+package tracing
+
+import (
+	"fmt"
+	"net/http"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Transport is a http.RoundTripper that traces RPC requests as child spans of the
+// span in the request context. Requests without a traced context are passed through,
+// so background polling of the clients does not produce root spans.
+type Transport struct {
+	base       http.RoundTripper
+	clientType string
+	clientName string
+	methodFn   func(req *http.Request) string
+}
+
+// NewTransport wraps the given round tripper (or http.DefaultTransport if nil) with request tracing.
+func NewTransport(base http.RoundTripper, clientType, clientName string, methodFn func(req *http.Request) string) *Transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+
+	return &Transport{
+		base:       base,
+		clientType: clientType,
+		clientName: clientName,
+		methodFn:   methodFn,
+	}
+}
+
+func (t *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if !trace.SpanContextFromContext(req.Context()).IsValid() {
+		return t.base.RoundTrip(req)
+	}
+
+	method := t.methodFn(req)
+
+	ctx, span := StartSpan(req.Context(), fmt.Sprintf("%v %v", t.clientType, method),
+		trace.WithSpanKind(trace.SpanKindClient),
+		trace.WithAttributes(
+			attribute.String("rpc.client_type", t.clientType),
+			attribute.String("rpc.client", t.clientName),
+			attribute.String("rpc.method", method),
+			attribute.String("http.request.method", req.Method),
+			attribute.String("server.address", req.URL.Host),
+		),
+	)
+	defer span.End()
+
+	// the request must not be modified by a round tripper, so propagate the trace context via a clone
+	req = req.Clone(ctx)
+	otel.GetTextMapPropagator().Inject(ctx, propagation.HeaderCarrier(req.Header))
+
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+
+		return resp, err
+	}
+
+	span.SetAttributes(attribute.Int("http.response.status_code", resp.StatusCode))
+
+	if resp.StatusCode >= 400 {
+		span.SetStatus(codes.Error, resp.Status)
+	}
+
+	return resp, nil
+}
@@ -5,30 +5,41 @@ import (
 	"crypto/ecdsa"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethpandaops/assertoor/pkg/clients"
 	"github.com/ethpandaops/assertoor/pkg/clients/execution"
+	"github.com/ethpandaops/assertoor/pkg/helper"
 	"github.com/ethpandaops/spamoor/spamoor"
 	"github.com/holiman/uint256"
 	"github.com/sirupsen/logrus"
 )
 
 type Spamoor struct {
+	ctx           context.Context
+	logger        logrus.FieldLogger
 	executionPool *execution.Pool
 	clientPool    *spamoor.ClientPool
 	txpool        *spamoor.TxPool
+	clientsMutex  sync.RWMutex
 	clients       map[*execution.Client]*spamoor.Client
+	clientCancels map[*execution.Client]context.CancelFunc
 	clientIdx     atomic.Uint64
 }
 
 func NewSpamoor(ctx context.Context, logger logrus.FieldLogger, executionPool *execution.Pool) (*Spamoor, error) {
 	s := &Spamoor{
+		ctx:           ctx,
+		logger:        logger,
 		executionPool: executionPool,
 		clients:       make(map[*execution.Client]*spamoor.Client),
+		clientCancels: make(map[*execution.Client]context.CancelFunc),
 	}
 
 	clientPool := spamoor.NewClientPool(ctx, logger.WithField("module", "clientpool"))
@@ -107,7 +118,13 @@ func (s *Spamoor) getClientOptions(client *execution.Client) *spamoor.ClientOpti
 		RpcHost: rpcURL,
 		ExternalClient: &spamoor.ExternalClientOptions{
 			GetBlockHeight: func(_ context.Context) (uint64, error) {
+				// removed & disabled endpoints fail the status check, so the spamoor client pool stops selecting them
+				if client.IsDisabled() || !slices.Contains(s.executionPool.GetAllEndpoints(), client) {
+					return 0, fmt.Errorf("endpoint %v has been removed or disabled", client.GetName())
+				}
+
 				blockNum, _ := client.GetLastHead()
+
 				return blockNum, nil
 			},
 		},
@@ -161,15 +178,83 @@ func (s *Spamoor) GetTxPool() *spamoor.TxPool {
 	return s.txpool
 }
 
-func (s *Spamoor) GetReadyClient() *spamoor.Client {
+// GetReadyClient returns the spamoor client of a ready execution endpoint, or of the next enabled endpoint
+// (round robin) if no endpoint is ready. The clients are selected from the endpoints of the execution pool,
+// so endpoints added at runtime are included and removed or disabled endpoints are skipped.
+// The sticky key of the context (see helper.WithStickyKey) is passed to the pool scheduler.
+func (s *Spamoor) GetReadyClient(ctx context.Context) *spamoor.Client {
+	readyEndpoint := s.executionPool.GetReadyEndpoint(execution.AnyClient, execution.WithStickyKey(helper.GetStickyKey(ctx)))
+	if client := s.GetClient(readyEndpoint); client != nil {
+		return client
+	}
+
+	endpoints := s.executionPool.GetAllEndpoints()
+	if len(endpoints) == 0 {
+		return nil
+	}
+
 	idx := s.clientIdx.Add(1)
-	return s.clientPool.GetClient(spamoor.WithClientSelectionMode(spamoor.SelectClientByIndex, int(idx%1000000)))
+
+	for i := range endpoints {
+		endpoint := endpoints[(idx+uint64(i))%uint64(len(endpoints))] //nolint:gosec // no overflow
+		if endpoint.IsDisabled() {
+			continue
+		}
+
+		if client := s.GetClient(endpoint); client != nil {
+			return client
+		}
+	}
+
+	return nil
 }
 
 func (s *Spamoor) GetClient(client *execution.Client) *spamoor.Client {
+	s.clientsMutex.RLock()
+	defer s.clientsMutex.RUnlock()
+
 	return s.clients[client]
 }
 
+// OnEndpointAdded creates the spamoor client for an endpoint that has been added to the client pool at runtime.
+// The spamoor client pool can't be extended after initialization, so the client is created via its own pool.
+func (s *Spamoor) OnEndpointAdded(poolClient *clients.PoolClient) error {
+	clientCtx, clientCancel := context.WithCancel(s.ctx)
+	clientPool := spamoor.NewClientPool(clientCtx, s.logger.WithField("module", "clientpool"))
+
+	err := clientPool.InitClients([]*spamoor.ClientOptions{s.getClientOptions(poolClient.ExecutionClient)})
+	if err != nil {
+		clientCancel()
+		return fmt.Errorf("could not init spamoor client: %w", err)
+	}
+
+	spamoorClients := clientPool.GetAllClients()
+	if len(spamoorClients) == 0 {
+		clientCancel()
+		return fmt.Errorf("could not init spamoor client for %v", poolClient.Config.Name)
+	}
+
+	s.clientsMutex.Lock()
+	s.clients[poolClient.ExecutionClient] = spamoorClients[0]
+	s.clientCancels[poolClient.ExecutionClient] = clientCancel
+	s.clientsMutex.Unlock()
+
+	return nil
+}
+
+// OnEndpointRemoved drops the spamoor client of an endpoint that has been removed from the client pool.
+func (s *Spamoor) OnEndpointRemoved(poolClient *clients.PoolClient) {
+	s.clientsMutex.Lock()
+	defer s.clientsMutex.Unlock()
+
+	delete(s.clients, poolClient.ExecutionClient)
+
+	if clientCancel := s.clientCancels[poolClient.ExecutionClient]; clientCancel != nil {
+		clientCancel()
+		delete(s.clientCancels, poolClient.ExecutionClient)
+	}
+}
+
 func (s *Spamoor) GetWalletByPrivkey(ctx context.Context, privkey *ecdsa.PrivateKey) (*spamoor.Wallet, error) {
 	publicKey := privkey.Public()
 	publicKeyECDSA, ok := publicKey.(*ecdsa.PublicKey)
@@ -183,7 +268,7 @@ func (s *Spamoor) GetWalletByPrivkey(ctx context.Context, privkey *ecdsa.Private
 	wallet := spamoor.NewWallet(privkey, address)
 	wallet = s.txpool.RegisterWallet(wallet, ctx)
 
-	client := s.GetReadyClient()
+	client := s.GetReadyClient(ctx)
 
 	err := wallet.UpdateWallet(ctx, client, false)
 	if err != nil {
@@ -197,7 +282,7 @@ func (s *Spamoor) GetWalletByAddress(ctx context.Context, address common.Address
 	wallet := spamoor.NewWallet(nil, address)
 	wallet = s.txpool.RegisterWallet(wallet, ctx)
 
-	client := s.GetReadyClient()
+	client := s.GetReadyClient(ctx)
 
 	err := wallet.UpdateWallet(ctx, client, false)
 	if err != nil {
@@ -0,0 +1,108 @@
+package types
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/ethpandaops/assertoor/pkg/clients/consensus"
+)
+
+// TestChainSchedule triggers test runs on chain events instead of wall clock times.
+type TestChainSchedule struct {
+	// Epochs triggers the test at the start of each of the given epochs.
+	Epochs []uint64 `yaml:"epochs" json:"epochs,omitempty"`
+	// Forks triggers the test at the activation epoch of each of the given forks (e.g. "electra").
+	Forks []string `yaml:"forks" json:"forks,omitempty"`
+	// EveryEpochs triggers the test at the start of every N-th epoch.
+	EveryEpochs uint64 `yaml:"everyEpochs" json:"everyEpochs,omitempty"`
+	// FinalityLoss triggers the test once the chain did not finalize for N epochs.
+	// The trigger fires once per incident and re-arms when finality advances again.
+	FinalityLoss uint64 `yaml:"finalityLoss" json:"finalityLoss,omitempty"`
+}
+
+// TestChainTrigger is a chain schedule trigger firing at a specific epoch.
+type TestChainTrigger struct {
+	Trigger string
+	Epoch   uint64
+}
+
+func (s *TestChainSchedule) Validate() error {
+	for _, fork := range s.Forks {
+		if !slices.Contains(consensus.ForkNames, strings.ToLower(fork)) {
+			return fmt.Errorf("unknown fork '%v' in chain schedule", fork)
+		}
+	}
+
+	return nil
+}
+
+// GetEpochTriggers returns the triggers that fire at the start of the given epoch.
+// The finality loss trigger is not epoch based and is not included.
+func (s *TestChainSchedule) GetEpochTriggers(epoch uint64, specs *consensus.ChainSpec) []string {
+	triggers := []string{}
+
+	if slices.Contains(s.Epochs, epoch) {
+		triggers = append(triggers, fmt.Sprintf("epoch %v", epoch))
+	}
+
+	for _, fork := range s.Forks {
+		if forkEpoch, ok := specs.GetForkEpoch(fork); ok && forkEpoch == epoch {
+			triggers = append(triggers, fmt.Sprintf("fork %v", strings.ToLower(fork)))
+		}
+	}
+
+	if s.EveryEpochs > 0 && epoch%s.EveryEpochs == 0 {
+		triggers = append(triggers, fmt.Sprintf("every %v epochs", s.EveryEpochs))
+	}
+
+	return triggers
+}
+
+// GetNextEpochTriggers returns the next firing epoch of each epoch based trigger after the given epoch.
+// Triggers that will not fire again are omitted.
+func (s *TestChainSchedule) GetNextEpochTriggers(currentEpoch uint64, specs *consensus.ChainSpec) []TestChainTrigger {
+	triggers := []TestChainTrigger{}
+
+	for _, epoch := range s.Epochs {
+		if epoch > currentEpoch {
+			triggers = append(triggers, TestChainTrigger{
+				Trigger: fmt.Sprintf("epoch %v", epoch),
+				Epoch:   epoch,
+			})
+		}
+	}
+
+	for _, fork := range s.Forks {
+		if forkEpoch, ok := specs.GetForkEpoch(fork); ok && forkEpoch > currentEpoch {
+			triggers = append(triggers, TestChainTrigger{
+				Trigger: fmt.Sprintf("fork %v", strings.ToLower(fork)),
+				Epoch:   forkEpoch,
+			})
+		}
+	}
+
+	if s.EveryEpochs > 0 {
+		triggers = append(triggers, TestChainTrigger{
+			Trigger: fmt.Sprintf("every %v epochs", s.EveryEpochs),
+			Epoch:   (currentEpoch/s.EveryEpochs + 1) * s.EveryEpochs,
+		})
+	}
+
+	return triggers
+}
+
+// GetFinalityLossTrigger returns the finality loss trigger description if the chain did not finalize
+// for the configured number of epochs. Empty if the trigger is disabled or the condition is not met.
+func (s *TestChainSchedule) GetFinalityLossTrigger(currentEpoch, finalizedEpoch uint64) string {
+	if s.FinalityLoss == 0 || currentEpoch < finalizedEpoch+2 {
+		return ""
+	}
+
+	// on a healthy chain the finalized epoch trails the current epoch by 2 epochs
+	if currentEpoch-finalizedEpoch-2 < s.FinalityLoss {
+		return ""
+	}
+
+	return fmt.Sprintf("finality lost for %v epochs", s.FinalityLoss)
+}
@@ -0,0 +1,150 @@
+package types
+
+import (
+	"math"
+	"slices"
+	"testing"
+
+	"github.com/ethpandaops/assertoor/pkg/clients/consensus"
+)
+
+func testChainSpec() *consensus.ChainSpec {
+	return &consensus.ChainSpec{
+		AltairForkEpoch:    0,
+		BellatrixForkEpoch: 0,
+		CappellaForkEpoch:  0,
+		DenebForkEpoch:     0,
+		ElectraForkEpoch:   10,
+		FuluForkEpoch:      20,
+		GloasForkEpoch:     math.MaxUint64,
+		SlotsPerEpoch:      32,
+	}
+}
+
+func TestChainScheduleValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule TestChainSchedule
+		wantErr  bool
+	}{
+		{name: "empty schedule"},
+		{name: "known forks", schedule: TestChainSchedule{Forks: []string{"electra", "Fulu"}}},
+		{name: "unknown fork", schedule: TestChainSchedule{Forks: []string{"electra", "osaka"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.schedule.Validate()
+			if (err != nil) != tt.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestChainScheduleGetEpochTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		schedule TestChainSchedule
+		epoch    uint64
+		want     []string
+	}{
+		{
+			name:     "listed epoch",
+			schedule: TestChainSchedule{Epochs: []uint64{5, 7}},
+			epoch:    7,
+			want:     []string{"epoch 7"},
+		},
+		{
+			name:     "unlisted epoch",
+			schedule: TestChainSchedule{Epochs: []uint64{5, 7}},
+			epoch:    6,
+			want:     []string{},
+		},
+		{
+			name:     "fork activation",
+			schedule: TestChainSchedule{Forks: []string{"Electra", "fulu"}},
+			epoch:    10,
+			want:     []string{"fork electra"},
+		},
+		{
+			name:     "unscheduled fork",
+			schedule: TestChainSchedule{Forks: []string{"gloas"}},
+			epoch:    0,
+			want:     []string{},
+		},
+		{
+			name:     "every n epochs",
+			schedule: TestChainSchedule{EveryEpochs: 4},
+			epoch:    12,
+			want:     []string{"every 4 epochs"},
+		},
+		{
+			name:     "every n epochs off interval",
+			schedule: TestChainSchedule{EveryEpochs: 4},
+			epoch:    13,
+			want:     []string{},
+		},
+		{
+			name:     "multiple triggers at once",
+			schedule: TestChainSchedule{Epochs: []uint64{20}, Forks: []string{"fulu"}, EveryEpochs: 10},
+			epoch:    20,
+			want:     []string{"epoch 20", "fork fulu", "every 10 epochs"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.schedule.GetEpochTriggers(tt.epoch, testChainSpec())
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("GetEpochTriggers() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestChainScheduleGetNextEpochTriggers(t *testing.T) {
+	schedule := TestChainSchedule{
+		Epochs:      []uint64{3, 15},
+		Forks:       []string{"electra", "fulu", "gloas"},
+		EveryEpochs: 8,
+	}
+
+	got := schedule.GetNextEpochTriggers(12, testChainSpec())
+	want := []TestChainTrigger{
+		{Trigger: "epoch 15", Epoch: 15},
+		{Trigger: "fork fulu", Epoch: 20},
+		{Trigger: "every 8 epochs", Epoch: 16},
+	}
+
+	if !slices.Equal(got, want) {
+		t.Errorf("GetNextEpochTriggers() = %v, want %v", got, want)
+	}
+}
+
+func TestChainScheduleGetFinalityLossTrigger(t *testing.T) {
+	tests := []struct {
+		name           string
+		finalityLoss   uint64
+		currentEpoch   uint64
+		finalizedEpoch uint64
+		wantTrigger    bool
+	}{
+		{name: "disabled", finalityLoss: 0, currentEpoch: 100, finalizedEpoch: 10},
+		{name: "healthy chain", finalityLoss: 3, currentEpoch: 12, finalizedEpoch: 10},
+		{name: "below threshold", finalityLoss: 3, currentEpoch: 14, finalizedEpoch: 10},
+		{name: "at threshold", finalityLoss: 3, currentEpoch: 15, finalizedEpoch: 10, wantTrigger: true},
+		{name: "finalized ahead of wallclock", finalityLoss: 1, currentEpoch: 5, finalizedEpoch: 10},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schedule := TestChainSchedule{FinalityLoss: tt.finalityLoss}
+
+			trigger := schedule.GetFinalityLossTrigger(tt.currentEpoch, tt.finalizedEpoch)
+			if (trigger != "") != tt.wantTrigger {
+				t.Errorf("GetFinalityLossTrigger() = %q, wantTrigger %v", trigger, tt.wantTrigger)
+			}
+		})
+	}
+}
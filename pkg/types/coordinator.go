@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"errors"
 
 	"github.com/ethpandaops/assertoor/pkg/clients"
 	"github.com/ethpandaops/assertoor/pkg/db"
@@ -10,6 +11,7 @@ import (
 	"github.com/ethpandaops/assertoor/pkg/names"
 	"github.com/ethpandaops/assertoor/pkg/playbooklibrary"
 	"github.com/ethpandaops/assertoor/pkg/txmgr"
+	"github.com/jmoiron/sqlx"
 	"github.com/sirupsen/logrus"
 )
 
@@ -39,7 +41,17 @@ type Coordinator interface {
 	// signature is kept as a deprecated fallback.
 	ScheduleTestWithOptions(descriptor TestDescriptor, configOverrides map[string]any, opts ScheduleOptions) (TestRunner, error)
 
+	// ScheduleTestSweep schedules one test run per combination of the matrix (or the test's own matrix if nil).
+	ScheduleTestSweep(descriptor TestDescriptor, configOverrides map[string]any, matrix map[string][]any, opts ScheduleOptions) (*TestSweep, error)
+
 	DeleteTestRun(runID uint64) error
+
+	// ImportTestRun allocates a run ID for a test run exported by another instance and returns it.
+	// importFn stores the test run with the allocated run ID within the given transaction.
+	ImportTestRun(importFn func(tx *sqlx.Tx, runID uint64) error) (uint64, error)
+
+	// PlanTest expands the task tree of a test without executing it.
+	PlanTest(descriptor TestDescriptor, configOverrides map[string]any) *TestPlan
 }
 
 // ScheduleOptions controls how a freshly scheduled test slots into
@@ -51,6 +63,23 @@ type ScheduleOptions struct {
 	AllowDuplicate bool
 	SkipQueue      bool
 	AfterRunID     uint64
+	// TriggerDepth is the number of triggered test runs that led to this test run (0 for runs that were not triggered).
+	TriggerDepth int
+}
+
+// ErrTestSweepTooLarge is returned if a test matrix expands into more test runs than allowed for a single sweep.
+var ErrTestSweepTooLarge = errors.New("test sweep exceeds the maximum number of runs")
+
+// TestSweep is a group of test runs, one per combination of a test matrix.
+type TestSweep struct {
+	SweepID uint64
+	TestID  string
+	Runs    []*TestSweepRun
+}
+
+type TestSweepRun struct {
+	Combination map[string]any
+	Test        TestRunner
 }
 
 type TestRegistry interface {
@@ -0,0 +1,43 @@
+package types
+
+// Plan query states, describing whether a jq query (configVars / if condition) could be evaluated at plan time.
+const (
+	PlanQueryResolved   = "resolved"   // query returned a value
+	PlanQueryUnresolved = "unresolved" // query returned no value yet, depends on variables set at runtime
+	PlanQueryInvalid    = "invalid"    // query could not be parsed or evaluated
+)
+
+// TestPlan is the expanded task tree of a test, built without executing any task.
+type TestPlan struct {
+	TestID       string      `json:"testId"`
+	Name         string      `json:"name"`
+	Valid        bool        `json:"valid"`
+	Errors       []string    `json:"errors,omitempty"`
+	Tasks        []*TaskPlan `json:"tasks"`
+	CleanupTasks []*TaskPlan `json:"cleanupTasks"`
+}
+
+// TaskPlan describes a single task within a TestPlan.
+type TaskPlan struct {
+	Index       TaskIndex        `json:"index"`
+	ParentIndex TaskIndex        `json:"parentIndex"`
+	Depth       uint64           `json:"depth"`
+	ID          string           `json:"id,omitempty"`
+	Name        string           `json:"name"`
+	Title       string           `json:"title"`
+	Timeout     string           `json:"timeout,omitempty"`
+	Config      any              `json:"config,omitempty"`
+	ConfigError string           `json:"configError,omitempty"`
+	ConfigVars  []*TaskPlanQuery `json:"configVars,omitempty"`
+	If          *TaskPlanQuery   `json:"if,omitempty"`
+	DependsOn   []TaskIndex      `json:"dependsOn,omitempty"`
+}
+
+// TaskPlanQuery is the plan time evaluation result of a jq query.
+type TaskPlanQuery struct {
+	Name   string `json:"name,omitempty"`
+	Query  string `json:"query"`
+	Status string `json:"status"`
+	Value  any    `json:"value,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
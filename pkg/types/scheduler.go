@@ -2,6 +2,7 @@ package types
 
 import (
 	"context"
+	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/clients"
 	"github.com/ethpandaops/assertoor/pkg/db"
@@ -19,6 +20,18 @@ type TaskSchedulerRunner interface {
 	ParseTaskOptions(rawtask helper.IRawMessage) (*TaskOptions, error)
 	ExecuteTask(ctx context.Context, taskIndex TaskIndex, taskWatchFn func(ctx context.Context, cancelFn context.CancelFunc, taskIndex TaskIndex)) error
 
+	// ExecuteTaskGraph executes a list of sibling tasks as a directed acyclic graph built from their
+	// dependsOn options. It blocks until all tasks completed, got skipped or the context got cancelled.
+	ExecuteTaskGraph(ctx context.Context, taskIndexes []TaskIndex, options *TaskGraphOptions) error
+
+	// ResolveDuration returns the wall clock value of a duration. Chain-relative durations (e.g. "3 epochs")
+	// are resolved via the consensus chain clock, waiting until genesis and specs are known.
+	ResolveDuration(ctx context.Context, duration *helper.ChainRelativeDuration) (time.Duration, error)
+
+	// WaitIfPaused blocks while the test run is paused. Long running tasks should call it
+	// at safe points (e.g. between slots) to hold off further actions while paused.
+	WaitIfPaused(ctx context.Context) error
+
 	// TestResultPath returns a filesystem path to a shared markdown file
 	// that every task in this test run can write to. The file is created
 	// lazily on first call. Whatever the tasks leave in it is persisted as
@@ -34,6 +47,7 @@ type TaskScheduler interface {
 	GetRootTasks() []TaskIndex
 	GetAllCleanupTasks() []TaskIndex
 	GetRootCleanupTasks() []TaskIndex
+	IsPaused() bool
 }
 
 type TaskServices interface {
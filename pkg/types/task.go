@@ -23,6 +23,10 @@ type TaskDescriptor struct {
 	Config      any
 	Outputs     []TaskOutputDefinition
 	NewTask     func(ctx *TaskContext, options *TaskOptions) (Task, error)
+
+	// Resumable marks tasks that can safely be executed again when a test
+	// run got interrupted while the task was running.
+	Resumable bool
 }
 
 type TaskOptions struct {
@@ -35,11 +39,13 @@ type TaskOptions struct {
 	// The title of the task - this is used to describe the task to the user.
 	Title string `yaml:"title" json:"title"`
 	// Timeout defines the max time waiting for the condition to be met.
-	Timeout helper.Duration `yaml:"timeout" json:"timeout"`
+	Timeout helper.ChainRelativeDuration `yaml:"timeout" json:"timeout"`
 	// The optional id of the task (for result access via tasks.<task-id>).
 	ID string `yaml:"id" json:"id"`
 	// The optional condition to run the task.
 	If string `yaml:"if" json:"if"`
+	// The optional IDs of sibling tasks that need to complete before the task starts (run_tasks only).
+	DependsOn []string `yaml:"dependsOn" json:"dependsOn,omitempty"`
 }
 
 type TaskIndex uint64
@@ -71,6 +77,7 @@ type TaskState interface {
 	GetTaskStatus() *TaskStatus
 	GetTaskStatusVars() Variables
 	GetScopeOwner() TaskIndex
+	DependsOn() []TaskIndex
 	GetTaskResultUpdateChan(oldResult TaskResult) <-chan bool
 }
 
@@ -0,0 +1,86 @@
+package types
+
+import "fmt"
+
+// Dependency failure modes of a task graph.
+const (
+	TaskGraphDependencyFailureSkip     = "skip"     // skip all tasks depending on a failed task
+	TaskGraphDependencyFailureContinue = "continue" // run dependent tasks regardless of the result of their dependencies
+)
+
+// TaskGraphOptions controls the execution of a task graph via ExecuteTaskGraph.
+type TaskGraphOptions struct {
+	// MaxParallel limits the number of tasks running at the same time (0 = unlimited).
+	MaxParallel uint64
+	// ContinueOnFailure keeps starting tasks after a task failed. Otherwise no new tasks are started after the first failure.
+	ContinueOnFailure bool
+	// DependencyFailure defines how to handle tasks depending on a failed task (defaults to "skip").
+	DependencyFailure string
+	// OnTaskComplete gets called after each task completed or got skipped.
+	OnTaskComplete func(taskIndex TaskIndex, err error)
+}
+
+// ValidateTaskGraph checks the dependsOn references of a list of sibling tasks.
+// All referenced task IDs must exist in the list and the dependencies must not form a cycle.
+func ValidateTaskGraph(taskOptions []*TaskOptions) error {
+	taskIDs := map[string]int{}
+
+	for i, options := range taskOptions {
+		if options.ID != "" {
+			taskIDs[options.ID] = i
+		}
+	}
+
+	for i, options := range taskOptions {
+		for _, dependency := range options.DependsOn {
+			depIdx, found := taskIDs[dependency]
+
+			switch {
+			case !found:
+				return fmt.Errorf("task #%v depends on unknown task id '%v'", i+1, dependency)
+			case depIdx == i:
+				return fmt.Errorf("task #%v depends on itself", i+1)
+			}
+		}
+	}
+
+	// detect dependency cycles via depth-first search
+	const (
+		visitPending = iota
+		visitActive
+		visitDone
+	)
+
+	visitState := make([]int, len(taskOptions))
+
+	var visitTask func(i int) error
+
+	visitTask = func(i int) error {
+		switch visitState[i] {
+		case visitActive:
+			return fmt.Errorf("dependency cycle at task '%v'", taskOptions[i].ID)
+		case visitDone:
+			return nil
+		}
+
+		visitState[i] = visitActive
+
+		for _, dependency := range taskOptions[i].DependsOn {
+			if err := visitTask(taskIDs[dependency]); err != nil {
+				return err
+			}
+		}
+
+		visitState[i] = visitDone
+
+		return nil
+	}
+
+	for i := range taskOptions {
+		if err := visitTask(i); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,114 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+func graphTask(id string, dependsOn ...string) *TaskOptions {
+	return &TaskOptions{
+		Name:      "run_shell",
+		ID:        id,
+		DependsOn: dependsOn,
+	}
+}
+
+func TestValidateTaskGraph(t *testing.T) {
+	tests := []struct {
+		name    string
+		tasks   []*TaskOptions
+		wantErr string
+	}{
+		{
+			name:  "no tasks",
+			tasks: nil,
+		},
+		{
+			name: "no dependencies",
+			tasks: []*TaskOptions{
+				graphTask("a"),
+				graphTask(""),
+				graphTask("c"),
+			},
+		},
+		{
+			name: "linear chain",
+			tasks: []*TaskOptions{
+				graphTask("a"),
+				graphTask("b", "a"),
+				graphTask("c", "b"),
+			},
+		},
+		{
+			name: "diamond",
+			tasks: []*TaskOptions{
+				graphTask("a"),
+				graphTask("b", "a"),
+				graphTask("c", "a"),
+				graphTask("d", "b", "c"),
+			},
+		},
+		{
+			name: "dependency on a later task",
+			tasks: []*TaskOptions{
+				graphTask("a", "b"),
+				graphTask("b"),
+			},
+		},
+		{
+			name: "task without id depending on others",
+			tasks: []*TaskOptions{
+				graphTask("a"),
+				graphTask("", "a"),
+			},
+		},
+		{
+			name: "unknown dependency",
+			tasks: []*TaskOptions{
+				graphTask("a"),
+				graphTask("b", "missing"),
+			},
+			wantErr: "task #2 depends on unknown task id 'missing'",
+		},
+		{
+			name: "self dependency",
+			tasks: []*TaskOptions{
+				graphTask("a", "a"),
+			},
+			wantErr: "task #1 depends on itself",
+		},
+		{
+			name: "two task cycle",
+			tasks: []*TaskOptions{
+				graphTask("a", "b"),
+				graphTask("b", "a"),
+			},
+			wantErr: "dependency cycle at task 'a'",
+		},
+		{
+			name: "cycle behind an acyclic prefix",
+			tasks: []*TaskOptions{
+				graphTask("a"),
+				graphTask("b", "a", "d"),
+				graphTask("c", "b"),
+				graphTask("d", "c"),
+			},
+			wantErr: "dependency cycle at task 'b'",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := ValidateTaskGraph(tt.tasks)
+
+			switch {
+			case tt.wantErr == "" && err != nil:
+				t.Errorf("unexpected error: %v", err)
+			case tt.wantErr != "" && err == nil:
+				t.Errorf("expected error containing %q, got nil", tt.wantErr)
+			case tt.wantErr != "" && !strings.Contains(err.Error(), tt.wantErr):
+				t.Errorf("expected error containing %q, got %q", tt.wantErr, err.Error())
+			}
+		})
+	}
+}
@@ -25,6 +25,8 @@ type TestRunner interface {
 	Run(ctx context.Context) error
 	Logger() logrus.FieldLogger
 	GetTestVariables() Variables
+	SetTestVariables(values map[string]any) error
+	Skip(reason string)
 }
 
 type Test interface {
@@ -37,37 +39,69 @@ type Test interface {
 	Status() TestStatus
 	GetTaskScheduler() TaskScheduler
 	AbortTest(skipCleanup bool)
+	Pause() error
+	Resume() error
+	IsPaused() bool
 }
 
 type TestConfig struct {
-	ID           string                 `yaml:"id" json:"id"`
-	Name         string                 `yaml:"name" json:"name"`
-	Description  string                 `yaml:"description" json:"description,omitempty"`
-	Version      string                 `yaml:"version" json:"version,omitempty"`
-	Tags         []string               `yaml:"tags" json:"tags,omitempty"`
-	Timeout      helper.Duration        `yaml:"timeout" json:"timeout"`
-	Config       map[string]interface{} `yaml:"config" json:"config"`
-	ConfigVars   map[string]string      `yaml:"configVars" json:"configVars"`
-	Tasks        []helper.RawMessage    `yaml:"tasks" json:"tasks"`
-	CleanupTasks []helper.RawMessage    `yaml:"cleanupTasks" json:"cleanupTasks"`
-	Schedule     *TestSchedule          `yaml:"schedule" json:"schedule"`
+	ID           string                       `yaml:"id" json:"id"`
+	Name         string                       `yaml:"name" json:"name"`
+	Description  string                       `yaml:"description" json:"description,omitempty"`
+	Version      string                       `yaml:"version" json:"version,omitempty"`
+	Tags         []string                     `yaml:"tags" json:"tags,omitempty"`
+	Timeout      helper.ChainRelativeDuration `yaml:"timeout" json:"timeout"`
+	Config       map[string]interface{}       `yaml:"config" json:"config"`
+	ConfigVars   map[string]string            `yaml:"configVars" json:"configVars"`
+	Tasks        []helper.RawMessage          `yaml:"tasks" json:"tasks"`
+	CleanupTasks []helper.RawMessage          `yaml:"cleanupTasks" json:"cleanupTasks"`
+	Schedule     *TestSchedule                `yaml:"schedule" json:"schedule"`
+	DependsOn    []TestDependency             `yaml:"dependsOn" json:"dependsOn,omitempty"`
+	Triggers     []TestTrigger                `yaml:"triggers" json:"triggers,omitempty"`
+	Matrix       map[string][]any             `yaml:"matrix" json:"matrix,omitempty"`
+}
+
+// TestDependency is an upstream test whose latest run must have completed with the required status before the test runs.
+type TestDependency struct {
+	Test   string `yaml:"test" json:"test"`
+	Status string `yaml:"status" json:"status,omitempty"` // required status, defaults to "success"
+}
+
+// TestTrigger is a downstream test that gets scheduled when the test completed with the given status.
+type TestTrigger struct {
+	Test   string `yaml:"test" json:"test"`
+	Status string `yaml:"status" json:"status,omitempty"` // required status, defaults to "success"
+}
+
+// MatchTestStatus checks a final test status against a required status of a test dependency or trigger.
+// An empty requirement matches "success", "any" matches all final states.
+func MatchTestStatus(required string, status TestStatus) bool {
+	switch required {
+	case "":
+		return status == TestStatusSuccess
+	case "any":
+		return status != TestStatusPending && status != TestStatusRunning
+	default:
+		return TestStatus(required) == status
+	}
 }
 
 type ExternalTestConfig struct {
-	ID         string                 `yaml:"id" json:"id"`
-	File       string                 `yaml:"file" json:"file"`
-	Name       string                 `yaml:"name" json:"name"`
-	Timeout    *helper.Duration       `yaml:"timeout" json:"timeout"`
-	Config     map[string]interface{} `yaml:"config" json:"config"`
-	ConfigVars map[string]string      `yaml:"configVars" json:"configVars"`
-	Schedule   *TestSchedule          `yaml:"schedule" json:"schedule"`
-	YamlSource string                 `yaml:"-" json:"-"` // Raw YAML source (for API-registered tests)
+	ID         string                        `yaml:"id" json:"id"`
+	File       string                        `yaml:"file" json:"file"`
+	Name       string                        `yaml:"name" json:"name"`
+	Timeout    *helper.ChainRelativeDuration `yaml:"timeout" json:"timeout"`
+	Config     map[string]interface{}        `yaml:"config" json:"config"`
+	ConfigVars map[string]string             `yaml:"configVars" json:"configVars"`
+	Schedule   *TestSchedule                 `yaml:"schedule" json:"schedule"`
+	YamlSource string                        `yaml:"-" json:"-"` // Raw YAML source (for API-registered tests)
 }
 
 type TestSchedule struct {
-	Startup   bool     `yaml:"startup" json:"startup"`
-	Cron      []string `yaml:"cron" json:"cron"`
-	SkipQueue bool     `yaml:"skipQueue" json:"skipQueue"`
+	Startup   bool               `yaml:"startup" json:"startup"`
+	Cron      []string           `yaml:"cron" json:"cron"`
+	Chain     *TestChainSchedule `yaml:"chain" json:"chain,omitempty"`
+	SkipQueue bool               `yaml:"skipQueue" json:"skipQueue"`
 }
 
 type TestDescriptor interface {
@@ -78,3 +112,10 @@ type TestDescriptor interface {
 	Vars() Variables
 	Err() error
 }
+
+// ClientVersion is the version of an endpoint captured when a test run starts.
+type ClientVersion struct {
+	Name             string `yaml:"name" json:"name"`
+	ConsensusVersion string `yaml:"consensusVersion,omitempty" json:"consensus_version,omitempty"`
+	ExecutionVersion string `yaml:"executionVersion,omitempty" json:"execution_version,omitempty"`
+}
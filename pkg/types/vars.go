@@ -5,6 +5,7 @@ type Variables interface {
 	LookupVar(name string) (interface{}, bool)
 	ResolveQuery(query string) (interface{}, bool, error)
 	SetVar(name string, value interface{})
+	SetSecretVar(name string, value interface{})
 	SetDefaultVar(name string, value interface{})
 	GetSubScope(name string) Variables
 	SetSubScope(name string, subScope Variables)
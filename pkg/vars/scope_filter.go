@@ -26,6 +26,10 @@ func (v *ScopeFilter) SetVar(name string, value interface{}) {
 	v.vars.SetVar(name, value)
 }
 
+func (v *ScopeFilter) SetSecretVar(name string, value interface{}) {
+	v.vars.SetSecretVar(name, value)
+}
+
 func (v *ScopeFilter) SetDefaultVar(name string, value interface{}) {
 	v.vars.SetDefaultVar(name, value)
 }
@@ -50,6 +54,10 @@ func (v *ScopeFilter) GetVarsMap(varsMap map[string]any, _ bool) map[string]any
 	return v.vars.GetVarsMap(varsMap, true)
 }
 
+func (v *ScopeFilter) getRawVarsMap(varsMap map[string]any, _ bool) map[string]any {
+	return getRawVarsMap(v.vars, varsMap, true)
+}
+
 func (v *ScopeFilter) ResolveQuery(queryStr string) (value interface{}, found bool, err error) {
 	return v.vars.ResolveQuery(queryStr)
 }
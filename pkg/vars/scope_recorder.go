@@ -0,0 +1,109 @@
+package vars
+
+import (
+	"sync"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+)
+
+// ScopeRecorder wraps a variable scope and keeps track of all variables
+// written through it, so the changes can be persisted and replayed later.
+type ScopeRecorder struct {
+	vars        types.Variables
+	recordMutex sync.Mutex
+	recordedMap map[string]any
+}
+
+func NewScopeRecorder(vars types.Variables) *ScopeRecorder {
+	return &ScopeRecorder{
+		vars:        vars,
+		recordedMap: map[string]any{},
+	}
+}
+
+// GetRecordedVars returns a copy of all variables written through the recorder.
+func (v *ScopeRecorder) GetRecordedVars() map[string]any {
+	v.recordMutex.Lock()
+	defer v.recordMutex.Unlock()
+
+	recordedMap := make(map[string]any, len(v.recordedMap))
+	for varName, varValue := range v.recordedMap {
+		recordedMap[varName] = varValue
+	}
+
+	return recordedMap
+}
+
+func (v *ScopeRecorder) GetVar(name string) interface{} {
+	return v.vars.GetVar(name)
+}
+
+func (v *ScopeRecorder) LookupVar(name string) (interface{}, bool) {
+	return v.vars.LookupVar(name)
+}
+
+func (v *ScopeRecorder) SetVar(name string, value interface{}) {
+	v.recordMutex.Lock()
+	v.recordedMap[name] = value
+	v.recordMutex.Unlock()
+
+	v.vars.SetVar(name, value)
+}
+
+// SetSecretVar sets a secret variable without recording it, so secrets are never persisted.
+func (v *ScopeRecorder) SetSecretVar(name string, value interface{}) {
+	v.vars.SetSecretVar(name, value)
+}
+
+func (v *ScopeRecorder) SetDefaultVar(name string, value interface{}) {
+	v.vars.SetDefaultVar(name, value)
+}
+
+func (v *ScopeRecorder) GetSubScope(name string) types.Variables {
+	return v.vars.GetSubScope(name)
+}
+
+func (v *ScopeRecorder) SetSubScope(name string, subScope types.Variables) {
+	v.vars.SetSubScope(name, subScope)
+}
+
+func (v *ScopeRecorder) NewScope() types.Variables {
+	return v.vars.NewScope()
+}
+
+func (v *ScopeRecorder) ResolvePlaceholders(str string) string {
+	return v.vars.ResolvePlaceholders(str)
+}
+
+func (v *ScopeRecorder) GetVarsMap(varsMap map[string]any, skipParent bool) map[string]any {
+	return v.vars.GetVarsMap(varsMap, skipParent)
+}
+
+func (v *ScopeRecorder) getRawVarsMap(varsMap map[string]any, skipParent bool) map[string]any {
+	return getRawVarsMap(v.vars, varsMap, skipParent)
+}
+
+func (v *ScopeRecorder) ResolveQuery(queryStr string) (value interface{}, found bool, err error) {
+	return v.vars.ResolveQuery(queryStr)
+}
+
+func (v *ScopeRecorder) ConsumeVars(config interface{}, consumeMap map[string]string) error {
+	return v.vars.ConsumeVars(config, consumeMap)
+}
+
+func (v *ScopeRecorder) CopyVars(source types.Variables, copyMap map[string]string) error {
+	for cfgName, varQuery := range copyMap {
+		val, ok, err := source.ResolveQuery(varQuery)
+		if err != nil {
+			return err
+		}
+
+		if !ok {
+			continue
+		}
+
+		v.SetVar(cfgName, val)
+	}
+
+	return nil
+}
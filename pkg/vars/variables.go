@@ -8,6 +8,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/itchyny/gojq"
 	"gopkg.in/yaml.v3"
@@ -26,6 +27,11 @@ type variableValue struct {
 	value     interface{}
 }
 
+// rawVarsMapper is implemented by variable scopes that can return their variables without redaction.
+type rawVarsMapper interface {
+	getRawVarsMap(varsMap map[string]any, skipParent bool) map[string]any
+}
+
 func newVariables(parentScope types.Variables) *Variables {
 	return &Variables{
 		parentScope: parentScope,
@@ -48,7 +54,7 @@ func (v *Variables) GetVar(name string) interface{} {
 
 	switch {
 	case subScope != nil:
-		return subScope.GetVarsMap(nil, false)
+		return getRawVarsMap(subScope, nil, false)
 	case varValue.isDefined:
 		return varValue.value
 	case v.parentScope != nil:
@@ -69,7 +75,7 @@ func (v *Variables) LookupVar(name string) (interface{}, bool) {
 
 	switch {
 	case subScope != nil:
-		return subScope.GetVarsMap(nil, false), true
+		return getRawVarsMap(subScope, nil, false), true
 	case varValue.isDefined:
 		return varValue.value, true
 	case v.parentScope != nil:
@@ -90,6 +96,13 @@ func (v *Variables) SetVar(name string, value interface{}) {
 	v.varsMutex.Unlock()
 }
 
+// SetSecretVar sets a variable that holds a secret. The value is registered for redaction, so it is
+// masked in variable maps, task configs and logs, but can still be used in placeholders and configVars.
+func (v *Variables) SetSecretVar(name string, value interface{}) {
+	secrets.Register(fmt.Sprintf("%v", value))
+	v.SetVar(name, value)
+}
+
 func (v *Variables) SetDefaultVar(name string, value interface{}) {
 	v.varsMutex.Lock()
 	v.defaultsMap[name] = variableValue{
@@ -171,7 +184,34 @@ func (v *Variables) ResolvePlaceholders(str string) string {
 	return str
 }
 
+// GetVarsMap returns all variables of the scope and its parent scopes with secrets masked.
 func (v *Variables) GetVarsMap(varsMap map[string]any, skipParent bool) map[string]any {
+	varsMap = v.getRawVarsMap(varsMap, skipParent)
+
+	if secrets.HasSecrets() {
+		for varName, varValue := range varsMap {
+			varsMap[varName] = secrets.RedactValue(varValue)
+		}
+	}
+
+	return varsMap
+}
+
+// GetRawVarsMap returns all variables of the scope without masking secrets.
+// It must only be used to pass variables on internally, never to show or persist them.
+func GetRawVarsMap(scope types.Variables, skipParent bool) map[string]any {
+	return getRawVarsMap(scope, nil, skipParent)
+}
+
+func getRawVarsMap(scope types.Variables, varsMap map[string]any, skipParent bool) map[string]any {
+	if rawScope, ok := scope.(rawVarsMapper); ok {
+		return rawScope.getRawVarsMap(varsMap, skipParent)
+	}
+
+	return scope.GetVarsMap(varsMap, skipParent)
+}
+
+func (v *Variables) getRawVarsMap(varsMap map[string]any, skipParent bool) map[string]any {
 	if varsMap == nil {
 		varsMap = map[string]any{}
 	}
@@ -184,7 +224,7 @@ func (v *Variables) GetVarsMap(varsMap map[string]any, skipParent bool) map[stri
 			continue
 		}
 
-		varsMap[scopeName] = subScope.GetVarsMap(nil, skipParent)
+		varsMap[scopeName] = getRawVarsMap(subScope, nil, skipParent)
 	}
 
 	for varName, varData := range v.varsMap {
@@ -199,7 +239,7 @@ func (v *Variables) GetVarsMap(varsMap map[string]any, skipParent bool) map[stri
 	v.varsMutex.RUnlock()
 
 	if v.parentScope != nil && !skipParent {
-		varsMap = v.parentScope.GetVarsMap(varsMap, false)
+		varsMap = getRawVarsMap(v.parentScope, varsMap, false)
 	}
 
 	v.varsMutex.RLock()
@@ -219,7 +259,7 @@ func (v *Variables) GetVarsMap(varsMap map[string]any, skipParent bool) map[stri
 }
 
 func (v *Variables) getGeneralizedVarsMap() (map[string]any, error) {
-	varsMap := v.GetVarsMap(nil, false)
+	varsMap := v.getRawVarsMap(nil, false)
 
 	// this is a bit hacky, but we're marshalling & unmarshalling varsMap here to generalize the types.
 	// ie. []string should be a []interface{} of strings
@@ -0,0 +1,117 @@
+package api
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/analytics"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/gorilla/mux"
+)
+
+const getAnalyticsDefaultWindow = 30 * 24 * time.Hour
+
+// GetAnalyticsTests godoc
+// @Id getAnalyticsTests
+// @Summary Get test analytics
+// @Tags Analytics
+// @Description Returns pass rate, flakiness score (status flips between consecutive successful/failed runs per `per` runs)
+// @Description and mean/p95 durations (milliseconds) of all tests with runs in the window.
+// @Produce json
+// @Param since query string false "Start of the window (unix timestamp, RFC3339 timestamp or duration like 24h, default 720h)"
+// @Param until query string false "End of the window (unix timestamp, RFC3339 timestamp or duration, default now)"
+// @Param per query int false "Number of runs the flakiness score is normalized to (default 10)"
+// @Success 200 {object} Response{data=[]analytics.Stats} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/analytics/tests [get]
+func (ah *APIHandler) GetAnalyticsTests(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	window, err := parseAnalyticsWindow(r)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	stats, err := analytics.GetTestStats(ah.coordinator.Database(), window)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), stats)
+}
+
+// GetAnalyticsTest godoc
+// @Id getAnalyticsTest
+// @Summary Get analytics of a single test
+// @Tags Analytics
+// @Description Returns the statistics of a test, the statistics of its tasks by task ID and the histogram of the
+// @Description tasks its failed runs first failed at, within the window.
+// @Produce json
+// @Param testId path string true "ID of the test"
+// @Param since query string false "Start of the window (unix timestamp, RFC3339 timestamp or duration like 24h, default 720h)"
+// @Param until query string false "End of the window (unix timestamp, RFC3339 timestamp or duration, default now)"
+// @Param per query int false "Number of runs the flakiness score is normalized to (default 10)"
+// @Success 200 {object} Response{data=analytics.TestAnalytics} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/analytics/test/{testId} [get]
+func (ah *APIHandler) GetAnalyticsTest(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	vars := mux.Vars(r)
+
+	window, err := parseAnalyticsWindow(r)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	testAnalytics, err := analytics.GetTestAnalytics(ah.coordinator.Database(), vars["testId"], window)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), testAnalytics)
+}
+
+func parseAnalyticsWindow(r *http.Request) (*analytics.Window, error) {
+	q := r.URL.Query()
+	window := &analytics.Window{
+		Since: time.Now().Add(-getAnalyticsDefaultWindow).UnixMilli(),
+	}
+
+	if since := q.Get("since"); since != "" {
+		sinceTime, err := helper.ParseTime(since)
+		if err != nil {
+			return nil, errors.New("invalid since provided")
+		}
+
+		window.Since = sinceTime.UnixMilli()
+	}
+
+	if until := q.Get("until"); until != "" {
+		untilTime, err := helper.ParseTime(until)
+		if err != nil {
+			return nil, errors.New("invalid until provided")
+		}
+
+		window.Until = untilTime.UnixMilli()
+	}
+
+	if per := q.Get("per"); per != "" {
+		perRuns, err := strconv.ParseUint(per, 10, 64)
+		if err != nil || perRuns == 0 {
+			return nil, errors.New("invalid per provided")
+		}
+
+		window.FlakinessRuns = perRuns
+	}
+
+	return window, nil
+}
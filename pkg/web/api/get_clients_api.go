@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/clients"
 	"github.com/ethpandaops/assertoor/pkg/clients/consensus"
 	"github.com/ethpandaops/assertoor/pkg/clients/execution"
 )
@@ -15,24 +16,26 @@ type GetClientsResponse struct {
 }
 
 type ClientResponse struct {
-	Index         int    `json:"index"`
-	Name          string `json:"name"`
-	CLVersion     string `json:"cl_version"`
-	CLType        int64  `json:"cl_type"`
-	CLHeadSlot    uint64 `json:"cl_head_slot"`
-	CLHeadRoot    string `json:"cl_head_root"`
-	CLStatus      string `json:"cl_status"`
-	CLLastRefresh string `json:"cl_refresh"`
-	CLLastError   string `json:"cl_error"`
-	CLIsReady     bool   `json:"cl_ready"`
-	ELVersion     string `json:"el_version"`
-	ELType        int64  `json:"el_type"`
-	ELHeadNumber  uint64 `json:"el_head_number"`
-	ELHeadHash    string `json:"el_head_hash"`
-	ELStatus      string `json:"el_status"`
-	ELLastRefresh string `json:"el_refresh"`
-	ELLastError   string `json:"el_error"`
-	ELIsReady     bool   `json:"el_ready"`
+	Index         int               `json:"index"`
+	Name          string            `json:"name"`
+	CLVersion     string            `json:"cl_version"`
+	CLType        int64             `json:"cl_type"`
+	CLHeadSlot    uint64            `json:"cl_head_slot"`
+	CLHeadRoot    string            `json:"cl_head_root"`
+	CLStatus      string            `json:"cl_status"`
+	CLLastRefresh string            `json:"cl_refresh"`
+	CLLastError   string            `json:"cl_error"`
+	CLIsReady     bool              `json:"cl_ready"`
+	ELVersion     string            `json:"el_version"`
+	ELType        int64             `json:"el_type"`
+	ELHeadNumber  uint64            `json:"el_head_number"`
+	ELHeadHash    string            `json:"el_head_hash"`
+	ELStatus      string            `json:"el_status"`
+	ELLastRefresh string            `json:"el_refresh"`
+	ELLastError   string            `json:"el_error"`
+	ELIsReady     bool              `json:"el_ready"`
+	Disabled      bool              `json:"disabled"`
+	Labels        map[string]string `json:"labels"`
 }
 
 // GetClients godoc
@@ -40,7 +43,9 @@ type ClientResponse struct {
 // @Summary Get list of configured clients
 // @Tags Client
 // @Description Returns the list of configured consensus and execution layer clients with their current status.
+// @Description Clients can be filtered by a label selector, e.g. `cl=lighthouse,supernode=true`.
 // @Produce  json
+// @Param selector query string false "Label selector"
 // @Success 200 {object} Response{data=GetClientsResponse} "Success"
 // @Failure 400 {object} Response "Failure"
 // @Failure 500 {object} Response "Server Error"
@@ -48,10 +53,26 @@ type ClientResponse struct {
 func (ah *APIHandler) GetClients(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", contentTypeJSON)
 
+	var selector *clients.Selector
+
+	if selectorStr := r.URL.Query().Get("selector"); selectorStr != "" {
+		var err error
+
+		selector, err = clients.ParseSelector(selectorStr)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+			return
+		}
+	}
+
 	clientPool := ah.coordinator.ClientPool()
-	clients := make([]*ClientResponse, 0, len(clientPool.GetAllClients()))
+	clientList := make([]*ClientResponse, 0, len(clientPool.GetAllClients()))
 
 	for _, client := range clientPool.GetAllClients() {
+		if selector != nil && !selector.Matches(client.GetLabels()) {
+			continue
+		}
+
 		headSlot, headRoot := client.ConsensusClient.GetLastHead()
 		blockNum, blockHash := client.ExecutionClient.GetLastHead()
 
@@ -70,6 +91,8 @@ func (ah *APIHandler) GetClients(w http.ResponseWriter, r *http.Request) {
 			ELHeadHash:    "0x" + hex.EncodeToString(blockHash[:]),
 			ELLastRefresh: client.ExecutionClient.GetLastEventTime().Format(time.RFC3339),
 			ELIsReady:     clientPool.GetExecutionPool().GetCanonicalFork(2).IsClientReady(client.ExecutionClient),
+			Disabled:      client.IsDisabled(),
+			Labels:        client.Config.Labels,
 		}
 
 		if lastError := client.ConsensusClient.GetLastError(); lastError != nil {
@@ -100,12 +123,12 @@ func (ah *APIHandler) GetClients(w http.ResponseWriter, r *http.Request) {
 			clientData.ELStatus = "synchronizing"
 		}
 
-		clients = append(clients, clientData)
+		clientList = append(clientList, clientData)
 	}
 
 	response := &GetClientsResponse{
-		Clients:     clients,
-		ClientCount: len(clients),
+		Clients:     clientList,
+		ClientCount: len(clientList),
 	}
 
 	ah.sendOKResponse(w, r.URL.String(), response)
@@ -0,0 +1,129 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/events"
+	"github.com/ethpandaops/assertoor/pkg/helper"
+)
+
+const (
+	getEventsDefaultLimit = 100
+	getEventsMaxLimit     = 1000
+)
+
+type GetEventsResponse struct {
+	Events []*events.Event `json:"events"`
+	Total  uint64          `json:"total"`
+	Offset uint64          `json:"offset"`
+	Limit  uint64          `json:"limit"`
+}
+
+// GetEvents godoc
+// @Id getEvents
+// @Summary Get event history
+// @Tags Events
+// @Description Returns a page of persisted test & task lifecycle events, ordered by event ID.
+// @Description Task log events are only included for authenticated requests (or when authentication is disabled).
+// @Produce json
+// @Param type query string false "Comma separated list of event types to return"
+// @Param run_id query int false "Return events of this test run only"
+// @Param after query int false "Return events with a higher event ID only"
+// @Param since query string false "Return events since this time (unix timestamp, RFC3339 timestamp or duration like 15m)"
+// @Param offset query int false "Number of events to skip"
+// @Param limit query int false "Maximum number of events to return (default 100, max 1000)"
+// @Success 200 {object} Response{data=GetEventsResponse} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 404 {object} Response "Event log disabled"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/events [get]
+func (ah *APIHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	eventBus := ah.coordinator.EventBus()
+	if eventBus == nil || eventBus.EventLog() == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "event log disabled", http.StatusNotFound)
+		return
+	}
+
+	q := r.URL.Query()
+	filter := &db.EventLogFilter{}
+
+	var err error
+
+	if eventTypes := q.Get("type"); eventTypes != "" {
+		for _, eventType := range strings.Split(eventTypes, ",") {
+			if eventType = strings.TrimSpace(eventType); eventType != "" {
+				filter.Types = append(filter.Types, eventType)
+			}
+		}
+	}
+
+	if runID := q.Get("run_id"); runID != "" {
+		filter.RunID, err = strconv.ParseUint(runID, 10, 64)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), "invalid run_id provided", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if afterID := q.Get("after"); afterID != "" {
+		filter.AfterID, err = strconv.ParseUint(afterID, 10, 64)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), "invalid after provided", http.StatusBadRequest)
+			return
+		}
+	}
+
+	if since := q.Get("since"); since != "" {
+		sinceTime, parseErr := helper.ParseTime(since)
+		if parseErr != nil {
+			ah.sendErrorResponse(w, r.URL.String(), parseErr.Error(), http.StatusBadRequest)
+			return
+		}
+
+		filter.Since = sinceTime.UnixMilli()
+	}
+
+	offset := uint64(0)
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		offset, err = strconv.ParseUint(offsetStr, 10, 64)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), "invalid offset provided", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := uint64(getEventsDefaultLimit)
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err = strconv.ParseUint(limitStr, 10, 64)
+		if err != nil || limit == 0 {
+			ah.sendErrorResponse(w, r.URL.String(), "invalid limit provided", http.StatusBadRequest)
+			return
+		}
+
+		if limit > getEventsMaxLimit {
+			limit = getEventsMaxLimit
+		}
+	}
+
+	// same visibility rules as the SSE event streams: task logs require auth unless auth is disabled
+	requireAuthLog := ah.authHandler != nil && !ah.authHandler.IsOpen()
+	filter.ExcludeTypes = events.GetHiddenEventTypes(requireAuthLog, ah.checkAuth(r))
+
+	eventList, total, err := eventBus.EventLog().LoadEvents(filter, offset, limit)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), &GetEventsResponse{
+		Events: eventList,
+		Total:  total,
+		Offset: offset,
+		Limit:  limit,
+	})
+}
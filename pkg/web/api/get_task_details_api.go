@@ -6,6 +6,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/gorilla/mux"
 	"gopkg.in/yaml.v3"
@@ -142,7 +143,7 @@ func (ah *APIHandler) GetTestRunTaskDetails(w http.ResponseWriter, r *http.Reque
 	}
 
 	// config yaml
-	if cfgData, err := yaml.Marshal(taskState.Config()); err == nil {
+	if cfgData, err := yaml.Marshal(secrets.RedactConfig(taskState.Config())); err == nil {
 		taskData.ConfigYaml = string(cfgData)
 	} else {
 		taskData.ConfigYaml = fmt.Sprintf("failed marshalling config: %v", err)
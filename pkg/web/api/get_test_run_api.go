@@ -17,6 +17,7 @@ type GetTestRunResponse struct {
 	TestID    string            `json:"test_id"`
 	Name      string            `json:"name"`
 	Status    types.TestStatus  `json:"status"`
+	Paused    bool              `json:"paused,omitempty"`
 	StartTime int64             `json:"start_time"`
 	StopTime  int64             `json:"stop_time"`
 	Tasks     []*GetTestRunTask `json:"tasks"`
@@ -121,6 +122,7 @@ func (ah *APIHandler) GetTestRun(w http.ResponseWriter, r *http.Request) {
 		TestID: testInstance.TestID(),
 		Name:   testInstance.Name(),
 		Status: testInstance.Status(),
+		Paused: testInstance.IsPaused(),
 		Tasks:  []*GetTestRunTask{},
 	}
 
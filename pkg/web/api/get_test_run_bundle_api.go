@@ -0,0 +1,69 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/assertoor/pkg/bundle"
+	"github.com/gorilla/mux"
+)
+
+// GetTestRunBundle godoc
+// @Id getTestRunBundle
+// @Summary Export a test run bundle
+// @Tags TestRun
+// @Description Packages a finished test run (test config & yaml, task states, logs, task results and the test result
+// @Description markdown) into a gzip compressed tar archive, which can be imported into another instance via
+// @Description `POST /api/v1/test_runs/import` or `assertoor bundle import`. Requires authentication as logs and
+// @Description results may contain sensitive data.
+// @Produce application/gzip
+// @Param runId path string true "ID of the test run"
+// @Success 200 {file} binary "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/test_run/{runId}/bundle [get]
+func (ah *APIHandler) GetTestRunBundle(w http.ResponseWriter, r *http.Request) {
+	if !ah.checkAuth(r) {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	runID, err := strconv.ParseUint(vars["runId"], 10, 64)
+	if err != nil {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendErrorResponse(w, r.URL.String(), "invalid runId provided", http.StatusBadRequest)
+
+		return
+	}
+
+	testBundle, err := bundle.Load(ah.coordinator.Database(), runID)
+	if err != nil {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	archive := &bytes.Buffer{}
+	if err := testBundle.Write(archive); err != nil {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/gzip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=\"assertoor-run-%v.tar.gz\"", runID))
+	w.Header().Set("Content-Length", strconv.Itoa(archive.Len()))
+
+	if _, err := w.Write(archive.Bytes()); err != nil {
+		ah.logger.Errorf("error writing bundle of test run %v: %v", runID, err)
+	}
+}
@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/ethpandaops/assertoor/pkg/db"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
 	"github.com/ethpandaops/assertoor/pkg/types"
 	"github.com/gorilla/mux"
 	"gopkg.in/yaml.v3"
@@ -17,6 +18,7 @@ type GetTestRunDetailsResponse struct {
 	TestID    string                    `json:"test_id"`
 	Name      string                    `json:"name"`
 	Status    types.TestStatus          `json:"status"`
+	Paused    bool                      `json:"paused,omitempty"`
 	StartTime int64                     `json:"start_time"`
 	StopTime  int64                     `json:"stop_time"`
 	Tasks     []*GetTestRunDetailedTask `json:"tasks"`
@@ -37,6 +39,7 @@ type GetTestRunDetailedTask struct {
 	Result          string                       `json:"result"`
 	ResultError     string                       `json:"result_error"`
 	RunConcurrent   bool                         `json:"run_concurrent,omitempty"`
+	DependsOn       []uint64                     `json:"depends_on,omitempty"`
 	Progress        float64                      `json:"progress"`
 	ProgressMessage string                       `json:"progress_message"`
 	Log             []*GetTestRunDetailedTaskLog `json:"log"`
@@ -91,6 +94,7 @@ func (ah *APIHandler) GetTestRunDetails(w http.ResponseWriter, r *http.Request)
 		TestID: testInstance.TestID(),
 		Name:   testInstance.Name(),
 		Status: testInstance.Status(),
+		Paused: testInstance.IsPaused(),
 		Tasks:  []*GetTestRunDetailedTask{},
 	}
 
@@ -178,6 +182,10 @@ func (ah *APIHandler) GetTestRunDetails(w http.ResponseWriter, r *http.Request)
 
 			taskData.RunConcurrent = isConfigRunConcurrent(taskState.Config())
 
+			for _, dependency := range taskState.DependsOn() {
+				taskData.DependsOn = append(taskData.DependsOn, uint64(dependency))
+			}
+
 			taskData.Progress = taskStatus.Progress
 			taskData.ProgressMessage = taskStatus.ProgressMessage
 
@@ -210,7 +218,7 @@ func (ah *APIHandler) GetTestRunDetails(w http.ResponseWriter, r *http.Request)
 				taskData.Log[i] = logData
 			}
 
-			taskConfig, err := yaml.Marshal(taskState.Config())
+			taskConfig, err := yaml.Marshal(secrets.RedactConfig(taskState.Config()))
 			if err != nil {
 				taskData.ConfigYaml = fmt.Sprintf("failed marshalling config: %v", err)
 			} else {
@@ -0,0 +1,83 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type GetTestRunNotificationsResponse struct {
+	RunID      uint64                             `json:"run_id"`
+	Deliveries []*GetTestRunNotificationsDelivery `json:"deliveries"`
+}
+
+type GetTestRunNotificationsDelivery struct {
+	DeliveryID   string `json:"delivery_id"`
+	Notifier     string `json:"notifier"`
+	EventType    string `json:"event_type"`
+	Status       string `json:"status"`
+	Attempts     int    `json:"attempts"`
+	ResponseCode int    `json:"response_code"`
+	Error        string `json:"error,omitempty"`
+	CreateTime   int64  `json:"create_time"`
+	UpdateTime   int64  `json:"update_time"`
+}
+
+// GetTestRunNotifications godoc
+// @Id getTestRunNotifications
+// @Summary Get notification deliveries of a test run
+// @Tags TestRun
+// @Description Returns the outbound notifications that were sent for the test run, with their delivery status and attempts.
+// @Produce json
+// @Param runId path string true "ID of the test run to get notification deliveries for"
+// @Success 200 {object} Response{data=GetTestRunNotificationsResponse} "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/test_run/{runId}/notifications [get]
+func (ah *APIHandler) GetTestRunNotifications(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	// Require authentication - delivery errors can contain responses of the notification endpoints
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	runID, err := strconv.ParseUint(vars["runId"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), "invalid runId provided", http.StatusBadRequest)
+		return
+	}
+
+	deliveries, err := ah.coordinator.Database().GetNotificationDeliveriesByRunID(runID)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed loading notification deliveries: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := &GetTestRunNotificationsResponse{
+		RunID:      runID,
+		Deliveries: make([]*GetTestRunNotificationsDelivery, 0, len(deliveries)),
+	}
+
+	for _, delivery := range deliveries {
+		response.Deliveries = append(response.Deliveries, &GetTestRunNotificationsDelivery{
+			DeliveryID:   delivery.DeliveryID,
+			Notifier:     delivery.Notifier,
+			EventType:    delivery.EventType,
+			Status:       delivery.Status,
+			Attempts:     delivery.Attempts,
+			ResponseCode: delivery.ResponseCode,
+			Error:        delivery.Error,
+			CreateTime:   delivery.CreateTime,
+			UpdateTime:   delivery.UpdateTime,
+		})
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), response)
+}
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ethpandaops/assertoor/pkg/report"
+	"github.com/gorilla/mux"
+)
+
+// GetTestRunReport godoc
+// @Id getTestRunReport
+// @Summary Get a test run report for CI systems
+// @Tags TestRun
+// @Description Returns the task tree of the test run as JUnit XML, TAP (version 13) or JSON summary.
+// @Description Each root task is mapped to a test suite, every task to a test case with its duration, result and failure message.
+// @Description Log excerpts are only included for authenticated requests.
+// @Produce xml
+// @Produce plain
+// @Produce json
+// @Param runId path string true "ID of the test run"
+// @Param format query string false "Report format (junit, tap or json-summary), defaults to junit"
+// @Success 200 {string} string "Report"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 404 {object} Response "Test run not found"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/test_run/{runId}/report [get]
+func (ah *APIHandler) GetTestRunReport(w http.ResponseWriter, r *http.Request) {
+	vars := mux.Vars(r)
+
+	runID, err := strconv.ParseUint(vars["runId"], 10, 64)
+	if err != nil {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendErrorResponse(w, r.URL.String(), "invalid runId provided", http.StatusBadRequest)
+
+		return
+	}
+
+	format := r.URL.Query().Get("format")
+	if format == "" {
+		format = report.FormatJUnit
+	}
+
+	testInstance := ah.coordinator.GetTestByRunID(runID)
+	if testInstance == nil {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendErrorResponse(w, r.URL.String(), "test run not found", http.StatusNotFound)
+
+		return
+	}
+
+	reportData, contentType, err := report.Generate(testInstance, format, ah.checkAuth(r))
+	if err != nil {
+		w.Header().Set("Content-Type", contentTypeJSON)
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	http.ServeContent(w, r, fmt.Sprintf("report-%v.%v", runID, report.FileExtension(format)), time.Now(), bytes.NewReader(reportData))
+}
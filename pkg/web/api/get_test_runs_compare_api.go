@@ -0,0 +1,72 @@
+package api
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/assertoor/pkg/report"
+)
+
+// GetTestRunsCompare godoc
+// @Id getTestRunsCompare
+// @Summary Compare two test runs
+// @Tags TestRun
+// @Description Aligns the task trees of two test runs by task ID (or title path for tasks without ID) and returns the differences
+// @Description in task results, durations, outputs and configuration, the differences in the test configuration (after configVars resolution)
+// @Description and the endpoint versions seen at the start of both runs.
+// @Description Config and output differences are only included for authenticated requests.
+// @Produce json
+// @Param a query string true "ID of the first (baseline) test run"
+// @Param b query string true "ID of the second test run"
+// @Success 200 {object} Response{data=report.Comparison} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 404 {object} Response "Test run not found"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/test_runs/compare [get]
+func (ah *APIHandler) GetTestRunsCompare(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	q := r.URL.Query()
+
+	runIDA, err := strconv.ParseUint(q.Get("a"), 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), "invalid run id a provided", http.StatusBadRequest)
+		return
+	}
+
+	runIDB, err := strconv.ParseUint(q.Get("b"), 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), "invalid run id b provided", http.StatusBadRequest)
+		return
+	}
+
+	testA := ah.coordinator.GetTestByRunID(runIDA)
+	if testA == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "test run a not found", http.StatusNotFound)
+		return
+	}
+
+	testB := ah.coordinator.GetTestByRunID(runIDB)
+	if testB == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "test run b not found", http.StatusNotFound)
+		return
+	}
+
+	comparison, err := report.CompareRuns(ah.coordinator.Database(), testA, testB)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !ah.checkAuth(r) {
+		// configs & outputs may contain secrets
+		comparison.Config = nil
+
+		for _, taskDiff := range comparison.Tasks {
+			taskDiff.Config = nil
+			taskDiff.Outputs = nil
+		}
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), comparison)
+}
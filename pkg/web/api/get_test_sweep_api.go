@@ -0,0 +1,140 @@
+package api
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"github.com/gorilla/mux"
+	"gopkg.in/yaml.v3"
+)
+
+type GetTestSweepResponse struct {
+	SweepID      uint64                 `json:"sweep_id"`
+	TestID       string                 `json:"test_id"`
+	CreateTime   int64                  `json:"create_time"`
+	Matrix       map[string][]any       `json:"matrix"`
+	Status       string                 `json:"status"`
+	StatusCounts map[string]int         `json:"status_counts"`
+	Runs         []*GetTestSweepRunData `json:"runs"`
+}
+
+type GetTestSweepRunData struct {
+	RunID       uint64         `json:"run_id"`
+	Combination map[string]any `json:"combination"`
+	Status      string         `json:"status"`
+	StartTime   int64          `json:"start_time"`
+	StopTime    int64          `json:"stop_time"`
+}
+
+// GetTestSweep godoc
+// @Id getTestSweep
+// @Summary Get test sweep by sweep ID
+// @Tags TestRun
+// @Description Returns the runs of a test sweep with their matrix combinations and the aggregated sweep status.
+// @Description The sweep is "pending" or "running" until all runs completed, "failure" if any run failed, "aborted" if any run got aborted and "success" otherwise.
+// @Produce json
+// @Param sweepId path string true "ID of the test sweep to get details for"
+// @Success 200 {object} Response{data=GetTestSweepResponse} "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 404 {object} Response "Sweep not found"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/test_sweep/{sweepId} [get]
+func (ah *APIHandler) GetTestSweep(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	vars := mux.Vars(r)
+
+	sweepID, err := strconv.ParseUint(vars["sweepId"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), "invalid sweepId provided", http.StatusBadRequest)
+		return
+	}
+
+	database := ah.coordinator.Database()
+
+	sweep, err := database.GetTestSweep(sweepID)
+	if errors.Is(err, sql.ErrNoRows) {
+		ah.sendErrorResponse(w, r.URL.String(), "test sweep not found", http.StatusNotFound)
+		return
+	} else if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed loading test sweep: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	sweepRuns, err := database.GetTestSweepRuns(sweepID)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed loading test sweep runs: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	response := &GetTestSweepResponse{
+		SweepID:      sweep.SweepID,
+		TestID:       sweep.TestID,
+		CreateTime:   sweep.CreateTime,
+		Matrix:       map[string][]any{},
+		StatusCounts: map[string]int{},
+		Runs:         make([]*GetTestSweepRunData, 0, len(sweepRuns)),
+	}
+
+	if err := yaml.Unmarshal([]byte(sweep.Matrix), &response.Matrix); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed decoding test sweep matrix: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	for _, sweepRun := range sweepRuns {
+		runData := &GetTestSweepRunData{
+			RunID:       sweepRun.RunID,
+			Combination: map[string]any{},
+		}
+
+		//nolint:errcheck // ignore errors, combination is left empty
+		yaml.Unmarshal([]byte(sweepRun.Combination), &runData.Combination)
+
+		// prefer the live state of runs that are still known to the runner
+		if testRun := ah.coordinator.GetTestByRunID(sweepRun.RunID); testRun != nil {
+			runData.Status = string(testRun.Status())
+
+			if !testRun.StartTime().IsZero() {
+				runData.StartTime = testRun.StartTime().Unix()
+			}
+
+			if !testRun.StopTime().IsZero() {
+				runData.StopTime = testRun.StopTime().Unix()
+			}
+		} else if dbTestRun, err := database.GetTestRunByRunID(sweepRun.RunID); err == nil {
+			runData.Status = dbTestRun.Status
+			runData.StartTime = dbTestRun.StartTime / 1000
+			runData.StopTime = dbTestRun.StopTime / 1000
+		} else {
+			continue
+		}
+
+		response.StatusCounts[runData.Status]++
+		response.Runs = append(response.Runs, runData)
+	}
+
+	response.Status = getTestSweepStatus(response.StatusCounts, len(response.Runs))
+
+	ah.sendOKResponse(w, r.URL.String(), response)
+}
+
+func getTestSweepStatus(statusCounts map[string]int, runCount int) string {
+	switch {
+	case runCount == 0:
+		return string(types.TestStatusSkipped)
+	case statusCounts[string(types.TestStatusPending)] == runCount:
+		return string(types.TestStatusPending)
+	case statusCounts[string(types.TestStatusPending)] > 0 || statusCounts[string(types.TestStatusRunning)] > 0:
+		return string(types.TestStatusRunning)
+	case statusCounts[string(types.TestStatusFailure)] > 0:
+		return string(types.TestStatusFailure)
+	case statusCounts[string(types.TestStatusAborted)] > 0:
+		return string(types.TestStatusAborted)
+	default:
+		return string(types.TestStatusSuccess)
+	}
+}
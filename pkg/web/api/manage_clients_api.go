@@ -0,0 +1,162 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethpandaops/assertoor/pkg/clients"
+	"github.com/gorilla/mux"
+)
+
+// PostClientsRequest is the body accepted by POST /api/v1/clients.
+type PostClientsRequest struct {
+	Name             string            `json:"name"`
+	ConsensusURL     string            `json:"consensus_url"`
+	ConsensusHeaders map[string]string `json:"consensus_headers"`
+	ExecutionURL     string            `json:"execution_url"`
+	ExecutionHeaders map[string]string `json:"execution_headers"`
+	Labels           map[string]string `json:"labels"`
+}
+
+type PostClientsResponse struct {
+	Index int    `json:"index"`
+	Name  string `json:"name"`
+}
+
+// PostClients godoc
+// @Id postClients
+// @Summary Add a client
+// @Tags Client
+// @Description Auth-required. Adds a consensus & execution client pair to the client pool at runtime.
+// @Description Runtime changes to the client pool are not persisted and get lost on restart.
+// @Accept json
+// @Produce json
+// @Param body body PostClientsRequest true "Client endpoint config"
+// @Success 200 {object} Response{data=PostClientsResponse} "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/clients [post]
+func (ah *APIHandler) PostClients(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	req := &PostClientsRequest{}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("error decoding request body json: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if req.Name == "" {
+		ah.sendErrorResponse(w, r.URL.String(), "name cannot be empty", http.StatusBadRequest)
+		return
+	}
+
+	if req.ConsensusURL == "" && req.ExecutionURL == "" {
+		ah.sendErrorResponse(w, r.URL.String(), "must have at least one URL", http.StatusBadRequest)
+		return
+	}
+
+	clientPool := ah.coordinator.ClientPool()
+
+	err := clientPool.AddClient(&clients.ClientConfig{
+		Name:             req.Name,
+		ConsensusURL:     req.ConsensusURL,
+		ConsensusHeaders: req.ConsensusHeaders,
+		ExecutionURL:     req.ExecutionURL,
+		ExecutionHeaders: req.ExecutionHeaders,
+		Labels:           req.Labels,
+	})
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	client := clientPool.GetClientByName(req.Name)
+	if client == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "client has been removed concurrently", http.StatusInternalServerError)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), &PostClientsResponse{
+		Index: int(client.ConsensusClient.GetIndex()),
+		Name:  req.Name,
+	})
+}
+
+// DeleteClient godoc
+// @Id deleteClient
+// @Summary Remove a client
+// @Tags Client
+// @Description Auth-required. Stops the consensus & execution client pair and removes it from the client pool.
+// @Produce json
+// @Param name path string true "Client name"
+// @Success 200 {object} Response "Success"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 404 {object} Response "Not Found"
+// @Router /api/v1/clients/{name} [delete]
+func (ah *APIHandler) DeleteClient(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	if err := ah.coordinator.ClientPool().RemoveClient(vars["name"]); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusNotFound)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), nil)
+}
+
+// PostClientAction godoc
+// @Id postClientAction
+// @Summary Disable or re-enable a client
+// @Tags Client
+// @Description Auth-required. Disabled clients keep following the chain, but are not used by tasks until they are re-enabled.
+// @Produce json
+// @Param name path string true "Client name"
+// @Param action path string true "Action (enable or disable)"
+// @Success 200 {object} Response "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 404 {object} Response "Not Found"
+// @Router /api/v1/clients/{name}/{action} [post]
+func (ah *APIHandler) PostClientAction(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	var enabled bool
+
+	switch vars["action"] {
+	case "enable":
+		enabled = true
+	case "disable":
+		enabled = false
+	default:
+		ah.sendErrorResponse(w, r.URL.String(), "invalid action provided", http.StatusBadRequest)
+		return
+	}
+
+	if err := ah.coordinator.ClientPool().SetClientEnabled(vars["name"], enabled); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusNotFound)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), nil)
+}
@@ -2,6 +2,7 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
 	"net/http"
 
@@ -25,6 +26,11 @@ type PostTestRunsScheduleRequest struct {
 	// should slot relative to the runner's pending queue. When unset
 	// the request falls back to SkipQueue. See ScheduleQueueOption.
 	Queue *ScheduleQueueOption `json:"queue,omitempty"`
+
+	// Matrix fans the schedule request out into one test run per
+	// combination of the given config overrides, grouped under a
+	// sweep ID. When unset or empty the test's own matrix (if any) is used.
+	Matrix map[string][]any `json:"matrix,omitempty"`
 }
 
 // ScheduleQueueMode is the discriminator for ScheduleQueueOption.
@@ -58,6 +64,16 @@ type PostTestRunsScheduleResponse struct {
 	RunID  uint64         `json:"run_id"`
 	Name   string         `json:"name"`
 	Config map[string]any `json:"config"`
+
+	// SweepID & Runs are set when the request was fanned out into a
+	// test sweep. RunID & Config then refer to the first run.
+	SweepID uint64                            `json:"sweep_id,omitempty"`
+	Runs    []PostTestRunsScheduleResponseRun `json:"runs,omitempty"`
+}
+
+type PostTestRunsScheduleResponseRun struct {
+	RunID       uint64         `json:"run_id"`
+	Combination map[string]any `json:"combination"`
 }
 
 // PostTestRunsSchedule godoc
@@ -65,6 +81,8 @@ type PostTestRunsScheduleResponse struct {
 // @Summary Schedule new test run by test ID
 // @Tags TestRun
 // @Description Returns the test & run id of the scheduled test execution.
+// @Description If a matrix is given (or the test defines one), one test run is scheduled per combination and the runs are grouped under a sweep ID.
+// @Description Matrices that expand into more runs than allowed by `coordinator.maxSweepRuns` are rejected. Either all runs of a sweep are scheduled or none.
 // @Produce json
 // @Param runOptions body PostTestRunsScheduleRequest true "Rest run options"
 // @Success 200 {object} Response{data=PostTestRunsScheduleResponse} "Success"
@@ -146,6 +164,54 @@ func (ah *APIHandler) PostTestRunsSchedule(w http.ResponseWriter, r *http.Reques
 		opts.SkipQueue = req.SkipQueue
 	}
 
+	// an empty matrix is treated like no matrix
+	if len(req.Matrix) == 0 {
+		req.Matrix = nil
+	}
+
+	for matrixKey, matrixValues := range req.Matrix {
+		if len(matrixValues) == 0 {
+			ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("matrix key %q has no values", matrixKey), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// create test sweep
+	if req.Matrix != nil || len(testDescriptor.Config().Matrix) > 0 {
+		sweep, err := ah.coordinator.ScheduleTestSweep(testDescriptor, req.Config, req.Matrix, opts)
+		if err != nil {
+			statusCode := http.StatusInternalServerError
+			if errors.Is(err, types.ErrTestSweepTooLarge) {
+				statusCode = http.StatusBadRequest
+			}
+
+			ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed creating test sweep: %v", err), statusCode)
+
+			return
+		}
+
+		firstRun := sweep.Runs[0].Test
+		response := &PostTestRunsScheduleResponse{
+			TestID:  testDescriptor.ID(),
+			RunID:   firstRun.RunID(),
+			Name:    firstRun.Name(),
+			Config:  firstRun.GetTestVariables().GetVarsMap(nil, false),
+			SweepID: sweep.SweepID,
+			Runs:    make([]PostTestRunsScheduleResponseRun, len(sweep.Runs)),
+		}
+
+		for idx, sweepRun := range sweep.Runs {
+			response.Runs[idx] = PostTestRunsScheduleResponseRun{
+				RunID:       sweepRun.Test.RunID(),
+				Combination: sweepRun.Combination,
+			}
+		}
+
+		ah.sendOKResponse(w, r.URL.String(), response)
+
+		return
+	}
+
 	// create test run
 	testInstance, err := ah.coordinator.ScheduleTestWithOptions(testDescriptor, req.Config, opts)
 	if err != nil {
@@ -0,0 +1,65 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+type PostTestRunPauseResponse struct {
+	TestID string `json:"test_id"`
+	RunID  uint64 `json:"run_id"`
+	Name   string `json:"name"`
+	Status string `json:"status"`
+	Paused bool   `json:"paused"`
+}
+
+// PostTestRunPause godoc
+// @Id postTestRunPause
+// @Summary Pause test run by run ID
+// @Tags TestRun
+// @Description Pauses the test run with given ID. No new tasks are started until the test run is resumed.
+// @Description Test and task timeouts keep counting while the test run is paused.
+// @Produce json
+// @Param runId path string true "ID of the test run to pause"
+// @Success 200 {object} Response{data=PostTestRunPauseResponse} "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 404 {object} Response "Test run not found"
+// @Router /api/v1/test_run/{runId}/pause [post]
+func (ah *APIHandler) PostTestRunPause(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	runID, err := strconv.ParseUint(vars["runId"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), "invalid runId provided", http.StatusBadRequest)
+		return
+	}
+
+	testInstance := ah.coordinator.GetTestByRunID(runID)
+	if testInstance == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "test run not found", http.StatusNotFound)
+		return
+	}
+
+	if err := testInstance.Pause(); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed to pause test run: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), &PostTestRunPauseResponse{
+		TestID: testInstance.TestID(),
+		RunID:  testInstance.RunID(),
+		Name:   testInstance.Name(),
+		Status: string(testInstance.Status()),
+		Paused: testInstance.IsPaused(),
+	})
+}
@@ -0,0 +1,56 @@
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/gorilla/mux"
+)
+
+// PostTestRunResume godoc
+// @Id postTestRunResume
+// @Summary Resume test run by run ID
+// @Tags TestRun
+// @Description Resumes the paused test run with given ID.
+// @Produce json
+// @Param runId path string true "ID of the test run to resume"
+// @Success 200 {object} Response{data=PostTestRunPauseResponse} "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 404 {object} Response "Test run not found"
+// @Router /api/v1/test_run/{runId}/resume [post]
+func (ah *APIHandler) PostTestRunResume(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	runID, err := strconv.ParseUint(vars["runId"], 10, 64)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), "invalid runId provided", http.StatusBadRequest)
+		return
+	}
+
+	testInstance := ah.coordinator.GetTestByRunID(runID)
+	if testInstance == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "test run not found", http.StatusNotFound)
+		return
+	}
+
+	if err := testInstance.Resume(); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("failed to resume test run: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), &PostTestRunPauseResponse{
+		TestID: testInstance.TestID(),
+		RunID:  testInstance.RunID(),
+		Name:   testInstance.Name(),
+		Status: string(testInstance.Status()),
+		Paused: testInstance.IsPaused(),
+	})
+}
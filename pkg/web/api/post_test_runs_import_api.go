@@ -0,0 +1,62 @@
+package api
+
+import (
+	"net/http"
+
+	"github.com/ethpandaops/assertoor/pkg/bundle"
+	"github.com/jmoiron/sqlx"
+)
+
+// postTestRunsImportMaxSize limits the size of uploaded test run bundles.
+const postTestRunsImportMaxSize = 256 * 1024 * 1024
+
+type PostTestRunsImportResponse struct {
+	RunID         uint64 `json:"run_id"`
+	OriginalRunID uint64 `json:"original_run_id"`
+	TestID        string `json:"test_id"`
+}
+
+// PostTestRunsImport godoc
+// @Id postTestRunsImport
+// @Summary Import a test run bundle
+// @Tags TestRun
+// @Description Imports a test run bundle exported via `GET /api/v1/test_run/{runId}/bundle` from another instance.
+// @Description The run gets a new run ID and shows up as finished, read-only test run.
+// @Accept application/gzip
+// @Produce json
+// @Param bundle body string true "Test run bundle (gzip compressed tar archive)"
+// @Success 200 {object} Response{data=PostTestRunsImportResponse} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/test_runs/import [post]
+func (ah *APIHandler) PostTestRunsImport(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	testBundle, err := bundle.Read(http.MaxBytesReader(w, r.Body, postTestRunsImportMaxSize))
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	database := ah.coordinator.Database()
+
+	runID, err := ah.coordinator.ImportTestRun(func(tx *sqlx.Tx, runID uint64) error {
+		return testBundle.Import(database, tx, runID)
+	})
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), &PostTestRunsImportResponse{
+		RunID:         runID,
+		OriginalRunID: testBundle.Manifest.RunID,
+		TestID:        testBundle.TestRun.TestID,
+	})
+}
@@ -0,0 +1,74 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"github.com/ethpandaops/assertoor/pkg/types"
+	"gopkg.in/yaml.v3"
+)
+
+type PostTestRunsPlanRequest struct {
+	TestID string         `json:"test_id"`
+	Config map[string]any `json:"config"`
+}
+
+// PostTestRunsPlan godoc
+// @Id postTestRunsPlan
+// @Summary Plan a test run without executing it
+// @Tags TestRun
+// @Description Expands the task tree of the test with given ID without executing any task.
+// @Description Config variables & conditions are resolved against the global & test variables and the config of each task is loaded & validated.
+// @Produce json
+// @Param planOptions body PostTestRunsPlanRequest true "Plan options"
+// @Success 200 {object} Response{data=types.TestPlan} "Success"
+// @Failure 400 {object} Response "Failure"
+// @Failure 404 {object} Response "Test not found"
+// @Router /api/v1/test_runs/plan [post]
+func (ah *APIHandler) PostTestRunsPlan(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	// parse request body
+	req := &PostTestRunsPlanRequest{}
+
+	if r.Header.Get("Content-Type") == contentTypeYAML {
+		decoder := yaml.NewDecoder(r.Body)
+
+		err := decoder.Decode(req)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("error decoding request body yaml: %v", err), http.StatusBadRequest)
+			return
+		}
+	} else {
+		decoder := json.NewDecoder(r.Body)
+
+		err := decoder.Decode(req)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("error decoding request body json: %v", err), http.StatusBadRequest)
+			return
+		}
+	}
+
+	// get test descriptor by test id
+	var testDescriptor types.TestDescriptor
+
+	for _, testDescr := range ah.coordinator.TestRegistry().GetTestDescriptors() {
+		if testDescr.ID() == req.TestID {
+			testDescriptor = testDescr
+			break
+		}
+	}
+
+	if testDescriptor == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "test not found", http.StatusNotFound)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), ah.coordinator.PlanTest(testDescriptor, req.Config))
+}
@@ -102,7 +102,7 @@ func (ah *APIHandler) PostTestsRegister(w http.ResponseWriter, r *http.Request)
 	testConfig := &types.TestConfig{
 		ID:           req.ID,
 		Name:         req.Name,
-		Timeout:      helper.Duration{},
+		Timeout:      helper.ChainRelativeDuration{},
 		Config:       req.Config,
 		ConfigVars:   req.ConfigVars,
 		Tasks:        req.Tasks,
@@ -70,13 +70,13 @@ func (ah *APIHandler) PostTestsRegisterExternal(w http.ResponseWriter, r *http.R
 	extTestCfg := &types.ExternalTestConfig{
 		File:       req.File,
 		Name:       req.Name,
-		Timeout:    &helper.Duration{},
+		Timeout:    &helper.ChainRelativeDuration{},
 		Config:     req.Config,
 		ConfigVars: req.ConfigVars,
 		Schedule:   req.Schedule,
 	}
 	if req.Timeout > 0 {
-		extTestCfg.Timeout = &helper.Duration{Duration: time.Duration(req.Timeout) * time.Second} //nolint:gosec // G115: timeout value is bounded by API input validation
+		extTestCfg.Timeout = &helper.ChainRelativeDuration{Duration: time.Duration(req.Timeout) * time.Second} //nolint:gosec // G115: timeout value is bounded by API input validation
 	}
 
 	// add test descriptor
@@ -83,33 +83,38 @@ func (ah *APIHandler) PutTestSchedule(w http.ResponseWriter, r *http.Request) {
 }
 
 // GetTestNextRunResponse describes the upcoming firings of a test's
-// cron schedule. `entries[].next` is a Unix timestamp; `expression`
-// is the originating cron expression. Empty list means the test has
-// no cron schedule.
+// cron & chain schedule. `entries[].next` is a Unix timestamp;
+// `expression` is the originating cron expression or chain trigger.
+// Chain triggers additionally carry the firing `epoch`. Empty list
+// means the test has no cron or predictable chain schedule.
 type GetTestNextRunResponse struct {
 	TestID   string                       `json:"test_id"`
 	Entries  []GetTestNextRunEntry        `json:"entries"`
 	Earliest *GetTestNextRunEntryEarliest `json:"earliest,omitempty"`
+	// Unpredictable chain triggers (e.g. finality loss) that may fire at any time.
+	Conditions []string `json:"conditions,omitempty"`
 }
 
 type GetTestNextRunEntry struct {
 	Expression string `json:"expression"`
 	Next       int64  `json:"next"`
+	Epoch      uint64 `json:"epoch,omitempty"`
 }
 
 type GetTestNextRunEntryEarliest struct {
 	Expression string `json:"expression"`
 	Next       int64  `json:"next"`
+	Epoch      uint64 `json:"epoch,omitempty"`
 }
 
 // GetTestNextRun godoc
 // @Id getTestNextRun
-// @Summary Get the next planned cron firings for a test
+// @Summary Get the next planned firings for a test
 // @Tags Test
-// @Description Walks each cron expression on the test's schedule
-// @Description and returns the next firing time per expression plus
-// @Description the overall earliest one. Empty when the test has no
-// @Description cron schedule.
+// @Description Walks each cron expression and chain trigger on the
+// @Description test's schedule and returns the next firing time per
+// @Description expression plus the overall earliest one. Empty when
+// @Description the test has no cron or chain schedule.
 // @Produce json
 // @Param testId path string true "Test ID"
 // @Success 200 {object} Response{data=GetTestNextRunResponse} "Success"
@@ -138,15 +143,13 @@ func (ah *APIHandler) GetTestNextRun(w http.ResponseWriter, r *http.Request) {
 	resp := &GetTestNextRunResponse{TestID: testID, Entries: []GetTestNextRunEntry{}}
 
 	cfg := descriptor.Config()
-	if cfg.Schedule == nil || len(cfg.Schedule.Cron) == 0 {
+	if cfg.Schedule == nil {
 		ah.sendOKResponse(w, r.URL.String(), resp)
 		return
 	}
 
 	now := time.Now()
 
-	var earliest *GetTestNextRunEntryEarliest
-
 	for _, expr := range cfg.Schedule.Cron {
 		parsed, err := cronexpr.Parse(expr)
 		if err != nil {
@@ -155,11 +158,22 @@ func (ah *APIHandler) GetTestNextRun(w http.ResponseWriter, r *http.Request) {
 		}
 
 		next := parsed.Next(now)
-		entry := GetTestNextRunEntry{Expression: expr, Next: next.Unix()}
-		resp.Entries = append(resp.Entries, entry)
+		resp.Entries = append(resp.Entries, GetTestNextRunEntry{Expression: expr, Next: next.Unix()})
+	}
 
-		if earliest == nil || next.Unix() < earliest.Next {
-			earliest = &GetTestNextRunEntryEarliest{Expression: expr, Next: next.Unix()}
+	if cfg.Schedule.Chain != nil {
+		resp.Entries = append(resp.Entries, ah.getChainScheduleEntries(cfg.Schedule.Chain)...)
+
+		if cfg.Schedule.Chain.FinalityLoss > 0 {
+			resp.Conditions = append(resp.Conditions, fmt.Sprintf("finality lost for %v epochs", cfg.Schedule.Chain.FinalityLoss))
+		}
+	}
+
+	var earliest *GetTestNextRunEntryEarliest
+
+	for _, entry := range resp.Entries {
+		if earliest == nil || entry.Next < earliest.Next {
+			earliest = &GetTestNextRunEntryEarliest{Expression: entry.Expression, Next: entry.Next, Epoch: entry.Epoch}
 		}
 	}
 
@@ -167,3 +181,35 @@ func (ah *APIHandler) GetTestNextRun(w http.ResponseWriter, r *http.Request) {
 
 	ah.sendOKResponse(w, r.URL.String(), resp)
 }
+
+// getChainScheduleEntries resolves the next firing epoch of each epoch based
+// chain trigger to a wallclock time. Empty until the chain genesis is known.
+func (ah *APIHandler) getChainScheduleEntries(chainSchedule *types.TestChainSchedule) []GetTestNextRunEntry {
+	blockCache := ah.coordinator.ClientPool().GetConsensusPool().GetBlockCache()
+
+	specs := blockCache.GetSpecs()
+	wallclock := blockCache.GetWallclock()
+
+	if specs == nil || wallclock == nil {
+		return nil
+	}
+
+	_, currentEpoch, err := wallclock.Now()
+	if err != nil {
+		return nil
+	}
+
+	entries := []GetTestNextRunEntry{}
+
+	for _, trigger := range chainSchedule.GetNextEpochTriggers(currentEpoch.Number(), specs) {
+		epoch := wallclock.Epochs().FromNumber(trigger.Epoch)
+
+		entries = append(entries, GetTestNextRunEntry{
+			Expression: trigger.Trigger,
+			Next:       epoch.TimeWindow().Start().Unix(),
+			Epoch:      trigger.Epoch,
+		})
+	}
+
+	return entries
+}
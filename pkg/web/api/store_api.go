@@ -0,0 +1,297 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/ethpandaops/assertoor/pkg/helper"
+	"github.com/ethpandaops/assertoor/pkg/kvstore"
+	"github.com/ethpandaops/assertoor/pkg/secrets"
+	"github.com/gorilla/mux"
+)
+
+const (
+	getStoreEntriesDefaultLimit = 100
+	getStoreEntriesMaxLimit     = 1000
+)
+
+type GetStoreEntriesResponse struct {
+	Entries []*kvstore.Entry `json:"entries"`
+	Total   uint64           `json:"total"`
+	Offset  uint64           `json:"offset"`
+	Limit   uint64           `json:"limit"`
+}
+
+// PutStoreEntryRequest is the body accepted by PUT /api/v1/store/{namespace}/{key}.
+type PutStoreEntryRequest struct {
+	// Value is the new value (any JSON value).
+	Value any `json:"value"`
+	// TTL lets the entry expire after the given duration (e.g. "24h"). Omit for no expiry.
+	TTL helper.Duration `json:"ttl"`
+	// Version makes the write conditional on the stored entry still having this version.
+	Version uint64 `json:"version"`
+	// OnlyIfMissing makes the write conditional on the key not existing yet.
+	OnlyIfMissing bool `json:"only_if_missing"`
+}
+
+// GetStoreNamespaces godoc
+// @Id getStoreNamespaces
+// @Summary Get key/value store namespaces
+// @Tags Store
+// @Description Auth-required. Returns all namespaces of the persistent key/value store with their number of (non-expired) entries.
+// @Produce json
+// @Success 200 {object} Response{data=[]kvstore.Namespace} "Success"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/store [get]
+func (ah *APIHandler) GetStoreNamespaces(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	namespaces, err := kvstore.NewStore(ah.coordinator.Database()).Namespaces()
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), namespaces)
+}
+
+// GetStoreEntries godoc
+// @Id getStoreEntries
+// @Summary Get key/value store entries
+// @Tags Store
+// @Description Auth-required. Returns a page of the (non-expired) entries of a key/value store namespace, ordered by key.
+// @Description Registered secrets are masked in the values.
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param prefix query string false "Return keys starting with this prefix only"
+// @Param offset query int false "Number of entries to skip"
+// @Param limit query int false "Maximum number of entries to return (default 100, max 1000)"
+// @Success 200 {object} Response{data=GetStoreEntriesResponse} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/store/{namespace} [get]
+func (ah *APIHandler) GetStoreEntries(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+	q := r.URL.Query()
+
+	var err error
+
+	offset := uint64(0)
+	if offsetStr := q.Get("offset"); offsetStr != "" {
+		offset, err = strconv.ParseUint(offsetStr, 10, 64)
+		if err != nil {
+			ah.sendErrorResponse(w, r.URL.String(), "invalid offset provided", http.StatusBadRequest)
+			return
+		}
+	}
+
+	limit := uint64(getStoreEntriesDefaultLimit)
+	if limitStr := q.Get("limit"); limitStr != "" {
+		limit, err = strconv.ParseUint(limitStr, 10, 64)
+		if err != nil || limit == 0 {
+			ah.sendErrorResponse(w, r.URL.String(), "invalid limit provided", http.StatusBadRequest)
+			return
+		}
+
+		if limit > getStoreEntriesMaxLimit {
+			limit = getStoreEntriesMaxLimit
+		}
+	}
+
+	entries, total, err := kvstore.NewStore(ah.coordinator.Database()).List(vars["namespace"], q.Get("prefix"), offset, limit)
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	for _, entry := range entries {
+		entry.Value = secrets.RedactValue(entry.Value)
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), &GetStoreEntriesResponse{
+		Entries: entries,
+		Total:   total,
+		Offset:  offset,
+		Limit:   limit,
+	})
+}
+
+// GetStoreEntry godoc
+// @Id getStoreEntry
+// @Summary Get a key/value store entry
+// @Tags Store
+// @Description Auth-required. Returns a single entry of the persistent key/value store. Registered secrets are masked in the value.
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param key path string true "Key"
+// @Success 200 {object} Response{data=kvstore.Entry} "Success"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 404 {object} Response "Not Found"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/store/{namespace}/{key} [get]
+func (ah *APIHandler) GetStoreEntry(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	entry, err := kvstore.NewStore(ah.coordinator.Database()).Get(vars["namespace"], vars["key"])
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if entry == nil {
+		ah.sendErrorResponse(w, r.URL.String(), "key not found", http.StatusNotFound)
+		return
+	}
+
+	entry.Value = secrets.RedactValue(entry.Value)
+
+	ah.sendOKResponse(w, r.URL.String(), entry)
+}
+
+// PutStoreEntry godoc
+// @Id putStoreEntry
+// @Summary Write a key/value store entry
+// @Tags Store
+// @Description Auth-required. Creates or replaces an entry of the persistent key/value store.
+// @Description The write can be made conditional on the key not existing yet (`only_if_missing`) or on the
+// @Description stored entry still having a specific `version`; a mismatch is rejected with 409.
+// @Accept json
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param key path string true "Key"
+// @Param body body PutStoreEntryRequest true "New value"
+// @Success 200 {object} Response{data=kvstore.Entry} "Success"
+// @Failure 400 {object} Response "Bad Request"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 409 {object} Response "Conflict"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/store/{namespace}/{key} [put]
+func (ah *APIHandler) PutStoreEntry(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+	namespace := vars["namespace"]
+	key := vars["key"]
+
+	if err := kvstore.ValidateKey(namespace, key); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &PutStoreEntryRequest{}
+
+	decoder := json.NewDecoder(r.Body)
+	decoder.UseNumber()
+
+	if err := decoder.Decode(req); err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), fmt.Sprintf("invalid JSON body: %v", err), http.StatusBadRequest)
+		return
+	}
+
+	if req.TTL.Duration < 0 {
+		ah.sendErrorResponse(w, r.URL.String(), "ttl must be a positive fixed duration", http.StatusBadRequest)
+		return
+	}
+
+	if req.OnlyIfMissing && req.Version > 0 {
+		ah.sendErrorResponse(w, r.URL.String(), "only_if_missing and version can not be used together", http.StatusBadRequest)
+		return
+	}
+
+	store := kvstore.NewStore(ah.coordinator.Database())
+	writeOpts := &kvstore.WriteOptions{
+		TTL: req.TTL.Duration,
+	}
+
+	var (
+		entry *kvstore.Entry
+		err   error
+	)
+
+	switch {
+	case req.OnlyIfMissing:
+		entry, err = store.Create(namespace, key, req.Value, writeOpts)
+	case req.Version > 0:
+		entry, err = store.Update(namespace, key, req.Value, req.Version, writeOpts)
+	default:
+		entry, err = store.Set(namespace, key, req.Value, writeOpts)
+	}
+
+	if errors.Is(err, kvstore.ErrConflict) {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusConflict)
+		return
+	} else if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	entry.Value = secrets.RedactValue(entry.Value)
+
+	ah.sendOKResponse(w, r.URL.String(), entry)
+}
+
+// DeleteStoreEntry godoc
+// @Id deleteStoreEntry
+// @Summary Delete a key/value store entry
+// @Tags Store
+// @Description Auth-required. Deletes an entry of the persistent key/value store.
+// @Produce json
+// @Param namespace path string true "Namespace"
+// @Param key path string true "Key"
+// @Success 200 {object} Response "Success"
+// @Failure 401 {object} Response "Unauthorized"
+// @Failure 404 {object} Response "Not Found"
+// @Failure 500 {object} Response "Server Error"
+// @Router /api/v1/store/{namespace}/{key} [delete]
+func (ah *APIHandler) DeleteStoreEntry(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", contentTypeJSON)
+
+	if !ah.checkAuth(r) {
+		ah.sendUnauthorizedResponse(w, r.URL.String())
+		return
+	}
+
+	vars := mux.Vars(r)
+
+	deleted, err := kvstore.NewStore(ah.coordinator.Database()).Delete(vars["namespace"], vars["key"])
+	if err != nil {
+		ah.sendErrorResponse(w, r.URL.String(), err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	if !deleted {
+		ah.sendErrorResponse(w, r.URL.String(), "key not found", http.StatusNotFound)
+		return
+	}
+
+	ah.sendOKResponse(w, r.URL.String(), nil)
+}
@@ -102,9 +102,12 @@ func (ws *Server) ConfigureRoutes(webConfig *types.WebConfig, aiConfig *types.AI
 		ws.router.HandleFunc("/api/v1/test/{testId}/yaml", apiHandler.GetTestYaml).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test/{testId}/latest_result", apiHandler.GetTestLatestResult).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test_runs", apiHandler.GetTestRuns).Methods("GET")
+		ws.router.HandleFunc("/api/v1/test_runs/compare", apiHandler.GetTestRunsCompare).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}", apiHandler.GetTestRun).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}/result", apiHandler.GetTestRunResult).Methods("GET")
+		ws.router.HandleFunc("/api/v1/test_run/{runId}/report", apiHandler.GetTestRunReport).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}/status", apiHandler.GetTestRunStatus).Methods("GET")
+		ws.router.HandleFunc("/api/v1/test_sweep/{sweepId}", apiHandler.GetTestSweep).Methods("GET")
 		ws.router.HandleFunc("/api/v1/task_descriptors", apiHandler.GetTaskDescriptors).Methods("GET")
 		ws.router.HandleFunc("/api/v1/task_descriptor/{name}", apiHandler.GetTaskDescriptor).Methods("GET")
 		ws.router.HandleFunc("/api/v1/clients", apiHandler.GetClients).Methods("GET")
@@ -118,6 +121,12 @@ func (ws *Server) ConfigureRoutes(webConfig *types.WebConfig, aiConfig *types.AI
 		ws.router.HandleFunc("/api/v1/test/{testId}/schedule", apiHandler.PutTestSchedule).Methods("PUT")
 		ws.router.HandleFunc("/api/v1/dashboard_config", apiHandler.GetDashboardConfig).Methods("GET")
 		ws.router.HandleFunc("/api/v1/dashboard_config", apiHandler.PutDashboardConfig).Methods("PUT")
+		ws.router.HandleFunc("/api/v1/events", apiHandler.GetEvents).Methods("GET")
+		ws.router.HandleFunc("/api/v1/analytics/tests", apiHandler.GetAnalyticsTests).Methods("GET")
+		ws.router.HandleFunc("/api/v1/analytics/test/{testId}", apiHandler.GetAnalyticsTest).Methods("GET")
+		ws.router.HandleFunc("/api/v1/store", apiHandler.GetStoreNamespaces).Methods("GET")
+		ws.router.HandleFunc("/api/v1/store/{namespace}", apiHandler.GetStoreEntries).Methods("GET")
+		ws.router.HandleFunc("/api/v1/store/{namespace}/{key:.+}", apiHandler.GetStoreEntry).Methods("GET")
 
 		// SSE event stream endpoints
 		if eventBus != nil {
@@ -153,11 +162,22 @@ func (ws *Server) ConfigureRoutes(webConfig *types.WebConfig, aiConfig *types.AI
 		ws.router.HandleFunc("/api/v1/tests/delete", apiHandler.PostTestsDelete).Methods("POST")
 		ws.router.HandleFunc("/api/v1/test_run", apiHandler.PostTestRunsSchedule).Methods("POST") // legacy
 		ws.router.HandleFunc("/api/v1/test_runs/schedule", apiHandler.PostTestRunsSchedule).Methods("POST")
+		ws.router.HandleFunc("/api/v1/test_runs/plan", apiHandler.PostTestRunsPlan).Methods("POST")
 		ws.router.HandleFunc("/api/v1/test_runs/delete", apiHandler.PostTestRunsDelete).Methods("POST")
+		ws.router.HandleFunc("/api/v1/test_runs/import", apiHandler.PostTestRunsImport).Methods("POST")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}/cancel", apiHandler.PostTestRunCancel).Methods("POST")
+		ws.router.HandleFunc("/api/v1/test_run/{runId}/pause", apiHandler.PostTestRunPause).Methods("POST")
+		ws.router.HandleFunc("/api/v1/test_run/{runId}/resume", apiHandler.PostTestRunResume).Methods("POST")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}/details", apiHandler.GetTestRunDetails).Methods("GET")
+		ws.router.HandleFunc("/api/v1/test_run/{runId}/bundle", apiHandler.GetTestRunBundle).Methods("GET")
+		ws.router.HandleFunc("/api/v1/test_run/{runId}/notifications", apiHandler.GetTestRunNotifications).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}/task/{taskIndex}/details", apiHandler.GetTestRunTaskDetails).Methods("GET")
 		ws.router.HandleFunc("/api/v1/test_run/{runId}/task/{taskId}/result/{resultType}/{fileId:.*}", apiHandler.GetTaskResult).Methods("GET")
+		ws.router.HandleFunc("/api/v1/store/{namespace}/{key:.+}", apiHandler.PutStoreEntry).Methods("PUT")
+		ws.router.HandleFunc("/api/v1/store/{namespace}/{key:.+}", apiHandler.DeleteStoreEntry).Methods("DELETE")
+		ws.router.HandleFunc("/api/v1/clients", apiHandler.PostClients).Methods("POST")
+		ws.router.HandleFunc("/api/v1/clients/{name}", apiHandler.DeleteClient).Methods("DELETE")
+		ws.router.HandleFunc("/api/v1/clients/{name}/{action}", apiHandler.PostClientAction).Methods("POST")
 
 		// AI endpoints (if enabled)
 		if aiConfig != nil && aiConfig.Enabled {
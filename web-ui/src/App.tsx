@@ -7,6 +7,7 @@ import Layout from './components/common/Layout';
 const Dashboard = lazy(() => import(/* webpackChunkName: "page-dashboard" */ './pages/Dashboard'));
 const Runs = lazy(() => import(/* webpackChunkName: "page-runs" */ './pages/Runs'));
 const TestRun = lazy(() => import(/* webpackChunkName: "page-testrun" */ './pages/TestRun'));
+const CompareRuns = lazy(() => import(/* webpackChunkName: "page-compare" */ './pages/CompareRuns'));
 const Registry = lazy(() => import(/* webpackChunkName: "page-registry" */ './pages/Registry'));
 const TestPage = lazy(() => import(/* webpackChunkName: "page-test" */ './pages/TestPage'));
 const Clients = lazy(() => import(/* webpackChunkName: "page-clients" */ './pages/Clients'));
@@ -50,6 +51,14 @@ function App() {
               </Suspense>
             }
           />
+          <Route
+            path="compare"
+            element={
+              <Suspense fallback={<PageLoader />}>
+                <CompareRuns />
+              </Suspense>
+            }
+          />
           <Route
             path="test/:testId"
             element={
@@ -18,6 +18,7 @@ import type {
   LibraryCheckResponse,
   RegisterExternalTestResponse,
   LatestResultResponse,
+  RunComparison,
 } from '../types/api';
 import { authStore } from '../stores/authStore';
 
@@ -110,6 +111,11 @@ export async function getTestRunDetails(runId: number): Promise<TestRunDetails>
   return fetchApiWithAuth<TestRunDetails>(`/test_run/${runId}/details`);
 }
 
+// Task-by-task comparison of two test runs (uses auth to include config & output diffs)
+export async function getTestRunsCompare(runIdA: number, runIdB: number): Promise<RunComparison> {
+  return fetchApiWithAuth<RunComparison>(`/test_runs/compare?a=${runIdA}&b=${runIdB}`);
+}
+
 // Latest run-level result markdown for a test (envelope form). Walks
 // the newest runs server-side and returns the first one that produced
 // a $ASSERTOOR_TEST_RESULT blob. `run_id === 0` and empty `markdown`
@@ -146,6 +152,28 @@ export async function getTestRunResult(runId: number): Promise<string | null> {
   return response.text();
 }
 
+// Test run bundle (gzip compressed tar archive of a finished run)
+export async function getTestRunBundle(runId: number): Promise<Blob> {
+  const authHeader = await authStore.getAuthHeader();
+  const headers: Record<string, string> = {};
+
+  if (authHeader) {
+    headers['Authorization'] = authHeader;
+  }
+
+  const response = await fetch(`${API_BASE}/test_run/${runId}/bundle`, { headers });
+
+  if (response.status === 401) {
+    throw new Error('Unauthorized: Please log in to perform this action');
+  }
+
+  if (!response.ok) {
+    throw new Error(`API error: ${response.status} ${response.statusText}`);
+  }
+
+  return response.blob();
+}
+
 // Task details
 export async function getTaskDetails(runId: number, taskIndex: number): Promise<TaskDetails> {
   return fetchApiWithAuth<TaskDetails>(`/test_run/${runId}/task/${taskIndex}/details`);
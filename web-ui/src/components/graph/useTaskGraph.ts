@@ -16,6 +16,8 @@ const GLUE_TASKS = new Set([
   'run_task_matrix',
   'run_task_options',
   'run_task_background',
+  'run_task_loop',
+  'run_task_switch',
 ]);
 
 // Tasks that always execute children concurrently (parallel lanes)
@@ -158,6 +160,36 @@ export function useTaskGraph(
           }
 
           return { first: allFirst, last: fgResult.last };
+        } else if (children.some(child => child.depends_on && child.depends_on.length > 0)) {
+          // Dependency graph: children start once their dependencies completed
+          const childResults = new Map<number, FlowResult>();
+          const dependedOn = new Set<number>();
+          const first: number[] = [];
+          const last: number[] = [];
+
+          for (const child of children) {
+            childResults.set(child.index, process(child));
+            for (const dep of child.depends_on || []) dependedOn.add(dep);
+          }
+
+          for (const child of children) {
+            const r = childResults.get(child.index)!;
+            const deps = (child.depends_on || []).filter(dep => childResults.has(dep));
+
+            if (deps.length === 0) {
+              first.push(...r.first);
+            } else {
+              const depLast: number[] = [];
+              for (const dep of deps) depLast.push(...childResults.get(dep)!.last);
+              connectNodes(depLast, r.first);
+            }
+
+            if (!dependedOn.has(child.index)) {
+              last.push(...r.last);
+            }
+          }
+
+          return { first, last };
         } else {
           // Sequential: children run one after another
           let prevLast: number[] = [];
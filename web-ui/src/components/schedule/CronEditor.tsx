@@ -36,6 +36,8 @@ export function CronEditor({ schedule, onChange, readOnly }: CronEditorProps) {
   const startup = schedule?.startup ?? false;
   const skipQueue = schedule?.skipQueue ?? false;
   const crons = schedule?.cron ?? [];
+  // Chain triggers aren't editable here, but must survive edits.
+  const chain = schedule?.chain;
 
   // Mutators emit a fresh schedule object. We never mutate the
   // incoming one so the caller can rely on reference equality for
@@ -44,12 +46,14 @@ export function CronEditor({ schedule, onChange, readOnly }: CronEditorProps) {
     const next: TestSchedule = {
       startup,
       cron: crons,
+      chain,
       skipQueue,
       ...changes,
     };
-    // If the schedule is empty (no startup, no crons, no skipQueue)
-    // emit null so callers can clear the schedule entirely.
-    if (!next.startup && !next.skipQueue && (!next.cron || next.cron.length === 0)) {
+    // If the schedule is empty (no startup, no crons, no chain
+    // triggers, no skipQueue) emit null so callers can clear the
+    // schedule entirely.
+    if (!next.startup && !next.skipQueue && !next.chain && (!next.cron || next.cron.length === 0)) {
       onChange(null);
     } else {
       onChange(next);
@@ -184,6 +184,7 @@ function summarizeSchedule(schedule: TestSchedule | null): string {
         : `${schedule.cron.length} cron entries`,
     );
   }
+  if (schedule.chain) bits.push('chain triggers');
   if (schedule.skipQueue) bits.push('off-queue');
   return bits.length === 0 ? 'No triggers configured' : bits.join(' · ');
 }
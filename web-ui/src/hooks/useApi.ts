@@ -6,6 +6,7 @@ export const queryKeys = {
   testRuns: (testId?: string) => ['testRuns', testId] as const,
   tests: ['tests'] as const,
   testRunDetails: (id: number) => ['testRunDetails', id] as const,
+  testRunsCompare: (a: number, b: number) => ['testRunsCompare', a, b] as const,
   testRunResult: (id: number) => ['testRunResult', id] as const,
   testLatestResult: (testId: string) => ['testLatestResult', testId] as const,
   testNextRun: (testId: string) => ['testNextRun', testId] as const,
@@ -62,6 +63,20 @@ export function useTestRunDetails(
   });
 }
 
+// Comparison of two test runs
+export function useTestRunsCompare(
+  runIdA: number,
+  runIdB: number,
+  options?: { enabled?: boolean; refetchInterval?: number | false }
+) {
+  return useQuery({
+    queryKey: queryKeys.testRunsCompare(runIdA, runIdB),
+    queryFn: () => api.getTestRunsCompare(runIdA, runIdB),
+    enabled: options?.enabled !== false && runIdA > 0 && runIdB > 0,
+    refetchInterval: options?.refetchInterval ?? false,
+  });
+}
+
 // Run-level Result markdown. Returns null when the run has not produced
 // a $ASSERTOOR_TEST_RESULT blob (HTTP 204).
 export function useTestRunResult(
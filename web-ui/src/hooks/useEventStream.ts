@@ -32,6 +32,8 @@ export function useEventStream(options: UseEventStreamOptions = {}) {
         case 'test.started':
         case 'test.completed':
         case 'test.failed':
+        case 'test.paused':
+        case 'test.resumed':
           queryClient.invalidateQueries({ queryKey: ['testRuns'] });
           queryClient.invalidateQueries({ queryKey: queryKeys.testRunDetails(event.testRunId) });
           break;
@@ -122,6 +124,8 @@ export function useEventStream(options: UseEventStreamOptions = {}) {
         'test.started',
         'test.completed',
         'test.failed',
+        'test.paused',
+        'test.resumed',
         'task.created',
         'task.started',
         'task.progress',
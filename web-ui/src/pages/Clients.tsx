@@ -1,10 +1,14 @@
+import { useMemo, useState } from 'react';
 import { useClients } from '../hooks/useApi';
 import { useClientEvents } from '../hooks/useClientEvents';
 import { formatRelativeTime } from '../utils/time';
+import { getClientLabels, parseSelector, matchesSelector } from '../utils/selector';
 import type { ClientData } from '../types/api';
 
 function Clients() {
   const { data, isLoading, error } = useClients();
+  const [selectorInput, setSelectorInput] = useState('');
+  const selector = useMemo(() => parseSelector(selectorInput), [selectorInput]);
 
   // Subscribe to client SSE events for real-time updates
   useClientEvents();
@@ -25,25 +29,53 @@ function Clients() {
     );
   }
 
-  const clients = data?.clients || [];
+  const allClients = data?.clients || [];
+  const clients = selector
+    ? allClients.filter((client) => matchesSelector(selector, getClientLabels(client)))
+    : allClients;
+
+  const addLabelFilter = (key: string, value: string) => {
+    const term = `${key}=${value}`;
+    const terms = selectorInput.split(',').map((t) => t.trim()).filter((t) => t !== '');
+    if (!terms.includes(term)) {
+      setSelectorInput([...terms, term].join(','));
+    }
+  };
 
   return (
     <div className="space-y-6">
       <div className="flex items-center justify-between">
         <h1 className="text-2xl font-bold">Clients</h1>
         <div className="text-sm text-[var(--color-text-secondary)]">
-          {data?.client_count || 0} clients configured
+          {selectorInput ? `${clients.length} of ` : ''}{data?.client_count || 0} clients configured
         </div>
       </div>
 
+      <div>
+        <input
+          type="search"
+          placeholder="Filter by labels, e.g. cl=lighthouse,supernode=true"
+          value={selectorInput}
+          onChange={(e) => setSelectorInput(e.target.value)}
+          className={`w-full px-2 py-1.5 text-sm font-mono bg-[var(--color-bg-secondary)] border rounded-sm focus:outline-none focus:ring-2 focus:ring-primary-500 ${
+            selectorInput.trim() && !selector ? 'border-error-500' : 'border-[var(--color-border)]'
+          }`}
+        />
+        {selectorInput.trim() && !selector && (
+          <p className="mt-1 text-xs text-error-600">Invalid selector</p>
+        )}
+      </div>
+
       {clients.length === 0 ? (
         <div className="card p-12 text-center">
-          <p className="text-[var(--color-text-secondary)]">No clients configured.</p>
+          <p className="text-[var(--color-text-secondary)]">
+            {allClients.length === 0 ? 'No clients configured.' : 'No clients match the selector.'}
+          </p>
         </div>
       ) : (
         <div className="space-y-4">
           {clients.map((client) => (
-            <ClientCard key={client.index} client={client} />
+            <ClientCard key={client.index} client={client} onLabelClick={addLabelFilter} />
           ))}
         </div>
       )}
@@ -53,15 +85,32 @@ function Clients() {
 
 interface ClientCardProps {
   client: ClientData;
+  onLabelClick: (key: string, value: string) => void;
 }
 
-function ClientCard({ client }: ClientCardProps) {
+function ClientCard({ client, onLabelClick }: ClientCardProps) {
   return (
     <div className="card overflow-hidden">
       <div className="card-header flex items-center justify-between">
         <div className="flex items-center gap-3">
           <span className="font-semibold">{client.name}</span>
           <span className="text-xs text-[var(--color-text-tertiary)]">#{client.index}</span>
+          {client.disabled && (
+            <span className="text-xs px-2 py-0.5 rounded-sm bg-amber-100 dark:bg-amber-900/40 text-amber-800 dark:text-amber-200 font-medium uppercase tracking-wider">
+              Disabled
+            </span>
+          )}
+          {Object.entries(client.labels || {}).sort(([a], [b]) => a.localeCompare(b)).map(([key, value]) => (
+            <button
+              key={key}
+              type="button"
+              onClick={() => onLabelClick(key, value)}
+              title="Filter by this label"
+              className="text-xs px-2 py-0.5 rounded-sm bg-[var(--color-bg-tertiary)] text-[var(--color-text-secondary)] font-mono hover:bg-primary-100 dark:hover:bg-primary-900/30"
+            >
+              {key}={value}
+            </button>
+          ))}
         </div>
         <div className="flex items-center gap-2">
           <ClientStatusIndicator ready={client.cl_ready} label="CL" />
@@ -0,0 +1,322 @@
+import { useState } from 'react';
+import { Link, useSearchParams } from 'react-router-dom';
+import { useTestRunsCompare } from '../hooks/useApi';
+import StatusBadge from '../components/common/StatusBadge';
+import { formatDateTime, formatDurationMs } from '../utils/time';
+import type { ComparedRun, ComparedTask, RunComparison, TaskDiff, ValueDiff } from '../types/api';
+
+// CompareRuns shows two test runs side by side. Tasks are aligned by
+// the backend (by task ID, or title path for tasks without ID), so
+// this page only renders the diff. Deep links use `?a=…&b=…`.
+function CompareRuns() {
+  const [searchParams] = useSearchParams();
+  const runIdA = parseInt(searchParams.get('a') || '0', 10);
+  const runIdB = parseInt(searchParams.get('b') || '0', 10);
+  const [changedOnly, setChangedOnly] = useState(false);
+
+  const { data, isLoading, error } = useTestRunsCompare(runIdA, runIdB);
+
+  if (!runIdA || !runIdB) {
+    return (
+      <div className="card p-6 text-center">
+        <p className="text-[var(--color-text-secondary)]">
+          Select two runs on the <Link to="/runs" className="text-primary-600 hover:underline">runs page</Link> to compare them.
+        </p>
+      </div>
+    );
+  }
+
+  if (isLoading) {
+    return (
+      <div className="flex items-center justify-center h-64">
+        <div className="animate-spin rounded-full size-8 border-b-2 border-primary-600"></div>
+      </div>
+    );
+  }
+
+  if (error || !data) {
+    return (
+      <div className="card p-6 text-center">
+        <p className="text-error-600">Failed to compare test runs: {error?.message}</p>
+      </div>
+    );
+  }
+
+  const tasks = changedOnly ? data.tasks.filter(isTaskChanged) : data.tasks;
+
+  return (
+    <div className="space-y-6">
+      <div className="flex items-center justify-between">
+        <h1 className="text-2xl font-bold">
+          Compare runs #{data.a.run_id} and #{data.b.run_id}
+        </h1>
+        <Link
+          to={`/compare?a=${data.b.run_id}&b=${data.a.run_id}`}
+          className="btn btn-secondary btn-sm"
+        >
+          Swap
+        </Link>
+      </div>
+
+      <div className="grid grid-cols-1 md:grid-cols-2 gap-4">
+        <RunCard label="A" run={data.a} />
+        <RunCard label="B" run={data.b} />
+      </div>
+
+      <SummaryCard comparison={data} />
+
+      {data.clients.length > 0 && (
+        <div className="card overflow-hidden">
+          <div className="card-header font-medium">Client versions</div>
+          <table className="table">
+            <thead>
+              <tr>
+                <th>Client</th>
+                <th>Consensus (A)</th>
+                <th>Consensus (B)</th>
+                <th>Execution (A)</th>
+                <th>Execution (B)</th>
+              </tr>
+            </thead>
+            <tbody>
+              {data.clients.map((client) => (
+                <tr key={client.name} className={client.changed ? 'bg-yellow-50 dark:bg-yellow-900/20' : ''}>
+                  <td className="font-medium">{client.name}</td>
+                  <td className="font-mono text-xs">{client.a_consensus || '-'}</td>
+                  <td className="font-mono text-xs">{client.b_consensus || '-'}</td>
+                  <td className="font-mono text-xs">{client.a_execution || '-'}</td>
+                  <td className="font-mono text-xs">{client.b_execution || '-'}</td>
+                </tr>
+              ))}
+            </tbody>
+          </table>
+        </div>
+      )}
+
+      {data.config && data.config.length > 0 && (
+        <div className="card overflow-hidden">
+          <div className="card-header font-medium">Test configuration</div>
+          <ValueDiffTable diffs={data.config} />
+        </div>
+      )}
+
+      <div className="card overflow-hidden">
+        <div className="card-header flex items-center justify-between">
+          <span className="font-medium">Tasks</span>
+          <label className="flex items-center gap-2 text-sm">
+            <input
+              type="checkbox"
+              checked={changedOnly}
+              onChange={(e) => setChangedOnly(e.target.checked)}
+            />
+            Changed only
+          </label>
+        </div>
+        {tasks.length === 0 ? (
+          <p className="p-6 text-center text-sm text-[var(--color-text-secondary)]">No differences.</p>
+        ) : (
+          <table className="table">
+            <thead>
+              <tr>
+                <th>Task</th>
+                <th className="w-28">Result (A)</th>
+                <th className="w-28">Result (B)</th>
+                <th className="w-28">Duration (A)</th>
+                <th className="w-28">Duration (B)</th>
+                <th className="w-28">Delta</th>
+              </tr>
+            </thead>
+            <tbody>
+              {tasks.map((task) => (
+                <TaskDiffRow key={task.key} task={task} runA={data.a} runB={data.b} />
+              ))}
+            </tbody>
+          </table>
+        )}
+      </div>
+    </div>
+  );
+}
+
+function RunCard({ label, run }: { label: string; run: ComparedRun }) {
+  return (
+    <div className="card p-4 space-y-2 text-sm">
+      <div className="flex items-center justify-between">
+        <span className="font-semibold">
+          {label}:{' '}
+          <Link to={`/run/${run.run_id}`} className="font-mono text-primary-600 hover:underline">
+            #{run.run_id}
+          </Link>{' '}
+          {run.name}
+        </span>
+        <StatusBadge status={run.status} size="sm" />
+      </div>
+      <div className="flex justify-between">
+        <span className="text-[var(--color-text-secondary)]">Test</span>
+        <Link to={`/test/${encodeURIComponent(run.test_id)}`} className="text-primary-600 hover:underline">
+          {run.test_id}
+        </Link>
+      </div>
+      <div className="flex justify-between">
+        <span className="text-[var(--color-text-secondary)]">Started</span>
+        <span>{formatDateTime(run.start_time)}</span>
+      </div>
+      <div className="flex justify-between">
+        <span className="text-[var(--color-text-secondary)]">Duration</span>
+        <span>{formatDurationMs(run.duration)}</span>
+      </div>
+    </div>
+  );
+}
+
+function SummaryCard({ comparison }: { comparison: RunComparison }) {
+  const { summary } = comparison;
+  const items = [
+    { label: 'Aligned tasks', value: summary.tasks },
+    { label: 'Only in A', value: summary.only_in_a },
+    { label: 'Only in B', value: summary.only_in_b },
+    { label: 'Result changed', value: summary.result_changed },
+    { label: 'Config changed', value: summary.config_changed },
+    { label: 'Outputs changed', value: summary.output_changed },
+    { label: 'Clients changed', value: summary.client_changed },
+  ];
+
+  return (
+    <div className="card p-4">
+      <div className="grid grid-cols-2 md:grid-cols-4 lg:grid-cols-7 gap-4 text-center">
+        {items.map((item) => (
+          <div key={item.label}>
+            <div className={`text-xl font-semibold ${item.value > 0 && item.label !== 'Aligned tasks' ? 'text-yellow-600 dark:text-yellow-400' : ''}`}>
+              {item.value}
+            </div>
+            <div className="text-xs text-[var(--color-text-secondary)]">{item.label}</div>
+          </div>
+        ))}
+      </div>
+    </div>
+  );
+}
+
+interface TaskDiffRowProps {
+  task: TaskDiff;
+  runA: ComparedRun;
+  runB: ComparedRun;
+}
+
+function TaskDiffRow({ task, runA, runB }: TaskDiffRowProps) {
+  const [expanded, setExpanded] = useState(false);
+  const hasDetails = (task.config?.length ?? 0) > 0 || (task.outputs?.length ?? 0) > 0 || !!task.a?.error || !!task.b?.error;
+
+  let rowClass = '';
+  if (!task.a || !task.b) {
+    rowClass = 'bg-blue-50 dark:bg-blue-900/20';
+  } else if (task.result_changed) {
+    rowClass = 'bg-yellow-50 dark:bg-yellow-900/20';
+  }
+
+  return (
+    <>
+      <tr className={rowClass}>
+        <td>
+          <div className="flex items-center gap-1" style={{ paddingLeft: `${task.depth * 16}px` }}>
+            {hasDetails ? (
+              <button
+                type="button"
+                onClick={() => setExpanded(!expanded)}
+                className="text-xs text-[var(--color-text-tertiary)] w-4"
+              >
+                {expanded ? '▾' : '▸'}
+              </button>
+            ) : (
+              <span className="w-4" />
+            )}
+            <span className="truncate" title={task.key}>{task.title || task.name}</span>
+            {task.cleanup && <span className="text-xs text-[var(--color-text-tertiary)]">(cleanup)</span>}
+          </div>
+        </td>
+        <td><TaskResultCell runId={runA.run_id} task={task.a} /></td>
+        <td><TaskResultCell runId={runB.run_id} task={task.b} /></td>
+        <td className="text-sm">{task.a ? formatDurationMs(task.a.duration) : '-'}</td>
+        <td className="text-sm">{task.b ? formatDurationMs(task.b.duration) : '-'}</td>
+        <td className="text-sm font-mono">{task.a && task.b ? formatDelta(task.duration_delta) : '-'}</td>
+      </tr>
+      {expanded && hasDetails && (
+        <tr>
+          <td colSpan={6} className="bg-[var(--color-bg-secondary)] space-y-2">
+            {(task.a?.error || task.b?.error) && (
+              <div className="grid grid-cols-2 gap-2 text-xs">
+                <div className="text-error-600">{task.a?.error}</div>
+                <div className="text-error-600">{task.b?.error}</div>
+              </div>
+            )}
+            {task.config && task.config.length > 0 && (
+              <div>
+                <div className="text-xs font-medium mb-1">Config</div>
+                <ValueDiffTable diffs={task.config} />
+              </div>
+            )}
+            {task.outputs && task.outputs.length > 0 && (
+              <div>
+                <div className="text-xs font-medium mb-1">Outputs</div>
+                <ValueDiffTable diffs={task.outputs} />
+              </div>
+            )}
+          </td>
+        </tr>
+      )}
+    </>
+  );
+}
+
+function TaskResultCell({ runId, task }: { runId: number; task: ComparedTask | null }) {
+  if (!task) {
+    return <span className="text-xs text-[var(--color-text-tertiary)]">not run</span>;
+  }
+
+  return (
+    <Link to={`/run/${runId}`} title={`Task #${task.index}`}>
+      <StatusBadge status={task.status} size="sm" />
+    </Link>
+  );
+}
+
+function ValueDiffTable({ diffs }: { diffs: ValueDiff[] }) {
+  return (
+    <table className="table text-xs">
+      <thead>
+        <tr>
+          <th className="w-1/4">Key</th>
+          <th>A</th>
+          <th>B</th>
+        </tr>
+      </thead>
+      <tbody>
+        {diffs.map((diff) => (
+          <tr key={diff.key}>
+            <td className="font-mono">{diff.key}</td>
+            <td className="font-mono break-all">{formatValue(diff.a)}</td>
+            <td className="font-mono break-all">{formatValue(diff.b)}</td>
+          </tr>
+        ))}
+      </tbody>
+    </table>
+  );
+}
+
+function isTaskChanged(task: TaskDiff): boolean {
+  return !task.a || !task.b || task.result_changed || (task.config?.length ?? 0) > 0 || (task.outputs?.length ?? 0) > 0;
+}
+
+function formatValue(value: unknown): string {
+  if (value === undefined || value === null) return '-';
+  if (typeof value === 'string') return value;
+  return JSON.stringify(value);
+}
+
+function formatDelta(ms: number): string {
+  if (ms === 0) return '±0';
+  const sign = ms > 0 ? '+' : '-';
+  return `${sign}${formatDurationMs(Math.abs(ms))}`;
+}
+
+export default CompareRuns;
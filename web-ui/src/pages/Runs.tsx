@@ -285,6 +285,13 @@ function RunsPane({ testId, tests }: RunsPaneProps) {
     [deleteMutation],
   );
 
+  // Compare the two selected runs, older run first.
+  const compareLink = useMemo(() => {
+    if (selected.size !== 2) return null;
+    const [a, b] = Array.from(selected).sort((x, y) => x - y);
+    return `/compare?a=${a}&b=${b}`;
+  }, [selected]);
+
   const allSelected = paginated.length > 0 && paginated.every((r) => selected.has(r.run_id));
   const someSelected = paginated.some((r) => selected.has(r.run_id));
 
@@ -373,18 +380,32 @@ function RunsPane({ testId, tests }: RunsPaneProps) {
 
       {/* Footer: bulk actions + pagination */}
       <div className="flex items-center justify-between gap-2 p-2 border-t border-[var(--color-border)] text-sm flex-shrink-0">
-        {isLoggedIn ? (
-          <button
-            type="button"
-            onClick={() => handleDelete(Array.from(selected))}
-            disabled={selected.size === 0 || deleteMutation.isPending}
-            className="btn btn-secondary btn-sm disabled:opacity-50"
-          >
-            Delete selected ({selected.size})
-          </button>
-        ) : (
-          <span />
-        )}
+        <div className="flex items-center gap-2">
+          {isLoggedIn && (
+            <button
+              type="button"
+              onClick={() => handleDelete(Array.from(selected))}
+              disabled={selected.size === 0 || deleteMutation.isPending}
+              className="btn btn-secondary btn-sm disabled:opacity-50"
+            >
+              Delete selected ({selected.size})
+            </button>
+          )}
+          {compareLink ? (
+            <Link to={compareLink} className="btn btn-secondary btn-sm">
+              Compare selected
+            </Link>
+          ) : (
+            <button
+              type="button"
+              disabled
+              title="Select two runs to compare"
+              className="btn btn-secondary btn-sm disabled:opacity-50"
+            >
+              Compare selected
+            </button>
+          )}
+        </div>
 
         <Pagination page={page} totalPages={totalPages} onChange={setPage} />
       </div>
@@ -10,6 +10,7 @@ import TaskList from '../components/task/TaskList';
 import TaskDetails from '../components/task/TaskDetails';
 import { TaskGraph } from '../components/graph';
 import RunResultPanel from '../components/run/RunResultPanel';
+import { getTestRunBundle } from '../api/client';
 import { formatDuration, formatRelativeTime } from '../utils/time';
 import type { SSEEvent, TaskLogEntry, TaskState, TestRunDetails } from '../types/api';
 
@@ -193,6 +194,7 @@ function TestRun() {
 
   const { data: details, isLoading, error } = useTestRunDetails(runIdNum, { refetchInterval: false });
   const cancelMutation = useCancelTestRun();
+  const [exporting, setExporting] = useState(false);
 
   // Get SSE logs for the selected task
   // Must return a new array reference so downstream useMemos detect changes
@@ -262,6 +264,25 @@ function TestRun() {
     }
   };
 
+  const handleExportBundle = async () => {
+    setExporting(true);
+    try {
+      const blob = await getTestRunBundle(runIdNum);
+      const url = URL.createObjectURL(blob);
+      const a = document.createElement('a');
+      a.href = url;
+      a.download = `assertoor-run-${runIdNum}.tar.gz`;
+      document.body.appendChild(a);
+      a.click();
+      document.body.removeChild(a);
+      URL.revokeObjectURL(url);
+    } catch (err) {
+      alert(`Failed to export test run: ${err instanceof Error ? err.message : err}`);
+    } finally {
+      setExporting(false);
+    }
+  };
+
   const selectedTask = selectedTaskIndex !== null
     ? details.tasks.find((t) => t.index === selectedTaskIndex)
     : null;
@@ -273,6 +294,7 @@ function TestRun() {
       : 0;
 
   const canCancel = isLoggedIn && (details.status === 'pending' || details.status === 'running');
+  const canExport = isLoggedIn && !canCancel;
 
   return (
     <div className="space-y-4">
@@ -300,6 +322,16 @@ function TestRun() {
             {cancelMutation.isPending ? 'Canceling...' : 'Cancel'}
           </button>
         )}
+        {canExport && (
+          <button
+            onClick={handleExportBundle}
+            disabled={exporting}
+            className="btn btn-secondary btn-sm"
+            title="Download a bundle of this run for import into another assertoor instance"
+          >
+            {exporting ? 'Exporting...' : 'Export bundle'}
+          </button>
+        )}
       </div>
 
       {/* Summary cards */}
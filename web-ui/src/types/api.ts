@@ -156,13 +156,23 @@ export interface LibraryCheckResponse {
 export interface TestSchedule {
   startup: boolean;
   cron: string[];
+  chain?: TestChainSchedule;
   skipQueue?: boolean;
 }
 
+// Chain event triggers of a test schedule
+export interface TestChainSchedule {
+  epochs?: number[];
+  forks?: string[];
+  everyEpochs?: number;
+  finalityLoss?: number;
+}
+
 // Single planned execution for a test's cron schedule.
 export interface TestNextRunEntry {
   expression: string;
   next: number; // Unix seconds
+  epoch?: number; // set for chain triggers
 }
 
 // Response from GET /api/v1/test/{testId}/next_run
@@ -170,6 +180,7 @@ export interface TestNextRunResponse {
   test_id: string;
   entries: TestNextRunEntry[];
   earliest?: TestNextRunEntry;
+  conditions?: string[];
 }
 
 // Queue entry returned by GET /api/v1/test_queue. The first entries
@@ -243,6 +254,7 @@ export interface TaskState {
   progress: number;
   progress_message: string;
   run_concurrent?: boolean;
+  depends_on?: number[];
 }
 
 // Task result file
@@ -319,6 +331,8 @@ export interface ClientData {
   el_refresh: string;
   el_error: string;
   el_ready: boolean;
+  disabled?: boolean;
+  labels?: Record<string, string>;
 }
 
 // Clients page data
@@ -355,6 +369,8 @@ export type SSEEventType =
   | 'test.started'
   | 'test.completed'
   | 'test.failed'
+  | 'test.paused'
+  | 'test.resumed'
   | 'task.created'
   | 'task.started'
   | 'task.progress'
@@ -390,3 +406,77 @@ export interface ClientStatusUpdateEvent {
   elStatus: string;
   elReady: boolean;
 }
+
+// Run comparison (from /api/v1/test_runs/compare)
+export interface ClientVersion {
+  name: string;
+  consensus_version?: string;
+  execution_version?: string;
+}
+
+export interface ComparedRun {
+  run_id: number;
+  test_id: string;
+  name: string;
+  status: TestStatus;
+  start_time: number;  // Unix timestamp
+  stop_time: number;   // Unix timestamp
+  duration: number;    // Milliseconds
+  clients: ClientVersion[];
+}
+
+export interface ComparisonSummary {
+  status_changed: boolean;
+  tasks: number;
+  only_in_a: number;
+  only_in_b: number;
+  result_changed: number;
+  config_changed: number;
+  output_changed: number;
+  client_changed: number;
+}
+
+export interface ValueDiff {
+  key: string;
+  a: unknown;
+  b: unknown;
+}
+
+export interface ClientDiff {
+  name: string;
+  a_consensus?: string;
+  b_consensus?: string;
+  a_execution?: string;
+  b_execution?: string;
+  changed: boolean;
+}
+
+export interface ComparedTask {
+  index: number;
+  status: TaskResult | 'running' | 'pending' | 'skipped';
+  duration: number;    // Milliseconds
+  error?: string;
+}
+
+export interface TaskDiff {
+  key: string;
+  depth: number;
+  name: string;
+  title: string;
+  cleanup?: boolean;
+  a: ComparedTask | null;
+  b: ComparedTask | null;
+  result_changed: boolean;
+  duration_delta: number;  // Milliseconds
+  config?: ValueDiff[];
+  outputs?: ValueDiff[];
+}
+
+export interface RunComparison {
+  a: ComparedRun;
+  b: ComparedRun;
+  summary: ComparisonSummary;
+  config: ValueDiff[] | null;
+  clients: ClientDiff[];
+  tasks: TaskDiff[];
+}
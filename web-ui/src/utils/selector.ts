@@ -0,0 +1,57 @@
+import type { ClientData } from '../types/api';
+
+// Label selectors, mirroring the selector syntax of the client pool (pkg/clients/selector.go):
+//   key=value, key!=value, key=a|b, key, !key
+// Requirements are comma separated and all need to match.
+
+interface SelectorRequirement {
+  key: string;
+  negated: boolean;
+  values: string[] | null; // null for existence checks
+}
+
+export type Selector = SelectorRequirement[];
+
+const keyPattern = /^(!?)([a-zA-Z0-9_./-]+)$/;
+const valuePattern = /^([a-zA-Z0-9_./-]+)\s*(!=|=)\s*([a-zA-Z0-9_./|-]*)$/;
+
+// parseSelector returns the parsed selector, or null if the selector is empty or invalid.
+export function parseSelector(selector: string): Selector | null {
+  const requirements: Selector = [];
+
+  for (const rawTerm of selector.split(',')) {
+    const term = rawTerm.trim();
+    if (term === '') continue;
+
+    const valueMatch = valuePattern.exec(term);
+    if (valueMatch) {
+      requirements.push({ key: valueMatch[1], negated: valueMatch[2] === '!=', values: valueMatch[3].split('|') });
+      continue;
+    }
+
+    const keyMatch = keyPattern.exec(term);
+    if (keyMatch) {
+      requirements.push({ key: keyMatch[2], negated: keyMatch[1] === '!', values: null });
+      continue;
+    }
+
+    return null;
+  }
+
+  return requirements.length > 0 ? requirements : null;
+}
+
+export function matchesSelector(selector: Selector, labels: Record<string, string>): boolean {
+  return selector.every((requirement) => {
+    const isSet = Object.prototype.hasOwnProperty.call(labels, requirement.key);
+    const matched = requirement.values === null
+      ? isSet
+      : isSet && requirement.values.includes(labels[requirement.key]);
+    return matched !== requirement.negated;
+  });
+}
+
+// getClientLabels returns the labels of a client, including the implicit `name` label.
+export function getClientLabels(client: ClientData): Record<string, string> {
+  return { ...(client.labels || {}), name: client.name };
+}